@@ -31,6 +31,8 @@ import (
 )
 
 var GAPIService *apicalls.Caller
+// shared by the handlers and the async tasks
+var NotifyService *notify.Notify
 
 func main() {
 
@@ -80,6 +82,7 @@ func main() {
 		fmt.Println(err)
 		return
 	}
+	NotifyService = notify.NewNotifyService(config.RedisClient, config.StreamRedisClient, config.QueriesPool, PushSender())
 	// initialize the asynchronous functions 
 	err = AsyncsInit()
 	if err != nil {
@@ -184,7 +187,9 @@ func routes(router *gin.Engine) {
 	})
 
 
-	notifyService := notify.NewNotifyService(redis, queries)
+	notifyService := NotifyService
+	// the live notifications stream is served under each role group
+	notifyHandler := handlers.NewNotifyHandler(notifyService)
 
 	openService := services.NewOpenService(queries)
 	openHandler := handlers.NewOpenHandler(openService)
@@ -200,16 +205,19 @@ func routes(router *gin.Engine) {
 	adminHandler := handlers.NewAdminHandler(adminService)
 	adminRoute := wmid.Group("/admin")
 	adminHandler.RegisterRoute(adminRoute)
+	notifyHandler.RegisterRoute(adminRoute)
 
 	companyService := services.NewCompanyService(queries, GAPIService, redis, notifyService)
 	companyHandler := handlers.NewCompanyHandler(companyService)
 	companyRoute := wmid.Group("/company")
 	companyHandler.RegisterRoute(companyRoute)
+	notifyHandler.RegisterRoute(companyRoute)
 
 	studentService := services.NewStudentService(queries, redis, GAPIService, notifyService)
 	studentHandler := handlers.NewStudentHandler(studentService)
 	studentRoute := wmid.Group("/student")
 	studentHandler.RegisterRoute(studentRoute)
+	notifyHandler.RegisterRoute(studentRoute)
 
 	superuserService := services.NewSuperService(queries)
 	superuserHandler := handlers.NewSuperUserHandler(superuserService)
@@ -238,7 +246,7 @@ func GoogleAPIService() (error) {
 
 
 
-	// this is unused as of now
+	// used for push notifications
 	firebaseApp, err := firebase.NewApp(context.Background(), nil, opts)
 	if err != nil {
 		return fmt.Errorf("error creating new firebase app : %s", err)
@@ -260,9 +268,18 @@ func GoogleAPIService() (error) {
 	return nil
 }
 
+// PushSender returns the sender for push notifications, FCM unless PushSender=fake is set in the environment,
+// in which case pushes are only logged.
+func PushSender() apicalls.PushSender {
+	if os.Getenv("PushSender") == "fake" {
+		return apicalls.NewFakePushSender()
+	}
+	return GAPIService
+}
+
 func AsyncsInit() error {
 
-	aService := tasks.NewAsyncService(config.QueriesPool, GAPIService)
+	aService := tasks.NewAsyncService(config.QueriesPool, GAPIService, NotifyService)
 	
 	err := aService.StartAsyncs()
 	if err != nil {
@@ -1,6 +1,7 @@
 package apicalls
 
 import (
+	"context"
 	"fmt"
 
 	firebase "firebase.google.com/go/v4"
@@ -67,11 +68,41 @@ func (p *Caller) GetCompleteForm(formID string) (*forms.Form, error) {
 	return formData, nil
 }
 
-func (p *Caller) SendFireNotification() error {
+// SendFireNotification sends the push message to the device tokens through FCM, in batches of FCMMulticastLimit.
+// Returns the tokens FCM reported as unregistered or invalid, which should be pruned.
+// err is only returned if a whole batch failed, failures of single tokens are not errors.
+func (p *Caller) SendFireNotification(ctx context.Context, tokens []string, msg *PushMessage) ([]string, error) {
 
-	// TODO:
+	if p.FireMsg == nil {
+		return nil, fmt.Errorf("firebase messaging client is not initialised")
+	}
 
+	var invalid []string
+	for start := 0; start < len(tokens); start += FCMMulticastLimit {
+		end := min(start + FCMMulticastLimit, len(tokens))
+		batch := tokens[start:end]
+
+		resp, err := p.FireMsg.SendEachForMulticast(ctx, &messaging.MulticastMessage{
+			Tokens: batch,
+			Data: msg.Data,
+			Notification: &messaging.Notification{
+				Title: msg.Title,
+				Body: msg.Body,
+			},
+		})
+		if err != nil {
+			return invalid, fmt.Errorf("failed to send push notifications : %v", err)
+		}
 
+		for i, r := range resp.Responses {
+			if r.Success {
+				continue
+			}
+			if messaging.IsUnregistered(r.Error) || messaging.IsInvalidArgument(r.Error) || messaging.IsSenderIDMismatch(r.Error) {
+				invalid = append(invalid, batch[i])
+			}
+		}
+	}
 
-	return nil
+	return invalid, nil
 }
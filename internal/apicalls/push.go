@@ -0,0 +1,71 @@
+package apicalls
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FCM accepts at most 500 tokens in one multicast message
+const FCMMulticastLimit = 500
+
+// PushMessage is a push notification for the devices of a user.
+type PushMessage struct {
+	Title string
+	Body string
+	// delivered to the app as is, eg. the deep link of the notification
+	Data map[string]string
+}
+
+// PushSender sends push messages to device tokens and returns the tokens that are no longer valid.
+// *Caller sends through FCM, FakePushSender is used locally and in tests.
+type PushSender interface {
+	SendFireNotification(ctx context.Context, tokens []string, msg *PushMessage) ([]string, error)
+}
+
+// FakePushSender records the messages it is asked to send instead of calling FCM.
+// Tokens in Invalid are reported back as invalid, like FCM does for unregistered devices.
+type FakePushSender struct {
+	mu sync.Mutex
+
+	Invalid map[string]bool
+	Sent []FakePush
+}
+
+type FakePush struct {
+	Token string
+	Message PushMessage
+}
+
+func NewFakePushSender() *FakePushSender {
+	return &FakePushSender{
+		Invalid: map[string]bool{},
+	}
+}
+
+func (f *FakePushSender) SendFireNotification(ctx context.Context, tokens []string, msg *PushMessage) ([]string, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var invalid []string
+	for _, t := range tokens {
+		if f.Invalid[t] {
+			invalid = append(invalid, t)
+			continue
+		}
+		f.Sent = append(f.Sent, FakePush{Token: t, Message: *msg})
+		fmt.Printf("Fake push to %s : %s - %s\n", t, msg.Title, msg.Body)
+	}
+
+	return invalid, nil
+}
+
+// Pushes returns the pushes sent so far, safe to call while sending
+func (f *FakePushSender) Pushes() []FakePush {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]FakePush(nil), f.Sent...)
+}
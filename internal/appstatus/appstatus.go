@@ -0,0 +1,143 @@
+package appstatus
+
+import (
+	"fmt"
+	"sort"
+)
+
+// application statuses, values of the application_status enum
+const (
+	Applied = "Applied"
+	UnderReview = "UnderReview"
+	ShortListed = "ShortListed"
+	Interview = "Interview"
+	Offered = "Offered"
+	Hired = "Hired"
+	Declined = "Declined"
+	Rejected = "Rejected"
+	Withdrawn = "Withdrawn"
+)
+
+// who changes the status of an application, admins may make any legal transition
+const (
+	ActorStudent = "student"
+	ActorCompany = "company"
+	ActorAdmin = "admin"
+	ActorSystem = "system"
+)
+
+// transitions are the legal transitions, from status to status to who may make them.
+// Applied -> UnderReview -> ShortListed -> Interview -> Offered -> Hired/Declined, companies may shortlist without
+// reviewing first or offer without an interview, see interviewCancelled for back to ShortListed.
+// Rejected and Withdrawn are possible from any status before an offer, Hired, Declined, Rejected and Withdrawn are final.
+var transitions = map[string]map[string]string{
+	Applied: {
+		UnderReview: ActorCompany,
+		ShortListed: ActorCompany,
+		Rejected: ActorCompany,
+		Withdrawn: ActorStudent,
+	},
+	UnderReview: {
+		ShortListed: ActorCompany,
+		Rejected: ActorCompany,
+		Withdrawn: ActorStudent,
+	},
+	ShortListed: {
+		Interview: ActorCompany,
+		Offered: ActorCompany,
+		Rejected: ActorCompany,
+		Withdrawn: ActorStudent,
+	},
+	Interview: {
+		Offered: ActorCompany,
+		Rejected: ActorCompany,
+		Withdrawn: ActorStudent,
+	},
+	Offered: {
+		Hired: ActorStudent,
+		Declined: ActorStudent,
+	},
+	Hired: {},
+	Declined: {},
+	Rejected: {},
+	Withdrawn: {},
+}
+
+// interviewCancelled are the transitions legal only along with cancelling the interview of the application,
+// its interview would stay scheduled otherwise
+var interviewCancelled = map[string]map[string]string{
+	Interview: {
+		ShortListed: ActorCompany,
+	},
+}
+
+// Valid reports whether status is an application status.
+func Valid(status string) bool {
+	_, exists := transitions[status]
+	return exists
+}
+
+// Final reports whether no transition is possible out of status.
+func Final(status string) bool {
+	return len(transitions[status]) == 0
+}
+
+// Late reports whether an application in status is far enough along that withdrawing it should be confirmed.
+func Late(status string) bool {
+	return status == ShortListed || status == Interview
+}
+
+// Check returns why the actor cannot move an application from one status to the other, nil if they can.
+// The error is meant for the user.
+func Check(from string, to string, actor string) error {
+
+	if !Valid(from) {
+		return fmt.Errorf("unknown application status %q", from)
+	}
+	if !Valid(to) {
+		return fmt.Errorf("unknown application status %q", to)
+	}
+	if from == to {
+		return fmt.Errorf("the application is already %s", to)
+	}
+	if Final(from) {
+		return fmt.Errorf("the application is %s, its status cannot change anymore", from)
+	}
+
+	by, legal := transitions[from][to]
+	if !legal {
+		return fmt.Errorf("an application cannot go from %s to %s", from, to)
+	}
+	if actor != by && actor != ActorAdmin && actor != ActorSystem {
+		return fmt.Errorf("only the %s can move an application from %s to %s", by, from, to)
+	}
+
+	return nil
+}
+
+// CheckCancellingInterview is Check for a change that cancels the interview of the application along with it,
+// which may also move it from Interview back to ShortListed.
+func CheckCancellingInterview(from string, to string, actor string) error {
+
+	by, legal := interviewCancelled[from][to]
+	if !legal {
+		return Check(from, to, actor)
+	}
+	if actor != by && actor != ActorAdmin && actor != ActorSystem {
+		return fmt.Errorf("only the %s can move an application from %s to %s", by, from, to)
+	}
+
+	return nil
+}
+
+// Next are the statuses the actor can move an application in status to, sorted.
+func Next(status string, actor string) []string {
+	next := []string{}
+	for to := range transitions[status] {
+		if Check(status, to, actor) == nil {
+			next = append(next, to)
+		}
+	}
+	sort.Strings(next)
+	return next
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// audited actions
+const (
+	EligibilityOverrideGranted = "eligibility_override_granted"
+	EligibilityOverrideRevoked = "eligibility_override_revoked"
+	StudentBacklogsUpdated = "student_backlogs_updated"
+	PlacementPolicyUpdated = "placement_policy_updated"
+	PolicyExceptionGranted = "policy_exception_granted"
+	PolicyExceptionRevoked = "policy_exception_revoked"
+	InterviewConflictOverridden = "interview_conflict_overridden"
+)
+
+// audited entities, EntityID is the primary key of the entity
+const (
+	EntityStudent = "student"
+	EntityJob = "job"
+	EntityPlacementPolicy = "placement_policy"
+	EntityInterview = "interview"
+)
+
+// Record appends an entry to the audit log, details is stored as JSON.
+func Record(ctx context.Context, queries *sqlc.Queries, actorID int64, action string, entityType string, entityID int64, details any) error {
+
+	detailsJson, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	return queries.InsertAuditLog(ctx, sqlc.InsertAuditLogParams{
+		ActorID: actorID,
+		Action: action,
+		EntityType: entityType,
+		EntityID: entityID,
+		Details: detailsJson,
+	})
+}
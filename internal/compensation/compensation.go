@@ -0,0 +1,168 @@
+package compensation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Compensation is the structured pay of a job. Amounts are in whole units of the currency,
+// per annum except the stipend which is per month, 0 if not specified.
+type Compensation struct {
+	Currency string // INR, USD, EUR or GBP
+	CTCMin int64
+	CTCMax int64 // same as CTCMin for a fixed CTC
+	Fixed int64 // fixed part of the CTC
+	Variable int64 // variable part of the CTC, bonuses and incentives
+	StipendMonthly int64 // internships
+	BondMonths int32 // length of the bond or service agreement, 0 if none
+	BondTerms string // eg. the amount payable on breaking the bond
+}
+
+var symbols = map[string]string{
+	"INR": "₹",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+}
+
+const (
+	lakh = 100000
+	crore = 10000000
+	maxBondMonths = 120
+)
+
+// Validate checks the compensation is consistent, the returned error is meant for the user.
+func (c *Compensation) Validate() error {
+
+	if _, exists := symbols[c.Currency]; !exists {
+		return fmt.Errorf("currency must be one of INR, USD, EUR or GBP")
+	}
+	if c.CTCMin < 0 || c.CTCMax < 0 || c.Fixed < 0 || c.Variable < 0 || c.StipendMonthly < 0 {
+		return fmt.Errorf("amounts cannot be negative")
+	}
+	if c.CTCMin == 0 && c.CTCMax == 0 && c.StipendMonthly == 0 {
+		return fmt.Errorf("a CTC or a stipend is required")
+	}
+	if c.CTCMax != 0 && c.CTCMin > c.CTCMax {
+		return fmt.Errorf("minimum CTC is more than the maximum CTC")
+	}
+	if c.Fixed + c.Variable > max(c.CTCMin, c.CTCMax) {
+		return fmt.Errorf("fixed and variable pay add up to more than the CTC")
+	}
+	if c.BondMonths < 0 || c.BondMonths > maxBondMonths {
+		return fmt.Errorf("bond must be within 0-%d months", maxBondMonths)
+	}
+	if c.BondMonths == 0 && c.BondTerms != "" {
+		return fmt.Errorf("bond terms given without a bond length")
+	}
+
+	return nil
+}
+
+// Normalize fills in what follows from the rest, a missing bound of the CTC range or the CTC from its split.
+func (c *Compensation) Normalize() {
+
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+	if c.Currency == "" {
+		c.Currency = "INR"
+	}
+	if c.CTCMin == 0 && c.CTCMax == 0 && c.Fixed + c.Variable != 0 {
+		c.CTCMin = c.Fixed + c.Variable
+	}
+	if c.CTCMax == 0 {
+		c.CTCMax = c.CTCMin
+	}
+	if c.CTCMin == 0 {
+		c.CTCMin = c.CTCMax
+	}
+	c.BondTerms = strings.TrimSpace(c.BondTerms)
+}
+
+// Display is the compensation as shown to students, eg. "₹6-8 LPA (₹5 LPA fixed + ₹1 LPA variable), 24-month bond".
+func (c *Compensation) Display() string {
+
+	parts := []string{}
+
+	if c.CTCMin != 0 || c.CTCMax != 0 {
+		ctc := c.annual(c.CTCMin)
+		if c.CTCMax != c.CTCMin {
+			ctc = c.rangeOf(c.CTCMin, c.CTCMax)
+		}
+		if c.Fixed != 0 || c.Variable != 0 {
+			split := []string{}
+			if c.Fixed != 0 {
+				split = append(split, c.annual(c.Fixed) + " fixed")
+			}
+			if c.Variable != 0 {
+				split = append(split, c.annual(c.Variable) + " variable")
+			}
+			ctc += " (" + strings.Join(split, " + ") + ")"
+		}
+		parts = append(parts, ctc)
+	}
+
+	if c.StipendMonthly != 0 {
+		parts = append(parts, fmt.Sprintf("%s%s/month stipend", symbols[c.Currency], short(c.StipendMonthly)))
+	}
+
+	if c.BondMonths != 0 {
+		bond := fmt.Sprintf("%d-month bond", c.BondMonths)
+		if c.BondTerms != "" {
+			bond += " (" + c.BondTerms + ")"
+		}
+		parts = append(parts, bond)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// annual formats a per annum amount, in lakhs (LPA) or crores for INR
+func (c *Compensation) annual(v int64) string {
+	if c.Currency == "INR" {
+		return "₹" + inr(v)
+	}
+	return symbols[c.Currency] + short(v) + " per annum"
+}
+
+func (c *Compensation) rangeOf(from int64, to int64) string {
+	if c.Currency == "INR" {
+		// the unit once, "₹6-8 LPA", when both are in lakhs
+		if from >= lakh && to < crore {
+			return fmt.Sprintf("₹%s-%s LPA", decimal(float64(from) / lakh), decimal(float64(to) / lakh))
+		}
+		return "₹" + inr(from) + " - ₹" + inr(to)
+	}
+	return fmt.Sprintf("%s%s-%s per annum", symbols[c.Currency], short(from), short(to))
+}
+
+// INR formats a per annum amount in INR, eg. "₹6 LPA".
+func INR(v int64) string {
+	return "₹" + inr(v)
+}
+
+func inr(v int64) string {
+	switch {
+	case v >= crore:
+		return decimal(float64(v) / crore) + " Cr"
+	case v >= lakh:
+		return decimal(float64(v) / lakh) + " LPA"
+	}
+	return short(v) + " per annum"
+}
+
+// short formats an amount in thousands or millions, eg. 25000 as 25k
+func short(v int64) string {
+	switch {
+	case v >= 1000000 && v % 10000 == 0:
+		return decimal(float64(v) / 1000000) + "M"
+	case v >= 1000 && v % 100 == 0:
+		return decimal(float64(v) / 1000) + "k"
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// decimal formats with at most 2 decimals and no trailing zeroes
+func decimal(f float64) string {
+	return strconv.FormatFloat(float64(int64(f * 100 + 0.5)) / 100, 'f', -1, 64)
+}
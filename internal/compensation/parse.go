@@ -0,0 +1,149 @@
+package compensation
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	digitGroups = regexp.MustCompile(`(\d),(\d)`)
+	currencySymbols = regexp.MustCompile(`[₹$€£]`)
+	bondPattern = regexp.MustCompile(`(?:(\d+(?:\.\d+)?)\s*[- ]?\s*(years?|yrs?|months?|mos?)\s*(?:of\s+)?(?:bond|service agreement|service contract))|(?:(?:bond|service agreement|service contract)\s*(?:of|for|:|-)?\s*(\d+(?:\.\d+)?)\s*(years?|yrs?|months?|mos?))`)
+	durationPattern = regexp.MustCompile(`\d+(?:\.\d+)?\s*(?:years?|yrs?|months?|mos?)\b`)
+	amountPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)(?:\s*(?:-|–|to)\s*(\d+(?:\.\d+)?))?\s*(lpa|lakhs?|lacs?|l|crores?|cr|k|million|mn|m)?\b`)
+	monthlyPattern = regexp.MustCompile(`per\s*month|/\s*month|/\s*mo\b|\bp\.?\s?m\b|monthly|a month`)
+	annualPattern = regexp.MustCompile(`lpa|per\s*annum|p\.?\s?a\b|/\s*(?:year|yr|annum)|per\s*year|annual|yearly|ctc`)
+	segmentSeparators = regexp.MustCompile(`[,;+()\n]|\band\b|\bplus\b`)
+	oneTimePattern = regexp.MustCompile(`joining|sign(?:ing)?[- ]?on|signing|relocation|one[- ]?time`)
+)
+
+var currencyMarkers = []struct{ marker *regexp.Regexp; currency string }{
+	{regexp.MustCompile(`₹|\binr\b|\brs\b`), "INR"},
+	{regexp.MustCompile(`\$|\busd\b`), "USD"},
+	{regexp.MustCompile(`€|\beur\b`), "EUR"},
+	{regexp.MustCompile(`£|\bgbp\b`), "GBP"},
+}
+
+var unitScale = map[string]float64{
+	"": 1,
+	"lpa": lakh,
+	"lakh": lakh,
+	"lakhs": lakh,
+	"lac": lakh,
+	"lacs": lakh,
+	"l": lakh,
+	"crore": crore,
+	"crores": crore,
+	"cr": crore,
+	"k": 1000,
+	"m": 1000000,
+	"mn": 1000000,
+	"million": 1000000,
+}
+
+// Parse reads a free-text salary like "6-8 LPA + 1L joining bonus, 2 year bond" or "₹25,000/month"
+// into a Compensation, monthly amounts of internships are taken as the stipend.
+// One-time payments like joining, sign-on or relocation bonuses are not part of the CTC and are left out,
+// they remain in the salary text. It reports false when no amount could be made out, the text is then best kept as is.
+func Parse(text string, internship bool) (*Compensation, bool) {
+
+	s := strings.ToLower(strings.TrimSpace(text))
+	if s == "" {
+		return nil, false
+	}
+	s = digitGroups.ReplaceAllString(s, "$1$2")
+
+	c := &Compensation{Currency: "INR"}
+	for _, m := range currencyMarkers {
+		if m.marker.MatchString(s) {
+			c.Currency = m.currency
+			break
+		}
+	}
+	// symbols repeated within a range, "₹6 - ₹8 lakh", would split it
+	s = currencySymbols.ReplaceAllString(s, "")
+
+	// the bond first, so its length is not taken for an amount
+	if m := bondPattern.FindStringSubmatch(s); m != nil {
+		value, unit := m[1], m[2]
+		if value == "" {
+			value, unit = m[3], m[4]
+		}
+		months, _ := strconv.ParseFloat(value, 64)
+		if strings.HasPrefix(unit, "y") {
+			months *= 12
+		}
+		c.BondMonths = int32(months + 0.5)
+		s = bondPattern.ReplaceAllString(s, " ")
+	}
+	s = durationPattern.ReplaceAllString(s, " ")
+
+	allMonthly := monthlyPattern.MatchString(s) && !annualPattern.MatchString(s)
+	found := false
+
+	for _, segment := range segmentSeparators.Split(s, -1) {
+		m := amountPattern.FindStringSubmatch(segment)
+		if m == nil || oneTimePattern.MatchString(segment) {
+			continue
+		}
+
+		low, _ := strconv.ParseFloat(m[1], 64)
+		high := low
+		if m[2] != "" {
+			high, _ = strconv.ParseFloat(m[2], 64)
+		}
+		scale := unitScale[m[3]]
+		// a bare small INR figure is in lakhs, "6-8" meaning 6-8 LPA
+		monthly := monthlyPattern.MatchString(segment) || (allMonthly && !annualPattern.MatchString(segment))
+		if m[3] == "" && c.Currency == "INR" && high < 100 && !monthly {
+			scale = lakh
+		}
+		from, to := int64(low * scale + 0.5), int64(high * scale + 0.5)
+		if from == 0 && to == 0 {
+			continue
+		}
+		found = true
+
+		switch {
+		case strings.Contains(segment, "stipend") || (monthly && internship):
+			c.StipendMonthly = from
+		case monthly:
+			from, to = from * 12, to * 12
+			fallthrough
+		default:
+			switch {
+			case strings.Contains(segment, "fixed") || strings.Contains(segment, "base"):
+				c.Fixed = from
+			case strings.Contains(segment, "variable") || strings.Contains(segment, "bonus") ||
+				strings.Contains(segment, "incentive") || strings.Contains(segment, "performance"):
+				c.Variable += from
+			case c.CTCMin == 0 && c.CTCMax == 0:
+				c.CTCMin, c.CTCMax = from, to
+			}
+		}
+	}
+
+	if !found {
+		return nil, false
+	}
+
+	// a variable part quoted on top of a fixed CTC, "10 LPA + 2 LPA bonus", adds to it
+	if c.Fixed == 0 && c.Variable != 0 && c.CTCMin == c.CTCMax && c.CTCMin != 0 {
+		c.Fixed = c.CTCMin
+		c.CTCMin += c.Variable
+		c.CTCMax = c.CTCMin
+	}
+
+	c.Normalize()
+	if c.Validate() != nil {
+		return nil, false
+	}
+
+	return c, true
+}
+
+// IsInternship reports whether a job type is an internship, whose monthly pay is a stipend.
+func IsInternship(jobType string) bool {
+	return strings.Contains(strings.ToLower(jobType), "intern")
+}
@@ -0,0 +1,44 @@
+package compensation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+
+	tests := []struct {
+		text string
+		internship bool
+		want *Compensation
+	}{
+		{"6-8 LPA + 1L joining bonus, 2 year bond", false, &Compensation{Currency: "INR", CTCMin: 600000, CTCMax: 800000, BondMonths: 24}},
+		{"1L joining bonus + 6-8 LPA", false, &Compensation{Currency: "INR", CTCMin: 600000, CTCMax: 800000}},
+		{"12 LPA + 50k sign-on bonus", false, &Compensation{Currency: "INR", CTCMin: 1200000, CTCMax: 1200000}},
+		{"₹25,000/month", true, &Compensation{Currency: "INR", StipendMonthly: 25000}},
+		{"₹25,000/month", false, &Compensation{Currency: "INR", CTCMin: 300000, CTCMax: 300000}},
+		{"Stipend 30k per month", true, &Compensation{Currency: "INR", StipendMonthly: 30000}},
+		{"10 LPA + 2 LPA bonus", false, &Compensation{Currency: "INR", CTCMin: 1200000, CTCMax: 1200000, Fixed: 1000000, Variable: 200000}},
+		{"12 LPA fixed, 3 LPA variable", false, &Compensation{Currency: "INR", CTCMin: 1500000, CTCMax: 1500000, Fixed: 1200000, Variable: 300000}},
+		{"6-8", false, &Compensation{Currency: "INR", CTCMin: 600000, CTCMax: 800000}},
+		{"1.2 Cr CTC", false, &Compensation{Currency: "INR", CTCMin: 12000000, CTCMax: 12000000}},
+		{"$120k per annum", false, &Compensation{Currency: "USD", CTCMin: 120000, CTCMax: 120000}},
+		{"€45,000 - €55,000 per year", false, &Compensation{Currency: "EUR", CTCMin: 45000, CTCMax: 55000}},
+		{"₹6 - ₹8 lakh", false, &Compensation{Currency: "INR", CTCMin: 600000, CTCMax: 800000}},
+		{"8 LPA with a service agreement of 18 months", false, &Compensation{Currency: "INR", CTCMin: 800000, CTCMax: 800000, BondMonths: 18}},
+		{"Competitive", false, nil},
+		{"", false, nil},
+		{"50k joining bonus", false, nil},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.text, tt.internship)
+		if ok != (tt.want != nil) {
+			t.Errorf("Parse(%q, %v) reported %v, want %v", tt.text, tt.internship, ok, tt.want != nil)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q, %v) = %+v, want %+v", tt.text, tt.internship, got, tt.want)
+		}
+	}
+}
@@ -13,6 +13,90 @@ const (
 	TestResultPollerTimeout = 900 // seconds // 15 mins
 )
 
+const (
+	DigestPollerTimeout = 600 // seconds // 10 mins
+	// a digest whose send time was missed by more than this is skipped until the next period
+	DigestSendWindow = 60 // mins
+	DigestNotificationsLimit = 20 // max notifications listed in one digest
+)
+
+const (
+	JobDeadlinesPollerTimeout = 300 // seconds // 5 mins
+	// students are reminded of eligible jobs they have not applied to this long before the deadline
+	JobDeadlineReminderWindow = 24 // hours
+)
+
+const (
+	JobSearchPageLimit = 20
+	JobSearchMaxPageLimit = 50
+)
+
+const (
+	RecommendationsLimit = 10
+	RecommendationsMaxLimit = 50
+	// jobs scoring less are not recommended, see recommend.Score
+	RecommendationMinScore = 20
+	// recommended jobs posted since the last digest
+	DigestRecommendationsLimit = 3
+)
+
+const (
+	// jobs parsed per batch when backfilling the structured compensation from free-text salaries
+	CompensationBackfillBatchSize = 200
+)
+
+const (
+	// jobs per bulk import file and its size
+	JobImportMaxRows = 100
+	JobImportMaxFileSize = 1 << 20 // bytes // 1 MB
+)
+
+const (
+	// applications per bulk shortlist or reject
+	BulkActionMaxApplications = 500
+	// students per email of a bulk action, they are not disclosed to each other
+	BulkActionEmailBatchSize = 50
+)
+
+const (
+	OffersPollerTimeout = 300 // seconds // 5 mins
+	// students have this long to respond to an offer unless the company sets a deadline
+	OfferResponseDays = 7
+	OfferLetterMaxFileSize = 2 << 20 // bytes // 2 MB
+	// offers per bulk offer, each generates its own letter
+	BulkOfferMaxApplications = 100
+	// characters of the name and the title of an offer letter template, and of its body
+	OfferTemplateNameMaxLength = 60
+	OfferTemplateTitleMaxLength = 120
+	OfferTemplateBodyMaxLength = 20000
+	// the logo on a generated offer letter is scaled down to fit, and to at most this many pixels wide
+	OfferLetterLogoMaxPixels = 600
+)
+
+const (
+	// stages of the hiring pipeline of a job
+	PipelineMaxStages = 15
+	// characters of the name of a stage
+	PipelineStageNameMaxLength = 60
+)
+
+const (
+	AnnouncementsPollerTimeout = 60 // seconds
+	// recipients of an announcement email per SMTP send, they are not disclosed to each other
+	AnnouncementEmailBatchSize = 50
+	NoticeBoardPageLimit = 20
+)
+
+const (
+	// interviews last this long unless scheduled with a duration, the calendar invite blocks it from the start
+	InterviewDefaultDuration = 60 // mins
+	InterviewMaxDuration = 480 // mins
+	// times either side may propose to reschedule an interview to, the other side accepts one of them
+	RescheduleMaxTimes = 3
+	RescheduleReasonMaxLength = 500
+	CalendarFeedTokenBytes = 24 // bytes // hex encoded to 48 chars
+)
+
 const (
 	SignupConfirmLinkTokenExpiration = 15 // mins
 	ResetLinkTokenExpiration = 15 // mins
@@ -47,9 +131,19 @@ const (
 const (
 	// 0 : infinite blocking
 	// x : waits for x milliseconds to return
-	NotificationsXReadBlock = 1 
+	// a heartbeat is sent to the SSE client every time the block times out
+	NotificationsXReadBlock = 15000 
 	// number of notifications to read in a batch
 	NotificationsXReadCount = 10 
+	// approx. number of latest notifications kept in a user's stream, older ones are only in the db
+	NotificationsStreamMaxLen = 100
+	// the stream of an inactive user is dropped after this
+	NotificationsStreamExpiry = 604800 // seconds // 7 days
+	// connections of the redis client of the SSE streams, every open stream holds one while blocked in XREAD,
+	// apart from the client of the cache and rate limiter so that open streams do not starve them
+	NotificationsStreamPoolSize = 1000
+	// max notifications returned in one page
+	NotificationsPageLimit = 50
 )
 
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	sqlc "go.mod/internal/sqlc/generate"
@@ -14,6 +15,8 @@ import (
 var	Pool *pgxpool.Pool
 var	QueriesPool *sqlc.Queries
 var	RedisClient *redis.Client
+// the client of the notification streams, see NotificationsStreamPoolSize
+var	StreamRedisClient *redis.Client
 
 
 func InitDB() (error) {
@@ -29,6 +32,7 @@ func InitDB() (error) {
 	}
 
 	// inittialize queries pool
+	Pool = pool
 	QueriesPool = sqlc.New(pool)
 	
 	// Connect to redis client
@@ -42,6 +46,13 @@ func InitDB() (error) {
 	if err != nil {
 		return fmt.Errorf("failed to connect to Redis: %v", err)
 	}
+	StreamRedisClient = redis.NewClient(&redis.Options{
+		Addr: os.Getenv("RedisAddress"),
+		Password: os.Getenv("RedisPassword"),
+		DB: 0,
+		Protocol: 2,
+		PoolSize: NotificationsStreamPoolSize,
+	})
 	fmt.Println("Redis connection is alive!")
 
 	conn, err := pool.Acquire(context.Background())
@@ -59,12 +70,38 @@ func InitDB() (error) {
 	return nil
 }
 
+// WithTx runs fn with queries in a transaction, committed if fn returns nil and rolled back otherwise
+func WithTx(ctx context.Context, fn func(queries *sqlc.Queries) error) error {
+
+	tx, err := Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction : %v", err)
+	}
+	// a no-op once committed
+	defer tx.Rollback(ctx)
+
+	err = fn(QueriesPool.WithTx(tx))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction : %v", err)
+	}
+
+	return nil
+}
+
 // Close DB and Redis connections
 func Close() (error) {
 	fmt.Println("Closing connections to Databases and Cache...")
 	if Pool != nil {
 		Pool.Close()
 	}
+	if StreamRedisClient != nil {
+		StreamRedisClient.Close()
+	}
 	if RedisClient != nil {
 		return RedisClient.Close()
 	}
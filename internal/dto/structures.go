@@ -1,24 +1,405 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/go-echarts/go-echarts/v2/charts"
 	"github.com/go-echarts/go-echarts/v2/opts"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/compensation"
+	"go.mod/internal/eligibility"
+	"go.mod/internal/pipeline"
+	"go.mod/internal/questions"
 	sqlc "go.mod/internal/sqlc/generate"
 	"google.golang.org/api/forms/v1"
 )
 
+// NewJobData is the schema for creating a job, any other field is rejected
 type NewJobData struct {
+	JobTitle string
+	JobLocation string
+	JobDescription string
+	JobType string
+	JobSalary string
+	SkillsRequired string // comma separated
+	JobPosition string
+	// optional JSON object of any additional details, Extras[key]=value form fields are accepted too
+	Extras string `formmap:"json"`
+	Deadline time.Time `form:"Deadline" time_format:"2006-01-02T15:04"` // optional, applications close after it
+	MaxApplicants int32 // optional, applications close once reached, 0 for no limit
+	Questions string // optional JSON array of questions asked on applying, see questions.Question
+	// optional JSON object of the structured pay, see compensation.Compensation, parsed from JobSalary if empty.
+	// JobSalary is optional if given, it is then displayed as the salary.
+	Compensation string
+}
+
+// UpdateJobData is the schema for updating a job, it replaces all the fields of the job
+type UpdateJobData struct {
 	JobId int64
+	// the revision the edit was made on, the update is rejected if the job was changed since
+	Revision int32
 	JobTitle string
 	JobLocation string
 	JobDescription string
 	JobType string
 	JobSalary string
-	SkillsRequired string
+	SkillsRequired string // comma separated
 	JobPosition string
-	Extras map[string]interface{}
+	// optional JSON object, the existing extras are kept if empty, Extras[key]=value form fields are accepted too
+	Extras string `formmap:"json"`
+	Deadline time.Time `form:"Deadline" time_format:"2006-01-02T15:04"` // zero for no deadline
+	MaxApplicants int32 // 0 for no limit
+	Questions string // optional JSON array, the existing questions are kept if empty
+	// optional JSON object, the existing compensation is kept if empty unless JobSalary or JobType changed
+	Compensation string
+}
+
+// ApplicationTimeline is the status history of an application, see appstatus
+type ApplicationTimeline struct {
+	ApplicationID int64
+	JobID int64
+	Title string
+	Status string
+	Next []string // statuses the viewer can move the application to
+	Events []StatusEvent // oldest first
+}
+
+// StatusEvent is a change of the status of an application, From is empty for the initial Applied
+type StatusEvent struct {
+	From string
+	To string
+	By string // student, company, admin or system
+	Reason string
+	At time.Time
+}
+
+// WithdrawApplication is the schema for a student withdrawing their application to a job
+type WithdrawApplication struct {
+	JobID int64
+	Reason string
+	Confirm bool // required to withdraw once shortlisted or interviewing
+}
+
+// NewOffer is the schema of an offer made to an applicant, sent as a form along with the offer letter, or the ID of
+// the template to generate it from
+type NewOffer struct {
+	ApplicationID int64 `form:"OfferApplicationId"`
+	CTC int64 `form:"CTC"` // per annum
+	Currency string `form:"Currency"` // INR if empty
+	JoiningDate time.Time `form:"JoiningDate" time_format:"2006-01-02"`
+	RespondBy time.Time `form:"RespondBy" time_format:"2006-01-02T15:04"` // optional, see offer.Terms
+	TemplateID int64 `form:"TemplateId"` // optional, the uploaded letter is used without one
+	Location string `form:"Location"` // optional, the location of the job otherwise
+}
+
+// BulkOffer is the schema of offers on the same terms to applications, given or matching the filter, each with a
+// letter generated from the template. Dates are formatted as in NewOffer, eg. "2026-07-01" and "2026-06-15T17:00".
+type BulkOffer struct {
+	ApplicationIDs []int64
+	Filter *ApplicationFilter
+	TemplateID int64
+	CTC int64
+	Currency string
+	JoiningDate string
+	RespondBy string // optional
+	Location string // optional
+}
+
+// OfferLetterTemplates are the offer letter templates of a company, with the placeholders they can use
+type OfferLetterTemplates struct {
+	Templates []sqlc.OfferLetterTemplatesRow
+	Placeholders []string
+}
+
+// OfferResponse is the schema for a student accepting or declining an offer
+type OfferResponse struct {
+	ApplicationID int64
+	Reason string // optional
+}
+
+// OfferDetails is an offer as shown to the student, Status is empty for an offer made before they were recorded
+type OfferDetails struct {
+	ApplicationID int64
+	JobID int64
+	Title string
+	CompanyName string
+	ApplicationStatus string
+	Status string
+	CTC string
+	JoiningDate *time.Time
+	RespondBy *time.Time
+	RespondedAt *time.Time
+	Reason string
+	HasLetter bool
+}
+
+// PlacementStats are the offers made and accepted, CTCs are of accepted full-time INR offers
+type PlacementStats struct {
+	Overall sqlc.PlacementStatsRow
+	Departments []sqlc.DepartmentPlacementStatsRow
+}
+
+// BulkApplicationAction is the schema to shortlist or reject applications in bulk, either the given applications
+// or those of a job matching the filter
+type BulkApplicationAction struct {
+	ApplicationIDs []int64
+	Filter *ApplicationFilter
+	Reason string // rejections only, shown to the students
+	Strict bool // change none unless all of them can be changed, otherwise those that cannot are skipped
+}
+
+// ApplicationFilter selects the applications to a job of the company
+type ApplicationFilter struct {
+	JobID int64
+	Statuses []string // current statuses, any if empty
+	TestID int64 // test of the job the scores are of, required with a score bound
+	MinScore *int64 // score at least
+	BelowScore *int64 // score below, students who did not take the test score 0
+}
+
+// BulkActionReport is the outcome of a bulk applicant action, by application in the order of their IDs
+type BulkActionReport struct {
+	To string
+	Total int
+	Changed int
+	Results []BulkActionResult
+}
+
+type BulkActionResult struct {
+	ApplicationID int64
+	From string
+	Changed bool
+	Message string // why it was not changed
+}
+
+// ApplicationStatusEmail is the data of the email to students whose application changed status
+type ApplicationStatusEmail struct {
+	Title string
+	JobTitle string
+	CompanyName string
+	Message string
+}
+
+// ApplicantExport is the query of an applicant export, with the column groups to include, all if none.
+// Column groups are profile, cgpa, status, tests, interview and answers.
+type ApplicantExport struct {
+	JobID int64 `form:"jobid"` // all jobs if 0, admins only
+	Format string `form:"format"` // csv (default), xlsx, or zip of the resumes
+	Columns []string `form:"column"`
+	Statuses []string `form:"status"` // any if none
+	ApplicationIDs []int64 `form:"applicationid"` // the selected applicants, all if none
+}
+
+// JobPipeline is the schema to set the hiring pipeline of a job, its stages in order. Stages given with their
+// StageID are kept along with the applicants in them, those without are added and those left out are removed.
+type JobPipeline struct {
+	JobID int64
+	Stages []pipeline.Stage
+}
+
+// StageMove is the schema to move applications to a stage of the pipeline of their job
+type StageMove struct {
+	ApplicationIDs []int64
+	StageID int64
+	Strict bool // move none unless all of them can be moved, otherwise those that cannot are skipped
+}
+
+// PipelineBoard is the pipeline of a job with the applicants in each stage, applications without a stage are in
+// the first one. Those rejected, withdrawn or declining the offer are in the stage they left the pipeline at.
+type PipelineBoard struct {
+	JobID int64
+	Title string
+	Stages []PipelineColumn
+}
+
+type PipelineColumn struct {
+	pipeline.Stage
+	Applicants []PipelineCard // in the process, offered or hired
+	Left []PipelineCard
+}
+
+type PipelineCard struct {
+	ApplicationID int64
+	StudentUserID int64
+	StudentName string
+	RollNumber string
+	Status string
+	InStageSince time.Time
+	Score *int64 // in the test of a test stage, nil if not taken
+	InterviewStatus string // of the interview of an interview stage, empty if none
+	InterviewAt *time.Time
+}
+
+// JobImportReport is the outcome of a bulk job import, rows are numbered from 1 in the order of the file
+type JobImportReport struct {
+	DryRun bool
+	Total int
+	Valid int
+	Imported []int64 // IDs of the jobs created as drafts, none on a dry run
+	Errors []JobImportError
+	// valid rows imported with a change, eg. a deadline that has passed left out
+	Warnings []JobImportError
+}
+
+// JobImportError is why a row of a bulk job import is invalid, or what was changed of it
+type JobImportError struct {
+	Row int
+	Title string
+	Message string
+}
+
+// Recommendation is an open job the student is eligible for and has not applied to, scored by how well it fits them
+type Recommendation struct {
+	JobID int64
+	Title string
+	CompanyName string
+	Location string
+	Type string
+	Salary string
+	Deadline *time.Time // nil if the job has no deadline
+	Score int // 0-100
+	Reasons []string // why it was recommended
+}
+
+// Applicant is an application to a job of the company along with the answers to the questions of the job
+type Applicant struct {
+	StudentID int64
+	StudentName string
+	RollNumber string
+	Gender string
+	Department string
+	StudentEmail string
+	ContactNo string
+	Cgpa pgtype.Float8
+	Skills pgtype.Text
+	JobID int64
+	Title string
+	Status string
+	InterviewStatus interface{}
+	ApplicationID int64
+	Answers []questions.Answer
+}
+
+// JobChange is the old and new value of a field in a job revision
+type JobChange struct {
+	From any
+	To any
+}
+
+// JobEligibility is the schema for the eligibility rules of a job, an empty one removes all restrictions
+type JobEligibility struct {
+	JobId int64
+	MinCGPA float64
+	Courses []string
+	Departments []string
+	YearsOfStudy []string
+	MaxBacklogs *int32 // null does not restrict, 0 allows none
+	Custom []eligibility.Constraint
+}
+
+// ApplicableJob is a job listed to a student, with whether they are eligible for it and why not
+type ApplicableJob struct {
+	JobID int64
+	Title string
+	Location string
+	Type string
+	Salary string
+	Position string
+	Skills []string
+	CompanyID int64
+	ActiveStatus bool
+	Deadline *time.Time // nil if the job has no deadline
+	Questions []questions.Question // to be answered on applying
+	Compensation *compensation.Compensation // nil if only the free-text salary is known
+	CompanyName string
+
+	Eligible bool
+	Reasons []string
+	Overridden bool // made eligible by an admin
+}
+
+// JobSearch are the query params of the job search, filters within a facet are ORed and across facets ANDed
+type JobSearch struct {
+	Query string `form:"q"` // websearch syntax, eg. golang -intern "remote work"
+	Locations []string `form:"location"`
+	Types []string `form:"type"`
+	Positions []string `form:"position"`
+	Industries []string `form:"industry"`
+	SalaryMin *float64 `form:"salarymin"` // in LPA, of the minimum CTC or the annual stipend of INR jobs
+	SalaryMax *float64 `form:"salarymax"` // in LPA
+	Posted string `form:"posted"` // 24h, 7d or 30d
+	Sort string `form:"sort"` // relevance (default), newest or salary
+	Cursor string `form:"cursor"` // NextCursor of the previous page
+	Limit int32 `form:"limit"`
+}
+
+type JobSearchResult struct {
+	Jobs []sqlc.SearchJobsRow
+	// facet (location, type, position, industry, salary, posted) -> counts of its values
+	Facets map[string][]FacetCount
+	// empty on the last page
+	NextCursor string
+}
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// StudentEligibility is the eligibility of a student for a job, as seen by the admins
+type StudentEligibility struct {
+	StudentID int64
+	StudentName string
+	RollNumber string
+	Eligible bool
+	Reasons []string
+	Overridden bool
+}
+
+type EligibilityOverride struct {
+	JobID int64
+	StudentID int64
+	Reason string
+	Revoke bool // revoke an existing override instead of granting one
+}
+
+// JobReviewDecision is the schema for an admin's review of a submitted job
+type JobReviewDecision struct {
+	JobID int64
+	Revision int32 // the revision reviewed, the review is rejected if the job was edited since
+	Approve bool
+	Comment string // required to reject, shown to the company
+}
+
+// JobReview is a past review of a job
+type JobReview struct {
+	Revision int32
+	Decision string
+	Comment string
+	CreatedAt time.Time
+}
+
+type StudentBacklogs struct {
+	StudentID int64
+	Backlogs int32
+}
+
+// PolicyException is the schema to grant or revoke an exception to a rule of the placement policy for a student
+type PolicyException struct {
+	StudentID int64
+	JobID int64 // 0 for all jobs
+	Rule string // max_offers, tier or accepted, see policy
+	Reason string
+	Revoke bool // revoke an existing exception instead of granting one
+}
+
+type JobRevision struct {
+	Revision int32
+	ChangedFields []string
+	Changes json.RawMessage // field -> JobChange
+	Material bool
+	CreatedAt time.Time
 }
 
 type AllJobs struct {
@@ -72,9 +453,12 @@ type NewInterview struct {
 	ApplicationId int64
 	UserId int64
 	DateTime time.Time `form:"DateTime" time_format:"2006-01-02T15:04"`
+	DurationMinutes int32 // config.InterviewDefaultDuration if 0
 	Type string
 	Location string
+	Panel string // optional, the interviewers
 	Notes string
+	Force bool // schedule despite conflicts, recorded in the audit log
 	StudentName string
 	StudentEmail string
 	JobTitle string
@@ -82,12 +466,25 @@ type NewInterview struct {
 	DT string
 }
 
+// InterviewConflictOverride is the audit log entry of an interview scheduled or updated despite conflicts
+type InterviewConflictOverride struct {
+	ApplicationID int64
+	DateTime time.Time
+	DurationMinutes int32
+	Location string
+	Panel string
+	Conflicts []sqlc.InterviewConflictsRow
+}
+
 type UpdateInterview struct {
 	InterviewID int64
-	DateTime time.Time `form:"DateTime" time_format:"2006-01-02T15:04"`
+	DateTime time.Time `form:"DateTime" time_format:"2006-01-02T15:04"` // unchanged if zero, a new time is proposed as a reschedule
+	DurationMinutes int32 // unchanged if 0
 	Type string
 	Location string
+	Panel string
 	Notes string
+	Force bool // update despite conflicts, recorded in the audit log
 
 	StudentName string
 	JobTitle string
@@ -95,6 +492,24 @@ type UpdateInterview struct {
 	DT string
 }
 
+// RescheduleProposal is the schema for either side proposing new times for a scheduled interview
+type RescheduleProposal struct {
+	InterviewID int64
+	Times []time.Time `form:"Times" time_format:"2006-01-02T15:04"`
+	Reason string
+	Force bool // companies alone, propose times conflicting with other events, recorded in the audit log
+}
+
+// RescheduleResponse is the schema for the other side responding to the pending reschedule proposal of an interview
+type RescheduleResponse struct {
+	InterviewID int64
+	Action string // reschedule.Accept, Reject or Counter
+	Time time.Time `form:"Time" time_format:"2006-01-02T15:04"` // accepted, one of the proposed times
+	Times []time.Time `form:"Times" time_format:"2006-01-02T15:04"` // counter-proposed
+	Reason string // required to counter-propose, optional otherwise
+	Force bool // companies alone, as for RescheduleProposal
+}
+
 type Offer struct {
 	ApplicationId int64 `form:"ApplicationId"`
 }
@@ -245,6 +660,80 @@ type NotificationData struct {
 	Title string
 	Description string
 	TimeStamp int64
+
+	// optional, defaults to general
+	Category string
+	// optional deep link to the entity the notification is about, eg. RefType: interview, RefID: interview_id
+	RefType string
+	RefID int64
+}
+
+type NotificationFilter struct {
+	Category string `form:"category"`
+	From string `form:"from"` // 2006-01-02, inclusive
+	To string `form:"to"` // 2006-01-02, inclusive
+	Archived bool `form:"archived"`
+}
+
+type DeviceToken struct {
+	Token string
+	Platform string // web, android or ios
+}
+
+type NotificationIDs struct {
+	NotifIDs []int64
+	All bool // mark all as read, NotifIDs is ignored
+	Archived bool // archive or unarchive
+}
+
+type DigestPreferences struct {
+	Frequency string // daily, weekly or off
+	SendHour int32 // 0-23, in the user's local time
+	Weekday int32 // 0 (Sunday) - 6, only used for weekly digests
+	Timezone string // IANA name, eg. Asia/Kolkata
+}
+
+type DigestData struct {
+	Email string
+	Frequency string
+	Since string
+
+	Notifications []NotificationData
+	Interviews []DigestItem
+	Tests []DigestItem
+	NewApplicants []DigestItem
+	Recommendations []DigestItem
+}
+
+type DigestItem struct {
+	Title string
+	Subtitle string
+	When string
+}
+
+type NewAnnouncement struct {
+	Title string
+	Message string
+
+	// targeting, zero values do not filter
+	// department, course, year of study and job (its applicants) only apply to students
+	TargetRole int64 // 1 student, 2 company, 3 admin, 0 everyone
+	Department string
+	Course string
+	YearOfStudy string
+	JobID int64
+
+	SkipEmail bool // only notify, no email
+	ScheduledAt time.Time // zero to deliver right away
+}
+
+type AnnouncementRead struct {
+	AnnouncementID int64
+}
+
+type AnnouncementData struct {
+	Title string
+	Message string
 }
 
 
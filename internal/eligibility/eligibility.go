@@ -0,0 +1,231 @@
+package eligibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Rules are the eligibility criteria of a job, zero values do not restrict.
+type Rules struct {
+	MinCGPA float64
+	Courses []string
+	Departments []string
+	YearsOfStudy []string
+	MaxBacklogs *int32 // nil does not restrict, 0 allows none
+	Custom []Constraint
+}
+
+// Constraint is a custom criterion on a key of the extras of the student,
+// eg. {Key: "TenthPercentage", Op: ">=", Value: "75"} or {Key: "Domicile", Op: "in", Value: "MH,GA"}.
+// Numeric values are compared as numbers, anything else as case-insensitive text.
+type Constraint struct {
+	Key string
+	Op string
+	Value string
+}
+
+// Profile is what a student is checked against.
+type Profile struct {
+	Course string
+	Department string
+	YearOfStudy string
+	CGPA pgtype.Float8
+	Backlogs int32
+	Extras map[string]any
+}
+
+var operators = map[string]bool{
+	"==": true,
+	"!=": true,
+	">": true,
+	">=": true,
+	"<": true,
+	"<=": true,
+	"in": true,
+}
+
+// protectedKeys are personal attributes custom constraints may not discriminate on
+var protectedKeys = map[string]bool{
+	"gender": true,
+	"sex": true,
+	"dob": true,
+	"age": true,
+	"religion": true,
+	"caste": true,
+	"category": true,
+	"maritalstatus": true,
+	"nationality": true,
+}
+
+// FromColumns builds the rules from the job_eligibility columns. A job without a row has all of them NULL,
+// which is no restriction.
+func FromColumns(minCGPA pgtype.Float8, courses []string, departments []string, years []string, maxBacklogs pgtype.Int4, custom []byte) (*Rules, error) {
+
+	r := &Rules{
+		MinCGPA: minCGPA.Float64,
+		Courses: courses,
+		Departments: departments,
+		YearsOfStudy: years,
+	}
+	if maxBacklogs.Valid {
+		r.MaxBacklogs = &maxBacklogs.Int32
+	}
+	if len(custom) != 0 {
+		err := json.Unmarshal(custom, &r.Custom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom eligibility constraints : %v", err)
+		}
+	}
+
+	return r, nil
+}
+
+// ProfileFrom builds the profile of a student from its columns, extras that are not a JSON object are ignored.
+func ProfileFrom(course string, department string, year string, cgpa pgtype.Float8, backlogs int32, extras []byte) *Profile {
+
+	p := &Profile{
+		Course: course,
+		Department: department,
+		YearOfStudy: year,
+		CGPA: cgpa,
+		Backlogs: backlogs,
+		Extras: make(map[string]any),
+	}
+	_ = json.Unmarshal(extras, &p.Extras)
+
+	return p
+}
+
+// Validate checks the rules are well formed, the returned error is meant for the user.
+func (r *Rules) Validate() error {
+
+	if r.MinCGPA < 0 || r.MinCGPA > 10 {
+		return fmt.Errorf("minimum CGPA must be within 0-10")
+	}
+	if r.MaxBacklogs != nil && *r.MaxBacklogs < 0 {
+		return fmt.Errorf("maximum backlogs cannot be negative")
+	}
+	for _, c := range r.Custom {
+		if c.Key == "" || c.Value == "" {
+			return fmt.Errorf("custom constraints need a key and a value")
+		}
+		if !operators[c.Op] {
+			return fmt.Errorf("unknown operator %q in custom constraint on %s, expected one of ==, !=, >, >=, <, <=, in", c.Op, c.Key)
+		}
+		if protectedKeys[normalizeKey(c.Key)] {
+			return fmt.Errorf("custom constraints cannot be on %s", c.Key)
+		}
+	}
+
+	return nil
+}
+
+// Check returns the reasons the student does not meet the rules, none if eligible.
+func (r *Rules) Check(p *Profile) []string {
+
+	reasons := []string{}
+
+	if r.MinCGPA > 0 {
+		if !p.CGPA.Valid {
+			reasons = append(reasons, fmt.Sprintf("Minimum CGPA of %.2f required, your CGPA is not on record.", r.MinCGPA))
+		} else if p.CGPA.Float64 < r.MinCGPA {
+			reasons = append(reasons, fmt.Sprintf("Minimum CGPA of %.2f required, yours is %.2f.", r.MinCGPA, p.CGPA.Float64))
+		}
+	}
+	if len(r.Courses) != 0 && !containsFold(r.Courses, p.Course) {
+		reasons = append(reasons, fmt.Sprintf("Open only to %s courses.", strings.Join(r.Courses, ", ")))
+	}
+	if len(r.Departments) != 0 && !containsFold(r.Departments, p.Department) {
+		reasons = append(reasons, fmt.Sprintf("Open only to %s departments.", strings.Join(r.Departments, ", ")))
+	}
+	if len(r.YearsOfStudy) != 0 && !containsFold(r.YearsOfStudy, p.YearOfStudy) {
+		reasons = append(reasons, fmt.Sprintf("Open only to year(s) %s.", strings.Join(r.YearsOfStudy, ", ")))
+	}
+	if r.MaxBacklogs != nil && p.Backlogs > *r.MaxBacklogs {
+		reasons = append(reasons, fmt.Sprintf("At most %d backlog(s) allowed, you have %d.", *r.MaxBacklogs, p.Backlogs))
+	}
+
+	for _, c := range r.Custom {
+		value, exists := lookup(p.Extras, c.Key)
+		if !exists {
+			reasons = append(reasons, fmt.Sprintf("Requires %s %s %s, which is not on your profile.", c.Key, c.Op, c.Value))
+			continue
+		}
+		if !compare(value, c.Op, c.Value) {
+			reasons = append(reasons, fmt.Sprintf("Requires %s %s %s, yours is %s.", c.Key, c.Op, c.Value, value))
+		}
+	}
+
+	return reasons
+}
+
+// Empty reports whether the rules do not restrict anyone.
+func (r *Rules) Empty() bool {
+	return r.MinCGPA == 0 && len(r.Courses) == 0 && len(r.Departments) == 0 && len(r.YearsOfStudy) == 0 &&
+		r.MaxBacklogs == nil && len(r.Custom) == 0
+}
+
+// lookup finds the key in the extras ignoring case, spaces and underscores, the value is stringified
+func lookup(extras map[string]any, key string) (string, bool) {
+	want := normalizeKey(key)
+	for k, v := range extras {
+		if normalizeKey(k) == want && v != nil {
+			return fmt.Sprint(v), true
+		}
+	}
+	return "", false
+}
+
+func compare(actual string, op string, expected string) bool {
+
+	if op == "in" {
+		for _, option := range strings.Split(expected, ",") {
+			if strings.EqualFold(strings.TrimSpace(option), strings.TrimSpace(actual)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	a, errA := strconv.ParseFloat(strings.TrimSpace(actual), 64)
+	e, errE := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+	if errA == nil && errE == nil {
+		switch op {
+		case "==": return a == e
+		case "!=": return a != e
+		case ">": return a > e
+		case ">=": return a >= e
+		case "<": return a < e
+		case "<=": return a <= e
+		}
+		return false
+	}
+
+	c := strings.Compare(strings.ToLower(strings.TrimSpace(actual)), strings.ToLower(strings.TrimSpace(expected)))
+	switch op {
+	case "==": return c == 0
+	case "!=": return c != 0
+	case ">": return c > 0
+	case ">=": return c >= 0
+	case "<": return c < 0
+	case "<=": return c <= 0
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(strings.TrimSpace(item), strings.TrimSpace(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(key))
+}
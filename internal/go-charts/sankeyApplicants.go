@@ -18,6 +18,8 @@ func SankeyApplicants(data *sqlc.ApplicantsCountRow) *charts.Sankey {
 	urc := hc + oc + slc + float32(data.ReviewedCount)
 	ac := float32(data.TotalApps)
 	rc := float32(data.RejectedCount)
+	// withdrawn by the student, from whichever stage, an outcome of its own
+	wc := float32(data.WithdrawnCount)
 	rcURC := urc - slc - float32(data.ReviewedCount)
 	rcSLC := slc - oc - float32(data.ShortlistedCount)
 
@@ -28,6 +30,7 @@ func SankeyApplicants(data *sqlc.ApplicantsCountRow) *charts.Sankey {
 		{Name: "Rejected", Value: fmt.Sprintf("%f", rc), Depth: opts.Int(3)},
 		{Name: "Offered", Value: fmt.Sprintf("%f", oc), Depth: opts.Int(3)},
 		{Name: "Hired", Value: fmt.Sprintf("%f", hc), Depth: opts.Int(4)},
+		{Name: "Withdrawn", Value: fmt.Sprintf("%f", wc), Depth: opts.Int(1)},
 	}
 
 	var sankeyLink = []opts.SankeyLink{
@@ -37,6 +40,7 @@ func SankeyApplicants(data *sqlc.ApplicantsCountRow) *charts.Sankey {
 		{Source: "Shortlisted", Target: "Offered", Value: float32(max(oc, zeroLinkval))},
 		{Source: "Shortlisted", Target: "Rejected", Value: float32(max(rcSLC, zeroLinkval))},
 		{Source: "Offered", Target: "Hired", Value: float32(max(hc, zeroLinkval))},
+		{Source: "Total Applicants", Target: "Withdrawn", Value: float32(max(wc, zeroLinkval))},
 	}
 
 
@@ -0,0 +1,71 @@
+package gocharts
+
+import (
+	"fmt"
+
+	"github.com/go-echarts/go-echarts/v2/charts"
+	"github.com/go-echarts/go-echarts/v2/opts"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// SankeyPipeline generates the sankey chart of the applicants to a job through the stages of its pipeline, in order
+// of position, with those who left the pipeline flowing out of the stage they left at
+func SankeyPipeline(jobID int64, stages []sqlc.CompanyPipelineFlowsRow) *charts.Sankey {
+	zeroLinkval := float32(0.05)
+
+	// node names are unique in a chart, stage names may be those of the outcomes
+	names := make(map[string]bool)
+	for _, s := range stages {
+		names[s.Name] = true
+	}
+	outcome := func(name string) string {
+		if names[name] {
+			return name + " (outcome)"
+		}
+		return name
+	}
+
+	var sankeyNode []opts.SankeyNode
+	var sankeyLink []opts.SankeyLink
+	outcomes := make(map[string]float32)
+	for i, s := range stages {
+		sankeyNode = append(sankeyNode, opts.SankeyNode{Name: s.Name, Value: fmt.Sprintf("%f", float32(s.Reached)), Depth: opts.Int(i)})
+
+		if i + 1 < len(stages) {
+			next := stages[i + 1]
+			sankeyLink = append(sankeyLink, opts.SankeyLink{Source: s.Name, Target: next.Name, Value: float32(max(float32(next.Reached), zeroLinkval))})
+		}
+		for _, out := range []struct {
+			name string
+			count int64
+		}{
+			{"Rejected", s.Rejected},
+			{"Withdrawn", s.Withdrawn},
+			{"Declined", s.Declined},
+			{"Hired", s.Hired},
+		} {
+			if out.count == 0 {
+				continue
+			}
+			outcomes[out.name] += float32(out.count)
+			sankeyLink = append(sankeyLink, opts.SankeyLink{Source: s.Name, Target: outcome(out.name), Value: float32(out.count)})
+		}
+	}
+	for _, name := range []string{"Rejected", "Withdrawn", "Declined", "Hired"} {
+		if value, exists := outcomes[name]; exists {
+			sankeyNode = append(sankeyNode, opts.SankeyNode{Name: outcome(name), Value: fmt.Sprintf("%f", value)})
+		}
+	}
+
+
+	sankey := charts.NewSankey()
+
+	sankey.AddSeries(fmt.Sprintf("%d", jobID), sankeyNode, sankeyLink)
+	sankey.SetGlobalOptions(
+		charts.WithTitleOpts(opts.Title{
+			Title: fmt.Sprintf("%d", jobID),
+		}),
+	)
+
+	return sankey
+}
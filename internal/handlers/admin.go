@@ -6,7 +6,10 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"go.mod/internal/config"
 	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/policy"
 	"go.mod/internal/services"
 )
 
@@ -39,10 +42,59 @@ func (h *AdminHandler) RegisterRoute(adminRoute *gin.RouterGroup) {
 	// generates the test results, returns them, and triggers other funcs
 	adminRoute.GET("/testresult", h.GenerateTestResult)
 
+	// compose targeted (and optionally scheduled) announcements, list them with read counts
+	adminRoute.POST("/announcements", h.NewAnnouncement)
+	adminRoute.GET("/announcements", h.ListAnnouncements)
+
+	// eligibility of all students for a job, per student overrides and backlogs, audited
+	adminRoute.GET("/jobeligibility", h.JobEligibilityStudents)
+	adminRoute.POST("/eligibilityoverride", h.EligibilityOverride)
+	adminRoute.POST("/studentbacklogs", h.UpdateStudentBacklogs)
+
+	// jobs awaiting review, and approve or reject one
+	adminRoute.GET("/jobreviews", h.JobReviewQueue)
+	adminRoute.POST("/reviewjob", h.ReviewJob)
+
+	// placement policy, offer limits, CTC tiers and blocking after acceptance, and per student exceptions, audited
+	adminRoute.GET("/placementpolicy", h.PlacementPolicy)
+	adminRoute.POST("/placementpolicy", h.UpdatePlacementPolicy)
+	adminRoute.POST("/policyexception", h.PolicyException)
+	adminRoute.GET("/policyexceptions", h.PolicyExceptions)
+	// offers made and accepted, placed students and CTCs, overall and by department
+	adminRoute.GET("/placementstats", h.PlacementStats)
+
+	// placement-wide applicant export with the selected columns, or the resumes
+	adminRoute.GET("/exportapplicants", h.ExportApplicants)
+
 }
 
 
 
+// extractUserID extracts the user ID from the context with explicit type assertion.
+// any returned error is directly included in the response as returned
+func (h *AdminHandler) extractUserID(ctx *gin.Context) (int64, *errs.Error) {
+
+	userid, exists := ctx.Get("ID")
+	if !exists {
+		return 0, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing user ID in request.",
+			ToRespondWith: true,
+		}
+	}
+
+	userID, ok := userid.(int64)
+	if !ok {
+		return 0, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "User ID of improper format.",
+			ToRespondWith: true,
+		}
+	}
+
+	return userID, nil 
+}
+
 func (h *AdminHandler) AdminDashboard(ctx *gin.Context) {
 	ctx.File("./template/dashboard/admindashboard.html")
 }
@@ -66,7 +118,19 @@ func (h *AdminHandler) GetNotifications(ctx *gin.Context) {
 		return
 	}
 
-	notifs, errf := h.AdminService.Notify.GetNotifications(ctx, userid.(int64), start, end)
+	// optional category, from, to and archived filters
+	filter := new(dto.NotificationFilter)
+	err := ctx.ShouldBindQuery(filter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid notification filters : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	notifs, errf := h.AdminService.Notify.GetNotifications(ctx, userid.(int64), start, end, filter)
 	if errf != nil {
 		if errf.Type != errs.Internal {
 			ctx.JSON(http.StatusBadRequest, gin.H{
@@ -152,4 +216,360 @@ func (h *AdminHandler) GenerateTestResult(ctx *gin.Context) {
 	}
 
 	ctx.File(os.Getenv("ResultDraftStorage"))
-}
\ No newline at end of file
+}
+
+// NewAnnouncement composes an announcement for the targeted audience, delivered at its scheduled time.
+func (h *AdminHandler) NewAnnouncement(ctx *gin.Context) {
+
+	data := new(dto.NewAnnouncement)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid announcement : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	announcementID, errf := h.AdminService.NewAnnouncement(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Scheduled announcement successfully.",
+		"AnnouncementID": announcementID,
+	})
+}
+
+// ListAnnouncements returns a page of all the announcements with their delivery and read counts.
+func (h *AdminHandler) ListAnnouncements(ctx *gin.Context) {
+
+	page := ctx.DefaultQuery("page", "1")
+
+	announcements, errf := h.AdminService.ListAnnouncements(ctx, page)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Data": announcements,
+		"Limit": config.NoticeBoardPageLimit,
+	})
+}
+
+// JobEligibilityStudents returns whether each student is eligible for the job in the query, with the reasons if not.
+func (h *AdminHandler) JobEligibilityStudents(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	students, errf := h.AdminService.JobEligibilityStudents(ctx, jobid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Data": students,
+	})
+}
+
+// EligibilityOverride grants or revokes a student's eligibility for a job regardless of its rules.
+func (h *AdminHandler) EligibilityOverride(ctx *gin.Context) {
+
+	data := new(dto.EligibilityOverride)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid eligibility override : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.AdminService.EligibilityOverride(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Updated eligibility override successfully.",
+	})
+}
+
+// JobReviewQueue returns the jobs submitted for review, the longest waiting first.
+func (h *AdminHandler) JobReviewQueue(ctx *gin.Context) {
+
+	queue, errf := h.AdminService.JobReviewQueue(ctx)
+	if errf != nil {
+		ctx.Set("error", errf.Message)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Jobs": queue,
+	})
+}
+
+// ReviewJob approves or rejects a submitted job, a comment is required to reject.
+func (h *AdminHandler) ReviewJob(ctx *gin.Context) {
+
+	data := new(dto.JobReviewDecision)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid job review : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.AdminService.ReviewJob(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Reviewed job successfully.",
+	})
+}
+
+// UpdateStudentBacklogs sets the number of backlogs of a student.
+func (h *AdminHandler) UpdateStudentBacklogs(ctx *gin.Context) {
+
+	data := new(dto.StudentBacklogs)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid backlogs : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.AdminService.UpdateStudentBacklogs(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Updated backlogs successfully.",
+	})
+}
+
+// PlacementPolicy returns the placement policy in effect.
+func (h *AdminHandler) PlacementPolicy(ctx *gin.Context) {
+
+	p, errf := h.AdminService.PlacementPolicy(ctx)
+	if errf != nil {
+		ctx.Set("error", errf.Message)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Policy": p,
+	})
+}
+
+// UpdatePlacementPolicy replaces the placement policy, the tiers are given as a JSON array.
+func (h *AdminHandler) UpdatePlacementPolicy(ctx *gin.Context) {
+
+	data := new(policy.Policy)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid placement policy : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.AdminService.UpdatePlacementPolicy(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Updated placement policy successfully.",
+	})
+}
+
+// PolicyException grants or revokes an exception to a rule of the placement policy for a student.
+func (h *AdminHandler) PolicyException(ctx *gin.Context) {
+
+	data := new(dto.PolicyException)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid placement policy exception : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.AdminService.PolicyException(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Updated placement policy exception successfully.",
+	})
+}
+
+// PolicyExceptions lists the placement policy exceptions, of one student if a student ID is given.
+func (h *AdminHandler) PolicyExceptions(ctx *gin.Context) {
+
+	exceptions, errf := h.AdminService.PolicyExceptions(ctx, ctx.Query("studentid"))
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Exceptions": exceptions,
+	})
+}
+
+// PlacementStats returns the offers made and their responses, and the placed students overall and by department
+func (h *AdminHandler) PlacementStats(ctx *gin.Context) {
+
+	stats, errf := h.AdminService.PlacementStats(ctx)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// ExportApplicants downloads the applicants to the jobs of all companies, or a job, as a CSV, XLSX or a ZIP of their resumes, uses dto.ApplicantExport
+func (h *AdminHandler) ExportApplicants(ctx *gin.Context) {
+
+	data := new(dto.ApplicantExport)
+	err := ctx.ShouldBindQuery(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid export query : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	file, errf := h.AdminService.ExportApplicants(ctx, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=" + file.Name)
+	ctx.Header("Content-Type", file.ContentType)
+	ctx.Status(http.StatusOK)
+	err = file.Write(ctx.Writer)
+	if err != nil {
+		// the response has started, only the log can tell
+		ctx.Set("error", "Failed to write applicant export : " + err.Error())
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
@@ -9,7 +10,9 @@ import (
 	"go.mod/internal/config"
 	errs "go.mod/internal/const"
 	"go.mod/internal/dto"
+	"go.mod/internal/letter"
 	"go.mod/internal/services"
+	"go.mod/internal/utils"
 )
 
 // >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
@@ -39,6 +42,22 @@ func (h *CompanyHandler) RegisterRoute(companyRoute *gin.RouterGroup) {
 	companyRoute.GET("/newjob", h.NewJob)
 	// post new job form
 	companyRoute.POST("/newjobpost", h.NewJobPost)
+	// update an existing job, records a new revision
+	companyRoute.POST("/updatejob", h.UpdateJob)
+	// get the edit history of a job
+	companyRoute.GET("/jobrevisions", h.JobRevisions)
+	// submit a draft or rejected job for review by the admins, and get its past reviews
+	companyRoute.POST("/submitjob", h.SubmitJob)
+	companyRoute.GET("/jobreviews", h.JobReviews)
+	// create jobs in bulk from a CSV or JSON file, and export jobs in the same format
+	companyRoute.POST("/importjobs", h.ImportJobs)
+	companyRoute.GET("/exportjobs", h.ExportJobs)
+	// get or set the eligibility rules of a job
+	companyRoute.GET("/jobeligibility", h.JobEligibility)
+	companyRoute.POST("/jobeligibility", h.SetJobEligibility)
+	// get or set the hiring pipeline of a job, its ordered stages
+	companyRoute.GET("/jobpipeline", h.JobPipeline)
+	companyRoute.POST("/jobpipeline", h.SetJobPipeline)
 
 	// get the template for all applicants
 	companyRoute.GET("/applicants", h.ApplicantsStatic)
@@ -47,6 +66,8 @@ func (h *CompanyHandler) RegisterRoute(companyRoute *gin.RouterGroup) {
 
 	// get any student's file (resume, result)
 	companyRoute.GET("/getstudentfile", h.GetResumeOrResultFile)
+	// get the file an applicant uploaded as an answer to a question of the job
+	companyRoute.GET("/answerfile", h.AnswerFile)
 	// get my job listings template
 	companyRoute.GET("/joblistings", h.JobListingsStatic)
 	// get my job listings
@@ -60,12 +81,30 @@ func (h *CompanyHandler) RegisterRoute(companyRoute *gin.RouterGroup) {
 	companyRoute.POST("/shortlist", h.ShortList)
 	// reject given application
 	companyRoute.POST("/reject", h.Reject)
-	// offer given application
+	// shortlist or reject many applications at once, given or by a filter, with a result per application
+	companyRoute.POST("/bulkshortlist", h.BulkShortList)
+	companyRoute.POST("/bulkreject", h.BulkReject)
+	// download the applicants to a job with the selected columns, or their resumes
+	companyRoute.GET("/exportapplicants", h.ExportApplicants)
+	// offer given application, with an uploaded offer letter or one generated from a template
 	companyRoute.POST("/offer", h.Offer)
+	// offer many applications at once on the same terms, each with a letter generated from a template
+	companyRoute.POST("/bulkoffer", h.BulkOffer)
+	// offer letter templates with placeholders, and a preview of one as a PDF
+	companyRoute.GET("/offertemplates", h.OfferLetterTemplates)
+	companyRoute.POST("/offertemplate", h.SaveOfferLetterTemplate)
+	companyRoute.GET("/deleteoffertemplate", h.DeleteOfferLetterTemplate)
+	companyRoute.GET("/offertemplatepreview", h.PreviewOfferLetterTemplate)
 	// schedule interview for given application
 	companyRoute.POST("/scheduleinterview", h.ScheduleInterview)
 	// cancel interview for given application
 	companyRoute.POST("/cancelinterview", h.CancelInterview)
+	// get the status history of given application
+	companyRoute.GET("/applicationtimeline", h.ApplicationTimeline)
+	// get the applicants to a job by the stage of its pipeline they are in
+	companyRoute.GET("/pipelineboard", h.PipelineBoard)
+	// move applications to a stage of the pipeline of their job
+	companyRoute.POST("/movestage", h.MoveStage)
 
 	// get new test form or template
 	companyRoute.GET("/newtest", h.NewTestStatic)
@@ -78,6 +117,10 @@ func (h *CompanyHandler) RegisterRoute(companyRoute *gin.RouterGroup) {
 	companyRoute.GET("/scheduleddata", h.ScheduledData)
 	// update interview details
 	companyRoute.POST("/updateinterview", h.UpdateInterview)
+	// propose new times for an interview, respond to the student's proposal, and get the proposals of an interview
+	companyRoute.POST("/proposereschedule", h.ProposeReschedule)
+	companyRoute.POST("/respondreschedule", h.RespondReschedule)
+	companyRoute.GET("/reschedules", h.Reschedules)
 
 	// get the completed events template
 	companyRoute.GET("/completed", h.CompletedStatic)
@@ -214,9 +257,25 @@ func (h *CompanyHandler) GetNotifications(ctx *gin.Context) {
 		return
 	}
 
-	notifs, errf := h.CompanyService.Notify.GetNotifications(ctx, userID, start, end)
+	// optional category, from, to and archived filters
+	filter := new(dto.NotificationFilter)
+	err := ctx.ShouldBindQuery(filter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid notification filters : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	notifs, errf := h.CompanyService.Notify.GetNotifications(ctx, userID, start, end, filter)
 	if errf != nil {
-		ctx.Set("error", errf.Message)
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
 		return
 	}
 
@@ -239,17 +298,752 @@ func (h *CompanyHandler) NewJob(ctx *gin.Context) {
 		ctx.Redirect(http.StatusSeeOther, GoogleFormLink)
 	}
 }
-// NewJobPost takes a post request and creates a new job
-func (h *CompanyHandler) NewJobPost(ctx *gin.Context) {
-	
-	jobdata := new(dto.NewJobData)
+// NewJobPost takes a post request and creates a new job, fields outside of dto.NewJobData are rejected
+func (h *CompanyHandler) NewJobPost(ctx *gin.Context) {
+	
+	jobdata := new(dto.NewJobData)
+
+	err := utils.BindStrict(ctx, jobdata)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid or incomplete form : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.NewJobPost(ctx, jobdata, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+	
+	// TODO: the user can just go back and submit form again which is dangerous
+	ctx.Status(http.StatusOK)
+}
+// UpdateJob replaces the fields of a job, the body must carry the revision it was edited on.
+// Fields outside of dto.UpdateJobData are rejected.
+func (h *CompanyHandler) UpdateJob(ctx *gin.Context) {
+
+	jobdata := new(dto.UpdateJobData)
+
+	err := utils.BindStrict(ctx, jobdata)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid or incomplete form : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	revision, errf := h.CompanyService.UpdateJob(ctx, jobdata, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Updated job successfully.",
+		"Revision": revision,
+	})
+}
+// JobEligibility returns the eligibility rules of a job
+func (h *CompanyHandler) JobEligibility(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	rules, errf := h.CompanyService.JobEligibility(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Eligibility": rules,
+	})
+}
+// SetJobEligibility replaces the eligibility rules of a job, fields outside of dto.JobEligibility are rejected
+func (h *CompanyHandler) SetJobEligibility(ctx *gin.Context) {
+
+	data := new(dto.JobEligibility)
+
+	err := utils.BindStrict(ctx, data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid eligibility rules : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.SetJobEligibility(ctx, data, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Updated eligibility rules successfully.",
+	})
+}
+// JobPipeline returns the stages of the hiring pipeline of a job, none if it has no pipeline
+func (h *CompanyHandler) JobPipeline(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	stages, errf := h.CompanyService.JobPipeline(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Stages": stages,
+	})
+}
+// SetJobPipeline sets the stages of the hiring pipeline of a job in order, uses dto.JobPipeline
+func (h *CompanyHandler) SetJobPipeline(ctx *gin.Context) {
+
+	data := new(dto.JobPipeline)
+
+	err := utils.BindStrict(ctx, data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid pipeline : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	stages, errf := h.CompanyService.SetJobPipeline(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Updated job pipeline successfully.",
+		"Stages": stages,
+	})
+}
+// JobRevisions returns the edit history of a job, with the old and new value of every changed field
+func (h *CompanyHandler) JobRevisions(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	revisions, errf := h.CompanyService.JobRevisions(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Revisions": revisions,
+	})
+}
+// SubmitJob submits a draft or rejected job for review, it is shown to students once approved
+func (h *CompanyHandler) SubmitJob(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.SubmitJob(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Job submitted for review successfully.",
+	})
+}
+// JobReviews returns the admin reviews of a job along with their comments, latest first
+func (h *CompanyHandler) JobReviews(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	reviews, errf := h.CompanyService.JobReviews(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Reviews": reviews,
+	})
+}
+// ImportJobs creates jobs in bulk from the CSV or JSON file in the form field Jobs, see services.ImportJobs.
+// With ?dryrun=true the jobs are only validated. Responds with the report of the valid and invalid rows.
+func (h *CompanyHandler) ImportJobs(ctx *gin.Context) {
+
+	file, err := ctx.FormFile("Jobs")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing the jobs file.",
+			ToRespondWith: true,
+		})
+		return
+	}
+	if file.Size > config.JobImportMaxFileSize {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("The jobs file is too large, the maximum is %d bytes.", config.JobImportMaxFileSize),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		ctx.Set("error", "Failed to open the jobs file : " + err.Error())
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		ctx.Set("error", "Failed to read the jobs file : " + err.Error())
+		return
+	}
+
+	report, errf := h.CompanyService.ImportJobs(ctx, userID, file.Filename, data, ctx.Query("dryrun") == "true")
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+// ExportJobs downloads the jobs of the company as a CSV or JSON file to import again,
+// ?format=csv|json, optionally only the ones posted within ?from= and ?to= (2006-01-02)
+func (h *CompanyHandler) ExportJobs(ctx *gin.Context) {
+
+	format := ctx.DefaultQuery("format", "csv")
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	file, contentType, errf := h.CompanyService.ExportJobs(ctx, userID, format, ctx.Query("from"), ctx.Query("to"))
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=jobs." + format)
+	ctx.Data(http.StatusOK, contentType, file)
+}
+// ExportApplicants downloads the applicants to a job of the company as a CSV, XLSX or a ZIP of their resumes, uses dto.ApplicantExport
+func (h *CompanyHandler) ExportApplicants(ctx *gin.Context) {
+
+	data := new(dto.ApplicantExport)
+	err := ctx.ShouldBindQuery(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid export query : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	file, errf := h.CompanyService.ExportApplicants(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=" + file.Name)
+	ctx.Header("Content-Type", file.ContentType)
+	ctx.Status(http.StatusOK)
+	err = file.Write(ctx.Writer)
+	if err != nil {
+		// the response has started, only the log can tell
+		ctx.Set("error", "Failed to write applicant export : " + err.Error())
+	}
+}
+// ApplicationTimeline returns the status history of an application and the statuses the company can move it to
+func (h *CompanyHandler) ApplicationTimeline(ctx *gin.Context) {
+
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	timeline, errf := h.CompanyService.ApplicationTimeline(ctx, userID, applicationid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Timeline": timeline,
+	})
+}
+// ApplicantsStatic returns the MyApplicants template for company role
+func (h *CompanyHandler) ApplicantsStatic(ctx *gin.Context) {
+	filePath := config.Paths.CompanyMyApplicantsTemplatePath
+	errf := h.checkFile(ctx, filePath)
+	if errf != nil {
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.File(filePath)
+}
+// ApplicantsData retrieves applicant records based on the specified criteria:
+// - Returns all applicants if jobid and appid == 0.
+// - Returns applicants for a specific job if a job ID is provided and appid == 0.
+// - Returns details of a specific application if an application ID is provided and jobid == 0.
+func (h *CompanyHandler) ApplicantsData(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	appid := ctx.Query("appid")
+	if jobid == "" || appid == "" { 
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing required fields in request url.",
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	applicantsData, errf := h.CompanyService.ApplicantsData(ctx, userID, jobid, appid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Applicants": applicantsData,
+	})
+}
+// GetResumeOrResultFile is specifically used to get student files like resume and result and cover letter in upcoming versions
+func (h *CompanyHandler) GetResumeOrResultFile(ctx *gin.Context) {
+
+	// get applicationid and type ('resume' or 'result') of file 
+	applicationid := ctx.Query("applicationid")
+	filetype := ctx.Query("type")
+	if applicationid == "" || filetype == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing required fields in request url.",
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	filePath, errf := h.CompanyService.GetResumeOrResultFilePath(ctx, userID, applicationid, filetype)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	// browser heavily caches these files
+	// prevent it from doing that by setting this header
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0")
+	ctx.File(filePath)
+}
+// AnswerFile serves the file an applicant uploaded as the answer to a file question of the job
+func (h *CompanyHandler) AnswerFile(ctx *gin.Context) {
+
+	applicationid := ctx.Query("applicationid")
+	questionID := ctx.Query("question")
+	if applicationid == "" || questionID == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing required fields in request url.",
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	filePath, errf := h.CompanyService.AnswerFilePath(ctx, userID, applicationid, questionID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0")
+	ctx.File(filePath)
+}
+// JobListingsStatic returns the JobListings page for the company role
+func (h *CompanyHandler) JobListingsStatic(ctx *gin.Context) {
+
+	filePath := config.Paths.CompanyMyJobListingsTemplatePath
+	errf := h.checkFile(ctx, filePath)
+	if errf != nil {
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.File(filePath)
+}
+// JobListingsData gets the JobListings data for the company role
+func (h *CompanyHandler) JobListingsData(ctx *gin.Context) {
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	jobListings, errf := h.CompanyService.JobListings(ctx, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Listings": jobListings,
+	})
+}
+// CloseJob marks the job listing active_status to false, effectively closing the listing for further applicants
+func (h *CompanyHandler) CloseJob(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.CloseJob(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Closed job successfully.",
+	})
+}
+// DeleteJob directly deletes the requested job, job will be archived instead of deletion in upcoming revisions. 
+func (h *CompanyHandler) DeleteJob(ctx *gin.Context) {
+
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.DeleteJob(ctx, jobid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Deleted job successfully.",
+	})
+}
+// ShortList changes the application status to 'ShortListed', the status has to be 'Under Review'
+func (h *CompanyHandler) ShortList(ctx *gin.Context) {
+
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID parameter in request url.", 
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.ShortList(ctx, applicationid, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Application shortlisted successfully",
+	})
+}
+// Reject changes the application status to 'Rejected', also changes interview status to 'Completed'.
+func (h *CompanyHandler) Reject(ctx *gin.Context) {
+
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID parameter in request url.", 
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.CompanyService.Reject(ctx, applicationid, userID, ctx.PostForm("Reason"))
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Application rejected successfully",
+	})
+}
+// PipelineBoard returns the stages of the pipeline of a job with the applicants in each, see dto.PipelineBoard
+func (h *CompanyHandler) PipelineBoard(ctx *gin.Context) {
 
-	err := ctx.Bind(jobdata)
-	if err != nil {
+	jobid := ctx.Query("jobid")
+	if jobid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.IncompleteForm,
-			Message: "Invalid or incomplete form.",
-			ToRespondWith: true,
+			Type: errs.MissingRequiredField,
+			Message: "Missing job ID parameter in request url.",
+			ToRespondWith: true, 
 		})
 		return
 	}
@@ -260,7 +1054,7 @@ func (h *CompanyHandler) NewJobPost(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.NewJobPost(ctx, jobdata, userID)
+	board, errf := h.CompanyService.PipelineBoard(ctx, jobid, userID)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -269,33 +1063,21 @@ func (h *CompanyHandler) NewJobPost(ctx *gin.Context) {
 		}
 		return
 	}
-	
-	// TODO: the user can just go back and submit form again which is dangerous
-	ctx.Status(http.StatusOK)
-}
-// ApplicantsStatic returns the MyApplicants template for company role
-func (h *CompanyHandler) ApplicantsStatic(ctx *gin.Context) {
-	filePath := config.Paths.CompanyMyApplicantsTemplatePath
-	errf := h.checkFile(ctx, filePath)
-	if errf != nil {
-		ctx.Status(http.StatusInternalServerError)
-		return
-	}
 
-	ctx.File(filePath)
+	ctx.JSON(http.StatusOK, gin.H{
+		"Board": board,
+	})
 }
-// ApplicantsData retrieves applicant records based on the specified criteria:
-// - Returns all applicants if jobid and appid == 0.
-// - Returns applicants for a specific job if a job ID is provided and appid == 0.
-// - Returns details of a specific application if an application ID is provided and jobid == 0.
-func (h *CompanyHandler) ApplicantsData(ctx *gin.Context) {
+// MoveStage moves applications to a stage of the pipeline of their job in one transaction, uses dto.StageMove
+func (h *CompanyHandler) MoveStage(ctx *gin.Context) {
 
-	jobid := ctx.Query("jobid")
-	appid := ctx.Query("appid")
-	if jobid == "" || appid == "" { 
+	data := new(dto.StageMove)
+	err := ctx.Bind(data)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "Missing required fields in request url.",
+			Type: errs.IncompleteForm,
+			Message: "Invalid stage move : " + err.Error(),
+			ToRespondWith: true,
 		})
 		return
 	}
@@ -306,7 +1088,7 @@ func (h *CompanyHandler) ApplicantsData(ctx *gin.Context) {
 		return
 	}
 
-	applicantsData, errf := h.CompanyService.ApplicantsData(ctx, userID, jobid, appid)
+	report, errf := h.CompanyService.MoveStage(ctx, userID, data)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -317,19 +1099,19 @@ func (h *CompanyHandler) ApplicantsData(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"Applicants": applicantsData,
+		"Report": report,
 	})
 }
-// GetResumeOrResultFile is specifically used to get student files like resume and result and cover letter in upcoming versions
-func (h *CompanyHandler) GetResumeOrResultFile(ctx *gin.Context) {
+// BulkShortList shortlists the applications given or matching the filter in one transaction, uses dto.BulkApplicationAction
+func (h *CompanyHandler) BulkShortList(ctx *gin.Context) {
 
-	// get applicationid and type ('resume' or 'result') of file 
-	applicationid := ctx.Query("applicationid")
-	filetype := ctx.Query("type")
-	if applicationid == "" || filetype == "" {
+	data := new(dto.BulkApplicationAction)
+	err := ctx.Bind(data)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "Missing required fields in request url.",
+			Type: errs.IncompleteForm,
+			Message: "Invalid bulk action : " + err.Error(),
+			ToRespondWith: true,
 		})
 		return
 	}
@@ -340,7 +1122,7 @@ func (h *CompanyHandler) GetResumeOrResultFile(ctx *gin.Context) {
 		return
 	}
 
-	filePath, errf := h.CompanyService.GetResumeOrResultFilePath(ctx, userID, applicationid, filetype)
+	report, errf := h.CompanyService.BulkShortList(ctx, userID, data)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -350,33 +1132,31 @@ func (h *CompanyHandler) GetResumeOrResultFile(ctx *gin.Context) {
 		return
 	}
 
-	// browser heavily caches these files
-	// prevent it from doing that by setting this header
-	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0")
-	ctx.File(filePath)
+	ctx.JSON(http.StatusOK, gin.H{
+		"Report": report,
+	})
 }
-// JobListingsStatic returns the JobListings page for the company role
-func (h *CompanyHandler) JobListingsStatic(ctx *gin.Context) {
+// BulkReject rejects the applications given or matching the filter in one transaction, uses dto.BulkApplicationAction
+func (h *CompanyHandler) BulkReject(ctx *gin.Context) {
 
-	filePath := config.Paths.CompanyMyJobListingsTemplatePath
-	errf := h.checkFile(ctx, filePath)
-	if errf != nil {
-		ctx.Status(http.StatusInternalServerError)
+	data := new(dto.BulkApplicationAction)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid bulk action : " + err.Error(),
+			ToRespondWith: true,
+		})
 		return
 	}
 
-	ctx.File(filePath)
-}
-// JobListingsData gets the JobListings data for the company role
-func (h *CompanyHandler) JobListingsData(ctx *gin.Context) {
-
 	userID, errf := h.extractUserID(ctx)
 	if errf != nil {
 		ctx.JSON(http.StatusBadRequest, errf)
 		return
 	}
 
-	jobListings, errf := h.CompanyService.JobListings(ctx, userID)
+	report, errf := h.CompanyService.BulkReject(ctx, userID, data)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -387,18 +1167,21 @@ func (h *CompanyHandler) JobListingsData(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"Listings": jobListings,
+		"Report": report,
 	})
 }
-// CloseJob marks the job listing active_status to false, effectively closing the listing for further applicants
-func (h *CompanyHandler) CloseJob(ctx *gin.Context) {
+// ScheduleInterview schedules a new interview from the submitted form, uses dto.NewInterview. Conflicting events
+// are responded with 409 unless the form forces the interview.
+func (h *CompanyHandler) ScheduleInterview(ctx *gin.Context) {
 
-	jobid := ctx.Query("jobid")
-	if jobid == "" {
+	data :=  new(dto.NewInterview)
+
+	err := ctx.Bind(data)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "Missing job ID parameter in request url.",
-			ToRespondWith: true, 
+			Type: errs.IncompleteForm,
+			Message: "Invalid format of submitted form.",
+			ToRespondWith: true,
 		})
 		return
 	}
@@ -408,10 +1191,16 @@ func (h *CompanyHandler) CloseJob(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errf)
 		return
 	}
+	data.UserId = userID
 
-	errf = h.CompanyService.CloseJob(ctx, jobid, userID)
+	conflicts, errf := h.CompanyService.ScheduleInterview(ctx, data)
 	if errf != nil {
-		if errf.ToRespondWith {
+		if len(conflicts) != 0 {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"Error": errf,
+				"Conflicts": conflicts,
+			})
+		} else if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
 		} else {
 			ctx.Set("error", errf.Message)
@@ -419,22 +1208,40 @@ func (h *CompanyHandler) CloseJob(ctx *gin.Context) {
 		return
 	}
 
+	// conflicts overridden by force, if any
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Closed job successfully.",
+		"status": "Interview scheduled successfully.",
+		"Conflicts": conflicts,
 	})
 }
-// DeleteJob directly deletes the requested job, job will be archived instead of deletion in upcoming revisions. 
-func (h *CompanyHandler) DeleteJob(ctx *gin.Context) {
+// Offer offers an application on the terms of dto.NewOffer, stores and emails the uploaded or generated offer letter,
+// updates interview status to 'Completed'.
+func (h *CompanyHandler) Offer(ctx *gin.Context) {
 
-	jobid := ctx.Query("jobid")
-	if jobid == "" {
+	data := new(dto.NewOffer)
+	err := ctx.ShouldBind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid offer : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	// the letter is generated when a template is given
+	offerLetter, err := ctx.FormFile("OfferLetter")
+	if (err != nil && data.TemplateID == 0) || data.ApplicationID == 0 {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
 			Type: errs.MissingRequiredField,
-			Message: "Missing job ID parameter in request url.",
+			Message: "Missing application ID, or Offer Letter or template ID.",
 			ToRespondWith: true,
 		})
 		return
 	}
+	if data.TemplateID != 0 {
+		offerLetter = nil
+	}
 
 	userID, errf := h.extractUserID(ctx)
 	if errf != nil {
@@ -442,7 +1249,7 @@ func (h *CompanyHandler) DeleteJob(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.DeleteJob(ctx, jobid, userID)
+	errf = h.CompanyService.Offer(ctx, userID, data, offerLetter)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -453,17 +1260,18 @@ func (h *CompanyHandler) DeleteJob(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Deleted job successfully.",
+		"status": "Application Offered successfully.",
 	})
 }
-// ShortList changes the application status to 'ShortListed', the status has to be 'Under Review'
-func (h *CompanyHandler) ShortList(ctx *gin.Context) {
+// BulkOffer offers the applications given or matching the filter, each with a letter generated from the template, uses dto.BulkOffer
+func (h *CompanyHandler) BulkOffer(ctx *gin.Context) {
 
-	applicationid := ctx.Query("applicationid")
-	if applicationid == "" {
+	data := new(dto.BulkOffer)
+	err := ctx.Bind(data)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "Missing application ID parameter in request url.", 
+			Type: errs.IncompleteForm,
+			Message: "Invalid bulk offer : " + err.Error(),
 			ToRespondWith: true,
 		})
 		return
@@ -475,7 +1283,7 @@ func (h *CompanyHandler) ShortList(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.ShortList(ctx, applicationid, userID)
+	report, errf := h.CompanyService.BulkOffer(ctx, userID, data)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -486,17 +1294,39 @@ func (h *CompanyHandler) ShortList(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Application shortlisted successfully",
+		"Report": report,
 	})
 }
-// Reject changes the application status to 'Rejected', also changes interview status to 'Completed'.
-func (h *CompanyHandler) Reject(ctx *gin.Context) {
+// OfferLetterTemplates returns the offer letter templates of the company and the placeholders they can use
+func (h *CompanyHandler) OfferLetterTemplates(ctx *gin.Context) {
 
-	applicationid := ctx.Query("applicationid")
-	if applicationid == "" {
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	templates, errf := h.CompanyService.OfferLetterTemplates(ctx, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+// SaveOfferLetterTemplate creates an offer letter template, or updates it when its ID is given, uses letter.Template
+func (h *CompanyHandler) SaveOfferLetterTemplate(ctx *gin.Context) {
+
+	data := new(letter.Template)
+	err := ctx.Bind(data)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "Missing application ID parameter in request url.", 
+			Type: errs.IncompleteForm,
+			Message: "Invalid offer letter template : " + err.Error(),
 			ToRespondWith: true,
 		})
 		return
@@ -508,7 +1338,7 @@ func (h *CompanyHandler) Reject(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.Reject(ctx, applicationid, userID)
+	templateID, errf := h.CompanyService.SaveOfferLetterTemplate(ctx, userID, data)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -519,19 +1349,18 @@ func (h *CompanyHandler) Reject(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Application rejected successfully",
+		"status": "Saved offer letter template successfully.",
+		"TemplateID": templateID,
 	})
 }
-// ScheduleInterview schedules a new interview from the submitted form, uses dto.NewInterview
-func (h *CompanyHandler) ScheduleInterview(ctx *gin.Context) {
+// DeleteOfferLetterTemplate deletes an offer letter template, the letters generated from it are kept
+func (h *CompanyHandler) DeleteOfferLetterTemplate(ctx *gin.Context) {
 
-	data :=  new(dto.NewInterview)
-
-	err := ctx.Bind(data)
-	if err != nil {
+	templateid := ctx.Query("templateid")
+	if templateid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
-			Type: errs.IncompleteForm,
-			Message: "Invalid format of submitted form.",
+			Type: errs.MissingRequiredField,
+			Message: "Missing template ID parameter in request url.",
 			ToRespondWith: true,
 		})
 		return
@@ -542,9 +1371,8 @@ func (h *CompanyHandler) ScheduleInterview(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errf)
 		return
 	}
-	data.UserId = userID
 
-	errf = h.CompanyService.ScheduleInterview(ctx, data)
+	errf = h.CompanyService.DeleteOfferLetterTemplate(ctx, userID, templateid)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -555,20 +1383,17 @@ func (h *CompanyHandler) ScheduleInterview(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Interview scheduled successfully.",
+		"status": "Deleted offer letter template successfully.",
 	})
 }
-// Offer changes the application status to 'Offered', sends an email with offer letter, updates interview status to 'Completed'.
-func (h *CompanyHandler) Offer(ctx *gin.Context) {
-
-	applicationid := ctx.PostForm("OfferApplicationId")
-	offerLetter, err := ctx.FormFile("OfferLetter")
+// PreviewOfferLetterTemplate responds with the PDF of an offer letter template filled in with sample fields
+func (h *CompanyHandler) PreviewOfferLetterTemplate(ctx *gin.Context) {
 
-	// TODO: validate and check file for size, type, etc
-	if err != nil || applicationid == "" {
+	templateid := ctx.Query("templateid")
+	if templateid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Error{
 			Type: errs.MissingRequiredField,
-			Message: "Missing application ID or Offer Letter.",
+			Message: "Missing template ID parameter in request url.",
 			ToRespondWith: true,
 		})
 		return
@@ -580,7 +1405,7 @@ func (h *CompanyHandler) Offer(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.Offer(ctx, userID, applicationid, offerLetter)
+	pdf, errf := h.CompanyService.PreviewOfferLetterTemplate(ctx, userID, templateid)
 	if errf != nil {
 		if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
@@ -590,9 +1415,8 @@ func (h *CompanyHandler) Offer(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"status": "Application Offered successfully.",
-	})
+	ctx.Header("Content-Disposition", `inline; filename="Offer Letter Preview.pdf"`)
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
 }
 // CancelInterview cancels the interview for given application ID, sends an email to student
 func (h *CompanyHandler) CancelInterview(ctx *gin.Context) {
@@ -740,7 +1564,8 @@ func (h *CompanyHandler) ScheduledData(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, uData)
 }
-// UpdateInterview updates the interview details for given interview_ID, uses dto.UpdateInterview
+// UpdateInterview updates the interview details for given interview_ID, uses dto.UpdateInterview. Conflicting
+// events are responded with 409 unless the form forces the update. A new time is proposed at /proposereschedule.
 func (h *CompanyHandler) UpdateInterview(ctx *gin.Context) {
 	
 	data := new(dto.UpdateInterview)
@@ -760,9 +1585,14 @@ func (h *CompanyHandler) UpdateInterview(ctx *gin.Context) {
 		return
 	}
 
-	errf = h.CompanyService.UpdateInterview(ctx, userID, data)
+	conflicts, errf := h.CompanyService.UpdateInterview(ctx, userID, data)
 	if errf != nil {
-		if errf.ToRespondWith {
+		if len(conflicts) != 0 {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"Error": errf,
+				"Conflicts": conflicts,
+			})
+		} else if errf.ToRespondWith {
 			ctx.JSON(http.StatusBadRequest, errf)
 		} else {
 			ctx.Set("error", errf.Message)
@@ -770,8 +1600,127 @@ func (h *CompanyHandler) UpdateInterview(ctx *gin.Context) {
 		return
 	}
 
+	// conflicts overridden by force, if any
 	ctx.JSON(http.StatusOK, gin.H{
 		"status": "Interview details updated successfully.",
+		"Conflicts": conflicts,
+	})
+}
+// ProposeReschedule proposes new times for an interview, uses dto.RescheduleProposal. Conflicting events are
+// responded with 409 unless the form forces the proposal.
+func (h *CompanyHandler) ProposeReschedule(ctx *gin.Context) {
+
+	data := new(dto.RescheduleProposal)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid reschedule proposal : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	conflicts, errf := h.CompanyService.ProposeReschedule(ctx, userID, data)
+	if errf != nil {
+		if len(conflicts) != 0 {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"Error": errf,
+				"Conflicts": conflicts,
+			})
+		} else if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	// conflicts overridden by force, if any
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Reschedule proposed successfully.",
+		"Conflicts": conflicts,
+	})
+}
+// RespondReschedule accepts, rejects or counter-proposes the reschedule proposed by the student of an interview,
+// uses dto.RescheduleResponse. Conflicting events are responded with 409 as for ProposeReschedule.
+func (h *CompanyHandler) RespondReschedule(ctx *gin.Context) {
+
+	data := new(dto.RescheduleResponse)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid reschedule response : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	conflicts, errf := h.CompanyService.RespondReschedule(ctx, userID, data)
+	if errf != nil {
+		if len(conflicts) != 0 {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"Error": errf,
+				"Conflicts": conflicts,
+			})
+		} else if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	// conflicts overridden by force, if any
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "Responded to reschedule successfully.",
+		"Conflicts": conflicts,
+	})
+}
+// Reschedules returns the reschedule proposals of the interview given as query, with their responses
+func (h *CompanyHandler) Reschedules(ctx *gin.Context) {
+
+	interviewid := ctx.Query("interviewid")
+	if interviewid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing interview ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	proposals, errf := h.CompanyService.Reschedules(ctx, userID, interviewid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Proposals": proposals,
 	})
 }
 // CompletedStatic returns the 'Completed' page for company role
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/notify"
+)
+
+// NotifyHandler serves the notification routes shared by all the roles.
+type NotifyHandler struct {
+	Notify *notify.Notify
+}
+
+func NewNotifyHandler(notifyService *notify.Notify) *NotifyHandler {
+	return &NotifyHandler{
+		Notify: notifyService,
+	}
+}
+
+// RegisterRoute registers the notification routes on a role group, eg. /laa/student/notificationsstream
+func (h *NotifyHandler) RegisterRoute(roleRoute *gin.RouterGroup) {
+	// live notifications and unread counts over server-sent events
+	roleRoute.GET("/notificationsstream", h.NotificationsStream)
+
+	// number of unread notifications
+	roleRoute.GET("/notificationsunread", h.UnreadCount)
+	// mark the given (or all) notifications as read
+	roleRoute.POST("/notificationsread", h.MarkRead)
+	// archive or unarchive the given notifications
+	roleRoute.POST("/notificationsarchive", h.Archive)
+	// delete the given notifications
+	roleRoute.POST("/notificationsdelete", h.Delete)
+
+	// register or unregister the push notification token of a device
+	roleRoute.POST("/registerdevice", h.RegisterDevice)
+	roleRoute.POST("/unregisterdevice", h.UnregisterDevice)
+}
+
+// extractUserID extracts the user ID and other required parameters from the context with explicit type assertion.
+// any returned error is directly included in the response as returned
+func (h *NotifyHandler) extractUserID(ctx *gin.Context) (int64, *errs.Error) {
+
+	userid, exists := ctx.Get("ID")
+	if !exists {
+		return 0, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing user ID in request.",
+			ToRespondWith: true,
+		}
+	}
+
+	userID, ok := userid.(int64)
+	if !ok {
+		return 0, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "User ID of improper format.",
+			ToRespondWith: true,
+		}
+	}
+
+	return userID, nil 
+}
+
+// NotificationsStream pushes the user's new notifications as server-sent events.
+// Every event carries the redis stream ID as its id, so a reconnecting client (Last-Event-ID header,
+// or lastEventId query param) resumes right after the last notification it received. Notifications trimmed from the
+// stream since then are sent first from the db, see notify.StreamStart.
+// The unread count is sent on connect and after every batch of notifications,
+// and a heartbeat comment keeps the connection alive while there is nothing to send.
+func (h *NotifyHandler) NotificationsStream(ctx *gin.Context) {
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("lastEventId")
+	}
+
+	reqCtx := ctx.Request.Context()
+
+	lastID, missed, err := h.Notify.StreamStart(reqCtx, userID, lastEventID)
+	if err != nil {
+		if errors.Is(err, notify.ErrInvalidEventID) {
+			ctx.JSON(http.StatusBadRequest, errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid last event ID, it is the id of a received event.",
+				ToRespondWith: true,
+			})
+			return
+		}
+		ctx.Set("error", "Failed to get notifications stream start : " + err.Error())
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// disables response buffering in nginx
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	// no longer in the stream, without an id so the client resumes from lastEventID until one from the stream
+	for _, m := range missed {
+		data, err := json.Marshal(gin.H{
+			"NotifID": m.NotifID,
+			"Title": m.Title.String,
+			"Description": m.Description.String,
+			"TimeStamp": m.Timestamp,
+			"Category": m.Category,
+			"RefType": m.RefType.String,
+			"RefID": m.RefID.Int64,
+		})
+		if err != nil {
+			continue
+		}
+		_, err = fmt.Fprintf(ctx.Writer, "event: notification\ndata: %s\n\n", data)
+		if err != nil {
+			return
+		}
+	}
+
+	err = h.sendUnreadCount(ctx, userID)
+	if err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-reqCtx.Done():
+			return
+		default:
+		}
+
+		messages, err := h.Notify.ReadStream(reqCtx, userID, lastID)
+		if err != nil {
+			if reqCtx.Err() == nil {
+				ctx.Set("error", "Failed to read notifications stream : " + err.Error())
+			}
+			return
+		}
+
+		if len(messages) == 0 {
+			_, err = fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+			ctx.Writer.Flush()
+			continue
+		}
+
+		for _, msg := range messages {
+			data, err := json.Marshal(streamNotification(msg.Values))
+			if err != nil {
+				continue
+			}
+			_, err = fmt.Fprintf(ctx.Writer, "id: %s\nevent: notification\ndata: %s\n\n", msg.ID, data)
+			if err != nil {
+				return
+			}
+			lastID = msg.ID
+		}
+
+		err = h.sendUnreadCount(ctx, userID)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// UnreadCount returns the number of unread notifications of the user.
+func (h *NotifyHandler) UnreadCount(ctx *gin.Context) {
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	count, errf := h.Notify.UnreadCount(ctx, userID)
+	if errf != nil {
+		ctx.Set("error", errf.Message)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"UnreadCount": count,
+	})
+}
+
+// MarkRead marks the notifications in the body as read, or all of them if All is set.
+func (h *NotifyHandler) MarkRead(ctx *gin.Context) {
+	h.bulkAction(ctx, h.Notify.MarkRead, "Marked notifications as read successfully.")
+}
+
+// Archive archives the notifications in the body, or unarchives them if Archived is false.
+func (h *NotifyHandler) Archive(ctx *gin.Context) {
+	h.bulkAction(ctx, h.Notify.Archive, "Updated archived notifications successfully.")
+}
+
+// Delete deletes the notifications in the body.
+func (h *NotifyHandler) Delete(ctx *gin.Context) {
+	h.bulkAction(ctx, h.Notify.Delete, "Deleted notifications successfully.")
+}
+
+// RegisterDevice registers the FCM token of the device in the body for push notifications.
+func (h *NotifyHandler) RegisterDevice(ctx *gin.Context) {
+	h.deviceAction(ctx, h.Notify.RegisterDevice, "Registered device successfully.")
+}
+
+// UnregisterDevice stops push notifications to the device in the body.
+func (h *NotifyHandler) UnregisterDevice(ctx *gin.Context) {
+	h.deviceAction(ctx, h.Notify.UnregisterDevice, "Unregistered device successfully.")
+}
+
+// deviceAction binds the device token from the body and applies action to it for the user
+func (h *NotifyHandler) deviceAction(ctx *gin.Context, action func(*gin.Context, int64, *dto.DeviceToken) *errs.Error, status string) {
+
+	data := new(dto.DeviceToken)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid device token : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = action(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": status,
+	})
+}
+
+// bulkAction binds the notification IDs from the body and applies action to them for the user
+func (h *NotifyHandler) bulkAction(ctx *gin.Context, action func(*gin.Context, int64, *dto.NotificationIDs) (int64, *errs.Error), status string) {
+
+	data := new(dto.NotificationIDs)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid notification IDs : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	count, errf := action(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": status,
+		"Count": count,
+	})
+}
+
+// sendUnreadCount writes an unread event with the current unread count and flushes the stream
+func (h *NotifyHandler) sendUnreadCount(ctx *gin.Context, userID int64) error {
+
+	count, errf := h.Notify.UnreadCount(ctx.Request.Context(), userID)
+	if errf != nil {
+		ctx.Set("error", errf.Message)
+		return fmt.Errorf("%s", errf.Message)
+	}
+
+	_, err := fmt.Fprintf(ctx.Writer, "event: unread\ndata: {\"UnreadCount\":%d}\n\n", count)
+	if err != nil {
+		return err
+	}
+	ctx.Writer.Flush()
+
+	return nil
+}
+
+// streamNotification converts the stream entry values, which redis returns as strings, to the notification json
+func streamNotification(values map[string]interface{}) gin.H {
+
+	data := gin.H{}
+	for k, v := range values {
+		str, _ := v.(string)
+		switch k {
+		case "NotifID", "TimeStamp", "RefID":
+			num, err := strconv.ParseInt(str, 10, 64)
+			if err == nil {
+				data[k] = num
+			}
+		default:
+			data[k] = str
+		}
+	}
+
+	return data
+}
@@ -26,6 +26,11 @@ func (h *OpenHandler) RegisterRoute(openRoute *gin.RouterGroup) {
 	openRoute.GET("/discussions", h.Discussions)
 	openRoute.GET("/discussionsdata", h.DiscussionsData)
 	openRoute.POST("/newdiscussion", h.NewDiscussion)
+	openRoute.GET("/digestpreferences", h.DigestPreferences)
+	openRoute.POST("/digestpreferences", h.UpdateDigestPreferences)
+	// announcements delivered to the user and their read receipts
+	openRoute.GET("/noticeboard", h.NoticeBoard)
+	openRoute.POST("/noticeboardread", h.ReadAnnouncement)
 }
 
 
@@ -169,3 +174,122 @@ func (h *OpenHandler) EditPost(ctx *gin.Context) {
 		"Status": "Edited discussion successfully.",
 	})
 }
+
+// DigestPreferences returns the daily/weekly digest email preferences of the user.
+func (h *OpenHandler) DigestPreferences(ctx *gin.Context) {
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	prefs, errf := h.OpenService.DigestPreferences(ctx, userID)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, prefs)
+}
+
+// UpdateDigestPreferences sets the digest frequency, local send time and timezone of the user.
+func (h *OpenHandler) UpdateDigestPreferences(ctx *gin.Context) {
+
+	data := new(dto.DigestPreferences)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid digest preferences : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.OpenService.UpdateDigestPreferences(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Updated digest preferences successfully.",
+	})
+}
+
+// NoticeBoard returns a page of the announcements delivered to the user.
+func (h *OpenHandler) NoticeBoard(ctx *gin.Context) {
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	page := ctx.DefaultQuery("page", "1")
+
+	announcements, errf := h.OpenService.NoticeBoard(ctx, userID, page)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Data": announcements,
+		"Limit": config.NoticeBoardPageLimit,
+	})
+}
+
+// ReadAnnouncement marks an announcement on the notice board of the user as read.
+func (h *OpenHandler) ReadAnnouncement(ctx *gin.Context) {
+
+	data := new(dto.AnnouncementRead)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid announcement : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	errf = h.OpenService.ReadAnnouncement(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Marked announcement as read successfully.",
+	})
+}
@@ -48,6 +48,8 @@ func (h *PublicHandler) RegisterRoute(publicRoute *gin.RouterGroup) {
 	publicRoute.GET("/confirmsignup", h.ConfirmSignup) //
 	// post the data from extra info page, indirect
 	publicRoute.POST("/extrainfopost", h.ExtraInfoPost) //
+	// secret iCal feed of upcoming interviews and tests, for calendar apps to subscribe to
+	publicRoute.GET("/calendarfeed/:token", h.CalendarFeed)
 
 }
 
@@ -331,4 +333,31 @@ func (h *PublicHandler) LogOut(ctx *gin.Context) {
 
 	ctx.Status(200)
 
-}
\ No newline at end of file
+}
+
+// CalendarFeed serves the iCal feed for the secret token in the url.
+func (h *PublicHandler) CalendarFeed(ctx *gin.Context) {
+
+	token := ctx.Param("token")
+	if token == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing calendar feed token.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	feed, errf := h.PublicService.CalendarFeed(ctx, token)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusNotFound, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Content-Disposition", "inline; filename=pms.ics")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
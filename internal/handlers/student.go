@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	errs "go.mod/internal/const"
@@ -32,10 +34,23 @@ func (h *StudentHandler) RegisterRoute(studentRoute *gin.RouterGroup) {
 	studentRoute.GET("/jobslist", h.JobsList)
 	// get list of applicable jobs as JSON
 	studentRoute.GET("/alljobs", h.ApplicableJobs)
+	// full-text search jobs with facets, sorting and cursor pagination
+	studentRoute.GET("/searchjobs", h.SearchJobs)
+	// open jobs fitting the student's skills, department and past applications, with the reasons
+	studentRoute.GET("/recommendations", h.Recommendations)
 
 	// post and apply to a job
 	studentRoute.POST("/applytojob", h.ApplyToJob)
-	studentRoute.GET("/cancelapplication", h.CancelApplication)
+	// withdraw an application with a reason, confirmed once shortlisted or interviewing
+	studentRoute.POST("/withdrawapplication", h.WithdrawApplication)
+	// get the status history of an application
+	studentRoute.GET("/applicationtimeline", h.ApplicationTimeline)
+	// get the offer of an application, and its offer letter
+	studentRoute.GET("/offer", h.Offer)
+	studentRoute.GET("/offerletter", h.OfferLetter)
+	// accept or decline an offer before its deadline
+	studentRoute.POST("/acceptoffer", h.AcceptOffer)
+	studentRoute.POST("/declineoffer", h.DeclineOffer)
 
 	// get template
 	studentRoute.GET("/myappsstatic", h.MyAppsStatic)
@@ -46,6 +61,10 @@ func (h *StudentHandler) RegisterRoute(studentRoute *gin.RouterGroup) {
 	studentRoute.GET("/upcoming", h.UpcomingStatic)
 	// upcoming events data with a filter
 	studentRoute.GET("/upcomingdata", h.UpcomingData)
+	// propose new times for an interview, respond to the company's proposal, and get the proposals of an interview
+	studentRoute.POST("/proposereschedule", h.ProposeReschedule)
+	studentRoute.POST("/respondreschedule", h.RespondReschedule)
+	studentRoute.GET("/reschedules", h.Reschedules)
 
 	// get take test template
 	studentRoute.GET("/taketest", h.TakeTestStatic)
@@ -78,6 +97,36 @@ func (h *StudentHandler) RegisterRoute(studentRoute *gin.RouterGroup) {
 	studentRoute.GET("/feedbacks", h.Feedbacks)
 	studentRoute.GET("/feedbacksdata", h.FeedbacksData)
 
+	// get (or create) the secret iCal feed url of upcoming interviews and tests
+	studentRoute.GET("/calendarfeed", h.CalendarFeed)
+	// invalidate the current feed url and get a new one
+	studentRoute.POST("/rotatecalendarfeed", h.RotateCalendarFeed)
+
+}
+
+// extractUserID extracts the user ID and other required parameters from the context with explicit type assertion.
+// any returned error is directly included in the response as returned
+func (h *StudentHandler) extractUserID(ctx *gin.Context) (int64, *errs.Error) {
+
+	userid, exists := ctx.Get("ID")
+	if !exists {
+		return 0, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing user ID in request.",
+			ToRespondWith: true,
+		}
+	}
+
+	userID, ok := userid.(int64)
+	if !ok {
+		return 0, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "User ID of improper format.",
+			ToRespondWith: true,
+		}
+	}
+
+	return userID, nil 
 }
 
 
@@ -128,7 +177,19 @@ func (h *StudentHandler) GetNotifications(ctx *gin.Context) {
 		return
 	}
 
-	notifs, errf := h.StudentService.Notify.GetNotifications(ctx, userid.(int64), start, end)
+	// optional category, from, to and archived filters
+	filter := new(dto.NotificationFilter)
+	err := ctx.ShouldBindQuery(filter)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid notification filters : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	notifs, errf := h.StudentService.Notify.GetNotifications(ctx, userid.(int64), start, end, filter)
 	if errf != nil {
 		if errf.Type != errs.Internal {
 			ctx.JSON(http.StatusBadRequest, gin.H{
@@ -163,7 +224,10 @@ func (h *StudentHandler) ApplicableJobs(ctx *gin.Context) {
 	}
 
 	// call the service that sends all job listings that the user has not yet applied for
-	alljobs, err := h.StudentService.GetApplicableJobs(ctx, jobType)
+	// optionally leave out the jobs the student is not eligible for
+	eligibleOnly := ctx.Query("eligibleonly") == "true"
+
+	alljobs, err := h.StudentService.GetApplicableJobs(ctx, jobType, eligibleOnly)
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -193,12 +257,25 @@ func (h *StudentHandler) ApplyToJob(ctx *gin.Context) {
 		})
 		return
 	}
-	// call service to add application to the database
-	err := h.StudentService.NewApplication(ctx, userID.(int64), jobId)
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	// answers to the questions of the job as Answers[<question ID>], in the form or as uploaded files
+	answers := ctx.PostFormMap("Answers")
+	files := make(map[string]*multipart.FileHeader)
+	if form, err := ctx.MultipartForm(); err == nil {
+		for key, headers := range form.File {
+			if strings.HasPrefix(key, "Answers[") && strings.HasSuffix(key, "]") && len(headers) != 0 {
+				files[strings.TrimSuffix(strings.TrimPrefix(key, "Answers["), "]")] = headers[0]
+			}
+		}
+	}
+	// call service to add application to the database, checks eligibility and the answers
+	errf := h.StudentService.NewApplication(ctx, userID.(int64), jobId, answers, files)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
 		return
 	}
 	// 200OK code 
@@ -206,36 +283,41 @@ func (h *StudentHandler) ApplyToJob(ctx *gin.Context) {
 		"status": "applied to job successfully",
 	})
 }
-func (h *StudentHandler) CancelApplication(ctx *gin.Context) {
-	// get user id from context
-	userID, exists := ctx.Get("ID")
-	if !exists {
-		ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{
-			"error": "error getting user ID", 
+// WithdrawApplication withdraws the student's application to a job, the application is kept as Withdrawn
+func (h *StudentHandler) WithdrawApplication(ctx *gin.Context) {
+
+	data := new(dto.WithdrawApplication)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid withdrawal : " + err.Error(),
+			ToRespondWith: true,
 		})
 		return
 	}
-	// get job id from request body
-	jobID := ctx.Query("jobid")
-	if jobID == "" {
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"error": "no job ID specified in request body", 
-		})
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
 		return
 	}
-	// call service
-	err := h.StudentService.CancelApplication(ctx, userID.(int64), jobID)
-	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+
+	errf = h.StudentService.WithdrawApplication(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
 		return
 	}
-	// respond with 200
+
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "successfully canceled application",
+		"Status": "Withdrew application successfully.",
 	})
 }
+
 func (h *StudentHandler) MyApplications(ctx *gin.Context) {
 
 	// get filters off the request body
@@ -548,39 +630,364 @@ func (h *StudentHandler) FeedbacksData(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, data)
 }
 
+// CalendarFeed returns the secret iCal feed url of the student, creating one on the first call.
+func (h *StudentHandler) CalendarFeed(ctx *gin.Context) {
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	feedURL, errf := h.StudentService.CalendarFeed(ctx, userID, false)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"FeedURL": feedURL,
+	})
+}
 
+// RotateCalendarFeed replaces the secret of the student's iCal feed, the old url stops working.
+func (h *StudentHandler) RotateCalendarFeed(ctx *gin.Context) {
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	feedURL, errf := h.StudentService.CalendarFeed(ctx, userID, true)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"FeedURL": feedURL,
+	})
+}
 
+// SearchJobs full-text searches the active jobs, see dto.JobSearch for the query params.
+// Returns a page of jobs, the facet counts and the cursor of the next page.
+func (h *StudentHandler) SearchJobs(ctx *gin.Context) {
 
+	search := new(dto.JobSearch)
+	err := ctx.ShouldBindQuery(search)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid search params : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	result, errf := h.StudentService.SearchJobs(ctx, userID, search)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, result)
+}
 
+// ApplicationTimeline returns the status history of an application and the statuses the student can move it to
+func (h *StudentHandler) ApplicationTimeline(ctx *gin.Context) {
 
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	timeline, errf := h.StudentService.ApplicationTimeline(ctx, userID, applicationid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"Timeline": timeline,
+	})
+}
 
+// Recommendations returns the jobs recommended for the student, best fitting first
+func (h *StudentHandler) Recommendations(ctx *gin.Context) {
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	recommendations, errf := h.StudentService.Recommendations(ctx, userID, ctx.Query("limit"))
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"Recommendations": recommendations,
+	})
+}
 
+// Offer returns the terms and the status of the offer of an application of the student
+func (h *StudentHandler) Offer(ctx *gin.Context) {
 
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	offer, errf := h.StudentService.Offer(ctx, userID, applicationid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Offer": offer,
+	})
+}
+
+// OfferLetter responds with the stored offer letter of an application of the student
+func (h *StudentHandler) OfferLetter(ctx *gin.Context) {
+
+	applicationid := ctx.Query("applicationid")
+	if applicationid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing application ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	filePath, errf := h.StudentService.OfferLetterPath(ctx, userID, applicationid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, proxy-revalidate, max-age=0")
+	ctx.File(filePath)
+}
+
+// AcceptOffer accepts the offer of an application of the student, the application is Hired
+func (h *StudentHandler) AcceptOffer(ctx *gin.Context) {
+	h.respondOffer(ctx, true)
+}
+
+// DeclineOffer declines the offer of an application of the student with an optional reason
+func (h *StudentHandler) DeclineOffer(ctx *gin.Context) {
+	h.respondOffer(ctx, false)
+}
+
+func (h *StudentHandler) respondOffer(ctx *gin.Context, accept bool) {
+
+	data := new(dto.OfferResponse)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid offer response : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	status := "Declined offer successfully."
+	if accept {
+		status = "Accepted offer successfully."
+		errf = h.StudentService.AcceptOffer(ctx, userID, data)
+	} else {
+		errf = h.StudentService.DeclineOffer(ctx, userID, data)
+	}
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": status,
+	})
+}
 
+// ProposeReschedule proposes new times for an interview of the student with a reason, uses dto.RescheduleProposal
+func (h *StudentHandler) ProposeReschedule(ctx *gin.Context) {
+
+	data := new(dto.RescheduleProposal)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid reschedule proposal : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
+
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	errf = h.StudentService.ProposeReschedule(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Reschedule proposed successfully.",
+	})
+}
 
+// RespondReschedule accepts, rejects or counter-proposes the reschedule proposed by the company of an interview,
+// uses dto.RescheduleResponse
+func (h *StudentHandler) RespondReschedule(ctx *gin.Context) {
 
+	data := new(dto.RescheduleResponse)
+	err := ctx.Bind(data)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.IncompleteForm,
+			Message: "Invalid reschedule response : " + err.Error(),
+			ToRespondWith: true,
+		})
+		return
+	}
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	errf = h.StudentService.RespondReschedule(ctx, userID, data)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"Status": "Responded to reschedule successfully.",
+	})
+}
 
+// Reschedules returns the reschedule proposals of the interview given as query, with their responses
+func (h *StudentHandler) Reschedules(ctx *gin.Context) {
 
+	interviewid := ctx.Query("interviewid")
+	if interviewid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing interview ID in request url.",
+			ToRespondWith: true,
+		})
+		return
+	}
 
+	userID, errf := h.extractUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
 
+	proposals, errf := h.StudentService.Reschedules(ctx, userID, interviewid)
+	if errf != nil {
+		if errf.ToRespondWith {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+		}
+		return
+	}
 
+	ctx.JSON(http.StatusOK, gin.H{
+		"Proposals": proposals,
+	})
+}
@@ -0,0 +1,263 @@
+package jobfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mod/internal/dto"
+)
+
+// Columns are the fields of a job in an import or export file, named as in dto.NewJobData, along with its eligibility rules.
+// Extras, Questions, Compensation and Eligibility are JSON, as text in a CSV and as is in a JSON file.
+var Columns = []string{
+	"JobTitle",
+	"JobLocation",
+	"JobDescription",
+	"JobType",
+	"JobSalary",
+	"SkillsRequired",
+	"JobPosition",
+	"Extras",
+	"Deadline",
+	"MaxApplicants",
+	"Questions",
+	"Compensation",
+	"Eligibility", // see eligibility.Rules
+}
+
+// JSON-valued columns, kept as raw JSON in a JSON file
+var jsonColumns = map[string]bool{
+	"Extras": true,
+	"Questions": true,
+	"Compensation": true,
+	"Eligibility": true,
+}
+
+// deadline layouts accepted, the first is the one of the job form and exports
+var deadlineLayouts = []string{
+	"2006-01-02T15:04",
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Record is a job of a file by column, missing columns are empty.
+type Record map[string]string
+
+// Read reads the jobs of a CSV or JSON file, told apart by the extension of its name.
+// A CSV has a header row of column names, in any order and case, a JSON file is an array of objects keyed by column.
+// Unknown columns are rejected.
+func Read(name string, data []byte) ([]Record, error) {
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return readCSV(data)
+	case ".json":
+		return readJSON(data)
+	}
+
+	return nil, fmt.Errorf("unsupported file type %q, use a .csv or .json file", filepath.Ext(name))
+}
+
+func readCSV(data []byte) ([]Record, error) {
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.TrimLeadingSpace = true
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV : %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("the CSV is empty, it needs a header row")
+	}
+
+	header := make([]string, len(rows[0]))
+	for i, name := range rows[0] {
+		column, ok := column(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		header[i] = column
+	}
+
+	records := make([]Record, 0, len(rows) - 1)
+	for _, row := range rows[1:] {
+		record := make(Record)
+		for i, value := range row {
+			record[header[i]] = strings.TrimSpace(value)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func readJSON(data []byte) ([]Record, error) {
+
+	var objects []map[string]json.RawMessage
+	err := json.Unmarshal(data, &objects)
+	if err != nil {
+		return nil, fmt.Errorf("the JSON must be an array of job objects : %v", err)
+	}
+
+	records := make([]Record, 0, len(objects))
+	for i, object := range objects {
+		record := make(Record)
+		for name, raw := range object {
+			column, ok := column(name)
+			if !ok {
+				return nil, fmt.Errorf("job %d : unknown field %q", i + 1, name)
+			}
+			value, err := jsonValue(column, raw)
+			if err != nil {
+				return nil, fmt.Errorf("job %d : %s : %v", i + 1, column, err)
+			}
+			record[column] = value
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// jsonValue is the text of a JSON field, strings unquoted, skills joined if given as an array
+// and the JSON-valued columns kept as JSON
+func jsonValue(column string, raw json.RawMessage) (string, error) {
+
+	raw = bytes.TrimSpace(raw)
+	if string(raw) == "null" {
+		return "", nil
+	}
+
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s), nil
+	}
+	if jsonColumns[column] {
+		return string(raw), nil
+	}
+	if column == "SkillsRequired" {
+		var skills []string
+		err := json.Unmarshal(raw, &skills)
+		if err != nil {
+			return "", fmt.Errorf("must be a string or an array of strings")
+		}
+		return strings.Join(skills, ", "), nil
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String(), nil
+	}
+
+	return "", fmt.Errorf("must be a string")
+}
+
+func column(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, c := range Columns {
+		if strings.EqualFold(c, name) {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// JobData is the job of a record, the errors are of its deadline and maximum applicants not being valid
+func (r Record) JobData() (*dto.NewJobData, error) {
+
+	jobdata := &dto.NewJobData{
+		JobTitle: r["JobTitle"],
+		JobLocation: r["JobLocation"],
+		JobDescription: r["JobDescription"],
+		JobType: r["JobType"],
+		JobSalary: r["JobSalary"],
+		SkillsRequired: r["SkillsRequired"],
+		JobPosition: r["JobPosition"],
+		Extras: r["Extras"],
+		Questions: r["Questions"],
+		Compensation: r["Compensation"],
+	}
+
+	if deadline := r["Deadline"]; deadline != "" {
+		parsed := false
+		for _, layout := range deadlineLayouts {
+			// as the job form, in local time unless the zone is given
+			t, err := time.ParseInLocation(layout, deadline, time.Local)
+			if err == nil {
+				jobdata.Deadline, parsed = t, true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf("invalid deadline %q, use the format 2006-01-02T15:04", deadline)
+		}
+	}
+
+	if maxApplicants := r["MaxApplicants"]; maxApplicants != "" {
+		n, err := strconv.ParseInt(maxApplicants, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum applicants %q, must be a whole number", maxApplicants)
+		}
+		jobdata.MaxApplicants = int32(n)
+	}
+
+	return jobdata, nil
+}
+
+// WriteCSV writes the records as a CSV with a header row of all the columns.
+func WriteCSV(records []Record) ([]byte, error) {
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	err := w.Write(Columns)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		row := make([]string, len(Columns))
+		for i, c := range Columns {
+			row[i] = record[c]
+		}
+		err = w.Write(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+// WriteJSON writes the records as a JSON array, JSON-valued columns as JSON and MaxApplicants as a number.
+// Empty columns are left out.
+func WriteJSON(records []Record) ([]byte, error) {
+
+	objects := make([]map[string]any, 0, len(records))
+	for _, record := range records {
+		object := make(map[string]any)
+		for _, c := range Columns {
+			value := record[c]
+			switch {
+			case value == "":
+				continue
+			case jsonColumns[c]:
+				object[c] = json.RawMessage(value)
+			case c == "MaxApplicants":
+				object[c] = json.Number(value)
+			default:
+				object[c] = value
+			}
+		}
+		objects = append(objects, object)
+	}
+
+	return json.MarshalIndent(objects, "", "  ")
+}
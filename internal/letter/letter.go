@@ -0,0 +1,139 @@
+package letter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mod/internal/config"
+)
+
+// placeholders of a template, each is replaced with the field of the same name, eg. {{StudentName}}
+var Placeholders = []string{
+	"StudentName",
+	"RollNumber",
+	"Course",
+	"Department",
+	"Role",
+	"JobType",
+	"Location",
+	"CompanyName",
+	"CompanyAddress",
+	"CTC",
+	"JoiningDate",
+	"RespondBy",
+	"Date",
+	"RepresentativeName",
+	"RepresentativeEmail",
+}
+
+var placeholderRegex = regexp.MustCompile(`{{\s*(\w+)\s*}}`)
+
+// Template is an offer letter template of a company, placeholders in the title and body are filled in for each offer.
+type Template struct {
+	TemplateID int64 // 0 for a new template
+	Name string
+	Title string // eg. "Offer of Employment"
+	Body string // paragraphs are separated by new lines
+}
+
+// Fields are the values of the placeholders of an offer letter.
+type Fields struct {
+	StudentName string
+	RollNumber string
+	Course string
+	Department string
+	Role string
+	JobType string
+	Location string
+	CompanyName string
+	CompanyAddress string
+	CTC string
+	JoiningDate string
+	RespondBy string
+	Date string
+	RepresentativeName string
+	RepresentativeEmail string
+}
+
+// Sample are fields to preview a template with.
+var Sample = Fields{
+	StudentName: "Student Name",
+	RollNumber: "21CS001",
+	Course: "B.Tech",
+	Department: "Computer Science",
+	Role: "Software Engineer",
+	JobType: "Full Time",
+	Location: "Pune",
+	CTC: "₹12 LPA",
+	JoiningDate: "01-07-2026",
+	RespondBy: "05:00 PM 15-06-2026",
+	Date: "01-06-2026",
+}
+
+func (f *Fields) values() map[string]string {
+	return map[string]string{
+		"StudentName": f.StudentName,
+		"RollNumber": f.RollNumber,
+		"Course": f.Course,
+		"Department": f.Department,
+		"Role": f.Role,
+		"JobType": f.JobType,
+		"Location": f.Location,
+		"CompanyName": f.CompanyName,
+		"CompanyAddress": f.CompanyAddress,
+		"CTC": f.CTC,
+		"JoiningDate": f.JoiningDate,
+		"RespondBy": f.RespondBy,
+		"Date": f.Date,
+		"RepresentativeName": f.RepresentativeName,
+		"RepresentativeEmail": f.RepresentativeEmail,
+	}
+}
+
+// Validate checks a template, the returned error is meant for the user. Unknown placeholders are rejected
+// so a typo does not end up in a letter.
+func (t *Template) Validate() error {
+
+	t.Name = strings.TrimSpace(t.Name)
+	t.Title = strings.TrimSpace(t.Title)
+	t.Body = strings.TrimSpace(strings.ReplaceAll(t.Body, "\r\n", "\n"))
+
+	switch {
+	case t.Name == "":
+		return fmt.Errorf("the template needs a name")
+	case t.Title == "":
+		return fmt.Errorf("the template needs a title")
+	case t.Body == "":
+		return fmt.Errorf("the template needs a body")
+	case len([]rune(t.Name)) > config.OfferTemplateNameMaxLength:
+		return fmt.Errorf("the name is longer than %d characters", config.OfferTemplateNameMaxLength)
+	case len([]rune(t.Title)) > config.OfferTemplateTitleMaxLength:
+		return fmt.Errorf("the title is longer than %d characters", config.OfferTemplateTitleMaxLength)
+	case len([]rune(t.Body)) > config.OfferTemplateBodyMaxLength:
+		return fmt.Errorf("the body is longer than %d characters", config.OfferTemplateBodyMaxLength)
+	}
+
+	known := (&Fields{}).values()
+	for _, text := range []string{t.Title, t.Body} {
+		for _, m := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+			if _, exists := known[m[1]]; !exists {
+				return fmt.Errorf("unknown placeholder %s, use one of %s", m[0], strings.Join(Placeholders, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fill replaces the placeholders in text with the fields, unknown placeholders are left as they are.
+func Fill(text string, fields *Fields) string {
+	values := fields.values()
+	return placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+		if value, exists := values[name]; exists {
+			return value
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,402 @@
+package letter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"strings"
+
+	"go.mod/internal/config"
+)
+
+// Letterhead is the header of the first page of a letter, the logo is the path of a JPEG or PNG image.
+type Letterhead struct {
+	Logo string // optional, a logo that cannot be read is left out
+	CompanyName string
+	Address string
+}
+
+// A4 in points, with the same margin on all sides
+const (
+	pageWidth = 595.28
+	pageHeight = 841.89
+	margin = 56.0
+
+	bodySize = 11.0
+	bodyLeading = 15.0
+	titleSize = 14.0
+	nameSize = 14.0
+	addressSize = 9.0
+	footerSize = 8.0
+	logoMaxHeight = 48.0
+	logoMaxWidth = 160.0
+)
+
+// widths of the characters 32 to 126 of Helvetica and Helvetica-Bold, in thousandths of the font size
+var (
+	regularWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	boldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// font is a standard font of PDF readers, text is WinAnsi encoded so it needs no embedding
+type font struct {
+	resource string
+	widths *[95]int
+}
+
+var (
+	regular = font{"F1", &regularWidths}
+	bold = font{"F2", &boldWidths}
+)
+
+// width of the encoded text in points at size
+func (f font) width(text string, size float64) float64 {
+	total := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c >= 32 && c <= 126 {
+			total += f.widths[c - 32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// encodeRune is r in WinAnsi, false if it cannot be shown. Runes that have no WinAnsi code but a close substitute,
+// eg. "₹" as "Rs.", are substituted, control characters are dropped.
+func encodeRune(r rune) (string, bool) {
+	switch {
+	case r == '\t':
+		return "    ", true
+	case r >= 32 && r <= 126, r >= 0xA0 && r <= 0xFF:
+		return string([]byte{byte(r)}), true
+	case r == '₹':
+		return "Rs.", true
+	case r == '€':
+		return "\x80", true
+	case r == '‘', r == '’':
+		return "'", true
+	case r == '“', r == '”':
+		return "\"", true
+	case r == '–', r == '—':
+		return "-", true
+	case r == '•':
+		return "\x95", true
+	case r < 32:
+		return "", true
+	}
+	return "", false
+}
+
+// encode converts text to WinAnsi, see encodeRune, runes it cannot show are replaced with "?"
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		encoded, ok := encodeRune(r)
+		if !ok {
+			encoded = "?"
+		}
+		b.WriteString(encoded)
+	}
+	return b.String()
+}
+
+// UnencodableError is the error of a letter with characters the fonts of a PDF cannot show, eg. of a name in a
+// non-Latin script. The letter is not written rather than showing them as "?".
+type UnencodableError struct {
+	Chars []rune // each once, in order of appearance
+}
+
+func (e *UnencodableError) Error() string {
+	quoted := make([]string, len(e.Chars))
+	for i, r := range e.Chars {
+		quoted[i] = fmt.Sprintf("%q", r)
+	}
+	return "the letter has characters that cannot be shown : " + strings.Join(quoted, ", ")
+}
+
+// checkEncodable returns an *UnencodableError of the characters of the texts that cannot be shown, nil if all can
+func checkEncodable(texts ...string) error {
+	seen := make(map[rune]bool)
+	chars := []rune{}
+	for _, text := range texts {
+		for _, r := range text {
+			if _, ok := encodeRune(r); !ok && !seen[r] {
+				seen[r] = true
+				chars = append(chars, r)
+			}
+		}
+	}
+	if len(chars) != 0 {
+		return &UnencodableError{Chars: chars}
+	}
+	return nil
+}
+
+// escape escapes the encoded text as a PDF string
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(text)
+}
+
+// wrap breaks the encoded text into lines at most width wide, at spaces where it can
+func wrap(text string, f font, size float64, width float64) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	lines := []string{}
+	line := ""
+	for _, word := range words {
+		// words longer than a line are broken wherever they overflow
+		for f.width(word, size) > width {
+			cut := len(word) - 1
+			for cut > 1 && f.width(word[:cut], size) > width {
+				cut--
+			}
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if f.width(candidate, size) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		line = candidate
+	}
+
+	return append(lines, line)
+}
+
+// page is the content stream of a page being laid out
+type page struct {
+	content bytes.Buffer
+}
+
+func (p *page) text(f font, size float64, x float64, y float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", f.resource, size, x, y, escape(text))
+}
+
+func (p *page) rule(y float64) {
+	fmt.Fprintf(&p.content, "0.6 w 0.6 G %.2f %.2f m %.2f %.2f l S 0 G\n", margin, y, pageWidth - margin, y)
+}
+
+func (p *page) image(name string, x float64, y float64, w float64, h float64) {
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q\n", w, h, x, y, name)
+}
+
+// logo is a decoded logo as RGB samples
+type logo struct {
+	width int
+	height int
+	rgb []byte
+}
+
+// readLogo reads and decodes the JPEG or PNG logo, transparency is flattened onto white and large logos are scaled down
+func readLogo(path string) (*logo, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	step := 1
+	for bounds.Dx() / step > config.OfferLetterLogoMaxPixels {
+		step++
+	}
+	l := &logo{width: (bounds.Dx() + step - 1) / step, height: (bounds.Dy() + step - 1) / step}
+	if l.width == 0 || l.height == 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+
+	l.rgb = make([]byte, 0, l.width * l.height * 3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += step {
+		for x := bounds.Min.X; x < bounds.Max.X; x += step {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			a := uint32(c.A)
+			// over white
+			l.rgb = append(l.rgb,
+				byte((uint32(c.R) * a + 255 * (255 - a)) / 255),
+				byte((uint32(c.G) * a + 255 * (255 - a)) / 255),
+				byte((uint32(c.B) * a + 255 * (255 - a)) / 255),
+			)
+		}
+	}
+
+	return l, nil
+}
+
+func deflate(data []byte) []byte {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	w.Write(data)
+	w.Close()
+	return b.Bytes()
+}
+
+// WritePDF writes a letter as an A4 PDF, the letterhead on the first page followed by the title and the body.
+// Paragraphs of the body are separated by new lines, pages are numbered in the footer.
+// Returns an *UnencodableError, without writing anything, if the letter has characters that cannot be shown.
+func WritePDF(w io.Writer, head *Letterhead, title string, body string) error {
+
+	err := checkEncodable(head.CompanyName, head.Address, title, body)
+	if err != nil {
+		return err
+	}
+
+	var pages []*page
+	current := &page{}
+	pages = append(pages, current)
+	y := pageHeight - margin
+
+	// letterhead, the logo to the left of the name and address
+	var img *logo
+	if head.Logo != "" {
+		img, _ = readLogo(head.Logo)
+	}
+	x := margin
+	headHeight := 0.0
+	if img != nil {
+		logoHeight := logoMaxHeight
+		logoWidth := float64(img.width) * logoHeight / float64(img.height)
+		if logoWidth > logoMaxWidth {
+			logoWidth = logoMaxWidth
+			logoHeight = float64(img.height) * logoWidth / float64(img.width)
+		}
+		current.image("Im1", margin, y - logoHeight, logoWidth, logoHeight)
+		x += logoWidth + 14
+		headHeight = logoHeight
+	}
+	textWidth := pageWidth - margin - x
+	ty := y - nameSize
+	for _, line := range wrap(encode(head.CompanyName), bold, nameSize, textWidth) {
+		current.text(bold, nameSize, x, ty, line)
+		ty -= nameSize + 4
+	}
+	for _, part := range strings.Split(head.Address, "\n") {
+		for _, line := range wrap(encode(part), regular, addressSize, textWidth) {
+			current.text(regular, addressSize, x, ty, line)
+			ty -= addressSize + 3
+		}
+	}
+	headHeight = max(headHeight, y - ty)
+	y -= headHeight + 10
+	current.rule(y)
+	y -= 30
+
+	// title, centred
+	for _, line := range wrap(encode(title), bold, titleSize, pageWidth - 2 * margin) {
+		current.text(bold, titleSize, (pageWidth - bold.width(line, titleSize)) / 2, y, line)
+		y -= titleSize + 6
+	}
+	y -= 14
+
+	// body, a blank line between paragraphs
+	for _, paragraph := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		for _, line := range wrap(encode(paragraph), regular, bodySize, pageWidth - 2 * margin) {
+			if y < margin + bodyLeading {
+				current = &page{}
+				pages = append(pages, current)
+				y = pageHeight - margin - bodySize
+			}
+			current.text(regular, bodySize, margin, y, line)
+			y -= bodyLeading
+		}
+	}
+
+	for i, p := range pages {
+		footer := fmt.Sprintf("Page %d of %d", i + 1, len(pages))
+		p.text(regular, footerSize, (pageWidth - regular.width(footer, footerSize)) / 2, margin / 2, footer)
+	}
+
+	// objects : 1 catalog, 2 pages, 3 and 4 fonts, 5 the logo if any, then a page and its content for each page
+	var objects [][]byte
+	object := func(format string, a ...any) {
+		objects = append(objects, []byte(fmt.Sprintf(format, a...)))
+	}
+	stream := func(dict string, data []byte) {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "<< %s /Length %d >>\nstream\n", dict, len(data))
+		b.Write(data)
+		b.WriteString("\nendstream")
+		objects = append(objects, b.Bytes())
+	}
+
+	first := 5
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if img != nil {
+		first = 6
+		resources += " /XObject << /Im1 5 0 R >>"
+	}
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", first + 2 * i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	if img != nil {
+		stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode", img.width, img.height), deflate(img.rgb))
+	}
+	for i, p := range pages {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << %s >> /Contents %d 0 R >>", pageWidth, pageHeight, resources, first + 2 * i + 1)
+		stream("/Filter /FlateDecode", deflate(p.content.Bytes()))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i + 1)
+		out.Write(obj)
+		out.WriteString("\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects) + 1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects) + 1, xref)
+
+	_, err = w.Write(out.Bytes())
+	return err
+}
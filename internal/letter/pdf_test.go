@@ -0,0 +1,100 @@
+package letter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var trailerPattern = regexp.MustCompile(`trailer\n<< /Size (\d+) /Root 1 0 R >>\nstartxref\n(\d+)\n%%EOF\n$`)
+
+// checkPDF checks the cross-reference table and trailer of a PDF point at its objects, returning their count
+func checkPDF(t *testing.T, pdf []byte) int {
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header : %q", pdf[:min(len(pdf), 16)])
+	}
+	m := trailerPattern.FindSubmatch(pdf)
+	if m == nil {
+		t.Fatalf("missing trailer : %q", pdf[max(0, len(pdf) - 80):])
+	}
+	size, _ := strconv.Atoi(string(m[1]))
+	xref, _ := strconv.Atoi(string(m[2]))
+
+	table := string(pdf[xref:])
+	header := fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", size)
+	if !strings.HasPrefix(table, header) {
+		t.Fatalf("startxref %d does not point at a table of %d entries : %q", xref, size, table[:min(len(table), 40)])
+	}
+	entries := table[len(header):]
+	for i := 1; i < size; i++ {
+		// entries are 20 bytes each
+		entry := entries[(i - 1) * 20 : i * 20]
+		if !strings.HasSuffix(entry, " 00000 n \n") {
+			t.Fatalf("malformed xref entry %d : %q", i, entry)
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		if err != nil {
+			t.Fatalf("malformed offset of object %d : %q", i, entry)
+		}
+		if !bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i))) {
+			t.Errorf("xref offset %d of object %d points at %q", offset, i, pdf[offset:min(len(pdf), offset + 12)])
+		}
+	}
+	if !strings.HasPrefix(entries[(size - 1) * 20:], "trailer\n") {
+		t.Errorf("xref table has more entries than the trailer size %d", size)
+	}
+
+	return size - 1
+}
+
+func TestWritePDF(t *testing.T) {
+
+	head := &Letterhead{CompanyName: "Acme Corp (India)", Address: "12 MG Road\nBengaluru 560001"}
+
+	tests := []struct {
+		name string
+		body string
+		objects int
+	}{
+		// catalog, pages, 2 fonts and a page with its content for each page
+		{"one page", "Dear Asha,\nWe are pleased to offer you the role of Analyst at ₹12 LPA – welcome!", 6},
+		{"three pages", strings.Repeat("A paragraph of the offer letter that is long enough to wrap over a line or two of the page.\n", 120), 10},
+	}
+
+	for _, tt := range tests {
+		var pdf bytes.Buffer
+		err := WritePDF(&pdf, head, "Offer of Employment", tt.body)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		objects := checkPDF(t, pdf.Bytes())
+		if objects != tt.objects {
+			t.Errorf("%s: got %d objects, want %d", tt.name, objects, tt.objects)
+		}
+	}
+}
+
+func TestWritePDFUnencodable(t *testing.T) {
+
+	var pdf bytes.Buffer
+	err := WritePDF(&pdf, &Letterhead{CompanyName: "Acme"}, "Offer", "Dear Łukasz Wójcik (卢卡斯), welcome Łukasz.")
+
+	var unencodable *UnencodableError
+	if !errors.As(err, &unencodable) {
+		t.Fatalf("got error %v, want an UnencodableError", err)
+	}
+	want := []rune{'Ł', '卢', '卡', '斯'}
+	if !reflect.DeepEqual(unencodable.Chars, want) {
+		t.Errorf("got characters %q, want %q", unencodable.Chars, want)
+	}
+	if pdf.Len() != 0 {
+		t.Errorf("wrote %d bytes of a letter that cannot be shown", pdf.Len())
+	}
+}
@@ -1,30 +1,62 @@
 package notify
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/redis/go-redis/v9"
+	"go.mod/internal/apicalls"
+	"go.mod/internal/config"
 	errs "go.mod/internal/const"
 	"go.mod/internal/dto"
 	sqlc "go.mod/internal/sqlc/generate"
 )
 
+// notification categories
+const (
+	CategoryGeneral = "general"
+	CategoryApplication = "application"
+	CategoryInterview = "interview"
+	CategoryTest = "test"
+	CategoryOffer = "offer"
+	CategoryAnnouncement = "announcement"
+)
+
+// types of entities a notification can link to, RefID is the primary key of the entity
+const (
+	RefApplication = "application"
+	RefInterview = "interview"
+	RefTest = "test"
+	RefJob = "job"
+	RefAnnouncement = "announcement"
+)
+
 type Notify struct {
 	RedisClient *redis.Client
+	// reads the streams of SSE clients, each blocks a connection of its pool while waiting
+	StreamClient *redis.Client
 	Queries *sqlc.Queries
+	// push notifications to the registered devices, nil disables them
+	Push apicalls.PushSender
 } 
 
-func NewNotifyService(redisClient *redis.Client, queries *sqlc.Queries) *Notify {
+func NewNotifyService(redisClient *redis.Client, streamClient *redis.Client, queries *sqlc.Queries, push apicalls.PushSender) *Notify {
 	return &Notify{
 		RedisClient: redisClient,
+		StreamClient: streamClient,
 		Queries: queries,
+		Push: push,
 	}
 }
 
-func (n *Notify) NewNotification(ctx *gin.Context, userID int64, toSend *dto.NotificationData) (*errs.Error) {
+func (n *Notify) NewNotification(ctx context.Context, userID int64, toSend *dto.NotificationData) (*errs.Error) {
 
 	if toSend == nil {
 		return &errs.Error{
@@ -33,11 +65,20 @@ func (n *Notify) NewNotification(ctx *gin.Context, userID int64, toSend *dto.Not
 		}
 	}
 
-	err := n.Queries.InsertNotifications(ctx, sqlc.InsertNotificationsParams{
+	timestamp := time.Now().Unix()
+
+	if toSend.Category == "" {
+		toSend.Category = CategoryGeneral
+	}
+
+	notifID, err := n.Queries.InsertNotifications(ctx, sqlc.InsertNotificationsParams{
 		UserID: userID,
 		Title: pgtype.Text{String: toSend.Title, Valid: true},
 		Description: pgtype.Text{String: toSend.Description, Valid: true},
-		Timestamp: time.Now().Unix(),
+		Timestamp: timestamp,
+		Category: toSend.Category,
+		RefType: pgtype.Text{String: toSend.RefType, Valid: toSend.RefType != ""},
+		RefID: pgtype.Int8{Int64: toSend.RefID, Valid: toSend.RefType != ""},
 	})
 	if err != nil {
 		return &errs.Error{
@@ -46,31 +87,221 @@ func (n *Notify) NewNotification(ctx *gin.Context, userID int64, toSend *dto.Not
 		}
 	}
 
+	// the notification is already persisted, a failed publish only misses the live push
+	err = n.publish(ctx, userID, notifID, toSend, timestamp)
+	if err != nil {
+		fmt.Printf("Failed to publish notification %d to stream : %v\n", notifID, err)
+	}
+
+	// has its own context, the request may be done before the push is
+	if n.Push != nil {
+		go n.pushToDevices(context.Background(), userID, notifID, toSend)
+	}
+
 	return nil
 }
 
-func (n *Notify) GetNotifications(ctx *gin.Context, userID int64, start string, end string) (*[]sqlc.Notification, *errs.Error) {
+// pushToDevices fans out the notification to all the registered devices of the user,
+// and prunes the tokens reported as invalid.
+func (n *Notify) pushToDevices(ctx context.Context, userID int64, notifID int64, toSend *dto.NotificationData) {
+
+	tokens, err := n.Queries.GetDeviceTokens(ctx, userID)
+	if err != nil {
+		fmt.Printf("Failed to get device tokens of user %d : %v\n", userID, err)
+		return
+	}
+	if len(tokens) == 0 {
+		return
+	}
+
+	data := map[string]string{
+		"NotifID": strconv.FormatInt(notifID, 10),
+		"Category": toSend.Category,
+	}
+	if toSend.RefType != "" {
+		data["RefType"] = toSend.RefType
+		data["RefID"] = strconv.FormatInt(toSend.RefID, 10)
+	}
+
+	invalid, err := n.Push.SendFireNotification(ctx, tokens, &apicalls.PushMessage{
+		Title: toSend.Title,
+		Body: toSend.Description,
+		Data: data,
+	})
+	if err != nil {
+		fmt.Printf("Failed to push notification %d : %v\n", notifID, err)
+	}
+
+	if len(invalid) != 0 {
+		err = n.Queries.DeleteDeviceTokens(ctx, invalid)
+		if err != nil {
+			fmt.Printf("Failed to prune invalid device tokens : %v\n", err)
+		}
+	}
+}
+
+// RegisterDevice registers the push token of a device for the user. A token moves to the latest user registering it.
+func (n *Notify) RegisterDevice(ctx *gin.Context, userID int64, data *dto.DeviceToken) *errs.Error {
+
+	if data.Token == "" {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Missing device token.",
+			ToRespondWith: true,
+		}
+	}
+	if data.Platform == "" {
+		data.Platform = "web"
+	}
+
+	err := n.Queries.RegisterDeviceToken(ctx, sqlc.RegisterDeviceTokenParams{
+		Token: data.Token,
+		UserID: userID,
+		Platform: data.Platform,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to register device token : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// UnregisterDevice removes the push token of a device of the user, eg. on logout.
+func (n *Notify) UnregisterDevice(ctx *gin.Context, userID int64, data *dto.DeviceToken) *errs.Error {
+
+	count, err := n.Queries.UnregisterDeviceToken(ctx, sqlc.UnregisterDeviceTokenParams{
+		Token: data.Token,
+		UserID: userID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to unregister device token : " + err.Error(),
+		}
+	}
+	if count == 0 {
+		return &errs.Error{
+			Type: errs.NotFound,
+			Message: "No such device token registered for the user.",
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
+
+// StreamKey is the redis stream of the user's live notifications.
+func StreamKey(userID int64) string {
+	return fmt.Sprintf("notifications:%d", userID)
+}
+
+// publish appends the notification to the user's redis stream, read by every server instance holding an SSE connection for the user.
+func (n *Notify) publish(ctx context.Context, userID int64, notifID int64, toSend *dto.NotificationData, timestamp int64) error {
+
+	key := StreamKey(userID)
+
+	pipe := n.RedisClient.Pipeline()
+	pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: config.NotificationsStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"NotifID": notifID,
+			"Title": toSend.Title,
+			"Description": toSend.Description,
+			"TimeStamp": timestamp,
+			"Category": toSend.Category,
+			"RefType": toSend.RefType,
+			"RefID": toSend.RefID,
+		},
+	})
+	pipe.Expire(ctx, key, config.NotificationsStreamExpiry * time.Second)
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+// UnreadCount returns the number of unread notifications of the user.
+func (n *Notify) UnreadCount(ctx context.Context, userID int64) (int64, *errs.Error) {
+
+	count, err := n.Queries.UnreadNotificationsCount(ctx, userID)
+	if err != nil {
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get unread notifications count : " + err.Error(),
+		}
+	}
+
+	return count, nil
+}
+
+// GetNotifications returns the notifications of the user from start (offset) to end (exclusive),
+// newest first, optionally filtered by category and date range. Archived notifications are only returned if asked for.
+func (n *Notify) GetNotifications(ctx *gin.Context, userID int64, start string, end string, filter *dto.NotificationFilter) (*[]sqlc.Notification, *errs.Error) {
 
 	pageStart, err := strconv.ParseInt(start, 10, 64)
 	if err != nil {
 		return nil , &errs.Error{
-			Type: errs.Internal,
+			Type: errs.InvalidFormat,
 			Message: "Failed to parse page start : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
-	_, err = strconv.ParseInt(end, 10, 64)
+	pageEnd, err := strconv.ParseInt(end, 10, 64)
 	if err != nil {
 		return nil , &errs.Error{
-			Type: errs.Internal,
+			Type: errs.InvalidFormat,
 			Message: "Failed to parse page end : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
+	if pageStart < 0 || pageEnd <= pageStart {
+		return nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Page end must be greater than page start, and start must not be negative.",
+			ToRespondWith: true,
+		}
+	}
+	limit := pageEnd - pageStart
+	if limit > config.NotificationsPageLimit {
+		limit = config.NotificationsPageLimit
+	}
 
-	allNotifs, err := n.Queries.GetNotifications(ctx, sqlc.GetNotificationsParams{
+	params := sqlc.GetNotificationsParams{
 		UserID: userID,
-		Limit: 15,
-		Offset: int32(pageStart),
-	})
+		Archived: filter.Archived,
+		Category: pgtype.Text{String: filter.Category, Valid: filter.Category != ""},
+		PageLimit: int32(limit),
+		PageOffset: int32(pageStart),
+	}
+	if filter.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", filter.From, time.Local)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid from date, expected YYYY-MM-DD : " + err.Error(),
+				ToRespondWith: true,
+			}
+		}
+		params.FromTs = pgtype.Int8{Int64: from.Unix(), Valid: true}
+	}
+	if filter.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", filter.To, time.Local)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid to date, expected YYYY-MM-DD : " + err.Error(),
+				ToRespondWith: true,
+			}
+		}
+		// inclusive of the whole day
+		params.ToTs = pgtype.Int8{Int64: to.AddDate(0, 0, 1).Unix() - 1, Valid: true}
+	}
+
+	allNotifs, err := n.Queries.GetNotifications(ctx, params)
 	if err != nil {
 		return nil, &errs.Error{
 			Type: errs.Internal,
@@ -81,3 +312,185 @@ func (n *Notify) GetNotifications(ctx *gin.Context, userID int64, start string,
 
 	return &allNotifs, nil
 }
+
+// MarkRead marks the given notifications of the user as read, or all of them if data.All is set.
+// Returns the number of notifications updated.
+func (n *Notify) MarkRead(ctx *gin.Context, userID int64, data *dto.NotificationIDs) (int64, *errs.Error) {
+
+	var count int64
+	var err error
+	if data.All {
+		count, err = n.Queries.MarkAllNotificationsRead(ctx, userID)
+	} else {
+		if len(data.NotifIDs) == 0 {
+			return 0, missingNotifIDs()
+		}
+		count, err = n.Queries.MarkNotificationsRead(ctx, sqlc.MarkNotificationsReadParams{
+			UserID: userID,
+			NotifIds: data.NotifIDs,
+		})
+	}
+	if err != nil {
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to mark notifications as read : " + err.Error(),
+		}
+	}
+
+	return count, nil
+}
+
+// Archive archives (or unarchives) the given notifications of the user. Returns the number of notifications updated.
+func (n *Notify) Archive(ctx *gin.Context, userID int64, data *dto.NotificationIDs) (int64, *errs.Error) {
+
+	if len(data.NotifIDs) == 0 {
+		return 0, missingNotifIDs()
+	}
+
+	count, err := n.Queries.ArchiveNotifications(ctx, sqlc.ArchiveNotificationsParams{
+		Archived: data.Archived,
+		UserID: userID,
+		NotifIds: data.NotifIDs,
+	})
+	if err != nil {
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to archive notifications : " + err.Error(),
+		}
+	}
+
+	return count, nil
+}
+
+// Delete deletes the given notifications of the user. Returns the number of notifications deleted.
+func (n *Notify) Delete(ctx *gin.Context, userID int64, data *dto.NotificationIDs) (int64, *errs.Error) {
+
+	if len(data.NotifIDs) == 0 {
+		return 0, missingNotifIDs()
+	}
+
+	count, err := n.Queries.DeleteNotifications(ctx, sqlc.DeleteNotificationsParams{
+		UserID: userID,
+		NotifIds: data.NotifIDs,
+	})
+	if err != nil {
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to delete notifications : " + err.Error(),
+		}
+	}
+
+	return count, nil
+}
+
+func missingNotifIDs() *errs.Error {
+	return &errs.Error{
+		Type: errs.MissingRequiredField,
+		Message: "No notification IDs given.",
+		ToRespondWith: true,
+	}
+}
+
+// ErrInvalidEventID is returned by StreamStart for a last event ID that is not a stream ID
+var ErrInvalidEventID = errors.New("invalid last event ID")
+
+var streamIDRegex = regexp.MustCompile(`^(\d+)-(\d+)$`)
+
+// StreamStart returns the ID to start reading the user's stream from.
+// Without lastEventID only the notifications after the latest existing one are read. When resuming from lastEventID,
+// the notifications since then that were trimmed from the stream, or dropped with it, are returned from the db
+// oldest first, at most config.NotificationsPageLimit of them. They may repeat notifications received in the second
+// before lastEventID, NotifID tells them apart. Returns ErrInvalidEventID if lastEventID is not a stream ID.
+func (n *Notify) StreamStart(ctx context.Context, userID int64, lastEventID string) (string, []sqlc.MissedNotificationsRow, error) {
+
+	key := StreamKey(userID)
+
+	if lastEventID == "" {
+		latest, err := n.StreamClient.XRevRangeN(ctx, key, "+", "-", 1).Result()
+		if err != nil {
+			return "", nil, err
+		}
+		if len(latest) == 0 {
+			return "0-0", nil, nil
+		}
+		return latest[0].ID, nil, nil
+	}
+
+	lastMs, lastSeq, ok := parseStreamID(lastEventID)
+	if !ok {
+		return "", nil, ErrInvalidEventID
+	}
+
+	params := sqlc.MissedNotificationsParams{
+		UserID: userID,
+		// notifications are timestamped in seconds before they are added to the stream
+		Since: int64(lastMs / 1000) - 1,
+		PageLimit: config.NotificationsPageLimit,
+	}
+	oldest, err := n.StreamClient.XRangeN(ctx, key, "-", "+", 1).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	if len(oldest) != 0 {
+		oldestMs, oldestSeq, _ := parseStreamID(oldest[0].ID)
+		if lastMs > oldestMs || (lastMs == oldestMs && lastSeq >= oldestSeq) {
+			// nothing after lastEventID was trimmed
+			return lastEventID, nil, nil
+		}
+		// the oldest in the stream and those after it are read from the stream
+		str, _ := oldest[0].Values["NotifID"].(string)
+		notifID, err := strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			params.BeforeID = pgtype.Int8{Int64: notifID, Valid: true}
+		}
+	}
+
+	missed, err := n.Queries.MissedNotifications(ctx, params)
+	if err != nil {
+		return "", nil, err
+	}
+	slices.Reverse(missed)
+
+	return lastEventID, missed, nil
+}
+
+// parseStreamID parses a redis stream ID, <milliseconds>-<sequence>
+func parseStreamID(id string) (uint64, uint64, bool) {
+
+	m := streamIDRegex.FindStringSubmatch(id)
+	if m == nil {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(m[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(m[2], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return ms, seq, true
+}
+
+// ReadStream blocks for at most NotificationsXReadBlock for notifications after lastID in the user's stream.
+// Returns no messages and no error if the block timed out.
+func (n *Notify) ReadStream(ctx context.Context, userID int64, lastID string) ([]redis.XMessage, error) {
+
+	streams, err := n.StreamClient.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{StreamKey(userID), lastID},
+		Count: config.NotificationsXReadCount,
+		Block: config.NotificationsXReadBlock * time.Millisecond,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+
+	return streams[0].Messages, nil
+}
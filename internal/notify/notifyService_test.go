@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+	"go.mod/internal/apicalls"
+	"go.mod/internal/dto"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// fakeDB keeps the device tokens and notifications of the queries used by the push fan-out in memory
+type fakeDB struct {
+	mu sync.Mutex
+	tokens map[string]int64 // token to user ID
+	notifID int64
+	pruned chan []string
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		tokens: map[string]int64{},
+		pruned: make(chan []string, 1),
+	}
+}
+
+func queryName(sql string) string {
+	name := strings.TrimPrefix(strings.SplitN(sql, "\n", 2)[0], "-- name: ")
+	return strings.Fields(name)[0]
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	switch queryName(sql) {
+	case "RegisterDeviceToken":
+		db.tokens[args[0].(string)] = args[1].(int64)
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	case "DeleteDeviceTokens":
+		tokens := args[0].([]string)
+		for _, t := range tokens {
+			delete(db.tokens, t)
+		}
+		db.pruned <- tokens
+		return pgconn.NewCommandTag(fmt.Sprintf("DELETE %d", len(tokens))), nil
+	}
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected exec %s", queryName(sql))
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if queryName(sql) != "GetDeviceTokens" {
+		return nil, fmt.Errorf("unexpected query %s", queryName(sql))
+	}
+	rows := &fakeRows{}
+	for token, userID := range db.tokens {
+		if userID == args[0].(int64) {
+			rows.values = append(rows.values, token)
+		}
+	}
+	return rows, nil
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if queryName(sql) != "InsertNotifications" {
+		return &fakeRows{err: fmt.Errorf("unexpected query %s", queryName(sql))}
+	}
+	db.notifID++
+	return &fakeRows{values: []string{fmt.Sprint(db.notifID)}, next: 1}
+}
+
+// fakeRows are rows of a single text or bigint column
+type fakeRows struct {
+	values []string
+	next int
+	err error
+}
+
+func (r *fakeRows) Close() {}
+func (r *fakeRows) Err() error { return r.err }
+func (r *fakeRows) CommandTag() pgconn.CommandTag { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) Values() ([]any, error) { return nil, nil }
+func (r *fakeRows) RawValues() [][]byte { return nil }
+func (r *fakeRows) Conn() *pgx.Conn { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.next >= len(r.values) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	value := r.values[r.next - 1]
+	switch d := dest[0].(type) {
+	case *string:
+		*d = value
+	case *int64:
+		_, err := fmt.Sscan(value, d)
+		return err
+	default:
+		return fmt.Errorf("unexpected scan into %T", d)
+	}
+	return nil
+}
+
+func TestNewNotificationPushesToDevices(t *testing.T) {
+
+	db := newFakeDB()
+	push := apicalls.NewFakePushSender()
+	push.Invalid["expired"] = true
+	// nothing listens there, the failed publish to the stream is only logged
+	unreachable := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer unreachable.Close()
+	n := NewNotifyService(unreachable, unreachable, sqlc.New(db), push)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	for _, device := range []dto.DeviceToken{
+		{Token: "phone", Platform: "android"},
+		{Token: "laptop"},
+		{Token: "expired", Platform: "ios"},
+	} {
+		errf := n.RegisterDevice(ctx, 7, &device)
+		if errf != nil {
+			t.Fatalf("RegisterDevice(%s) : %s", device.Token, errf.Message)
+		}
+	}
+	// of another user, not pushed to
+	errf := n.RegisterDevice(ctx, 8, &dto.DeviceToken{Token: "other"})
+	if errf != nil {
+		t.Fatalf("RegisterDevice(other) : %s", errf.Message)
+	}
+
+	errf = n.NewNotification(context.Background(), 7, &dto.NotificationData{
+		Title: "Interview scheduled",
+		Description: "Your interview is at 10:00 AM.",
+		Category: CategoryInterview,
+		RefType: RefInterview,
+		RefID: 42,
+	})
+	if errf != nil {
+		t.Fatalf("NewNotification : %s", errf.Message)
+	}
+
+	// the fan-out runs in the background, the invalid token is pruned after the push
+	select {
+	case pruned := <-db.pruned:
+		if len(pruned) != 1 || pruned[0] != "expired" {
+			t.Errorf("pruned %v, want [expired]", pruned)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("invalid token was not pruned")
+	}
+
+	sent := map[string]apicalls.PushMessage{}
+	for _, p := range push.Pushes() {
+		sent[p.Token] = p.Message
+	}
+	if len(sent) != 2 {
+		t.Fatalf("pushed to %d devices, want the 2 valid devices of the user", len(sent))
+	}
+	for _, token := range []string{"phone", "laptop"} {
+		msg, ok := sent[token]
+		if !ok {
+			t.Errorf("not pushed to %s", token)
+			continue
+		}
+		if msg.Title != "Interview scheduled" || msg.Data["RefType"] != RefInterview || msg.Data["RefID"] != "42" || msg.Data["NotifID"] != "1" {
+			t.Errorf("pushed %+v to %s", msg, token)
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, exists := db.tokens["expired"]; exists {
+		t.Error("invalid token is still registered")
+	}
+	if len(db.tokens) != 3 {
+		t.Errorf("%d tokens registered after pruning, want 3", len(db.tokens))
+	}
+}
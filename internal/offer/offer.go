@@ -0,0 +1,76 @@
+package offer
+
+import (
+	"fmt"
+	"time"
+
+	"go.mod/internal/compensation"
+	"go.mod/internal/config"
+)
+
+// statuses of an offer, values of offers.status
+const (
+	Pending = "Pending"
+	Accepted = "Accepted"
+	Declined = "Declined"
+	Expired = "Expired"
+)
+
+// Terms are the terms of an offer, the student accepts or declines them by RespondBy.
+type Terms struct {
+	CTC int64 // per annum, in whole units of Currency
+	Currency string
+	JoiningDate time.Time // the date alone is of use
+	RespondBy time.Time
+}
+
+// Validate checks the terms of an offer made at now, the returned error is meant for the user.
+// The currency defaults to INR and the deadline to config.OfferResponseDays from now, or the joining date if sooner.
+func (t *Terms) Validate(now time.Time) error {
+
+	c := &compensation.Compensation{Currency: t.Currency, CTCMin: t.CTC}
+	c.Normalize()
+	if t.CTC <= 0 {
+		return fmt.Errorf("the CTC of the offer is required")
+	}
+	err := c.Validate()
+	if err != nil {
+		return err
+	}
+	t.Currency = c.Currency
+
+	if t.JoiningDate.IsZero() {
+		return fmt.Errorf("the joining date is required")
+	}
+	joining := time.Date(t.JoiningDate.Year(), t.JoiningDate.Month(), t.JoiningDate.Day(), 0, 0, 0, 0, now.Location())
+	if !joining.After(now) {
+		return fmt.Errorf("the joining date must be after today")
+	}
+	t.JoiningDate = joining
+
+	if t.RespondBy.IsZero() {
+		t.RespondBy = now.AddDate(0, 0, config.OfferResponseDays)
+		if t.RespondBy.After(joining) {
+			t.RespondBy = joining
+		}
+	}
+	if !t.RespondBy.After(now) {
+		return fmt.Errorf("the response deadline cannot be in the past")
+	}
+	if t.RespondBy.After(joining) {
+		return fmt.Errorf("the response deadline must be before the joining date")
+	}
+
+	return nil
+}
+
+// Display is the CTC as shown to students, eg. "₹12 LPA".
+func (t *Terms) Display() string {
+	return Display(t.CTC, t.Currency)
+}
+
+// Display is a CTC per annum in the currency as shown to students.
+func Display(ctc int64, currency string) string {
+	c := &compensation.Compensation{Currency: currency, CTCMin: ctc, CTCMax: ctc}
+	return c.Display()
+}
@@ -0,0 +1,105 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mod/internal/appstatus"
+	"go.mod/internal/config"
+)
+
+// kinds of a stage of the pipeline, values of job_pipeline_stages.kind
+const (
+	KindScreening = "screening"
+	KindTest = "test"
+	KindInterview = "interview"
+	KindOffer = "offer"
+)
+
+// Stage is a stage of the hiring pipeline of a job, eg. {Name: "Aptitude test", Kind: "test", TestID: 12}.
+// A test stage is of a test of the job, the interviews scheduled while an applicant is in an interview stage are
+// linked to it. Applicants move to the offer stage, if any, when they are offered. StageID is 0 for a new stage.
+type Stage struct {
+	StageID int64
+	Position int32 // from 1, set by the order of the stages
+	Name string
+	Kind string
+	TestID int64
+	TestName string
+}
+
+// ValidKind reports whether kind is a kind of stage.
+func ValidKind(kind string) bool {
+	return kind == KindScreening || kind == KindTest || kind == KindInterview || kind == KindOffer
+}
+
+// Validate checks the stages of a pipeline in their order and numbers their positions, the returned error is meant
+// for the user. No stages is a job without a pipeline.
+func Validate(stages []Stage) error {
+
+	if len(stages) > config.PipelineMaxStages {
+		return fmt.Errorf("a pipeline can have at most %d stages", config.PipelineMaxStages)
+	}
+
+	names := make(map[string]bool)
+	ids := make(map[int64]bool)
+	for i := range stages {
+		s := &stages[i]
+		s.Position = int32(i + 1)
+		s.Name = strings.TrimSpace(s.Name)
+		s.Kind = strings.ToLower(strings.TrimSpace(s.Kind))
+		if s.Name == "" {
+			return fmt.Errorf("every stage needs a name")
+		}
+		if len([]rune(s.Name)) > config.PipelineStageNameMaxLength {
+			return fmt.Errorf("stage name %s is longer than %d characters", s.Name, config.PipelineStageNameMaxLength)
+		}
+		if names[strings.ToLower(s.Name)] {
+			return fmt.Errorf("stage %s is given more than once", s.Name)
+		}
+		if s.StageID != 0 && ids[s.StageID] {
+			return fmt.Errorf("stage ID %d is given more than once", s.StageID)
+		}
+		names[strings.ToLower(s.Name)], ids[s.StageID] = true, true
+
+		if !ValidKind(s.Kind) {
+			return fmt.Errorf("invalid kind %q of stage %s, must be screening, test, interview or offer", s.Kind, s.Name)
+		}
+		if s.Kind == KindTest && s.TestID == 0 {
+			return fmt.Errorf("test stage %s needs the test ID of a test of the job", s.Name)
+		}
+		if s.Kind != KindTest && s.TestID != 0 {
+			return fmt.Errorf("only test stages can have a test, %s is a %s stage", s.Name, s.Kind)
+		}
+		if s.Kind == KindOffer && i != len(stages) - 1 {
+			return fmt.Errorf("the offer stage %s must be the last stage", s.Name)
+		}
+	}
+	if len(stages) == 1 && stages[0].Kind == KindOffer {
+		return fmt.Errorf("a pipeline needs a stage before the offer")
+	}
+
+	return nil
+}
+
+// StatusOnMove is the status an application in status moves to along with being moved to the stage, "" if it stays.
+// Moving past the first stage shortlists, and moving on from an interview is back to shortlisted for the next round.
+// The error is meant for the user.
+func StatusOnMove(status string, to *Stage) (string, error) {
+
+	if to.Kind == KindOffer {
+		return "", fmt.Errorf("applicants move to the offer stage %s when they are offered", to.Name)
+	}
+	if appstatus.Final(status) || status == appstatus.Offered {
+		return "", fmt.Errorf("the application is %s, it cannot move between stages anymore", status)
+	}
+
+	switch {
+	case status == appstatus.Interview:
+		return appstatus.ShortListed, nil
+	case to.Position > 1 && (status == appstatus.Applied || status == appstatus.UnderReview):
+		return appstatus.ShortListed, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,276 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/compensation"
+)
+
+// rules of the placement policy, exceptions are granted per rule
+const (
+	RuleMaxOffers = "max_offers"
+	RuleTier = "tier"
+	RuleAccepted = "accepted"
+)
+
+// statuses of an application counted as an offer the student received
+var offerStatuses = map[string]bool{
+	"Offered": true,
+	"Hired": true,
+	"Declined": true,
+}
+
+// Tier is a band of jobs by CTC, eg. {Name: "Dream", MinCTC: 1000000}.
+// A job is in the highest tier whose MinCTC it reaches.
+type Tier struct {
+	Name string
+	MinCTC int64 // INR per annum
+}
+
+// Policy is the placement policy, it applies to full-time jobs only, internships are neither restricted nor counted.
+//   - MaxOffers, when not 0, is the most offers a student may hold, applying is blocked once reached. Declined and
+//     expired offers are given up, they are not counted.
+//   - BlockAfterAcceptance blocks applying to and being offered other jobs once an offer is accepted.
+//   - Tiers, when given, let a student who received an offer apply only to jobs of a higher tier than the best one.
+//     Declined offers count, declining does not open the lower tiers again.
+type Policy struct {
+	MaxOffers int32
+	BlockAfterAcceptance bool
+	Tiers []Tier
+}
+
+// Job is what the policy knows of a job, CTC is 0 if not known.
+type Job struct {
+	Title string
+	Company string
+	Internship bool
+	CTC int64 // INR per annum, the top of the range
+}
+
+// Placement is an offer a student received, Status is the status of the application.
+type Placement struct {
+	Job
+	Status string
+}
+
+// Violation is a rule the policy does not allow an application or offer by, Reason is meant for the user.
+type Violation struct {
+	Rule string
+	Reason string
+}
+
+// ValidRule reports whether rule is a rule of the policy.
+func ValidRule(rule string) bool {
+	return rule == RuleMaxOffers || rule == RuleTier || rule == RuleAccepted
+}
+
+// FromColumns builds the policy from its columns in the database.
+func FromColumns(maxOffers int32, blockAfterAcceptance bool, tiers []byte) (*Policy, error) {
+
+	p := &Policy{
+		MaxOffers: maxOffers,
+		BlockAfterAcceptance: blockAfterAcceptance,
+		Tiers: []Tier{},
+	}
+	if len(tiers) != 0 {
+		err := json.Unmarshal(tiers, &p.Tiers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement policy tiers : %v", err)
+		}
+	}
+	sort.Slice(p.Tiers, func(i, j int) bool { return p.Tiers[i].MinCTC < p.Tiers[j].MinCTC })
+
+	return p, nil
+}
+
+// Validate checks the policy and sorts its tiers by CTC, the returned error is meant for the user.
+func (p *Policy) Validate() error {
+
+	if p.MaxOffers < 0 {
+		return fmt.Errorf("maximum offers cannot be negative, use 0 for no limit")
+	}
+
+	names := make(map[string]bool)
+	ctcs := make(map[int64]bool)
+	for i := range p.Tiers {
+		t := &p.Tiers[i]
+		t.Name = strings.TrimSpace(t.Name)
+		if t.Name == "" {
+			return fmt.Errorf("every tier needs a name")
+		}
+		if t.MinCTC < 0 {
+			return fmt.Errorf("minimum CTC of tier %s cannot be negative", t.Name)
+		}
+		if names[strings.ToLower(t.Name)] {
+			return fmt.Errorf("tier %s is given more than once", t.Name)
+		}
+		if ctcs[t.MinCTC] {
+			return fmt.Errorf("tiers cannot have the same minimum CTC")
+		}
+		names[strings.ToLower(t.Name)], ctcs[t.MinCTC] = true, true
+	}
+	if p.Tiers == nil {
+		p.Tiers = []Tier{}
+	}
+	sort.Slice(p.Tiers, func(i, j int) bool { return p.Tiers[i].MinCTC < p.Tiers[j].MinCTC })
+
+	return nil
+}
+
+// JobFrom builds the job from its type and structured compensation, only an INR CTC is known to the policy.
+func JobFrom(title string, company string, jobType string, currency pgtype.Text, ctcMin pgtype.Int8, ctcMax pgtype.Int8) Job {
+	job := Job{
+		Title: title,
+		Company: company,
+		Internship: compensation.IsInternship(jobType),
+	}
+	if currency.Valid && currency.String == "INR" {
+		job.CTC = max(ctcMin.Int64, ctcMax.Int64)
+	}
+	return job
+}
+
+// Check returns the rules of the policy that do not allow a student with the placements to apply to or be offered
+// the job, except those the student was granted an exception to. None if it is allowed.
+func (p *Policy) Check(job Job, placements []Placement, exceptions []string) []Violation {
+
+	if job.Internship {
+		return nil
+	}
+
+	excepted := make(map[string]bool)
+	for _, rule := range exceptions {
+		excepted[rule] = true
+	}
+
+	offers := []Placement{}
+	for _, placement := range placements {
+		if !placement.Internship && offerStatuses[placement.Status] {
+			offers = append(offers, placement)
+		}
+	}
+	if len(offers) == 0 {
+		return nil
+	}
+
+	violations := []Violation{}
+
+	if p.BlockAfterAcceptance && !excepted[RuleAccepted] {
+		for _, offer := range offers {
+			if offer.Status == "Hired" {
+				violations = append(violations, Violation{
+					Rule: RuleAccepted,
+					Reason: fmt.Sprintf("The offer for %s at %s was accepted, no other jobs are open after accepting an offer.", offer.Title, offer.Company),
+				})
+				break
+			}
+		}
+	}
+
+	held := 0
+	for _, offer := range offers {
+		if offer.Status != "Declined" {
+			held++
+		}
+	}
+	if p.MaxOffers != 0 && held >= int(p.MaxOffers) && !excepted[RuleMaxOffers] {
+		violations = append(violations, Violation{
+			Rule: RuleMaxOffers,
+			Reason: fmt.Sprintf("%d offer(s) held already, at most %d are allowed.", held, p.MaxOffers),
+		})
+	}
+
+	if len(p.Tiers) != 0 && !excepted[RuleTier] {
+		best := offers[0]
+		for _, offer := range offers[1:] {
+			if offer.CTC > best.CTC {
+				best = offer
+			}
+		}
+		bestTier := p.tier(best.CTC)
+
+		offered := fmt.Sprintf("The best offer received is for %s at %s", best.Title, best.Company)
+		if best.CTC != 0 {
+			offered += fmt.Sprintf(" (%s, in %s)", compensation.INR(best.CTC), p.tierName(bestTier))
+		}
+
+		switch {
+		case bestTier == len(p.Tiers) - 1:
+			violations = append(violations, Violation{
+				Rule: RuleTier,
+				Reason: offered + ", which is in the highest tier, no other jobs are open.",
+			})
+		case job.CTC == 0:
+			violations = append(violations, Violation{
+				Rule: RuleTier,
+				Reason: offered + fmt.Sprintf(", only jobs of the %s tier or above are open and the CTC of this job is not known.", p.Tiers[bestTier + 1].Name),
+			})
+		case p.tier(job.CTC) <= bestTier:
+			violations = append(violations, Violation{
+				Rule: RuleTier,
+				Reason: offered + fmt.Sprintf(", only jobs of the %s tier or above are open, this job is in %s (%s).",
+					p.Tiers[bestTier + 1].Name, p.tierName(p.tier(job.CTC)), compensation.INR(job.CTC)),
+			})
+		}
+	}
+
+	return violations
+}
+
+// CheckAcceptance returns the rules of the policy that do not allow a student with the placements to accept an offer
+// for the job, except those the student was granted an exception to. None if it is allowed.
+// Only an offer accepted before blocks accepting, the offer itself is one of the placements.
+func (p *Policy) CheckAcceptance(job Job, placements []Placement, exceptions []string) []Violation {
+
+	if job.Internship || !p.BlockAfterAcceptance {
+		return nil
+	}
+	for _, rule := range exceptions {
+		if rule == RuleAccepted {
+			return nil
+		}
+	}
+
+	for _, placement := range placements {
+		if !placement.Internship && placement.Status == "Hired" {
+			return []Violation{{
+				Rule: RuleAccepted,
+				Reason: fmt.Sprintf("The offer for %s at %s was accepted, no other offers can be accepted after it.", placement.Title, placement.Company),
+			}}
+		}
+	}
+
+	return nil
+}
+
+// tier is the index of the tier of the CTC, -1 if it is below all of them
+func (p *Policy) tier(ctc int64) int {
+	tier := -1
+	for i, t := range p.Tiers {
+		if ctc >= t.MinCTC {
+			tier = i
+		}
+	}
+	return tier
+}
+
+// tierName is "the <name> tier" of the tier, "no tier" if it is below all of them
+func (p *Policy) tierName(tier int) string {
+	if tier < 0 {
+		return "no tier"
+	}
+	return "the " + p.Tiers[tier].Name + " tier"
+}
+
+// Reasons are the reasons of the violations.
+func Reasons(violations []Violation) []string {
+	reasons := make([]string, 0, len(violations))
+	for _, v := range violations {
+		reasons = append(reasons, v.Reason)
+	}
+	return reasons
+}
@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func rules(violations []Violation) []string {
+	rules := []string{}
+	for _, v := range violations {
+		rules = append(rules, v.Rule)
+	}
+	return rules
+}
+
+func TestCheck(t *testing.T) {
+
+	tiers := []Tier{{Name: "Regular", MinCTC: 0}, {Name: "Dream", MinCTC: 1000000}, {Name: "Super Dream", MinCTC: 2000000}}
+	regular := Job{Title: "Analyst", Company: "Acme", CTC: 600000}
+	dream := Job{Title: "Engineer", Company: "Globex", CTC: 1200000}
+	superDream := Job{Title: "Researcher", Company: "Initech", CTC: 2500000}
+	intern := Job{Title: "Intern", Company: "Acme", Internship: true, CTC: 0}
+
+	tests := []struct {
+		name string
+		policy Policy
+		job Job
+		placements []Placement
+		exceptions []string
+		want []string
+	}{
+		{"no offers", Policy{MaxOffers: 1, BlockAfterAcceptance: true, Tiers: tiers}, regular, nil, nil, []string{}},
+		{"internship not restricted", Policy{MaxOffers: 1, BlockAfterAcceptance: true}, intern, []Placement{{regular, "Hired"}}, nil, []string{}},
+		{"internship offers not counted", Policy{MaxOffers: 1}, regular, []Placement{{intern, "Offered"}}, nil, []string{}},
+		{"applications not counted", Policy{MaxOffers: 1}, regular, []Placement{{dream, "Interview"}}, nil, []string{}},
+
+		{"accepted blocks", Policy{BlockAfterAcceptance: true}, dream, []Placement{{regular, "Hired"}}, nil, []string{RuleAccepted}},
+		{"accepted excepted", Policy{BlockAfterAcceptance: true}, dream, []Placement{{regular, "Hired"}}, []string{RuleAccepted}, []string{}},
+		{"pending does not block", Policy{BlockAfterAcceptance: true}, dream, []Placement{{regular, "Offered"}}, nil, []string{}},
+
+		{"max offers reached", Policy{MaxOffers: 2}, regular, []Placement{{regular, "Offered"}, {dream, "Hired"}}, nil, []string{RuleMaxOffers}},
+		{"max offers not reached", Policy{MaxOffers: 2}, regular, []Placement{{regular, "Offered"}}, nil, []string{}},
+		{"declined offers not counted", Policy{MaxOffers: 2}, regular, []Placement{{regular, "Offered"}, {dream, "Declined"}}, nil, []string{}},
+		{"max offers excepted", Policy{MaxOffers: 1}, regular, []Placement{{regular, "Offered"}}, []string{RuleMaxOffers}, []string{}},
+		{"no max offers", Policy{}, regular, []Placement{{regular, "Offered"}, {dream, "Offered"}}, nil, []string{}},
+
+		{"higher tier open", Policy{Tiers: tiers}, dream, []Placement{{regular, "Offered"}}, nil, []string{}},
+		{"same tier closed", Policy{Tiers: tiers}, regular, []Placement{{regular, "Offered"}}, nil, []string{RuleTier}},
+		{"lower tier closed", Policy{Tiers: tiers}, regular, []Placement{{dream, "Offered"}}, nil, []string{RuleTier}},
+		{"declined offer counts for tier", Policy{Tiers: tiers}, regular, []Placement{{dream, "Declined"}}, nil, []string{RuleTier}},
+		{"best offer decides tier", Policy{Tiers: tiers}, dream, []Placement{{regular, "Offered"}, {dream, "Offered"}}, nil, []string{RuleTier}},
+		{"highest tier closes all", Policy{Tiers: tiers}, superDream, []Placement{{superDream, "Offered"}}, nil, []string{RuleTier}},
+		{"unknown CTC closed", Policy{Tiers: tiers}, Job{Title: "Consultant", Company: "Hooli"}, []Placement{{regular, "Offered"}}, nil, []string{RuleTier}},
+		{"tier excepted", Policy{Tiers: tiers}, regular, []Placement{{dream, "Offered"}}, []string{RuleTier}, []string{}},
+
+		{"rules combine", Policy{MaxOffers: 1, BlockAfterAcceptance: true, Tiers: tiers}, regular, []Placement{{dream, "Hired"}}, nil, []string{RuleAccepted, RuleMaxOffers, RuleTier}},
+	}
+
+	for _, tt := range tests {
+		got := rules(tt.policy.Check(tt.job, tt.placements, tt.exceptions))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAcceptance(t *testing.T) {
+
+	regular := Job{Title: "Analyst", Company: "Acme", CTC: 600000}
+	dream := Job{Title: "Engineer", Company: "Globex", CTC: 1200000}
+	intern := Job{Title: "Intern", Company: "Acme", Internship: true}
+
+	tests := []struct {
+		name string
+		policy Policy
+		job Job
+		placements []Placement
+		exceptions []string
+		want []string
+	}{
+		{"first acceptance", Policy{BlockAfterAcceptance: true}, dream, []Placement{{dream, "Offered"}, {regular, "Offered"}}, nil, []string{}},
+		{"accepted before", Policy{BlockAfterAcceptance: true}, dream, []Placement{{dream, "Offered"}, {regular, "Hired"}}, nil, []string{RuleAccepted}},
+		{"accepted internship", Policy{BlockAfterAcceptance: true}, dream, []Placement{{dream, "Offered"}, {intern, "Hired"}}, nil, []string{}},
+		{"internship accepted after", Policy{BlockAfterAcceptance: true}, intern, []Placement{{intern, "Offered"}, {regular, "Hired"}}, nil, []string{}},
+		{"not blocking", Policy{}, dream, []Placement{{dream, "Offered"}, {regular, "Hired"}}, nil, []string{}},
+		{"excepted", Policy{BlockAfterAcceptance: true}, dream, []Placement{{dream, "Offered"}, {regular, "Hired"}}, []string{RuleAccepted}, []string{}},
+	}
+
+	for _, tt := range tests {
+		got := rules(tt.policy.CheckAcceptance(tt.job, tt.placements, tt.exceptions))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name string
+		policy Policy
+		ok bool
+	}{
+		{"empty", Policy{}, true},
+		{"negative max offers", Policy{MaxOffers: -1}, false},
+		{"tiers", Policy{Tiers: []Tier{{Name: "Dream", MinCTC: 1000000}, {Name: "Regular", MinCTC: 0}}}, true},
+		{"unnamed tier", Policy{Tiers: []Tier{{Name: " ", MinCTC: 0}}}, false},
+		{"negative CTC", Policy{Tiers: []Tier{{Name: "Regular", MinCTC: -1}}}, false},
+		{"repeated name", Policy{Tiers: []Tier{{Name: "Dream", MinCTC: 0}, {Name: "dream", MinCTC: 1}}}, false},
+		{"repeated CTC", Policy{Tiers: []Tier{{Name: "Regular", MinCTC: 0}, {Name: "Dream", MinCTC: 0}}}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.policy.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: got error %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+
+	p := Policy{Tiers: []Tier{{Name: "Dream", MinCTC: 1000000}, {Name: "Regular", MinCTC: 0}}}
+	p.Validate()
+	if p.Tiers[0].Name != "Regular" {
+		t.Errorf("tiers not sorted by CTC : %v", p.Tiers)
+	}
+}
@@ -0,0 +1,267 @@
+package questions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// question types
+const (
+	TypeText = "text"
+	TypeChoice = "choice"
+	TypeNumber = "number"
+	TypeFile = "file"
+	TypeURL = "url"
+)
+
+const (
+	MaxQuestions = 20
+	DefaultMaxLength = 1000 // runes // of a text answer
+	MaxMaxLength = 5000
+)
+
+// Question is a job-specific question asked on applying, eg.
+// {ID: "notice", Label: "Notice period (days)", Type: "number", Required: true, Min: 0, Max: 90}.
+// Answers are stored by ID, so it must not change for as long as the job takes applications.
+type Question struct {
+	ID string
+	Label string
+	Type string
+	Required bool
+	Options []string `json:",omitempty"` // choice only
+	MaxLength int `json:",omitempty"` // text only, DefaultMaxLength if 0
+	Min *float64 `json:",omitempty"` // number only
+	Max *float64 `json:",omitempty"` // number only
+}
+
+// Answer is an answer along with its question, as shown to the company.
+// The value of a file answer is the stored path of the file.
+type Answer struct {
+	QuestionID string
+	Label string
+	Type string
+	Value string
+}
+
+var types = map[string]bool{
+	TypeText: true,
+	TypeChoice: true,
+	TypeNumber: true,
+	TypeFile: true,
+	TypeURL: true,
+}
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,40}$`)
+
+// Parse decodes the questions of a job, NULL or empty is no questions.
+func Parse(raw []byte) ([]Question, error) {
+
+	qs := []Question{}
+	if len(raw) == 0 {
+		return qs, nil
+	}
+	err := json.Unmarshal(raw, &qs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job questions : %v", err)
+	}
+	if qs == nil {
+		qs = []Question{}
+	}
+
+	return qs, nil
+}
+
+// Validate checks the questions are well formed, the returned error is meant for the user.
+func Validate(qs []Question) error {
+
+	if len(qs) > MaxQuestions {
+		return fmt.Errorf("at most %d questions can be asked", MaxQuestions)
+	}
+
+	seen := make(map[string]bool)
+	for _, q := range qs {
+		if !idPattern.MatchString(q.ID) {
+			return fmt.Errorf("question ID %q must be 1-40 letters, digits, - or _", q.ID)
+		}
+		if seen[q.ID] {
+			return fmt.Errorf("question ID %s is used more than once", q.ID)
+		}
+		seen[q.ID] = true
+
+		if strings.TrimSpace(q.Label) == "" {
+			return fmt.Errorf("question %s needs a label", q.ID)
+		}
+		if !types[q.Type] {
+			return fmt.Errorf("unknown type %q of question %s, expected one of text, choice, number, file, url", q.Type, q.ID)
+		}
+
+		if q.Type == TypeChoice {
+			if len(q.Options) < 2 {
+				return fmt.Errorf("choice question %s needs at least 2 options", q.ID)
+			}
+			options := make(map[string]bool)
+			for _, o := range q.Options {
+				key := strings.ToLower(strings.TrimSpace(o))
+				if key == "" || options[key] {
+					return fmt.Errorf("options of question %s must be non-empty and distinct", q.ID)
+				}
+				options[key] = true
+			}
+		} else if len(q.Options) != 0 {
+			return fmt.Errorf("only choice questions have options, %s is a %s question", q.ID, q.Type)
+		}
+
+		if q.MaxLength < 0 || q.MaxLength > MaxMaxLength {
+			return fmt.Errorf("maximum length of question %s must be within 0-%d", q.ID, MaxMaxLength)
+		}
+		if q.MaxLength != 0 && q.Type != TypeText {
+			return fmt.Errorf("only text questions have a maximum length, %s is a %s question", q.ID, q.Type)
+		}
+
+		if (q.Min != nil || q.Max != nil) && q.Type != TypeNumber {
+			return fmt.Errorf("only number questions have a minimum or maximum, %s is a %s question", q.ID, q.Type)
+		}
+		if q.Min != nil && q.Max != nil && *q.Min > *q.Max {
+			return fmt.Errorf("minimum of question %s is more than its maximum", q.ID)
+		}
+	}
+
+	return nil
+}
+
+// Check validates the answers of an applicant against the questions and returns them normalised, along with
+// the reasons they were not accepted, none if they were. files are the IDs of the file questions a file was uploaded for,
+// their answers are set by the caller once stored.
+func Check(qs []Question, answers map[string]string, files map[string]bool) (map[string]string, []string) {
+
+	reasons := []string{}
+	checked := make(map[string]string)
+
+	asked := make(map[string]bool)
+	for _, q := range qs {
+		asked[q.ID] = true
+	}
+	for id := range answers {
+		if !asked[id] {
+			reasons = append(reasons, fmt.Sprintf("%s is not a question of this job.", id))
+		}
+	}
+	for id := range files {
+		if !asked[id] {
+			reasons = append(reasons, fmt.Sprintf("%s is not a question of this job.", id))
+		}
+	}
+
+	for _, q := range qs {
+		if q.Type == TypeFile {
+			if answers[q.ID] != "" {
+				reasons = append(reasons, fmt.Sprintf("%s must be uploaded as a file.", q.Label))
+			} else if q.Required && !files[q.ID] {
+				reasons = append(reasons, fmt.Sprintf("%s is required.", q.Label))
+			}
+			continue
+		}
+		if files[q.ID] {
+			reasons = append(reasons, fmt.Sprintf("%s cannot be answered with a file.", q.Label))
+			continue
+		}
+
+		value := strings.TrimSpace(answers[q.ID])
+		if value == "" {
+			if q.Required {
+				reasons = append(reasons, fmt.Sprintf("%s is required.", q.Label))
+			}
+			continue
+		}
+
+		switch q.Type {
+		case TypeText:
+			limit := q.MaxLength
+			if limit == 0 {
+				limit = DefaultMaxLength
+			}
+			if utf8.RuneCountInString(value) > limit {
+				reasons = append(reasons, fmt.Sprintf("%s must be at most %d characters.", q.Label, limit))
+				continue
+			}
+
+		case TypeChoice:
+			option, ok := matchOption(q.Options, value)
+			if !ok {
+				reasons = append(reasons, fmt.Sprintf("%s must be one of %s.", q.Label, strings.Join(q.Options, ", ")))
+				continue
+			}
+			value = option
+
+		case TypeNumber:
+			n, err := strconv.ParseFloat(value, 64)
+			// NaN passes any minimum and maximum
+			if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+				reasons = append(reasons, fmt.Sprintf("%s must be a number.", q.Label))
+				continue
+			}
+			if q.Min != nil && n < *q.Min {
+				reasons = append(reasons, fmt.Sprintf("%s must be at least %g.", q.Label, *q.Min))
+				continue
+			}
+			if q.Max != nil && n > *q.Max {
+				reasons = append(reasons, fmt.Sprintf("%s must be at most %g.", q.Label, *q.Max))
+				continue
+			}
+
+		case TypeURL:
+			u, err := url.ParseRequestURI(value)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				reasons = append(reasons, fmt.Sprintf("%s must be an http(s) link.", q.Label))
+				continue
+			}
+		}
+
+		checked[q.ID] = value
+	}
+
+	return checked, reasons
+}
+
+// Pair lists the answers in the order of the questions. Answers to questions removed since are listed last, labelled by their ID.
+func Pair(qs []Question, answers map[string]string) []Answer {
+
+	paired := []Answer{}
+	asked := make(map[string]bool)
+	for _, q := range qs {
+		asked[q.ID] = true
+		value, exists := answers[q.ID]
+		if !exists {
+			continue
+		}
+		paired = append(paired, Answer{QuestionID: q.ID, Label: q.Label, Type: q.Type, Value: value})
+	}
+	removed := []string{}
+	for id := range answers {
+		if !asked[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		paired = append(paired, Answer{QuestionID: id, Label: id, Value: answers[id]})
+	}
+
+	return paired
+}
+
+func matchOption(options []string, value string) (string, bool) {
+	for _, o := range options {
+		if strings.EqualFold(strings.TrimSpace(o), value) {
+			return o, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,47 @@
+package questions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+
+	zero, ninety := 0.0, 90.0
+	qs := []Question{
+		{ID: "notice", Label: "Notice period", Type: TypeNumber, Required: true, Min: &zero, Max: &ninety},
+		{ID: "shift", Label: "Shift", Type: TypeChoice, Options: []string{"Day", "Night"}},
+		{ID: "why", Label: "Why us", Type: TypeText, Required: true},
+	}
+
+	tests := []struct {
+		name string
+		answers map[string]string
+		want map[string]string
+		reasons int
+	}{
+		{"valid", map[string]string{"notice": "30", "shift": "Night", "why": "Growth"}, map[string]string{"notice": "30", "shift": "Night", "why": "Growth"}, 0},
+		{"optional left out", map[string]string{"notice": "0", "why": "Growth"}, map[string]string{"notice": "0", "why": "Growth"}, 0},
+		{"option normalised", map[string]string{"notice": "90", "shift": " night ", "why": "Growth"}, map[string]string{"notice": "90", "shift": "Night", "why": "Growth"}, 0},
+		{"unknown option", map[string]string{"notice": "30", "shift": "Evening", "why": "Growth"}, map[string]string{"notice": "30", "why": "Growth"}, 1},
+		{"required missing", map[string]string{"shift": "Day"}, map[string]string{"shift": "Day"}, 2},
+		{"required blank", map[string]string{"notice": " ", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"not a number", map[string]string{"notice": "thirty", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"below minimum", map[string]string{"notice": "-1", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"above maximum", map[string]string{"notice": "91", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"NaN", map[string]string{"notice": "NaN", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"Inf", map[string]string{"notice": "Inf", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"+Inf", map[string]string{"notice": "+Inf", "why": "Growth"}, map[string]string{"why": "Growth"}, 1},
+		{"not asked", map[string]string{"notice": "30", "why": "Growth", "salary": "10"}, map[string]string{"notice": "30", "why": "Growth"}, 1},
+	}
+
+	for _, tt := range tests {
+		got, reasons := Check(qs, tt.answers, nil)
+		if len(reasons) != tt.reasons {
+			t.Errorf("%s: got reasons %q, want %d", tt.name, reasons, tt.reasons)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package recommend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/appstatus"
+	"go.mod/internal/config"
+	"go.mod/internal/dto"
+	"go.mod/internal/eligibility"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// score weights, they add up to 100
+const (
+	skillsWeight = 60
+	departmentWeight = 20
+	historyWeight = 20
+)
+
+// Student is what jobs are scored against, skills normalised.
+type Student struct {
+	Skills []string
+	Department string
+	History []Applied
+}
+
+// Applied is a job the student applied to before.
+type Applied struct {
+	Skills []string
+	Type string
+	Position string
+	Rejected bool
+}
+
+// Job is an open job the student is eligible for, skills normalised.
+type Job struct {
+	Skills []string
+	Type string
+	Position string
+	Departments []string // the departments it is restricted to, none if open to all
+}
+
+// Score rates how well the job fits the student out of 100, along with the reasons :
+// skill overlap (60), department fit (20) and similarity to the jobs they applied to before (20).
+func Score(s *Student, j *Job) (int, []string) {
+
+	reasons := []string{}
+	score := 0.0
+
+	studentSkills := make(map[string]bool)
+	for _, skill := range s.Skills {
+		studentSkills[skill] = true
+	}
+
+	if len(j.Skills) != 0 {
+		matched, missing := []string{}, []string{}
+		for _, skill := range j.Skills {
+			if studentSkills[skill] {
+				matched = append(matched, skill)
+			} else {
+				missing = append(missing, skill)
+			}
+		}
+		score += skillsWeight * float64(len(matched)) / float64(len(j.Skills))
+
+		if len(matched) != 0 {
+			reasons = append(reasons, fmt.Sprintf("Matches %d of %d required skills : %s.", len(matched), len(j.Skills), strings.Join(matched, ", ")))
+		}
+		if len(matched) != 0 && len(missing) != 0 {
+			reasons = append(reasons, fmt.Sprintf("Skills to brush up on : %s.", strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(j.Departments) == 0 {
+		score += departmentWeight / 4
+	} else {
+		for _, d := range j.Departments {
+			if strings.EqualFold(strings.TrimSpace(d), strings.TrimSpace(s.Department)) {
+				score += departmentWeight
+				reasons = append(reasons, fmt.Sprintf("Targets your department, %s.", s.Department))
+				break
+			}
+		}
+	}
+
+	// the most similar job applied to, rejected ones count half
+	best, bestShared, sameKind := 0.0, []string{}, false
+	for _, a := range s.History {
+		shared := intersect(j.Skills, a.Skills)
+		similarity := 0.0
+		if union := len(j.Skills) + len(a.Skills) - len(shared); union != 0 {
+			similarity = float64(len(shared)) / float64(union)
+		}
+		kind := strings.EqualFold(a.Type, j.Type) && strings.EqualFold(a.Position, j.Position)
+		if kind {
+			similarity = min(similarity + 0.25, 1)
+		}
+		if a.Rejected {
+			similarity /= 2
+		}
+		if similarity > best {
+			best, bestShared, sameKind = similarity, shared, kind
+		}
+	}
+	if best > 0 {
+		score += historyWeight * best
+		if len(bestShared) != 0 {
+			reasons = append(reasons, fmt.Sprintf("Similar to jobs you applied to, sharing %s.", strings.Join(bestShared, ", ")))
+		} else if sameKind {
+			reasons = append(reasons, fmt.Sprintf("Same kind of role as jobs you applied to, %s %s.", j.Type, j.Position))
+		}
+	}
+
+	return int(score + 0.5), reasons
+}
+
+// ForStudent returns the open jobs the student is eligible for and has not applied to, best fitting first.
+// Only jobs posted after postedAfter are considered unless it is zero, jobs scoring below RecommendationMinScore are left out.
+func ForStudent(ctx context.Context, queries *sqlc.Queries, userID int64, postedAfter time.Time, limit int) ([]dto.Recommendation, error) {
+
+	st, err := queries.RecommendationProfile(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student profile : %v", err)
+	}
+	profile := eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras)
+
+	history, err := queries.ApplicationHistory(ctx, st.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application history : %v", err)
+	}
+	student := &Student{
+		Skills: ParseSkills(st.Skills.String),
+		Department: st.Department,
+	}
+	for _, h := range history {
+		student.History = append(student.History, Applied{
+			Skills: NormalizeAll(h.Skills),
+			Type: h.Type,
+			Position: h.Position,
+			Rejected: h.Status == appstatus.Rejected,
+		})
+	}
+
+	jobs, err := queries.RecommendableJobs(ctx, sqlc.RecommendableJobsParams{
+		StudentID: st.StudentID,
+		PostedAfter: pgtype.Timestamptz{Time: postedAfter, Valid: !postedAfter.IsZero()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open jobs : %v", err)
+	}
+
+	recommendations := []dto.Recommendation{}
+	for _, j := range jobs {
+		if !j.Overridden {
+			rules, err := eligibility.FromColumns(j.MinCgpa, j.Courses, j.Departments, j.YearsOfStudy, j.MaxBacklogs, j.Custom)
+			if err != nil {
+				return nil, err
+			}
+			if len(rules.Check(profile)) != 0 {
+				continue
+			}
+		}
+
+		score, reasons := Score(student, &Job{
+			Skills: NormalizeAll(j.Skills),
+			Type: j.Type,
+			Position: j.Position,
+			Departments: j.Departments,
+		})
+		if score < config.RecommendationMinScore {
+			continue
+		}
+
+		r := dto.Recommendation{
+			JobID: j.JobID,
+			Title: j.Title,
+			CompanyName: j.CompanyName,
+			Location: j.Location,
+			Type: j.Type,
+			Salary: j.Salary,
+			Score: score,
+			Reasons: reasons,
+		}
+		if j.Deadline.Valid {
+			r.Deadline = &j.Deadline.Time
+		}
+		recommendations = append(recommendations, r)
+	}
+
+	// best fit first, the closest deadline first among equals
+	sort.SliceStable(recommendations, func(a, b int) bool {
+		ra, rb := recommendations[a], recommendations[b]
+		if ra.Score != rb.Score {
+			return ra.Score > rb.Score
+		}
+		if (ra.Deadline == nil) != (rb.Deadline == nil) {
+			return ra.Deadline != nil
+		}
+		if ra.Deadline != nil && !ra.Deadline.Equal(*rb.Deadline) {
+			return ra.Deadline.Before(*rb.Deadline)
+		}
+		return ra.JobID > rb.JobID
+	})
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+func intersect(a []string, b []string) []string {
+	in := make(map[string]bool)
+	for _, s := range b {
+		in[s] = true
+	}
+	shared := []string{}
+	for _, s := range a {
+		if in[s] {
+			shared = append(shared, s)
+		}
+	}
+	return shared
+}
@@ -0,0 +1,159 @@
+package recommend
+
+import (
+	"regexp"
+	"strings"
+)
+
+// aliases maps the compact form of a skill (lowercase, without spaces, dots, hyphens and underscores)
+// to its canonical name, so "ReactJS", "react.js" and "React" are all "react".
+// Skills not listed are kept as written, lowercased.
+var aliases = map[string]string{
+	"go": "go",
+	"golang": "go",
+	"js": "javascript",
+	"javascript": "javascript",
+	"es6": "javascript",
+	"ecmascript": "javascript",
+	"ts": "typescript",
+	"typescript": "typescript",
+	"react": "react",
+	"reactjs": "react",
+	"node": "node.js",
+	"nodejs": "node.js",
+	"express": "express",
+	"expressjs": "express",
+	"vue": "vue",
+	"vuejs": "vue",
+	"angular": "angular",
+	"angularjs": "angular",
+	"nextjs": "next.js",
+	"html": "html",
+	"html5": "html",
+	"css": "css",
+	"css3": "css",
+	"py": "python",
+	"python": "python",
+	"python3": "python",
+	"django": "django",
+	"flask": "flask",
+	"fastapi": "fastapi",
+	"java": "java",
+	"spring": "spring",
+	"springboot": "spring",
+	"c++": "c++",
+	"cpp": "c++",
+	"cplusplus": "c++",
+	"c#": "c#",
+	"csharp": "c#",
+	"net": ".net",
+	"dotnet": ".net",
+	"aspnet": ".net",
+	"sql": "sql",
+	"postgres": "postgresql",
+	"postgresql": "postgresql",
+	"psql": "postgresql",
+	"mysql": "mysql",
+	"mongo": "mongodb",
+	"mongodb": "mongodb",
+	"redis": "redis",
+	"graphql": "graphql",
+	"rest": "rest api",
+	"restapi": "rest api",
+	"restapis": "rest api",
+	"restful": "rest api",
+	"docker": "docker",
+	"k8s": "kubernetes",
+	"kubernetes": "kubernetes",
+	"cicd": "ci/cd",
+	"ci/cd": "ci/cd",
+	"git": "git",
+	"github": "git",
+	"linux": "linux",
+	"unix": "linux",
+	"aws": "aws",
+	"amazonwebservices": "aws",
+	"gcp": "gcp",
+	"googlecloud": "gcp",
+	"googlecloudplatform": "gcp",
+	"azure": "azure",
+	"microsoftazure": "azure",
+	"ml": "machine learning",
+	"machinelearning": "machine learning",
+	"dl": "deep learning",
+	"deeplearning": "deep learning",
+	"ai": "artificial intelligence",
+	"artificialintelligence": "artificial intelligence",
+	"nlp": "natural language processing",
+	"naturallanguageprocessing": "natural language processing",
+	"computervision": "computer vision",
+	"tf": "tensorflow",
+	"tensorflow": "tensorflow",
+	"torch": "pytorch",
+	"pytorch": "pytorch",
+	"dsa": "data structures and algorithms",
+	"datastructures": "data structures and algorithms",
+	"algorithms": "data structures and algorithms",
+	"datastructuresandalgorithms": "data structures and algorithms",
+	"oop": "object oriented programming",
+	"oops": "object oriented programming",
+	"objectorientedprogramming": "object oriented programming",
+	"excel": "excel",
+	"msexcel": "excel",
+	"powerbi": "power bi",
+	"tableau": "tableau",
+	"matlab": "matlab",
+	"autocad": "autocad",
+	"solidworks": "solidworks",
+	"kotlin": "kotlin",
+	"swift": "swift",
+	"flutter": "flutter",
+	"dart": "dart",
+	"rust": "rust",
+	"php": "php",
+	"laravel": "laravel",
+}
+
+var spaces = regexp.MustCompile(`\s+`)
+
+// Normalize returns the canonical name of a skill, empty for a blank one.
+func Normalize(skill string) string {
+
+	s := strings.ToLower(strings.TrimSpace(skill))
+	s = strings.Trim(s, ".:-* ")
+	s = spaces.ReplaceAllString(s, " ")
+	if s == "" {
+		return ""
+	}
+
+	compact := strings.NewReplacer(" ", "", ".", "", "-", "", "_", "").Replace(s)
+	if canonical, exists := aliases[compact]; exists {
+		return canonical
+	}
+
+	return s
+}
+
+// NormalizeAll normalises the skills, dropping blanks and duplicates and keeping the order.
+func NormalizeAll(skills []string) []string {
+
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, skill := range skills {
+		s := Normalize(skill)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		normalized = append(normalized, s)
+	}
+
+	return normalized
+}
+
+// ParseSkills splits the free-text skills of a student, eg. "Golang, React.js; SQL", and normalises them.
+func ParseSkills(text string) []string {
+	return NormalizeAll(strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == '|' || r == '\n'
+	}))
+}
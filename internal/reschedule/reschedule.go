@@ -0,0 +1,90 @@
+package reschedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mod/internal/config"
+)
+
+// statuses of an interview being rescheduled, values of the interview_status enum. The interview keeps its time
+// while Requested, and is Scheduled again once a proposal is accepted or rejected.
+const (
+	Scheduled = "Scheduled"
+	Requested = "Reschedule Requested"
+)
+
+// statuses of a proposal, values of interview_reschedule_proposals.status
+const (
+	Pending = "Pending"
+	Accepted = "Accepted"
+	Rejected = "Rejected"
+	Countered = "Countered" // answered with a proposal of the other side
+	Cancelled = "Cancelled" // the interview was completed or cancelled before a response
+)
+
+// responses to a pending proposal
+const (
+	Accept = "accept"
+	Reject = "reject"
+	Counter = "counter"
+)
+
+// Status is the status a proposal is left in by the response, "" for an unknown response.
+func Status(response string) string {
+	switch response {
+	case Accept:
+		return Accepted
+	case Reject:
+		return Rejected
+	case Counter:
+		return Countered
+	}
+	return ""
+}
+
+// Proposal are the times proposed to reschedule an interview to, with the reason.
+type Proposal struct {
+	Times []time.Time
+	Reason string
+}
+
+// Validate checks a proposal made at now, the returned error is meant for the user.
+// The times are sorted with the repeated ones removed.
+func (p *Proposal) Validate(now time.Time) error {
+
+	p.Reason = strings.TrimSpace(p.Reason)
+	if p.Reason == "" {
+		return fmt.Errorf("a reason for the reschedule is required")
+	}
+	if len([]rune(p.Reason)) > config.RescheduleReasonMaxLength {
+		return fmt.Errorf("the reason is longer than %d characters", config.RescheduleReasonMaxLength)
+	}
+
+	times := make([]time.Time, 0, len(p.Times))
+	for _, t := range p.Times {
+		if !t.IsZero() {
+			times = append(times, t)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	p.Times = times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i - 1]) {
+			p.Times = append(p.Times, t)
+		}
+	}
+
+	switch {
+	case len(p.Times) == 0:
+		return fmt.Errorf("at least one time is to be proposed")
+	case len(p.Times) > config.RescheduleMaxTimes:
+		return fmt.Errorf("at most %d times can be proposed", config.RescheduleMaxTimes)
+	case !p.Times[0].After(now):
+		return fmt.Errorf("the proposed time %s is in the past", p.Times[0].Format("03:04 PM 02-01-2006"))
+	}
+
+	return nil
+}
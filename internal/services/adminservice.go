@@ -1,14 +1,27 @@
 package services
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"go.mod/internal/apicalls"
+	"go.mod/internal/audit"
+	"go.mod/internal/config"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/eligibility"
 	"go.mod/internal/notify"
+	"go.mod/internal/policy"
+	"go.mod/internal/sheet"
 	sqlc "go.mod/internal/sqlc/generate"
 	"go.mod/internal/utils"
 )
@@ -111,3 +124,602 @@ func (a *AdminService) GenerateTestResult(ctx *gin.Context, testid string) (erro
 
 	return nil
 }
+
+// NewAnnouncement validates and stores an announcement, which the announcements poller delivers
+// once its scheduled time has passed (right away if not scheduled).
+func (a *AdminService) NewAnnouncement(ctx *gin.Context, userID int64, data *dto.NewAnnouncement) (int64, *errs.Error) {
+
+	if data.Title == "" || data.Message == "" {
+		return 0, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Announcement title and message are required.",
+			ToRespondWith: true,
+		}
+	}
+	if data.TargetRole < 0 || data.TargetRole > 3 {
+		return 0, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Target role must be 1 (students), 2 (companies), 3 (admins) or 0 for everyone.",
+			ToRespondWith: true,
+		}
+	}
+
+	// the student filters narrow the audience down to students
+	if data.Department != "" || data.Course != "" || data.YearOfStudy != "" || data.JobID != 0 {
+		if data.TargetRole != 0 && data.TargetRole != 1 {
+			return 0, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Department, course, year of study and job filters can only target students.",
+				ToRespondWith: true,
+			}
+		}
+		data.TargetRole = 1
+	}
+
+	scheduledAt := time.Now()
+	if !data.ScheduledAt.IsZero() {
+		if data.ScheduledAt.Before(scheduledAt) {
+			return 0, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Scheduled time of the announcement is in the past.",
+				ToRespondWith: true,
+			}
+		}
+		scheduledAt = data.ScheduledAt
+	}
+
+	announcementID, err := a.queries.InsertAnnouncement(ctx, sqlc.InsertAnnouncementParams{
+		AuthorID: userID,
+		Title: data.Title,
+		Message: data.Message,
+		TargetRole: pgtype.Int8{Int64: data.TargetRole, Valid: data.TargetRole != 0},
+		Department: pgtype.Text{String: data.Department, Valid: data.Department != ""},
+		Course: pgtype.Text{String: data.Course, Valid: data.Course != ""},
+		YearOfStudy: pgtype.Text{String: data.YearOfStudy, Valid: data.YearOfStudy != ""},
+		JobID: pgtype.Int8{Int64: data.JobID, Valid: data.JobID != 0},
+		SendEmail: !data.SkipEmail,
+		ScheduledAt: pgtype.Timestamptz{Time: scheduledAt, Valid: true},
+	})
+	if err != nil {
+		var pgerr *pgconn.PgError
+		if errors.As(err, &pgerr) {
+			if pgerr.Code == errs.ForeignKeyViolation {
+				return 0, &errs.Error{
+					Type: errs.NotFound,
+					Message: "No such job to target the applicants of.",
+					ToRespondWith: true,
+				}
+			}
+		}
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to insert announcement : " + err.Error(),
+		}
+	}
+
+	return announcementID, nil
+}
+
+// ListAnnouncements returns a page of all the announcements, latest first, with their recipient and read counts.
+func (a *AdminService) ListAnnouncements(ctx *gin.Context, page string) (*[]sqlc.ListAnnouncementsRow, *errs.Error) {
+
+	pageNo, err := strconv.ParseInt(page, 10, 64)
+	if err != nil || pageNo < 1 {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Page number must be an integer greater than 0.",
+			ToRespondWith: true,
+		}
+	}
+
+	limit := int64(config.NoticeBoardPageLimit)
+
+	announcements, err := a.queries.ListAnnouncements(ctx, sqlc.ListAnnouncementsParams{
+		Limit: int32(limit),
+		Offset: int32((pageNo - 1) * limit),
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get announcements : " + err.Error(),
+		}
+	}
+
+	return &announcements, nil
+}
+
+// JobEligibilityStudents returns the eligibility of every student for a job, with the reasons and overrides.
+func (a *AdminService) JobEligibilityStudents(ctx *gin.Context, jobid string) (*[]dto.StudentEligibility, *errs.Error) {
+
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	rules := &eligibility.Rules{}
+	row, err := a.queries.GetJobEligibility(ctx, jobID)
+	if err == nil {
+		rules, err = eligibility.FromColumns(row.MinCgpa, row.Courses, row.Departments, row.YearsOfStudy, row.MaxBacklogs, row.Custom)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+	} else if err.Error() != errs.NoRowsMatch {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get eligibility rules : " + err.Error(),
+		}
+	}
+
+	students, err := a.queries.StudentsEligibilityProfiles(ctx, jobID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get students : " + err.Error(),
+		}
+	}
+
+	data := make([]dto.StudentEligibility, 0, len(students))
+	for _, st := range students {
+		reasons := rules.Check(eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras))
+		data = append(data, dto.StudentEligibility{
+			StudentID: st.StudentID,
+			StudentName: st.StudentName,
+			RollNumber: st.RollNumber,
+			Eligible: len(reasons) == 0 || st.Overridden,
+			Reasons: reasons,
+			Overridden: st.Overridden,
+		})
+	}
+
+	return &data, nil
+}
+
+// EligibilityOverride grants (or revokes) a student eligibility for a job regardless of its rules, recorded in the audit log.
+func (a *AdminService) EligibilityOverride(ctx *gin.Context, userID int64, data *dto.EligibilityOverride) *errs.Error {
+
+	if data.JobID == 0 || data.StudentID == 0 {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Job ID and student ID are required.",
+			ToRespondWith: true,
+		}
+	}
+
+	action := audit.EligibilityOverrideGranted
+	if data.Revoke {
+		action = audit.EligibilityOverrideRevoked
+
+		count, err := a.queries.DeleteEligibilityOverride(ctx, sqlc.DeleteEligibilityOverrideParams{
+			JobID: data.JobID,
+			StudentID: data.StudentID,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to revoke eligibility override : " + err.Error(),
+			}
+		}
+		if count == 0 {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "No such eligibility override to revoke.",
+				ToRespondWith: true,
+			}
+		}
+	} else {
+		if data.Reason == "" {
+			return &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "A reason is required to override eligibility.",
+				ToRespondWith: true,
+			}
+		}
+
+		err := a.queries.UpsertEligibilityOverride(ctx, sqlc.UpsertEligibilityOverrideParams{
+			JobID: data.JobID,
+			StudentID: data.StudentID,
+			GrantedBy: userID,
+			Reason: data.Reason,
+		})
+		if err != nil {
+			var pgerr *pgconn.PgError
+			if errors.As(err, &pgerr) {
+				if pgerr.Code == errs.ForeignKeyViolation {
+					return &errs.Error{
+						Type: errs.NotFound,
+						Message: "No such job or student.",
+						ToRespondWith: true,
+					}
+				}
+			}
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to grant eligibility override : " + err.Error(),
+			}
+		}
+	}
+
+	err := audit.Record(ctx, a.queries, userID, action, audit.EntityStudent, data.StudentID, data)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to record eligibility override in audit log : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// JobReviewQueue returns the jobs awaiting review, the longest waiting first.
+func (a *AdminService) JobReviewQueue(ctx *gin.Context) (*[]sqlc.JobReviewQueueRow, *errs.Error) {
+
+	queue, err := a.queries.JobReviewQueue(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job review queue : " + err.Error(),
+		}
+	}
+
+	return &queue, nil
+}
+
+// ReviewJob approves or rejects a submitted job. The review is of the revision the admin saw,
+// so it fails if the company edited the job since. A rejected job can be edited and resubmitted by the company.
+func (a *AdminService) ReviewJob(ctx *gin.Context, userID int64, data *dto.JobReviewDecision) *errs.Error {
+
+	if data.JobID == 0 || data.Revision == 0 {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Job ID and revision are required.",
+			ToRespondWith: true,
+		}
+	}
+
+	decision := jobApproved
+	if !data.Approve {
+		decision = jobRejected
+		if data.Comment == "" {
+			return &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "A comment is required to reject a job.",
+				ToRespondWith: true,
+			}
+		}
+	}
+
+	// the decision and its record are one
+	var job sqlc.ReviewJobRow
+	err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		var err error
+		job, err = queries.ReviewJob(ctx, sqlc.ReviewJobParams{
+			Decision: decision,
+			JobID: data.JobID,
+			Revision: data.Revision,
+		})
+		if err != nil {
+			return err
+		}
+		err = queries.InsertJobReview(ctx, sqlc.InsertJobReviewParams{
+			JobID: data.JobID,
+			Revision: data.Revision,
+			ReviewerID: userID,
+			Decision: decision,
+			Comment: data.Comment,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record job review : %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.InvalidState,
+				Message: fmt.Sprintf("The job is not awaiting review or was edited since revision %d. Reload the queue and review again.", data.Revision),
+				ToRespondWith: true,
+			}
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to review job : " + err.Error(),
+		}
+	}
+
+	title := "Job approved"
+	description := fmt.Sprintf("%s was approved and is now visible to students.", job.Title)
+	if decision == jobRejected {
+		title = "Job rejected"
+		description = fmt.Sprintf("%s was rejected : %s. Edit and resubmit it for review.", job.Title, data.Comment)
+	}
+
+	// the decision is saved, failing notifications are only logged
+	errf := a.Notify.NewNotification(ctx, job.CompanyUserID, &dto.NotificationData{
+		Title: title,
+		Description: description,
+		RefType: notify.RefJob,
+		RefID: data.JobID,
+	})
+	if errf != nil {
+		fmt.Printf("Failed to notify the company of the review of job %d : %s\n", data.JobID, errf.Message)
+	}
+
+	// the other admins, the queue changed for them
+	errf = notifyAdmins(ctx, a.queries, a.Notify, userID, &dto.NotificationData{
+		Title: title,
+		Description: fmt.Sprintf("%s was %s.", job.Title, decision),
+		RefType: notify.RefJob,
+		RefID: data.JobID,
+	})
+	if errf != nil {
+		fmt.Printf("Failed to notify admins of the review of job %d : %s\n", data.JobID, errf.Message)
+	}
+
+	return nil
+}
+
+// notifyAdmins sends the notification to every admin except the given user
+func notifyAdmins(ctx context.Context, queries *sqlc.Queries, n *notify.Notify, except int64, data *dto.NotificationData) *errs.Error {
+
+	admins, err := queries.AdminUserIDs(ctx)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get admins to notify : " + err.Error(),
+		}
+	}
+
+	for _, adminID := range admins {
+		if adminID == except {
+			continue
+		}
+		errf := n.NewNotification(ctx, adminID, data)
+		if errf != nil {
+			return errf
+		}
+	}
+
+	return nil
+}
+
+// UpdateStudentBacklogs sets the number of backlogs of a student, checked against the max backlogs of jobs.
+func (a *AdminService) UpdateStudentBacklogs(ctx *gin.Context, userID int64, data *dto.StudentBacklogs) *errs.Error {
+
+	if data.Backlogs < 0 {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Backlogs cannot be negative.",
+			ToRespondWith: true,
+		}
+	}
+
+	count, err := a.queries.UpdateStudentBacklogs(ctx, sqlc.UpdateStudentBacklogsParams{
+		Backlogs: data.Backlogs,
+		StudentID: data.StudentID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to update backlogs : " + err.Error(),
+		}
+	}
+	if count == 0 {
+		return &errs.Error{
+			Type: errs.NotFound,
+			Message: "No such student.",
+			ToRespondWith: true,
+		}
+	}
+
+	err = audit.Record(ctx, a.queries, userID, audit.StudentBacklogsUpdated, audit.EntityStudent, data.StudentID, data)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to record backlogs update in audit log : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// PlacementPolicy returns the placement policy in effect.
+func (a *AdminService) PlacementPolicy(ctx *gin.Context) (*policy.Policy, *errs.Error) {
+	return placementPolicy(ctx, a.queries)
+}
+
+// UpdatePlacementPolicy replaces the placement policy, it applies to applications and offers from then on.
+func (a *AdminService) UpdatePlacementPolicy(ctx *gin.Context, userID int64, data *policy.Policy) *errs.Error {
+
+	err := data.Validate()
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid placement policy, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
+
+	tiersJson, err := json.Marshal(data.Tiers)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to marshal placement policy tiers : " + err.Error(),
+		}
+	}
+
+	err = a.queries.UpdatePlacementPolicy(ctx, sqlc.UpdatePlacementPolicyParams{
+		MaxOffers: data.MaxOffers,
+		BlockAfterAcceptance: data.BlockAfterAcceptance,
+		Tiers: tiersJson,
+		UpdatedBy: pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to update placement policy : " + err.Error(),
+		}
+	}
+
+	err = audit.Record(ctx, a.queries, userID, audit.PlacementPolicyUpdated, audit.EntityPlacementPolicy, 1, data)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to record placement policy update in audit log : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// PolicyException grants or revokes an exception to a rule of the placement policy for a student,
+// for one job or, when no job is given, all of them.
+func (a *AdminService) PolicyException(ctx *gin.Context, userID int64, data *dto.PolicyException) *errs.Error {
+
+	if data.StudentID == 0 || data.Rule == "" {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Student ID and rule are required.",
+			ToRespondWith: true,
+		}
+	}
+	if !policy.ValidRule(data.Rule) {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid rule, must be one of max_offers, tier or accepted.",
+			ToRespondWith: true,
+		}
+	}
+	jobID := pgtype.Int8{Int64: data.JobID, Valid: data.JobID != 0}
+
+	action := audit.PolicyExceptionGranted
+	if data.Revoke {
+		action = audit.PolicyExceptionRevoked
+
+		count, err := a.queries.DeletePolicyException(ctx, sqlc.DeletePolicyExceptionParams{
+			StudentID: data.StudentID,
+			JobID: jobID,
+			Rule: data.Rule,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to revoke placement policy exception : " + err.Error(),
+			}
+		}
+		if count == 0 {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "No such placement policy exception to revoke.",
+				ToRespondWith: true,
+			}
+		}
+	} else {
+		if data.Reason == "" {
+			return &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "A reason is required to grant an exception to the placement policy.",
+				ToRespondWith: true,
+			}
+		}
+
+		err := a.queries.UpsertPolicyException(ctx, sqlc.UpsertPolicyExceptionParams{
+			StudentID: data.StudentID,
+			JobID: jobID,
+			Rule: data.Rule,
+			Reason: data.Reason,
+			GrantedBy: userID,
+		})
+		if err != nil {
+			var pgerr *pgconn.PgError
+			if errors.As(err, &pgerr) {
+				if pgerr.Code == errs.ForeignKeyViolation {
+					return &errs.Error{
+						Type: errs.NotFound,
+						Message: "No such job or student.",
+						ToRespondWith: true,
+					}
+				}
+			}
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to grant placement policy exception : " + err.Error(),
+			}
+		}
+	}
+
+	err := audit.Record(ctx, a.queries, userID, action, audit.EntityStudent, data.StudentID, data)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to record placement policy exception in audit log : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// PolicyExceptions lists the placement policy exceptions of a student, or all of them when no student is given,
+// the latest first.
+func (a *AdminService) PolicyExceptions(ctx *gin.Context, studentid string) (*[]sqlc.ListPolicyExceptionsRow, *errs.Error) {
+
+	var studentID int64
+	if studentid != "" {
+		var err error
+		studentID, err = strconv.ParseInt(studentid, 10, 64)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid student ID, failed to parse to int.",
+				ToRespondWith: true,
+			}
+		}
+	}
+
+	exceptions, err := a.queries.ListPolicyExceptions(ctx, studentID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get placement policy exceptions : " + err.Error(),
+		}
+	}
+
+	return &exceptions, nil
+}
+
+// PlacementStats returns the offers made and their responses, and the placed students overall and by department
+func (a *AdminService) PlacementStats(ctx *gin.Context) (*dto.PlacementStats, *errs.Error) {
+
+	overall, err := a.queries.PlacementStats(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get placement stats : " + err.Error(),
+		}
+	}
+
+	departments, err := a.queries.DepartmentPlacementStats(ctx)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get department placement stats : " + err.Error(),
+		}
+	}
+
+	return &dto.PlacementStats{
+		Overall: overall,
+		Departments: departments,
+	}, nil
+}
+
+// ExportApplicants exports the applicants to the jobs of all companies, or the given job, as a CSV, XLSX or a ZIP of
+// their resumes, see exportApplicants.
+func (a *AdminService) ExportApplicants(ctx *gin.Context, data *dto.ApplicantExport) (*sheet.File, *errs.Error) {
+	return exportApplicants(ctx, a.queries, 0, data)
+}
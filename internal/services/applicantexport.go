@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/appstatus"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/questions"
+	"go.mod/internal/sheet"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// column groups of an applicant export, all of them unless some are selected
+var applicantColumnGroups = []string{"profile", "cgpa", "status", "tests", "interview", "answers"}
+
+// unsafeFileName are the characters replaced in the names of the files of a ZIP
+var unsafeFileName = regexp.MustCompile(`[^A-Za-z0-9._ -]+`)
+
+// exportApplicants exports the applicants to the jobs of the company of the user, or of all companies if the user ID
+// is 0, as a CSV or XLSX of the selected column groups, or as a ZIP of their resumes named by roll number.
+// The resumes are in a folder per job when the applicants are of more than one. Companies are not given the gender of
+// the applicants, only the placement cell is.
+func exportApplicants(ctx context.Context, queries *sqlc.Queries, companyUserID int64, data *dto.ApplicantExport) (*sheet.File, *errs.Error) {
+
+	format := strings.ToLower(data.Format)
+	if format == "" {
+		format = sheet.FormatCSV
+	}
+	if _, exists := sheet.ContentTypes[format]; !exists {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid export format, must be csv, xlsx or zip.",
+			ToRespondWith: true,
+		}
+	}
+
+	groups := make(map[string]bool)
+	for _, group := range data.Columns {
+		group = strings.ToLower(strings.TrimSpace(group))
+		valid := false
+		for _, g := range applicantColumnGroups {
+			valid = valid || g == group
+		}
+		if !valid {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: fmt.Sprintf("Invalid column %q, must be one of %s.", group, strings.Join(applicantColumnGroups, ", ")),
+				ToRespondWith: true,
+			}
+		}
+		groups[group] = true
+	}
+	if len(groups) == 0 {
+		for _, g := range applicantColumnGroups {
+			groups[g] = true
+		}
+	}
+
+	statuses := []string{}
+	for _, status := range data.Statuses {
+		if !appstatus.Valid(status) {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: fmt.Sprintf("Invalid application status %q.", status),
+				ToRespondWith: true,
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	applicationIDs := data.ApplicationIDs
+	if applicationIDs == nil {
+		applicationIDs = []int64{}
+	}
+
+	rows, err := queries.ExportApplicants(ctx, sqlc.ExportApplicantsParams{
+		CompanyUserID: companyUserID,
+		JobID: data.JobID,
+		Statuses: statuses,
+		ApplicationIds: applicationIDs,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get applicants : " + err.Error(),
+		}
+	}
+	if len(rows) == 0 {
+		return nil, &errs.Error{
+			Type: errs.NotFound,
+			Message: "No applicants found for the given job and filters.",
+			ToRespondWith: true,
+		}
+	}
+
+	jobs := make(map[int64]bool)
+	for _, row := range rows {
+		jobs[row.JobID] = true
+	}
+	multipleJobs := len(jobs) > 1
+
+	name := "applicants"
+	if data.JobID != 0 {
+		name += "-" + strconv.FormatInt(data.JobID, 10)
+	}
+	file := &sheet.File{
+		Name: name + "." + format,
+		ContentType: sheet.ContentTypes[format],
+	}
+
+	if format == sheet.FormatZIP {
+		entries := []sheet.Entry{}
+		for _, row := range rows {
+			if !row.ResumeUrl.Valid || row.ResumeUrl.String == "" {
+				continue
+			}
+			if _, err := os.Stat(row.ResumeUrl.String); err != nil {
+				continue
+			}
+			ext := filepath.Ext(row.ResumeUrl.String)
+			if ext == "" {
+				ext = ".pdf"
+			}
+			entryName := unsafeFileName.ReplaceAllString(row.RollNumber, "_") + ext
+			if multipleJobs {
+				entryName = unsafeFileName.ReplaceAllString(fmt.Sprintf("%s - %s (%d)", row.CompanyName, row.Title, row.JobID), "_") + "/" + entryName
+			}
+			entries = append(entries, sheet.Entry{Name: entryName, Path: row.ResumeUrl.String})
+		}
+		if len(entries) == 0 {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "None of the selected applicants have uploaded a resume.",
+				ToRespondWith: true,
+			}
+		}
+
+		file.Write = func(w io.Writer) error {
+			return sheet.WriteZIP(w, entries)
+		}
+		return file, nil
+	}
+
+	s, errf := applicantSheet(ctx, queries, companyUserID, data.JobID, rows, groups, multipleJobs)
+	if errf != nil {
+		return nil, errf
+	}
+	if !multipleJobs {
+		s.Name = rows[0].Title
+	}
+
+	file.Write = s.WriteCSV
+	if format == sheet.FormatXLSX {
+		file.Write = s.WriteXLSX
+	}
+
+	return file, nil
+}
+
+// applicantSheet lays out the applicants in the column groups, a column per test of their jobs and per question
+// asked, prefixed by the job when they are of more than one
+func applicantSheet(ctx context.Context, queries *sqlc.Queries, companyUserID int64, jobID int64, rows []sqlc.ExportApplicantsRow, groups map[string]bool, multipleJobs bool) (*sheet.Sheet, *errs.Error) {
+
+	s := &sheet.Sheet{
+		Name: "Applicants",
+		Columns: []sheet.Column{{Name: "Application ID", Number: true}},
+	}
+	if multipleJobs {
+		s.Columns = append(s.Columns, sheet.Column{Name: "Company"}, sheet.Column{Name: "Job ID", Number: true})
+	}
+	s.Columns = append(s.Columns, sheet.Column{Name: "Job Title"})
+
+	if groups["profile"] {
+		names := []string{"Name", "Roll Number"}
+		if companyUserID == 0 {
+			names = append(names, "Gender")
+		}
+		names = append(names, "Course", "Department", "Year Of Study", "Email", "Contact", "Skills")
+		for _, name := range names {
+			s.Columns = append(s.Columns, sheet.Column{Name: name})
+		}
+	}
+	if groups["cgpa"] {
+		s.Columns = append(s.Columns, sheet.Column{Name: "CGPA", Number: true})
+	}
+	if groups["status"] {
+		s.Columns = append(s.Columns, sheet.Column{Name: "Status"}, sheet.Column{Name: "Applied At"})
+	}
+	if groups["interview"] {
+		s.Columns = append(s.Columns, sheet.Column{Name: "Interview Status"}, sheet.Column{Name: "Interview At"})
+	}
+
+	titles := make(map[int64]string)
+	for _, row := range rows {
+		titles[row.JobID] = row.Title
+	}
+	prefix := func(jobID int64) string {
+		if multipleJobs {
+			return titles[jobID] + " : "
+		}
+		return ""
+	}
+
+	// test ID -> column, user ID -> test ID -> score
+	testColumns := make(map[int64]int)
+	testJobs := make(map[int64]int64)
+	scores := make(map[int64]map[int64]int64)
+	if groups["tests"] {
+		results, err := queries.ExportTestScores(ctx, sqlc.ExportTestScoresParams{
+			CompanyUserID: companyUserID,
+			JobID: jobID,
+		})
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to get test scores : " + err.Error(),
+			}
+		}
+		for _, result := range results {
+			if _, applied := titles[result.JobID.Int64]; !applied {
+				continue
+			}
+			if _, exists := testColumns[result.TestID]; !exists {
+				testColumns[result.TestID] = len(s.Columns)
+				testJobs[result.TestID] = result.JobID.Int64
+				s.Columns = append(s.Columns, sheet.Column{Name: prefix(result.JobID.Int64) + result.TestName + " Score", Number: true})
+			}
+			if scores[result.UserID] == nil {
+				scores[result.UserID] = make(map[int64]int64)
+			}
+			scores[result.UserID][result.TestID] = result.Score
+		}
+	}
+
+	// job ID/question ID -> column, in the order the questions are asked
+	answerColumns := make(map[string]int)
+	paired := make([][]questions.Answer, len(rows))
+	if groups["answers"] {
+		for i, row := range rows {
+			answers, errf := pairAnswers(row.Questions, row.Answers)
+			if errf != nil {
+				return nil, errf
+			}
+			paired[i] = answers
+			for _, a := range answers {
+				key := fmt.Sprintf("%d/%s", row.JobID, a.QuestionID)
+				if _, exists := answerColumns[key]; !exists {
+					answerColumns[key] = len(s.Columns)
+					s.Columns = append(s.Columns, sheet.Column{Name: prefix(row.JobID) + a.Label, Number: a.Type == questions.TypeNumber})
+				}
+			}
+		}
+	}
+
+	s.Rows = make([][]string, 0, len(rows))
+	for i, row := range rows {
+		cells := []string{strconv.FormatInt(row.ApplicationID, 10)}
+		if multipleJobs {
+			cells = append(cells, row.CompanyName, strconv.FormatInt(row.JobID, 10))
+		}
+		cells = append(cells, row.Title)
+
+		if groups["profile"] {
+			cells = append(cells, row.StudentName, row.RollNumber)
+			if companyUserID == 0 {
+				cells = append(cells, row.Gender)
+			}
+			cells = append(cells, row.Course, row.Department, row.YearOfStudy, row.StudentEmail, row.ContactNo, row.Skills.String)
+		}
+		if groups["cgpa"] {
+			cgpa := ""
+			if row.Cgpa.Valid {
+				cgpa = strconv.FormatFloat(row.Cgpa.Float64, 'f', -1, 64)
+			}
+			cells = append(cells, cgpa)
+		}
+		if groups["status"] {
+			cells = append(cells, row.Status, exportTime(row.CreatedAt))
+		}
+		if groups["interview"] {
+			cells = append(cells, row.InterviewStatus, exportTime(row.InterviewAt))
+		}
+
+		cells = append(cells, make([]string, len(s.Columns) - len(cells))...)
+		for testID, column := range testColumns {
+			score, taken := scores[row.StudentUserID][testID]
+			if taken && testJobs[testID] == row.JobID {
+				cells[column] = strconv.FormatInt(score, 10)
+			}
+		}
+		for _, a := range paired[i] {
+			value := a.Value
+			// the stored path of an uploaded file, only its name is of use outside
+			if a.Type == questions.TypeFile {
+				value = filepath.Base(value)
+			}
+			cells[answerColumns[fmt.Sprintf("%d/%s", row.JobID, a.QuestionID)]] = value
+		}
+
+		s.Rows = append(s.Rows, cells)
+	}
+
+	return s, nil
+}
+
+// exportTime formats a time for an export in local time, empty if NULL
+func exportTime(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Local().Format("2006-01-02 15:04")
+}
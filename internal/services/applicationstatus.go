@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/appstatus"
+	"go.mod/internal/config"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// statusChange is a change of the status of an application by an actor, see appstatus
+type statusChange struct {
+	ApplicationID int64
+	To string
+	ActorID int64 // user ID, 0 for the system
+	Actor string // appstatus.Actor*
+	Reason string
+	CancelsInterview bool // the interview of the application is cancelled along with it, see appstatus.CheckCancellingInterview
+	// optional, run in the same transaction after the status changed, an error rolls the change back
+	Then func(queries *sqlc.Queries, parties *sqlc.ApplicationPartiesRow) *errs.Error
+}
+
+// errStatusChangeAborted rolls back a status change, the reason is returned separately as an *errs.Error
+var errStatusChangeAborted = errors.New("status change aborted")
+
+// changeApplicationStatus is the one place the status of an application changes. The change must be a legal transition
+// for the actor, see appstatus.Check, companies may only change the applications to their jobs and students their own.
+// The change is recorded in the status history of the application in the same transaction.
+// Returns the parties of the application, with Status the status it was changed from.
+func changeApplicationStatus(ctx context.Context, change *statusChange) (*sqlc.ApplicationPartiesRow, *errs.Error) {
+
+	parties, err := config.QueriesPool.ApplicationParties(ctx, change.ApplicationID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application : " + err.Error(),
+		}
+	}
+
+	if (change.Actor == appstatus.ActorCompany && parties.CompanyUserID != change.ActorID) ||
+		(change.Actor == appstatus.ActorStudent && parties.StudentUserID != change.ActorID) {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		}
+	}
+
+	var errf *errs.Error
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		var err error
+		errf, err = applyStatusChange(ctx, queries, change, &parties)
+		if err == nil && errf != nil {
+			return errStatusChangeAborted
+		}
+		return err
+	})
+	if errf != nil {
+		return nil, errf
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to change application status : " + err.Error(),
+		}
+	}
+
+	return &parties, nil
+}
+
+// applyStatusChange changes the status of an application within the transaction of queries, the parties are checked
+// by the caller. The status is locked until committed, so concurrent changes are checked against the status they leave.
+// Returns an *errs.Error if the change is not allowed or Then fails, the transaction is to be rolled back then,
+// and an error if the change itself failed. Sets the Status of parties to the status changed from.
+func applyStatusChange(ctx context.Context, queries *sqlc.Queries, change *statusChange, parties *sqlc.ApplicationPartiesRow) (*errs.Error, error) {
+
+	from, err := queries.LockApplicationStatus(ctx, change.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := appstatus.Check
+	if change.CancelsInterview {
+		check = appstatus.CheckCancellingInterview
+	}
+	err = check(from, change.To, change.Actor)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidState,
+			Message: "Cannot change the application status, " + err.Error() + ".",
+			ToRespondWith: true,
+		}, nil
+	}
+
+	err = queries.SetApplicationStatus(ctx, sqlc.SetApplicationStatusParams{
+		Status: change.To,
+		ApplicationID: change.ApplicationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = queries.InsertApplicationStatusHistory(ctx, sqlc.InsertApplicationStatusHistoryParams{
+		ApplicationID: change.ApplicationID,
+		FromStatus: pgtype.Text{String: from, Valid: true},
+		ToStatus: change.To,
+		ActorID: pgtype.Int8{Int64: change.ActorID, Valid: change.ActorID != 0},
+		ActorRole: change.Actor,
+		Reason: change.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parties.Status = from
+	if change.Then != nil {
+		return change.Then(queries, parties), nil
+	}
+
+	return nil, nil
+}
+
+// ExpireDueOffers expires the pending offers past their response deadline, each in the transaction that declines its
+// application by the system, see applyStatusChange. Returns the offers expired along with their application, once each.
+// Failures of single offers are logged, they are tried again the next time.
+func ExpireDueOffers(ctx context.Context) ([]sqlc.DueOffersRow, error) {
+
+	due, err := config.QueriesPool.DueOffers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := []sqlc.DueOffersRow{}
+	for _, o := range due {
+		var errf *errs.Error
+		responded := false
+		err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+			var err error
+			errf, err = applyStatusChange(ctx, queries, &statusChange{
+				ApplicationID: o.ApplicationID,
+				To: appstatus.Declined,
+				Actor: appstatus.ActorSystem,
+				Reason: "The offer expired without a response.",
+				Then: func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+					n, err := queries.ExpireOffer(ctx, o.ApplicationID)
+					if err != nil {
+						return &errs.Error{
+							Type: errs.Internal,
+							Message: "Failed to expire offer : " + err.Error(),
+						}
+					}
+					if n == 0 {
+						responded = true
+						return &errs.Error{
+							Type: errs.InvalidState,
+							Message: "The offer was responded to before it expired.",
+						}
+					}
+					return nil
+				},
+			}, &sqlc.ApplicationPartiesRow{
+				JobID: o.JobID,
+				Title: o.Title,
+				StudentUserID: o.StudentUserID,
+				CompanyUserID: o.CompanyUserID,
+			})
+			if err == nil && errf != nil {
+				return errStatusChangeAborted
+			}
+			return err
+		})
+		if responded {
+			continue
+		}
+		// the application is not Offered anymore, eg. changed by an admin, the offer alone expires
+		if errf != nil && errf.Type == errs.InvalidState {
+			_, err = config.QueriesPool.ExpireOffer(ctx, o.ApplicationID)
+			if err != nil {
+				fmt.Printf("Failed to expire the offer of application %d : %v\n", o.ApplicationID, err)
+			}
+			continue
+		}
+		if errf != nil {
+			fmt.Printf("Failed to expire the offer of application %d : %s\n", o.ApplicationID, errf.Message)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Failed to expire the offer of application %d : %v\n", o.ApplicationID, err)
+			continue
+		}
+		expired = append(expired, o)
+	}
+
+	return expired, nil
+}
+
+// applicationTimeline returns the status history of an application, oldest first, along with the statuses the user
+// can move it to next. Only the student who applied and the company of the job may see it, admins any.
+func applicationTimeline(ctx context.Context, queries *sqlc.Queries, applicationID int64, userID int64, actor string) (*dto.ApplicationTimeline, *errs.Error) {
+
+	parties, err := queries.ApplicationParties(ctx, applicationID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application : " + err.Error(),
+		}
+	}
+
+	if (actor == appstatus.ActorCompany && parties.CompanyUserID != userID) ||
+		(actor == appstatus.ActorStudent && parties.StudentUserID != userID) {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		}
+	}
+
+	history, err := queries.ApplicationTimeline(ctx, applicationID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application timeline : " + err.Error(),
+		}
+	}
+
+	timeline := &dto.ApplicationTimeline{
+		ApplicationID: applicationID,
+		JobID: parties.JobID,
+		Title: parties.Title,
+		Status: parties.Status,
+		Next: appstatus.Next(parties.Status, actor),
+		Events: make([]dto.StatusEvent, 0, len(history)),
+	}
+	for _, h := range history {
+		timeline.Events = append(timeline.Events, dto.StatusEvent{
+			From: h.FromStatus.String,
+			To: h.ToStatus,
+			By: h.ActorRole,
+			Reason: h.Reason,
+			At: h.CreatedAt.Time,
+		})
+	}
+
+	return timeline, nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -8,6 +10,8 @@ import (
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,11 +22,22 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/redis/go-redis/v9"
 	"go.mod/internal/apicalls"
+	"go.mod/internal/appstatus"
+	"go.mod/internal/audit"
+	"go.mod/internal/compensation"
 	"go.mod/internal/config"
 	errs "go.mod/internal/const"
 	"go.mod/internal/dto"
+	"go.mod/internal/eligibility"
 	gocharts "go.mod/internal/go-charts"
+	"go.mod/internal/jobfile"
+	"go.mod/internal/letter"
 	"go.mod/internal/notify"
+	"go.mod/internal/offer"
+	"go.mod/internal/pipeline"
+	"go.mod/internal/questions"
+	"go.mod/internal/reschedule"
+	"go.mod/internal/sheet"
 	sqlc "go.mod/internal/sqlc/generate"
 	"go.mod/internal/utils"
 )
@@ -57,84 +72,19 @@ func (c *CompanyService) DashboardData(ctx *gin.Context, userID int64) (*sqlc.Co
 	return &data, nil
 }
 
-func (c *CompanyService) NewJobPost(ctx *gin.Context, jobdata *dto.NewJobData, userID int64) (*errs.Error) {	
-	// split skills into []text
-	skills := strings.Split(jobdata.SkillsRequired, ",")
-	for i, skill := range skills {
-		// trim off spaces
-		skills[i] = strings.TrimSpace(skill)
-	}
-
-	// create map of extra params // flexiblity
-	extras := make(map[string]interface{})
-	for key, values := range ctx.Request.Form {
-		if _, exists := map[string]bool{
-			"JobId": true,
-			"JobLocation": true,
-			"JobTitle": true,
-			"JobDescription": true,
-			"JobType": true,
-			"JobSalary": true,
-			"SkillsRequired": true,
-			"JobPosition": true,
-		}[key]; !exists {
-			if len(values) > 0 {
-				extras[key] = values[0]
-			}
-		}
-	}
-
-	extraJson, err := json.Marshal(extras)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to marshal extra params : " + err.Error(),
-		}
-	}
-	// TODO: also used to update the job, can be a vulnerability
-	if (jobdata.JobId != 0) {
-		err = c.queries.UpdateJob(ctx, sqlc.UpdateJobParams{
-			Location: jobdata.JobLocation,
-			Title: jobdata.JobTitle,
-			Description: pgtype.Text{String: jobdata.JobDescription, Valid: true},
-			Type: jobdata.JobType,
-			Salary: jobdata.JobSalary,
-			Skills: skills,
-			Position: jobdata.JobPosition,
-			Extras: extraJson,
-			JobID: jobdata.JobId,
-			UserID: userID,
-		})
-		if err != nil {
-			if err.Error() == errs.NoRowsMatch {
-				return &errs.Error{
-					Type: errs.Unauthorized,
-					Message: "You are not allowed to alter this job, or it does not exist.",
-					ToRespondWith: true,
-				}
-			}
-			return &errs.Error{
-				Type: errs.Internal,
-				Message: "Failed to update job listing : " + err.Error(),
-			}
-		}
+// NewJobPost validates and creates a new job listing for the company of the user, as a draft until submitted for review and approved.
+// The salary is parsed into its structured compensation unless that is given, which then makes the salary optional.
+func (c *CompanyService) NewJobPost(ctx *gin.Context, jobdata *dto.NewJobData, userID int64) (*errs.Error) {
 
-		return nil
+	job, errf := newJob(jobdata, userID)
+	if errf != nil {
+		return errf
 	}
 
-	// TODO: need to better validate incoming data 
-	// add job data to db
-	err = c.queries.InsertNewJob(ctx, sqlc.InsertNewJobParams{
-		DataUrl: pgtype.Text{String: "", Valid: true},
-		UserID: userID,
-		Title: jobdata.JobTitle,
-		Location: jobdata.JobLocation,
-		Type: jobdata.JobType,
-		Salary: jobdata.JobSalary,
-		Skills: skills,
-		Position: jobdata.JobPosition,
-		Extras: extraJson,
-		Description: pgtype.Text{String: jobdata.JobDescription, Valid: true},
+	// add job data to db, along with its compensation
+	err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		_, err := insertJob(ctx, queries, job)
+		return err
 	})
 	if err != nil {
 		return &errs.Error{
@@ -146,1199 +96,3982 @@ func (c *CompanyService) NewJobPost(ctx *gin.Context, jobdata *dto.NewJobData, u
 	return nil
 }
 
-func (c *CompanyService) ApplicantsData(ctx *gin.Context, userID int64, jobid string, appid string) (*[]sqlc.GetApplicantsRow, *errs.Error){
+// preparedJob is a validated new job ready to be inserted
+type preparedJob struct {
+	params sqlc.InsertNewJobParams
+	comp *compensation.Compensation
+	parsed bool
+	rules *eligibility.Rules // of imported jobs, nil if none
+}
 
+// newJob validates a new job of the company of the user, the errors are meant for the user
+func newJob(jobdata *dto.NewJobData, userID int64) (*preparedJob, *errs.Error) {
 
-	// parse jobid to int64
-	jobID, err := strconv.ParseInt(jobid, 10, 64)
-	if err != nil {
+	if jobdata.JobTitle == "" || jobdata.JobLocation == "" || jobdata.JobType == "" || (jobdata.JobSalary == "" && jobdata.Compensation == "") || jobdata.JobPosition == "" {
 		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to parse job ID string to int : " + err.Error(),
+			Type: errs.MissingRequiredField,
+			Message: "Job title, location, type, salary and position are required.",
+			ToRespondWith: true,
 		}
 	}
-	appID, err := strconv.ParseInt(appid, 10, 64)
+
+	comp, parsed, errf := jobCompensation(jobdata.Compensation, jobdata.JobSalary, jobdata.JobType)
+	if errf != nil {
+		return nil, errf
+	}
+	salary := jobdata.JobSalary
+	if salary == "" {
+		salary = comp.Display()
+	}
+
+	extraJson, errf := jobExtras(jobdata.Extras)
+	if errf != nil {
+		return nil, errf
+	}
+
+	deadline, maxApplicants, errf := jobLimits(jobdata.Deadline, jobdata.MaxApplicants)
+	if errf != nil {
+		return nil, errf
+	}
+
+	questionsJson, errf := jobQuestions(jobdata.Questions)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &preparedJob{
+		params: sqlc.InsertNewJobParams{
+			DataUrl: pgtype.Text{String: "", Valid: true},
+			UserID: userID,
+			Title: jobdata.JobTitle,
+			Location: jobdata.JobLocation,
+			Type: jobdata.JobType,
+			Salary: salary,
+			Skills: jobSkills(jobdata.SkillsRequired),
+			Position: jobdata.JobPosition,
+			Extras: extraJson,
+			Description: pgtype.Text{String: jobdata.JobDescription, Valid: true},
+			Deadline: deadline,
+			MaxApplicants: maxApplicants,
+			Questions: questionsJson,
+		},
+		comp: comp,
+		parsed: parsed,
+	}, nil
+}
+
+// insertJob inserts a prepared job, its compensation and eligibility rules, returning its ID
+func insertJob(ctx context.Context, queries *sqlc.Queries, job *preparedJob) (int64, error) {
+
+	jobID, err := queries.InsertNewJob(ctx, job.params)
 	if err != nil {
-		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to parse application ID string to int : " + err.Error(),
+		return 0, err
+	}
+
+	if job.comp != nil {
+		err = queries.UpsertJobCompensation(ctx, compensationParams(jobID, job.comp, job.parsed))
+		if err != nil {
+			return 0, fmt.Errorf("failed to save job compensation : %v", err)
 		}
 	}
-	
-	// db call
-	applicantsData, err := c.queries.GetApplicants(ctx, sqlc.GetApplicantsParams{
-		UserID: userID,
-		JobID: jobID,
-		ApplicationID: appID,
-	})
-	if err != nil {
-		if err.Error() == errs.NoRowsMatch {
-			return nil, &errs.Error{
-				Type: errs.NotFound,
-				Message: "No applications found for the given user ID, job ID and application ID",
-				ToRespondWith: true,
-			}	
+
+	if job.rules != nil && !job.rules.Empty() {
+		params, err := eligibilityParams(jobID, job.rules)
+		if err != nil {
+			return 0, err
 		}
-		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to parse job ID string to int : " + err.Error(),
+		err = queries.UpsertJobEligibility(ctx, params)
+		if err != nil {
+			return 0, fmt.Errorf("failed to save eligibility rules : %v", err)
 		}
 	}
 
-	// return 
-	return &applicantsData, nil
+	return jobID, nil
 }
 
-func (c *CompanyService) GetResumeOrResultFilePath(ctx *gin.Context, userID int64, applicationid string, filetype string) (string, *errs.Error) {
-	// parse applicationid to int64
-	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+// ImportJobs validates the jobs of a CSV or JSON file, see jobfile.Read, and creates the valid ones as drafts of the company
+// of the user in one transaction, so either all of them are created or none. Every invalid job is reported along with
+// its row, numbered from 1 in the order of the file. With dryRun the jobs are only validated.
+// A deadline that has passed, as in an export of a past season, is left out and reported as a warning of its row.
+func (c *CompanyService) ImportJobs(ctx *gin.Context, userID int64, fileName string, data []byte, dryRun bool) (*dto.JobImportReport, *errs.Error) {
+
+	records, err := jobfile.Read(fileName, data)
 	if err != nil {
-		return "", &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.InvalidFormat,
-			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			Message: "Invalid job import file : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
-
-	// get both file paths (resume and result)
-	filePaths, err := c.queries.GetResumeAndResultPath(ctx, sqlc.GetResumeAndResultPathParams{
-		UserID: userID,
-		ApplicationID: applicationId,
-	})
-	if err != nil {
-		if err.Error() == errs.NoRowsMatch {
-			return "", &errs.Error{
-				Type: errs.Unauthorized,
-				Message: "The given user ID is not authorized to view the requested file.",
-				ToRespondWith: true,
-			}
-		}
-		return "", &errs.Error{
-			Type: errs.Internal,
-			Message: "Could not get resume and result path : " + err.Error(),
+	if len(records) == 0 || len(records) > config.JobImportMaxRows {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("The file must have 1-%d jobs, it has %d.", config.JobImportMaxRows, len(records)),
+			ToRespondWith: true,
 		}
 	}
 
-	// check what type is requested
-	filepath := filePaths.ResultUrl
-	if filetype == "resume" {
-		filepath = filePaths.ResumeUrl.String
+	report := &dto.JobImportReport{
+		DryRun: dryRun,
+		Total: len(records),
+		Imported: []int64{},
+		Errors: []dto.JobImportError{},
+		Warnings: []dto.JobImportError{},
 	}
 
-    // Check if the file exists
-    if _, err := os.Stat(filepath); err != nil {
-        return "", &errs.Error{
-			Type: errs.Internal,
-			Message: "File not found at path : " + filepath + " : " + err.Error(),
+	jobs := []*preparedJob{}
+	for i, record := range records {
+		jobdata, err := record.JobData()
+		if err != nil {
+			report.Errors = append(report.Errors, dto.JobImportError{Row: i + 1, Title: record["JobTitle"], Message: err.Error()})
+			continue
 		}
-    }
 
-	// by default updates the application status to UnderReview from Applied
-	_, err = c.queries.ApplicationStatusToAnd(ctx, sqlc.ApplicationStatusToAndParams{
-		Status: "UnderReview",
-		ApplicationID: applicationId,
-		Status_2: "Applied",
-	})
-	if err != nil {
-		if err.Error() != errs.NoRowsMatch {
-			return "", &errs.Error{
-				Type: errs.Internal,
-				Message: "Failed to update application status : " + err.Error(),
+		warning := ""
+		if !jobdata.Deadline.IsZero() && !jobdata.Deadline.After(time.Now()) {
+			warning = fmt.Sprintf("The deadline %s has passed, the job was imported without one.", record["Deadline"])
+			jobdata.Deadline = time.Time{}
+		}
+
+		job, errf := newJob(jobdata, userID)
+		if errf == nil && record["Eligibility"] != "" {
+			job.rules, errf = importedEligibility(record["Eligibility"])
+		}
+		if errf != nil {
+			if !errf.ToRespondWith {
+				return nil, errf
 			}
+			report.Errors = append(report.Errors, dto.JobImportError{Row: i + 1, Title: jobdata.JobTitle, Message: errf.Message})
+			continue
+		}
+		if warning != "" {
+			report.Warnings = append(report.Warnings, dto.JobImportError{Row: i + 1, Title: jobdata.JobTitle, Message: warning})
 		}
+		jobs = append(jobs, job)
 	}
+	report.Valid = len(jobs)
 
-	// return file path 
-	return filepath, nil
-}
-
-func (c *CompanyService) JobListings(ctx *gin.Context, userID int64) (*[]sqlc.GetJobListingsRow, *errs.Error){
+	if dryRun || len(jobs) == 0 {
+		return report, nil
+	}
 
-	jobListings, err := c.queries.GetJobListings(ctx, userID)
-	if err != nil {
-		if err.Error() == errs.NoRowsMatch {
-			return nil, &errs.Error{
-				Type: errs.Unauthorized,
-				Message: "The given user ID is not authorized to view requested data.",
-				ToRespondWith: true,
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		for _, job := range jobs {
+			jobID, err := insertJob(ctx, queries, job)
+			if err != nil {
+				return err
 			}
+			report.Imported = append(report.Imported, jobID)
 		}
+		return nil
+	})
+	if err != nil {
 		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to get job listings data for company : " + err.Error(),
+			Message: "Failed to import jobs : " + err.Error(),
 		}
 	}
 
-	return &jobListings, nil
+	return report, nil
 }
 
-func (c *CompanyService) CloseJob(ctx *gin.Context, jobid string, userID int64) (*errs.Error){
+// ExportJobs exports the jobs of the company of the user posted within from and to (2006-01-02, either optional)
+// as a CSV or JSON file in the format of ImportJobs, returning the file and its content type.
+// Only compensations given explicitly are exported, parsed ones are parsed again from the salary on import.
+// Deadlines are exported as they are, the ones that have passed by the time of an import are left out by it.
+func (c *CompanyService) ExportJobs(ctx *gin.Context, userID int64, format string, from string, to string) ([]byte, string, *errs.Error) {
 
-	// parse jobid from string to int64
-	jobID, err := strconv.ParseInt(jobid, 10, 64)
-	if err != nil {
-		return &errs.Error{
+	if format != "csv" && format != "json" {
+		return nil, "", &errs.Error{
 			Type: errs.InvalidFormat,
-			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			Message: "Export format must be csv or json.",
+			ToRespondWith: true,
 		}
 	}
 
-	// db query to change jobs.active_status to false
-	err = c.queries.CloseJob(ctx, sqlc.CloseJobParams{
-		JobID: jobID,
+	var postedFrom, postedTo pgtype.Timestamptz
+	for _, d := range []struct{ value string; into *pgtype.Timestamptz }{{from, &postedFrom}, {to, &postedTo}} {
+		if d.value == "" {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02", d.value, time.Local)
+		if err != nil {
+			return nil, "", &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid date, use the format 2006-01-02 : " + d.value,
+				ToRespondWith: true,
+			}
+		}
+		*d.into = pgtype.Timestamptz{Time: t, Valid: true}
+	}
+	// to is inclusive
+	if postedTo.Valid {
+		postedTo.Time = postedTo.Time.AddDate(0, 0, 1)
+	}
+
+	jobs, err := c.queries.ExportCompanyJobs(ctx, sqlc.ExportCompanyJobsParams{
 		UserID: userID,
+		PostedFrom: postedFrom,
+		PostedTo: postedTo,
 	})
 	if err != nil {
-		if err.Error() == errs.NoRowsMatch {
-			return &errs.Error{
-				Type: errs.Unauthorized,
-				Message: "The given user ID is not authorized to change status of requested job.",
-				ToRespondWith: true,
-			}	
+		return nil, "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get jobs to export : " + err.Error(),
+		}
+	}
+
+	records := make([]jobfile.Record, 0, len(jobs))
+	for _, j := range jobs {
+		record := jobfile.Record{
+			"JobTitle": j.Title,
+			"JobLocation": j.Location,
+			"JobDescription": j.Description.String,
+			"JobType": j.Type,
+			"JobSalary": j.Salary,
+			"SkillsRequired": strings.Join(j.Skills, ", "),
+			"JobPosition": j.Position,
+		}
+		if len(canonicalJSON(j.Extras)) != 0 {
+			record["Extras"] = string(j.Extras)
+		}
+		if j.Deadline.Valid {
+			record["Deadline"] = j.Deadline.Time.In(time.Local).Format("2006-01-02T15:04")
+		}
+		if j.MaxApplicants.Valid {
+			record["MaxApplicants"] = strconv.Itoa(int(j.MaxApplicants.Int32))
+		}
+		if qs, _ := questions.Parse(j.Questions); len(qs) != 0 {
+			record["Questions"] = string(j.Questions)
+		}
+		if j.Currency.Valid && !j.Parsed.Bool {
+			compJson, err := json.Marshal(&compensation.Compensation{
+				Currency: j.Currency.String,
+				CTCMin: j.CtcMin.Int64,
+				CTCMax: j.CtcMax.Int64,
+				Fixed: j.FixedPay.Int64,
+				Variable: j.VariablePay.Int64,
+				StipendMonthly: j.StipendMonthly.Int64,
+				BondMonths: j.BondMonths.Int32,
+				BondTerms: j.BondTerms.String,
+			})
+			if err != nil {
+				return nil, "", &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to marshal job compensation : " + err.Error(),
+				}
+			}
+			record["Compensation"] = string(compJson)
 		}
-		return &errs.Error{
+		rules, err := eligibility.FromColumns(j.MinCgpa, j.Courses, j.Departments, j.YearsOfStudy, j.MaxBacklogs, j.Custom)
+		if err != nil {
+			return nil, "", &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		if !rules.Empty() {
+			rulesJson, err := json.Marshal(rules)
+			if err != nil {
+				return nil, "", &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to marshal job eligibility rules : " + err.Error(),
+				}
+			}
+			record["Eligibility"] = string(rulesJson)
+		}
+		records = append(records, record)
+	}
+
+	var file []byte
+	contentType := "text/csv; charset=utf-8"
+	if format == "csv" {
+		file, err = jobfile.WriteCSV(records)
+	} else {
+		file, err = jobfile.WriteJSON(records)
+		contentType = "application/json; charset=utf-8"
+	}
+	if err != nil {
+		return nil, "", &errs.Error{
 			Type: errs.Internal,
-			Message: fmt.Sprintf("Failed to close job for job ID : %d : %v", jobID, err.Error()),
+			Message: "Failed to write jobs export : " + err.Error(),
 		}
 	}
 
-	return nil
+	return file, contentType, nil
 }
 
-func (c *CompanyService) DeleteJob(ctx *gin.Context, jobid string, userID int64) (*errs.Error){
+// UpdateJob replaces the fields of a job of the company of the user and records the edit as a new revision,
+// in one transaction. The edit is rejected if the job was changed since the revision it was made on.
+// A change of a reviewed field (see reviewedJobFields) of an approved job resubmits it for review in the same
+// transaction, the admins, and the applicants of material changes (see materialJobFields), are notified once committed.
+// The structured compensation is replaced if given, else parsed again from the salary if that or the job type changed.
+// A job closed once its deadline passed is opened again if the deadline is moved to the future or removed.
+func (c *CompanyService) UpdateJob(ctx *gin.Context, jobdata *dto.UpdateJobData, userID int64) (int32, *errs.Error) {
 
-	jobID, err := strconv.ParseInt(jobid, 10, 64)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.InvalidFormat,
-			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+	if jobdata.JobTitle == "" || jobdata.JobLocation == "" || jobdata.JobType == "" || (jobdata.JobSalary == "" && jobdata.Compensation == "") || jobdata.JobPosition == "" {
+		return 0, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Job title, location, type, salary and position are required.",
+			ToRespondWith: true,
 		}
 	}
 
-	err = c.queries.DeleteJob(ctx, sqlc.DeleteJobParams{
-		JobID: jobID,
+	current, err := c.queries.GetJobForUpdate(ctx, sqlc.GetJobForUpdateParams{
+		JobID: jobdata.JobId,
 		UserID: userID,
 	})
 	if err != nil {
 		if err.Error() == errs.NoRowsMatch {
-			return &errs.Error{
+			return 0, &errs.Error{
 				Type: errs.Unauthorized,
-				Message: "The given user ID is not authorized to change status of requested job.",
+				Message: "You are not allowed to alter this job, or it does not exist.",
 				ToRespondWith: true,
-			}	
+			}
 		}
-		return &errs.Error{
+		return 0, &errs.Error{
 			Type: errs.Internal,
-			Message: fmt.Sprintf("Failed to delete job for job ID : %d : %v", jobID, err.Error()),
+			Message: "Failed to get job to update : " + err.Error(),
 		}
 	}
-
-	return nil
-}
-
-func (c *CompanyService) ShortList(ctx *gin.Context, applicationid string, userID int64) (*errs.Error){
-
-	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.InvalidFormat,
-			Message: "Invalid applications ID, failed to parse to int : " + err.Error(),
+	if current.Revision != jobdata.Revision {
+		return 0, &errs.Error{
+			Type: errs.InvalidState,
+			Message: fmt.Sprintf("The job was changed since revision %d, it is now at revision %d. Reload and edit again.", jobdata.Revision, current.Revision),
+			ToRespondWith: true,
 		}
 	}
 
-	jobOwnerID, err := c.queries.GetUserIDCompanyIDJobIDApplicationID(ctx, applicationId)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get job owner user ID : " + err.Error(),
+	extraJson := current.Extras
+	if jobdata.Extras != "" {
+		var errf *errs.Error
+		extraJson, errf = jobExtras(jobdata.Extras)
+		if errf != nil {
+			return 0, errf
 		}
 	}
-
-	if userID != jobOwnerID {
-		return &errs.Error{
-			Type: errs.Unauthorized,
-			Message: "The given user ID is not authorized to access requested application.",
-			ToRespondWith: true,
+	questionsJson := current.Questions
+	if jobdata.Questions != "" {
+		var errf *errs.Error
+		questionsJson, errf = jobQuestions(jobdata.Questions)
+		if errf != nil {
+			return 0, errf
 		}
 	}
+	skills := jobSkills(jobdata.SkillsRequired)
 
-	studentUserID, err := c.queries.ApplicationStatusToAnd(ctx, sqlc.ApplicationStatusToAndParams{
-		Status: "ShortListed",
-		ApplicationID: applicationId,	
-		Status_2: "UnderReview",
-	})
-	if err != nil {
-		return &errs.Error{
+	deadline, maxApplicants, errf := jobLimits(jobdata.Deadline, jobdata.MaxApplicants)
+	// a past deadline is only accepted if it is the existing one
+	if errf != nil && !(errf.Type == errs.InvalidState && deadline.Time.Equal(current.Deadline.Time)) {
+		return 0, errf
+	}
+
+	var currentComp *compensation.Compensation
+	row, err := c.queries.GetJobCompensation(ctx, jobdata.JobId)
+	if err == nil {
+		currentComp = compensationFromRow(&row)
+	} else if err.Error() != errs.NoRowsMatch {
+		return 0, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to change application status : " + err.Error(),
+			Message: "Failed to get job compensation : " + err.Error(),
 		}
 	}
-
-	errf := c.Notify.NewNotification(ctx, studentUserID, &dto.NotificationData{
-		Title: "Application Shortlisted",
-		Description: fmt.Sprintf("Your application (ID: %s) has been shortlisted.", applicationid),
-	})
-	if errf != nil {
-		return errf
+	comp, parsed := currentComp, false
+	// the compensation is left as is unless given or the salary it was parsed from changed
+	recompensate := jobdata.Compensation != "" || jobdata.JobSalary != current.Salary || jobdata.JobType != current.Type
+	if recompensate {
+		comp, parsed, errf = jobCompensation(jobdata.Compensation, jobdata.JobSalary, jobdata.JobType)
+		if errf != nil {
+			return 0, errf
+		}
+	}
+	salary := jobdata.JobSalary
+	if salary == "" {
+		salary = comp.Display()
+	}
+
+	// old and new value of every changed field
+	changes := make(map[string]dto.JobChange)
+	compare := func(field string, from any, to any) {
+		if !reflect.DeepEqual(from, to) {
+			changes[field] = dto.JobChange{From: from, To: to}
+		}
+	}
+	compare("Title", current.Title, jobdata.JobTitle)
+	compare("Location", current.Location, jobdata.JobLocation)
+	compare("Description", current.Description.String, jobdata.JobDescription)
+	compare("Type", current.Type, jobdata.JobType)
+	compare("Salary", current.Salary, salary)
+	compare("Skills", current.Skills, skills)
+	compare("Position", current.Position, jobdata.JobPosition)
+	compare("Extras", canonicalJSON(current.Extras), canonicalJSON(extraJson))
+	compare("Deadline", jobDeadline(current.Deadline), jobDeadline(deadline))
+	compare("MaxApplicants", current.MaxApplicants.Int32, maxApplicants.Int32)
+	currentQuestions, _ := questions.Parse(current.Questions)
+	newQuestions, _ := questions.Parse(questionsJson)
+	compare("Questions", currentQuestions, newQuestions)
+	compare("Compensation", currentComp, comp)
+
+	if len(changes) == 0 {
+		return current.Revision, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Nothing to update, the job is unchanged.",
+			ToRespondWith: true,
+		}
 	}
 
-	return nil
-}
+	var revision int32
+	var material []string
+	var resubmitted int64
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		var err error
+		revision, err = queries.UpdateJob(ctx, sqlc.UpdateJobParams{
+			Location: jobdata.JobLocation,
+			Title: jobdata.JobTitle,
+			Description: pgtype.Text{String: jobdata.JobDescription, Valid: true},
+			Type: jobdata.JobType,
+			Salary: salary,
+			Skills: skills,
+			Position: jobdata.JobPosition,
+			Extras: extraJson,
+			JobID: jobdata.JobId,
+			UserID: userID,
+			Revision: jobdata.Revision,
+			Deadline: deadline,
+			MaxApplicants: maxApplicants,
+			Questions: questionsJson,
+		})
+		if err != nil {
+			return err
+		}
 
-func (c *CompanyService) Reject(ctx *gin.Context, applicationid string, userID int64) (*errs.Error){
+		if recompensate {
+			err = saveJobCompensation(ctx, queries, jobdata.JobId, comp, parsed)
+			if err != nil {
+				return err
+			}
+		}
 
-	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.InvalidFormat,
-			Message: "Invalid applications ID, failed to parse to int : " + err.Error(),
+		material, err = recordJobRevision(ctx, queries, jobdata.JobId, revision, userID, changes)
+		if err != nil || !reviewedChange(changes) {
+			return err
 		}
-	}
 
-	jobOwnerID, err := c.queries.GetUserIDCompanyIDJobIDApplicationID(ctx, applicationId)
+		// edits of what a live job shows are reviewed again, it is hidden from students until approved
+		resubmitted, err = queries.ResubmitApprovedJob(ctx, jobdata.JobId)
+		if err != nil {
+			return fmt.Errorf("failed to resubmit job for review : %v", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return &errs.Error{
+		// changed between the read and the update
+		if err.Error() == errs.NoRowsMatch {
+			return 0, &errs.Error{
+				Type: errs.InvalidState,
+				Message: "The job was changed while editing. Reload and edit again.",
+				ToRespondWith: true,
+			}
+		}
+		return 0, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to get job owner user ID : " + err.Error(),
+			Message: "Failed to update job listing : " + err.Error(),
 		}
 	}
 
-	if userID != jobOwnerID {
-		return &errs.Error{
-			Type: errs.Unauthorized,
-			Message: "The given user ID is not authorized to access requested application.",
-			ToRespondWith: true,
-		} 
+	c.notifyJobEdited(ctx, jobdata.JobId, jobdata.JobTitle, userID, material, resubmitted != 0)
+
+	return revision, nil
+}
+
+// notifyJobEdited notifies the admins and the company of a job resubmitted for review by an edit,
+// and the applicants of the material changes. The edit is already saved, failures are only logged.
+func (c *CompanyService) notifyJobEdited(ctx *gin.Context, jobID int64, title string, userID int64, material []string, resubmitted bool) {
+
+	if resubmitted {
+		errf := c.notifyJobSubmitted(ctx, jobID, title, userID, true)
+		if errf != nil {
+			fmt.Printf("Failed to notify of job %d resubmitted for review : %s\n", jobID, errf.Message)
+		}
 	}
 
-	studentUserID, err := c.queries.ApplicationStatusTo(ctx, sqlc.ApplicationStatusToParams{
-		Status: "Rejected",
-		ApplicationID: applicationId,
-	})
+	if len(material) != 0 {
+		c.notifyJobChange(ctx, jobID, title, material)
+	}
+}
+
+// approval states of a job, only approved jobs are shown to students
+const (
+	jobDraft = "draft"
+	jobSubmitted = "submitted"
+	jobApproved = "approved"
+	jobRejected = "rejected"
+)
+
+// SubmitJob submits a draft or rejected job of the company of the user for review by the admins.
+func (c *CompanyService) SubmitJob(ctx *gin.Context, jobid string, userID int64) *errs.Error {
+
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
 	if err != nil {
 		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to change application status : " + err.Error(),
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
 
-	err = c.queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
-		Status: "Completed",
-		ApplicationID: applicationId,
+	title, err := c.queries.SubmitJob(ctx, sqlc.SubmitJobParams{
+		JobID: jobID,
+		UserID: userID,
 	})
 	if err != nil {
+		if err.Error() != errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to submit job for review : " + err.Error(),
+			}
+		}
+
+		// not the company's job, or not in a state to submit
+		current, err := c.queries.GetJobForUpdate(ctx, sqlc.GetJobForUpdateParams{
+			JobID: jobID,
+			UserID: userID,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "You are not allowed to alter this job, or it does not exist.",
+				ToRespondWith: true,
+			}
+		}
 		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to change interview status : " + err.Error(),
+			Type: errs.InvalidState,
+			Message: fmt.Sprintf("Only draft or rejected jobs can be submitted for review, this job is %s.", current.ApprovalStatus),
+			ToRespondWith: true,
 		}
 	}
 
-	errf := c.Notify.NewNotification(ctx, studentUserID, &dto.NotificationData{
-		Title: "Application Rejected",
-		Description: fmt.Sprintf("Your application (ID: %s) has been Rejected.", applicationid),
+	return c.notifyJobSubmitted(ctx, jobID, title, userID, false)
+}
+
+// notifyJobSubmitted notifies the admins of a job awaiting their review, and the company of the submission
+func (c *CompanyService) notifyJobSubmitted(ctx *gin.Context, jobID int64, title string, userID int64, edited bool) *errs.Error {
+
+	description := fmt.Sprintf("%s was submitted for review.", title)
+	if edited {
+		description = fmt.Sprintf("%s was edited after approval and is hidden from students until reviewed again.", title)
+	}
+
+	errf := c.Notify.NewNotification(ctx, userID, &dto.NotificationData{
+		Title: "Job submitted for review",
+		Description: description,
+		RefType: notify.RefJob,
+		RefID: jobID,
 	})
 	if errf != nil {
 		return errf
 	}
-	
-	return nil
+
+	return notifyAdmins(ctx, c.queries, c.Notify, 0, &dto.NotificationData{
+		Title: "Job awaiting review",
+		Description: description,
+		RefType: notify.RefJob,
+		RefID: jobID,
+	})
 }
 
-func (c *CompanyService) ScheduleInterview(ctx *gin.Context, data *dto.NewInterview) (*errs.Error) {
+// JobReviews returns the reviews of a job of the company of the user, latest first.
+func (c *CompanyService) JobReviews(ctx *gin.Context, jobid string, userID int64) (*[]dto.JobReview, *errs.Error) {
 
-	if (data.DateTime.Compare(time.Now()) != 1) {
-		return &errs.Error{
-			Type: errs.PreconditionFailed,
-			Message: "Interview Date-Time cannot be in the past.",
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
 			ToRespondWith: true,
 		}
 	}
 
-	
-
-
-
-	dt, err := c.queries.ScheduleInterview(ctx, sqlc.ScheduleInterviewParams{
-		ApplicationID: data.ApplicationId,
-		UserID: data.UserId,
-		DateTime: pgtype.Timestamptz{Time: data.DateTime, Valid: true},
-		Type: data.Type,
-		Notes: pgtype.Text{String: data.Notes, Valid: true},
-		Location: data.Location,
+	reviews, err := c.queries.JobReviews(ctx, sqlc.JobReviewsParams{
+		JobID: jobID,
+		UserID: userID,
 	})
 	if err != nil {
-		var pgerr *pgconn.PgError
-		if errors.As(err, &pgerr) {
-			if pgerr.Code == errs.UniqueViolation {
-				return &errs.Error{
-					Type: errs.ObjectExists,
-					Message: "Cannot schedule multiple interviews for the same application.",
-					ToRespondWith: true,
-				}
-			}
-		}		
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to insert new interview in db : " + err.Error(),
+			Message: "Failed to get job reviews : " + err.Error(),
 		}
 	}
 
+	data := make([]dto.JobReview, 0, len(reviews))
+	for _, r := range reviews {
+		data = append(data, dto.JobReview{
+			Revision: r.Revision,
+			Decision: r.Decision,
+			Comment: r.Comment,
+			CreatedAt: r.CreatedAt.Time,
+		})
+	}
 
-	// student name and email and job title and company name for email template
-	studentData, err := c.queries.GetScheduleInterviewData(ctx, data.ApplicationId)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get student data for email : " + err.Error(),
+	return &data, nil
+}
+
+// recordJobRevision records the changes that made the given revision of a job,
+// returning the material ones to notify the applicants of, see materialJobFields and notifyJobChange.
+func recordJobRevision(ctx context.Context, queries *sqlc.Queries, jobID int64, revision int32, userID int64, changes map[string]dto.JobChange) ([]string, error) {
+
+	fields := make([]string, 0, len(changes))
+	material := []string{}
+	for field := range changes {
+		fields = append(fields, field)
+		if materialJobFields[field] {
+			material = append(material, field)
 		}
 	}
+	sort.Strings(fields)
+	sort.Strings(material)
 
-	data.StudentName = studentData.StudentName
-	data.CompanyName = studentData.CompanyName
-	data.JobTitle = studentData.Title
-	data.DT = dt
-	
-	// execute email template
-	template, err := utils.DynamicHTML("./template/emails/interviewScheduled.html", data)
+	changesJson, err := json.Marshal(changes)
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get dynamic template for new interview email : " + err.Error(),
-		}
+		return nil, fmt.Errorf("failed to marshal job changes : %v", err)
 	}
-	// send new interview email to student
-	go utils.SendEmailHTML(template, []string{studentData.StudentEmail})
 
-	errf := c.Notify.NewNotification(ctx, studentData.UserID, &dto.NotificationData{
-		Title: "Interview Scheduled",
-		Description: fmt.Sprintf("New Interview scheduled for application (ID: %d).", data.ApplicationId),
+	err = queries.InsertJobRevision(ctx, sqlc.InsertJobRevisionParams{
+		JobID: jobID,
+		Revision: revision,
+		ChangedBy: userID,
+		ChangedFields: fields,
+		Changes: changesJson,
+		Material: len(material) != 0,
 	})
-	if errf != nil {
-		return errf
+	if err != nil {
+		return nil, fmt.Errorf("failed to record job revision : %v", err)
 	}
 
-	// no error
-	return nil
+	return material, nil
 }
 
-func (c *CompanyService) Offer(ctx *gin.Context, userID int64, applicationid string, offerLetter *multipart.FileHeader) (*errs.Error) {
+// JobRevisions returns the edit history of a job of the company of the user, latest first.
+func (c *CompanyService) JobRevisions(ctx *gin.Context, jobid string, userID int64) (*[]dto.JobRevision, *errs.Error) {
 
-	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.InvalidFormat,
-			Message: "Failed to parse application ID : " + err.Error(),
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
 
-	jobOwnerID, err := c.queries.GetUserIDCompanyIDJobIDApplicationID(ctx, applicationId)
+	revisions, err := c.queries.JobRevisions(ctx, sqlc.JobRevisionsParams{
+		JobID: jobID,
+		UserID: userID,
+	})
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to get job owner user ID : " + err.Error(),
+			Message: "Failed to get job revisions : " + err.Error(),
 		}
 	}
 
-	if userID != jobOwnerID {
-		return &errs.Error{
-			Type: errs.Unauthorized,
-			Message: "The given user ID is not authorized to access requested application.",
-			ToRespondWith: true,
-		} 
+	// changes as a JSON object instead of base64 encoded bytes
+	data := make([]dto.JobRevision, 0, len(revisions))
+	for _, r := range revisions {
+		data = append(data, dto.JobRevision{
+			Revision: r.Revision,
+			ChangedFields: r.ChangedFields,
+			Changes: r.Changes,
+			Material: r.Material,
+			CreatedAt: r.CreatedAt.Time,
+		})
 	}
 
-	// TODO: atomicity problem 
-	// update interview status to 'Completed'
-	err = c.queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
-		ApplicationID: applicationId,
-		Status: "Completed",
-	})
+	return &data, nil
+}
+
+// notifyJobChange notifies the applicants (except the rejected and withdrawn ones) of a job of material changes to it.
+// The change is already saved, failures are only logged.
+func (c *CompanyService) notifyJobChange(ctx *gin.Context, jobID int64, title string, fields []string) {
+
+	applicants, err := c.queries.JobApplicantsUserIDs(ctx, jobID)
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to change interview status : " + err.Error(),
-		}
+		fmt.Printf("Failed to get applicants to notify of change to job %d : %v\n", jobID, err)
+		return
 	}
 
-	studentUserID, err := c.queries.ApplicationStatusTo(ctx, sqlc.ApplicationStatusToParams{
-		ApplicationID: applicationId,
-		Status: "Offered",
-	})
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to update application status : " + err.Error(),
+	for _, applicantUserID := range applicants {
+		errf := c.Notify.NewNotification(ctx, applicantUserID, &dto.NotificationData{
+			Title: "Job you applied to was updated",
+			Description: fmt.Sprintf("%s was updated after you applied, changed : %s.", title, strings.Join(fields, ", ")),
+			Category: notify.CategoryApplication,
+			RefType: notify.RefJob,
+			RefID: jobID,
+		})
+		if errf != nil {
+			fmt.Printf("Failed to notify user %d of change to job %d : %s\n", applicantUserID, jobID, errf.Message)
 		}
 	}
+}
 
-	offerData, err := c.queries.GetOfferLetterData(ctx, applicationId)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to fetch offer letter data for email : " + err.Error(),
+// materialJobFields are the fields whose change after applications exist is notified to the applicants
+var materialJobFields = map[string]bool{
+	"Salary": true,
+	"Compensation": true,
+	"Location": true,
+	"Eligibility": true,
+}
+
+// reviewedJobFields are the fields shown to students whose change resubmits an approved job for review. The deadline
+// and the applicant cap only limit applying, they are left out so the application window can change without review.
+var reviewedJobFields = map[string]bool{
+	"Title": true,
+	"Description": true,
+	"Type": true,
+	"Position": true,
+	"Skills": true,
+	"Extras": true,
+	"Questions": true,
+	"Salary": true,
+	"Compensation": true,
+	"Location": true,
+	"Eligibility": true,
+}
+
+// reviewedChange reports whether the changes of a job change a reviewed field, see reviewedJobFields
+func reviewedChange(changes map[string]dto.JobChange) bool {
+	for field := range changes {
+		if reviewedJobFields[field] {
+			return true
 		}
 	}
+	return false
+}
 
-	template, err := utils.DynamicHTML("./template/emails/offerEmail.html", offerData)
-	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get dynamic template for offer email : " + err.Error(),
+// jobLimits validates the application deadline and applicant cap of a job, zero values are no limit.
+// A deadline in the past is returned along with an InvalidState error.
+func jobLimits(deadline time.Time, maxApplicants int32) (pgtype.Timestamptz, pgtype.Int4, *errs.Error) {
+
+	if maxApplicants < 0 {
+		return pgtype.Timestamptz{}, pgtype.Int4{}, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Maximum applicants cannot be negative, use 0 for no limit.",
+			ToRespondWith: true,
 		}
 	}
-	go utils.SendEmailHTMLWithAttachmentFileHeader(template, []string{offerData.StudentEmail}, offerLetter)
 
-	errf := c.Notify.NewNotification(ctx, studentUserID, &dto.NotificationData{
-		Title: "Offered !!",
-		Description: fmt.Sprintf("Congratulations! New job offer received. (ID: %s)", applicationid),
-	})
-	if errf != nil {
-		return errf
+	d := pgtype.Timestamptz{Time: deadline, Valid: !deadline.IsZero()}
+	m := pgtype.Int4{Int32: maxApplicants, Valid: maxApplicants != 0}
+
+	if d.Valid && !deadline.After(time.Now()) {
+		return d, m, &errs.Error{
+			Type: errs.InvalidState,
+			Message: "The application deadline must be in the future.",
+			ToRespondWith: true,
+		}
 	}
 
-	// no error
-	return nil
+	return d, m, nil
 }
 
-func (c *CompanyService) CancelInterview(ctx *gin.Context, userID int64, applicationid string) (*errs.Error) {
+// jobCompensation validates the structured compensation of a job given as a JSON object, see compensation.Compensation,
+// or else parses it from the free-text salary, reporting it was parsed. It is nil if neither makes out an amount.
+func jobCompensation(raw string, salary string, jobType string) (*compensation.Compensation, bool, *errs.Error) {
 
-	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	if raw == "" {
+		comp, ok := compensation.Parse(salary, compensation.IsInternship(jobType))
+		return comp, ok, nil
+	}
+
+	comp := &compensation.Compensation{}
+	err := json.Unmarshal([]byte(raw), comp)
 	if err != nil {
-		return &errs.Error{
+		return nil, false, &errs.Error{
 			Type: errs.InvalidFormat,
-			Message: "Failed to parse application ID : " + err.Error(),
-		}	}
-
-	jobOwnerID, err := c.queries.GetUserIDCompanyIDJobIDApplicationID(ctx, applicationId)
-	if err != nil {	
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get job owner user ID : " + err.Error(),
-		}	
-	}
-	if userID != jobOwnerID {
-		return &errs.Error{
-			Type: errs.Unauthorized,
-			Message: "The given user ID is not authorized to access requested application.",
+			Message: "Job compensation must be a JSON object : " + err.Error(),
 			ToRespondWith: true,
-		} 
+		}
 	}
 
-	data, err := c.queries.CancelInterviewEmailData(ctx, applicationId)
+	comp.Normalize()
+	err = comp.Validate()
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get data for email : " + err.Error(),
-		}	
+		return nil, false, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job compensation : " + err.Error(),
+			ToRespondWith: true,
+		}
 	}
 
-	newData := dto.CancelInterview{
-		StudentName: data.StudentName,
-		StudentEmail: data.StudentEmail,
-		JobTitle: data.Title,
-		CompanyName: data.CompanyName,
-		DateTime: data.DateTime,
-		RepresentativeEmail: data.RepresentativeEmail,
-		RepresentativeName: data.RepresentativeName,
+	return comp, false, nil
+}
+
+// saveJobCompensation stores the structured compensation of a job, a nil one removes it
+func saveJobCompensation(ctx context.Context, queries *sqlc.Queries, jobID int64, comp *compensation.Compensation, parsed bool) error {
+
+	var err error
+	if comp == nil {
+		err = queries.DeleteJobCompensation(ctx, jobID)
+	} else {
+		err = queries.UpsertJobCompensation(ctx, compensationParams(jobID, comp, parsed))
 	}
-	template, err := utils.DynamicHTML("./template/emails/interviewCancelled.html", newData)
 	if err != nil {
-		return &errs.Error{
+		return fmt.Errorf("failed to save job compensation : %v", err)
+	}
+
+	return nil
+}
+
+// jobDeadline is the deadline as recorded in job revisions, nil if there is none
+func jobDeadline(deadline pgtype.Timestamptz) any {
+	if !deadline.Valid {
+		return nil
+	}
+	return deadline.Time.UTC().Format(time.RFC3339)
+}
+
+// jobSkills splits the comma separated skills
+func jobSkills(skillsRequired string) []string {
+	skills := strings.Split(skillsRequired, ",")
+	for i, skill := range skills {
+		// trim off spaces
+		skills[i] = strings.TrimSpace(skill)
+	}
+	return skills
+}
+
+// jobExtras validates the optional extras of a job to be a JSON object, an empty string is an empty object
+func jobExtras(extras string) ([]byte, *errs.Error) {
+	if extras == "" {
+		return []byte("{}"), nil
+	}
+
+	// a null decodes without an error but leaves m nil
+	var m map[string]any
+	err := json.Unmarshal([]byte(extras), &m)
+	if err != nil || m == nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Job extras must be a JSON object.",
+			ToRespondWith: true,
+		}
+	}
+
+	extraJson, err := json.Marshal(m)
+	if err != nil {
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to get dynamic template for offer email : " + err.Error(),
+			Message: "Failed to marshal job extras : " + err.Error(),
 		}
 	}
-	go utils.SendEmailHTML(template, []string{data.StudentEmail})
 
-	// TODO: dont delete interview, make it cancelled
-	err = c.queries.DeleteInterview(ctx, applicationId)
+	return extraJson, nil
+}
+
+// jobQuestions validates the JSON array of questions of a job, empty is no questions
+func jobQuestions(raw string) ([]byte, *errs.Error) {
+	if raw == "" {
+		return []byte("[]"), nil
+	}
+
+	qs, err := questions.Parse([]byte(raw))
+	if err == nil {
+		err = questions.Validate(qs)
+	}
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job questions : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	questionsJson, err := json.Marshal(qs)
+	if err != nil {
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to delete interview : " + err.Error(),
-		}	
+			Message: "Failed to marshal job questions : " + err.Error(),
+		}
 	}
 
-	return nil
+	return questionsJson, nil
 }
 
-const (
-	GForm = "GForms"
-	CSVJSON = "CSVJSON"
-	Manual = "Manual"
-)
+// canonicalJSON decodes a JSON object so differently formatted but equal objects compare equal
+func canonicalJSON(raw []byte) map[string]any {
+	m := make(map[string]any)
+	_ = json.Unmarshal(raw, &m)
+	return m
+}
 
-func (c *CompanyService) NewTestPost(ctx *gin.Context, userID int64, newtestData *dto.NewTestPost) (*errs.Error) {
+func (c *CompanyService) ApplicantsData(ctx *gin.Context, userID int64, jobid string, appid string) (*[]dto.Applicant, *errs.Error){
 
-	var formID string
-	var errf *errs.Error
 
-	switch newtestData.UploadMethod {
-	case GForm :
-		gformData := new(dto.NewTestGForms)
-		err := ctx.Bind(gformData)
-		if err != nil {
-			return &errs.Error{
-				Type: errs.IncompleteForm,
-				Message: "Failed to bind GForm : " + err.Error(),
-			}
+	// parse jobid to int64
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to parse job ID string to int : " + err.Error(),
 		}
-		formID, errf = c.NewTestPostGForm(ctx, gformData)
+	}
+	appID, err := strconv.ParseInt(appid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to parse application ID string to int : " + err.Error(),
+		}
+	}
+	
+	// db call
+	applicantsData, err := c.queries.GetApplicants(ctx, sqlc.GetApplicantsParams{
+		UserID: userID,
+		JobID: jobID,
+		ApplicationID: appID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "No applications found for the given user ID, job ID and application ID",
+				ToRespondWith: true,
+			}	
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to parse job ID string to int : " + err.Error(),
+		}
+	}
+
+	// answers paired with the questions of the job instead of the raw JSON of both
+	applicants := make([]dto.Applicant, 0, len(applicantsData))
+	for _, a := range applicantsData {
+		answers, errf := pairAnswers(a.Questions, a.Answers)
 		if errf != nil {
-			return errf
+			return nil, errf
+		}
+		applicants = append(applicants, dto.Applicant{
+			StudentID: a.StudentID,
+			StudentName: a.StudentName,
+			RollNumber: a.RollNumber,
+			Gender: a.Gender,
+			Department: a.Department,
+			StudentEmail: a.StudentEmail,
+			ContactNo: a.ContactNo,
+			Cgpa: a.Cgpa,
+			Skills: a.Skills,
+			JobID: a.JobID,
+			Title: a.Title,
+			Status: a.Status,
+			InterviewStatus: a.InterviewStatus,
+			ApplicationID: a.ApplicationID,
+			Answers: answers,
+		})
+	}
+
+	// return 
+	return &applicants, nil
+}
+
+// ExportApplicants exports the applicants to a job of the company of the user as a CSV, XLSX or a ZIP of their resumes,
+// see exportApplicants.
+func (c *CompanyService) ExportApplicants(ctx *gin.Context, userID int64, data *dto.ApplicantExport) (*sheet.File, *errs.Error) {
+
+	if data.JobID == 0 {
+		return nil, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Job ID is required to export applicants.",
+			ToRespondWith: true,
 		}
-	case CSVJSON:
-		// TODO: 
-	case Manual:
-		// TODO:
-	default:
-	}	
+	}
 
-	err := c.queries.NewTest(ctx, sqlc.NewTestParams{
-		TestName: newtestData.Name,
-		Description: pgtype.Text{String: newtestData.Description, Valid: true},
-		Duration: newtestData.Duration,
-		QCount: newtestData.QuestionCount,
-		EndTime: pgtype.Timestamptz{Time: newtestData.EndDateTime, Valid: true},
-		Type: newtestData.Type,
-		UploadMethod: newtestData.UploadMethod,
-		JobID: pgtype.Int8{Int64: newtestData.BindedJobId, Valid: true},
+	return exportApplicants(ctx, c.queries, userID, data)
+}
+
+// AnswerFilePath returns the stored path of the file an applicant uploaded as the answer to a question of a job of the company.
+func (c *CompanyService) AnswerFilePath(ctx *gin.Context, userID int64, applicationid string, questionID string) (string, *errs.Error) {
+
+	applicationID, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return "", &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	row, err := c.queries.ApplicationAnswers(ctx, sqlc.ApplicationAnswersParams{
+		ApplicationID: applicationID,
 		UserID: userID,
-		FileID: formID,
-		Threshold: int32(newtestData.Threshold),
 	})
 	if err != nil {
-		var pgerr *pgconn.PgError
-		// TODO: this can also fail, have a fallback (else statement)
-		if errors.As(err, &pgerr) {
-			if pgerr.Code == errs.UniqueViolation {
-				return &errs.Error{
-					Type: errs.UniqueViolation,
-					Message: "The test already exists ! You cannot create multiple tests with the same test file.",
-					ToRespondWith: true,
+		if err.Error() == errs.NoRowsMatch {
+			return "", &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to view the requested file.",
+				ToRespondWith: true,
+			}
+		}
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application answers : " + err.Error(),
+		}
+	}
+
+	answers, errf := pairAnswers(row.Questions, row.Answers)
+	if errf != nil {
+		return "", errf
+	}
+	for _, a := range answers {
+		if a.QuestionID == questionID && a.Type == questions.TypeFile {
+			if _, err := os.Stat(a.Value); err != nil {
+				return "", &errs.Error{
+					Type: errs.Internal,
+					Message: "File not found at path : " + a.Value + " : " + err.Error(),
 				}
-			} 
-			return &errs.Error{
+			}
+			return a.Value, nil
+		}
+	}
+
+	return "", &errs.Error{
+		Type: errs.NotFound,
+		Message: "No file was uploaded for this question.",
+		ToRespondWith: true,
+	}
+}
+
+// pairAnswers decodes the answers of an application and pairs them with the questions of the job
+func pairAnswers(questionsJson []byte, answersJson []byte) ([]questions.Answer, *errs.Error) {
+
+	qs, err := questions.Parse(questionsJson)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+	answers := make(map[string]string)
+	if len(answersJson) != 0 {
+		err = json.Unmarshal(answersJson, &answers)
+		if err != nil {
+			return nil, &errs.Error{
 				Type: errs.Internal,
-				Message: "Failed to insert new test in db : " + err.Error(),
+				Message: "Failed to unmarshal application answers : " + err.Error(),
+			}
+		}
+	}
+
+	return questions.Pair(qs, answers), nil
+}
+
+func (c *CompanyService) GetResumeOrResultFilePath(ctx *gin.Context, userID int64, applicationid string, filetype string) (string, *errs.Error) {
+	// parse applicationid to int64
+	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return "", &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+		}
+	}
+
+	// get both file paths (resume and result)
+	filePaths, err := c.queries.GetResumeAndResultPath(ctx, sqlc.GetResumeAndResultPathParams{
+		UserID: userID,
+		ApplicationID: applicationId,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return "", &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to view the requested file.",
+				ToRespondWith: true,
+			}
+		}
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Could not get resume and result path : " + err.Error(),
+		}
+	}
+
+	// check what type is requested
+	filepath := filePaths.ResultUrl
+	if filetype == "resume" {
+		filepath = filePaths.ResumeUrl.String
+	}
+
+    // Check if the file exists
+    if _, err := os.Stat(filepath); err != nil {
+        return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "File not found at path : " + filepath + " : " + err.Error(),
+		}
+    }
+
+	// by default moves the application to UnderReview, if it is still Applied
+	_, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: applicationId,
+		To: appstatus.UnderReview,
+		ActorID: userID,
+		Actor: appstatus.ActorCompany,
+		Reason: "Viewed by the company.",
+	})
+	if errf != nil && errf.Type != errs.InvalidState {
+		return "", errf
+	}
+
+	// return file path 
+	return filepath, nil
+}
+
+func (c *CompanyService) JobListings(ctx *gin.Context, userID int64) (*[]sqlc.GetJobListingsRow, *errs.Error){
+
+	jobListings, err := c.queries.GetJobListings(ctx, userID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to view requested data.",
+				ToRespondWith: true,
 			}
 		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job listings data for company : " + err.Error(),
+		}
+	}
+
+	return &jobListings, nil
+}
+
+func (c *CompanyService) CloseJob(ctx *gin.Context, jobid string, userID int64) (*errs.Error){
+
+	// parse jobid from string to int64
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+		}
+	}
+
+	// db query to change jobs.active_status to false
+	err = c.queries.CloseJob(ctx, sqlc.CloseJobParams{
+		JobID: jobID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to change status of requested job.",
+				ToRespondWith: true,
+			}	
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: fmt.Sprintf("Failed to close job for job ID : %d : %v", jobID, err.Error()),
+		}
+	}
+
+	return nil
+}
+
+func (c *CompanyService) DeleteJob(ctx *gin.Context, jobid string, userID int64) (*errs.Error){
+
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+		}
+	}
+
+	err = c.queries.DeleteJob(ctx, sqlc.DeleteJobParams{
+		JobID: jobID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to change status of requested job.",
+				ToRespondWith: true,
+			}	
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: fmt.Sprintf("Failed to delete job for job ID : %d : %v", jobID, err.Error()),
+		}
+	}
+
+	return nil
+}
+
+// ShortList shortlists an application to a job of the company of the user, see appstatus for when it can be.
+func (c *CompanyService) ShortList(ctx *gin.Context, applicationid string, userID int64) (*errs.Error){
+
+	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid applications ID, failed to parse to int : " + err.Error(),
+		}
+	}
+
+	parties, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: applicationId,
+		To: appstatus.ShortListed,
+		ActorID: userID,
+		Actor: appstatus.ActorCompany,
+	})
+	if errf != nil {
+		return errf
+	}
+
+	errf = c.Notify.NewNotification(ctx, parties.StudentUserID, &dto.NotificationData{
+		Title: "Application Shortlisted",
+		Description: fmt.Sprintf("Your application (ID: %s) has been shortlisted.", applicationid),
+		Category: notify.CategoryApplication,
+		RefType: notify.RefApplication,
+		RefID: applicationId,
+	})
+	if errf != nil {
+		return errf
+	}
+
+	return nil
+}
+
+// Reject rejects an application to a job of the company of the user with an optional reason shown to the student,
+// completing its interview if any.
+func (c *CompanyService) Reject(ctx *gin.Context, applicationid string, userID int64, reason string) (*errs.Error){
+
+	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid applications ID, failed to parse to int : " + err.Error(),
+		}
+	}
+
+	parties, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: applicationId,
+		To: appstatus.Rejected,
+		ActorID: userID,
+		Actor: appstatus.ActorCompany,
+		Reason: reason,
+		Then: func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+			err := queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
+				Status: "Completed",
+				ApplicationID: applicationId,
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to change interview status : " + err.Error(),
+				}
+			}
+			return nil
+		},
+	})
+	if errf != nil {
+		return errf
+	}
+
+	description := fmt.Sprintf("Your application (ID: %s) has been Rejected.", applicationid)
+	if reason != "" {
+		description += " Reason : " + reason
+	}
+	errf = c.Notify.NewNotification(ctx, parties.StudentUserID, &dto.NotificationData{
+		Title: "Application Rejected",
+		Description: description,
+		Category: notify.CategoryApplication,
+		RefType: notify.RefApplication,
+		RefID: applicationId,
+	})
+	if errf != nil {
+		return errf
+	}
+
+	return nil
+}
+
+// BulkShortList shortlists applications to the jobs of the company of the user in one transaction, see bulkStatusChange.
+func (c *CompanyService) BulkShortList(ctx *gin.Context, userID int64, data *dto.BulkApplicationAction) (*dto.BulkActionReport, *errs.Error) {
+	return c.bulkStatusChange(ctx, userID, data, appstatus.ShortListed, nil)
+}
+
+// BulkReject rejects applications to the jobs of the company of the user in one transaction with an optional reason
+// shown to the students, completing their interviews if any. See bulkStatusChange.
+func (c *CompanyService) BulkReject(ctx *gin.Context, userID int64, data *dto.BulkApplicationAction) (*dto.BulkActionReport, *errs.Error) {
+	return c.bulkStatusChange(ctx, userID, data, appstatus.Rejected, func(queries *sqlc.Queries, applicationID int64) *errs.Error {
+		err := queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
+			Status: "Completed",
+			ApplicationID: applicationID,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to change interview status : " + err.Error(),
+			}
+		}
+		return nil
+	})
+}
+
+// bulkStatusChange moves the applications, given or matching the filter, to the status in one transaction, running
+// then for each one changed. All of them must be to the jobs of the company of the user. Those that cannot be moved,
+// eg. already rejected, are skipped and reported, unless strict in which case none are changed.
+// The students of the changed applications are notified and emailed in batches once committed.
+func (c *CompanyService) bulkStatusChange(ctx *gin.Context, userID int64, data *dto.BulkApplicationAction, to string, then func(queries *sqlc.Queries, applicationID int64) *errs.Error) (*dto.BulkActionReport, *errs.Error) {
+
+	applicationIDs, errf := c.bulkApplicationIDs(ctx, userID, data)
+	if errf != nil {
+		return nil, errf
+	}
+
+	report := &dto.BulkActionReport{
+		To: to,
+		Total: len(applicationIDs),
+		Results: make([]dto.BulkActionResult, 0, len(applicationIDs)),
+	}
+	if len(applicationIDs) == 0 {
+		return report, nil
+	}
+
+	rows, errf := c.ownedApplications(ctx, userID, applicationIDs)
+	if errf != nil {
+		return nil, errf
+	}
+
+	var abort *errs.Error
+	changed := []sqlc.BulkApplicationPartiesRow{}
+	err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		for _, row := range rows {
+			change := &statusChange{
+				ApplicationID: row.ApplicationID,
+				To: to,
+				ActorID: userID,
+				Actor: appstatus.ActorCompany,
+				Reason: data.Reason,
+			}
+			if then != nil {
+				applicationID := row.ApplicationID
+				change.Then = func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+					return then(queries, applicationID)
+				}
+			}
+
+			parties := sqlc.ApplicationPartiesRow{
+				Status: row.Status,
+				JobID: row.JobID,
+				Title: row.Title,
+				StudentUserID: row.StudentUserID,
+				CompanyUserID: row.CompanyUserID,
+			}
+			errf, err := applyStatusChange(ctx, queries, change, &parties)
+			if err != nil {
+				return err
+			}
+
+			result := dto.BulkActionResult{
+				ApplicationID: row.ApplicationID,
+				From: parties.Status,
+				Changed: errf == nil,
+			}
+			if errf != nil {
+				// a disallowed transition is checked before anything is written, it alone can be skipped
+				if errf.Type != errs.InvalidState || data.Strict {
+					abort = &errs.Error{
+						Type: errf.Type,
+						Message: fmt.Sprintf("No changes made, application %d : %s", row.ApplicationID, errf.Message),
+						ToRespondWith: errf.ToRespondWith,
+					}
+					return errStatusChangeAborted
+				}
+				result.Message = errf.Message
+			} else {
+				changed = append(changed, row)
+			}
+			report.Results = append(report.Results, result)
+		}
+		return nil
+	})
+	if abort != nil {
+		return nil, abort
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to change application statuses : " + err.Error(),
+		}
+	}
+	report.Changed = len(changed)
+
+	c.notifyBulkStatusChange(changed, to, data.Reason)
+
+	return report, nil
+}
+
+// ownedApplications returns the parties of the applications, in the order of their IDs, all of which must be to the
+// jobs of the company of the user
+func (c *CompanyService) ownedApplications(ctx *gin.Context, userID int64, applicationIDs []int64) ([]sqlc.BulkApplicationPartiesRow, *errs.Error) {
+
+	rows, err := c.queries.BulkApplicationParties(ctx, applicationIDs)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get applications : " + err.Error(),
+		}
+	}
+	owned := make(map[int64]bool)
+	for _, row := range rows {
+		if row.CompanyUserID == userID {
+			owned[row.ApplicationID] = true
+		}
+	}
+	unauthorized := []string{}
+	for _, id := range applicationIDs {
+		if !owned[id] {
+			unauthorized = append(unauthorized, strconv.FormatInt(id, 10))
+		}
+	}
+	if len(unauthorized) != 0 {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "No changes made, applications " + strings.Join(unauthorized, ", ") + " do not exist or are not to your jobs.",
+			ToRespondWith: true,
+		}
+	}
+
+	return rows, nil
+}
+
+// bulkApplicationIDs are the IDs of the applications of a bulk action, the given ones without duplicates
+// or those matching the filter, sorted
+func (c *CompanyService) bulkApplicationIDs(ctx *gin.Context, userID int64, data *dto.BulkApplicationAction) ([]int64, *errs.Error) {
+
+	if (len(data.ApplicationIDs) == 0) == (data.Filter == nil) {
+		return nil, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Either application IDs or a filter are required.",
+			ToRespondWith: true,
+		}
+	}
+
+	var applicationIDs []int64
+	if data.Filter == nil {
+		seen := make(map[int64]bool)
+		for _, id := range data.ApplicationIDs {
+			if !seen[id] {
+				seen[id] = true
+				applicationIDs = append(applicationIDs, id)
+			}
+		}
+		sort.Slice(applicationIDs, func(i, j int) bool { return applicationIDs[i] < applicationIDs[j] })
+	} else {
+		filter := data.Filter
+		if filter.JobID == 0 {
+			return nil, &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "Job ID is required to filter applications.",
+				ToRespondWith: true,
+			}
+		}
+		for _, status := range filter.Statuses {
+			if !appstatus.Valid(status) {
+				return nil, &errs.Error{
+					Type: errs.InvalidFormat,
+					Message: fmt.Sprintf("Invalid application status %q in filter.", status),
+					ToRespondWith: true,
+				}
+			}
+		}
+		if filter.MinScore != nil || filter.BelowScore != nil {
+			if filter.TestID == 0 {
+				return nil, &errs.Error{
+					Type: errs.MissingRequiredField,
+					Message: "Test ID is required to filter applications by score.",
+					ToRespondWith: true,
+				}
+			}
+			exists, err := c.queries.JobTestExists(ctx, sqlc.JobTestExistsParams{
+				TestID: filter.TestID,
+				JobID: filter.JobID,
+			})
+			if err != nil {
+				return nil, &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to get test : " + err.Error(),
+				}
+			}
+			if !exists {
+				return nil, &errs.Error{
+					Type: errs.NotFound,
+					Message: "The test is not of the given job.",
+					ToRespondWith: true,
+				}
+			}
+		}
+
+		statuses := filter.Statuses
+		if statuses == nil {
+			statuses = []string{}
+		}
+		var minScore, belowScore pgtype.Int8
+		if filter.MinScore != nil {
+			minScore = pgtype.Int8{Int64: *filter.MinScore, Valid: true}
+		}
+		if filter.BelowScore != nil {
+			belowScore = pgtype.Int8{Int64: *filter.BelowScore, Valid: true}
+		}
+
+		var err error
+		applicationIDs, err = c.queries.FilterJobApplications(ctx, sqlc.FilterJobApplicationsParams{
+			TestID: filter.TestID,
+			JobID: filter.JobID,
+			UserID: userID,
+			MinScore: minScore,
+			BelowScore: belowScore,
+			Statuses: statuses,
+		})
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to filter applications : " + err.Error(),
+			}
+		}
+	}
+
+	if len(applicationIDs) > config.BulkActionMaxApplications {
+		return nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: fmt.Sprintf("At most %d applications can be changed at once, %d given.", config.BulkActionMaxApplications, len(applicationIDs)),
+			ToRespondWith: true,
+		}
+	}
+
+	return applicationIDs, nil
+}
+
+// notifyBulkStatusChange notifies the students of the applications changed to the status, and emails them
+// in batches by job, in the background
+func (c *CompanyService) notifyBulkStatusChange(changed []sqlc.BulkApplicationPartiesRow, to string, reason string) {
+
+	title, verb := "Application Shortlisted", "shortlisted"
+	if to == appstatus.Rejected {
+		title, verb = "Application Rejected", "Rejected"
+	}
+
+	go func() {
+		ctx := context.Background()
+
+		emails := make(map[int64][]string)
+		for _, row := range changed {
+			description := fmt.Sprintf("Your application (ID: %d) has been %s.", row.ApplicationID, verb)
+			if reason != "" && to == appstatus.Rejected {
+				description += " Reason : " + reason
+			}
+			errf := c.Notify.NewNotification(ctx, row.StudentUserID, &dto.NotificationData{
+				Title: title,
+				Description: description,
+				Category: notify.CategoryApplication,
+				RefType: notify.RefApplication,
+				RefID: row.ApplicationID,
+			})
+			if errf != nil {
+				fmt.Printf("Failed to notify user %d of application %d : %s\n", row.StudentUserID, row.ApplicationID, errf.Message)
+			}
+			emails[row.JobID] = append(emails[row.JobID], row.StudentEmail)
+		}
+
+		for _, row := range changed {
+			recipients, pending := emails[row.JobID]
+			if !pending {
+				continue
+			}
+			delete(emails, row.JobID)
+
+			message := "Your application has been " + verb + "."
+			if reason != "" && to == appstatus.Rejected {
+				message += "\nReason : " + reason
+			}
+			template, err := utils.DynamicHTML("./template/emails/applicationStatus.html", &dto.ApplicationStatusEmail{
+				Title: title,
+				JobTitle: row.Title,
+				CompanyName: row.CompanyName,
+				Message: message,
+			})
+			if err != nil {
+				fmt.Printf("Failed to get dynamic template for application status email : %v\n", err)
+				return
+			}
+			for start := 0; start < len(recipients); start += config.BulkActionEmailBatchSize {
+				end := min(start + config.BulkActionEmailBatchSize, len(recipients))
+				utils.SendEmailHTML(template, recipients[start:end])
+			}
+		}
+	} ()
+}
+
+// ScheduleInterview schedules an interview of the application, moving it to Interview. An interview overlapping
+// events of the student, or of the company at the location or with the panel, is not scheduled and the conflicts are
+// returned, unless forced in which case the override is recorded in the audit log.
+func (c *CompanyService) ScheduleInterview(ctx *gin.Context, data *dto.NewInterview) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	if (data.DateTime.Compare(time.Now()) != 1) {
+		return nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Interview Date-Time cannot be in the past.",
+			ToRespondWith: true,
+		}
+	}
+	if data.DurationMinutes == 0 {
+		data.DurationMinutes = config.InterviewDefaultDuration
+	}
+	if data.DurationMinutes < 0 || data.DurationMinutes > config.InterviewMaxDuration {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("Interview duration must be between 1 and %d minutes.", config.InterviewMaxDuration),
+			ToRespondWith: true,
+		}
+	}
+
+	// the application is checked to be of the company before its student's schedule is looked at
+	parties, err := c.queries.ApplicationParties(ctx, data.ApplicationId)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application : " + err.Error(),
+		}
+	}
+	if parties.CompanyUserID != data.UserId {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		}
+	}
+
+	// the application moves to Interview along with the interview being scheduled, checked for conflicts in the same
+	// transaction
+	var newInterview sqlc.ScheduleInterviewRow
+	var conflicts []sqlc.InterviewConflictsRow
+	unforced := false
+	_, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: data.ApplicationId,
+		To: appstatus.Interview,
+		ActorID: data.UserId,
+		Actor: appstatus.ActorCompany,
+		Then: func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+			var errf *errs.Error
+			conflicts, errf = interviewConflicts(ctx, queries, data.ApplicationId, 0, data.DateTime, data.DurationMinutes, data.Location, data.Panel)
+			if errf != nil {
+				return errf
+			}
+			if len(conflicts) != 0 && !data.Force {
+				unforced = true
+				return conflictError(conflicts)
+			}
+
+			var err error
+			newInterview, err = queries.ScheduleInterview(ctx, sqlc.ScheduleInterviewParams{
+				ApplicationID: data.ApplicationId,
+				UserID: data.UserId,
+				DateTime: pgtype.Timestamptz{Time: data.DateTime, Valid: true},
+				Type: data.Type,
+				Notes: pgtype.Text{String: data.Notes, Valid: true},
+				Location: data.Location,
+				DurationMinutes: data.DurationMinutes,
+				Panel: data.Panel,
+			})
+			if err != nil {
+				var pgerr *pgconn.PgError
+				if errors.As(err, &pgerr) {
+					if pgerr.Code == errs.UniqueViolation {
+						return &errs.Error{
+							Type: errs.ObjectExists,
+							Message: "Cannot schedule multiple interviews for the same application.",
+							ToRespondWith: true,
+						}
+					}
+				}		
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to insert new interview in db : " + err.Error(),
+				}
+			}
+			// an interview round of the pipeline of the job if the application is in an interview stage
+			err = queries.LinkInterviewStage(ctx, newInterview.InterviewID)
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to link interview to its pipeline stage : " + err.Error(),
+				}
+			}
+			if len(conflicts) != 0 {
+				err = audit.Record(ctx, queries, data.UserId, audit.InterviewConflictOverridden, audit.EntityInterview, newInterview.InterviewID, &dto.InterviewConflictOverride{
+					ApplicationID: data.ApplicationId,
+					DateTime: data.DateTime,
+					DurationMinutes: data.DurationMinutes,
+					Location: data.Location,
+					Panel: data.Panel,
+					Conflicts: conflicts,
+				})
+				if err != nil {
+					return &errs.Error{
+						Type: errs.Internal,
+						Message: "Failed to record conflict override in audit log : " + err.Error(),
+					}
+				}
+			}
+			return nil
+		},
+	})
+	if unforced {
+		return conflicts, errf
+	}
+	if errf != nil {
+		return nil, errf
+	}
+
+
+	// student name and email and job title and company name for email template
+	studentData, err := c.queries.GetScheduleInterviewData(ctx, data.ApplicationId)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get student data for email : " + err.Error(),
+		}
+	}
+
+	data.StudentName = studentData.StudentName
+	data.CompanyName = studentData.CompanyName
+	data.JobTitle = studentData.Title
+	data.DT = newInterview.DateTime
+	
+	// execute email template
+	template, err := utils.DynamicHTML("./template/emails/interviewScheduled.html", data)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get dynamic template for new interview email : " + err.Error(),
+		}
+	}
+	invite := utils.ICalendar(utils.ICalRequest, interviewEvent(newInterview.InterviewID, 0, data.DateTime, data.DurationMinutes, data.Type, data.Location, data.Notes, data.CompanyName, data.JobTitle, studentData.StudentEmail))
+	// send new interview email to student along with the calendar invite
+	go utils.SendEmailHTMLWithInvite(template, []string{studentData.StudentEmail}, invite, utils.ICalRequest)
+
+	errf = c.Notify.NewNotification(ctx, studentData.UserID, &dto.NotificationData{
+		Title: "Interview Scheduled",
+		Description: fmt.Sprintf("New Interview scheduled for application (ID: %d).", data.ApplicationId),
+		Category: notify.CategoryInterview,
+		RefType: notify.RefInterview,
+		RefID: newInterview.InterviewID,
+	})
+	if errf != nil {
+		return nil, errf
+	}
+
+	// no error
+	return nil, nil
+}
+
+// Offer offers the application on the terms given, with the uploaded offer letter, or one generated from a template
+// of the company, stored as the document of the offer. The student accepts or declines it by the response deadline,
+// after which it expires.
+func (c *CompanyService) Offer(ctx *gin.Context, userID int64, data *dto.NewOffer, offerLetter *multipart.FileHeader) (*errs.Error) {
+
+	terms := &offer.Terms{
+		CTC: data.CTC,
+		Currency: data.Currency,
+		JoiningDate: data.JoiningDate,
+		RespondBy: data.RespondBy,
+	}
+	err := terms.Validate(time.Now())
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid offer, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
+
+	var write offerLetterWriter
+	if data.TemplateID != 0 {
+		template, errf := c.offerLetterTemplate(ctx, userID, data.TemplateID)
+		if errf != nil {
+			return errf
+		}
+		write = c.generatedOfferLetter(ctx, template, terms, data.Location)
+	} else {
+		if offerLetter == nil {
+			return &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "Either an offer letter or a template to generate it from is required.",
+				ToRespondWith: true,
+			}
+		}
+		if offerLetter.Header.Get("Content-Type") != "application/pdf" {
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The offer letter must be a PDF.",
+				ToRespondWith: true,
+			}
+		}
+		if offerLetter.Size > config.OfferLetterMaxFileSize {
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The offer letter size exceeds the limit.",
+				ToRespondWith: true,
+			}
+		}
+		write = func(_ int64, path string) error {
+			_, err := utils.SaveFile(ctx, path, offerLetter)
+			return err
+		}
+	}
+
+	return c.makeOffer(ctx, userID, data.ApplicationID, terms, data.TemplateID, write)
+}
+
+// BulkOffer offers applications, given or matching the filter, on the same terms with a letter generated for each
+// from the template. Every application is offered on its own, those that cannot be, eg. not allowed by the placement
+// policy, are skipped and reported.
+func (c *CompanyService) BulkOffer(ctx *gin.Context, userID int64, data *dto.BulkOffer) (*dto.BulkActionReport, *errs.Error) {
+
+	if data.TemplateID == 0 {
+		return nil, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Template ID is required to generate the offer letters.",
+			ToRespondWith: true,
+		}
+	}
+
+	terms := &offer.Terms{
+		CTC: data.CTC,
+		Currency: data.Currency,
+	}
+	var err error
+	if data.JoiningDate != "" {
+		terms.JoiningDate, err = time.ParseInLocation("2006-01-02", data.JoiningDate, time.Local)
+	}
+	if err == nil && data.RespondBy != "" {
+		terms.RespondBy, err = time.ParseInLocation("2006-01-02T15:04", data.RespondBy, time.Local)
+	}
+	if err == nil {
+		err = terms.Validate(time.Now())
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid offer, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
+
+	template, errf := c.offerLetterTemplate(ctx, userID, data.TemplateID)
+	if errf != nil {
+		return nil, errf
+	}
+
+	applicationIDs, errf := c.bulkApplicationIDs(ctx, userID, &dto.BulkApplicationAction{
+		ApplicationIDs: data.ApplicationIDs,
+		Filter: data.Filter,
+	})
+	if errf != nil {
+		return nil, errf
+	}
+	if len(applicationIDs) > config.BulkOfferMaxApplications {
+		return nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: fmt.Sprintf("At most %d applications can be offered at once.", config.BulkOfferMaxApplications),
+			ToRespondWith: true,
+		}
+	}
+
+	report := &dto.BulkActionReport{
+		To: appstatus.Offered,
+		Total: len(applicationIDs),
+		Results: make([]dto.BulkActionResult, 0, len(applicationIDs)),
+	}
+	if len(applicationIDs) == 0 {
+		return report, nil
+	}
+
+	rows, errf := c.ownedApplications(ctx, userID, applicationIDs)
+	if errf != nil {
+		return nil, errf
+	}
+
+	write := c.generatedOfferLetter(ctx, template, terms, data.Location)
+	for _, row := range rows {
+		result := dto.BulkActionResult{
+			ApplicationID: row.ApplicationID,
+			From: row.Status,
+		}
+		errf := c.makeOffer(ctx, userID, row.ApplicationID, terms, data.TemplateID, write)
+		if errf != nil {
+			result.Message = errf.Message
+			if !errf.ToRespondWith {
+				fmt.Printf("Failed to offer application %d : %s\n", row.ApplicationID, errf.Message)
+				result.Message = "Failed to make the offer, try again."
+			}
+		} else {
+			result.Changed = true
+			report.Changed++
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+// makeOffer offers the application on the terms, once the placement policy allows it, with the offer letter written
+// by write. The letter is removed if the offer cannot be made. The student is emailed the letter and notified.
+// The policy is checked in the transaction that offers, with the student locked, see checkPlacementPolicy.
+func (c *CompanyService) makeOffer(ctx *gin.Context, userID int64, applicationID int64, terms *offer.Terms, templateID int64, write offerLetterWriter) *errs.Error {
+
+	errf := c.checkOfferApplication(ctx, userID, applicationID)
+	if errf != nil {
+		return errf
+	}
+
+	userUUID, err := c.queries.GetUserUUIDFromUserID(ctx, userID)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get user UUID : " + err.Error(),
+		}
+	}
+	letterPath := fmt.Sprintf("%s%s&%d&offer-%d.pdf", os.Getenv("OfferStorageDir"), hex.EncodeToString(userUUID.Bytes[:]), time.Now().Unix(), applicationID)
+	err = write(applicationID, letterPath)
+	if err != nil {
+		var unencodable *letter.UnencodableError
+		if errors.As(err, &unencodable) {
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The offer letter cannot be generated, " + err.Error() + ". Upload the offer letter instead.",
+				ToRespondWith: true,
+			}
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to save offer letter : " + err.Error(),
+		}
+	}
+
+	// the interview, if any, is completed along with the offer, and the application moves to the offer stage
+	// of the pipeline of the job if it has one
+	parties, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: applicationID,
+		To: appstatus.Offered,
+		ActorID: userID,
+		Actor: appstatus.ActorCompany,
+		Then: func(queries *sqlc.Queries, parties *sqlc.ApplicationPartiesRow) *errs.Error {
+			errf := checkOfferPolicy(ctx, queries, parties)
+			if errf != nil {
+				return errf
+			}
+			_, err := queries.InsertOffer(ctx, sqlc.InsertOfferParams{
+				ApplicationID: applicationID,
+				Ctc: terms.CTC,
+				Currency: terms.Currency,
+				JoiningDate: pgtype.Date{Time: terms.JoiningDate, Valid: true},
+				RespondBy: pgtype.Timestamptz{Time: terms.RespondBy, Valid: true},
+				LetterUrl: letterPath,
+				OfferedBy: pgtype.Int8{Int64: userID, Valid: true},
+				TemplateID: pgtype.Int8{Int64: templateID, Valid: templateID != 0},
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to insert offer : " + err.Error(),
+				}
+			}
+			err = queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
+				ApplicationID: applicationID,
+				Status: "Completed",
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to change interview status : " + err.Error(),
+				}
+			}
+			err = queries.MoveToOfferStage(ctx, sqlc.MoveToOfferStageParams{
+				ApplicationID: applicationID,
+				MovedBy: pgtype.Int8{Int64: userID, Valid: true},
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to move application to the offer stage : " + err.Error(),
+				}
+			}
+			return nil
+		},
+	})
+	if errf != nil {
+		os.Remove(letterPath)
+		return errf
+	}
+
+	offerData, err := c.queries.GetOfferLetterData(ctx, applicationID)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to fetch offer letter data for email : " + err.Error(),
+		}
+	}
+
+	template, err := utils.DynamicHTML("./template/emails/offerEmail.html", offerData)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get dynamic template for offer email : " + err.Error(),
+		}
+	}
+	go utils.SendEmailHTMLWithAttachmentFilePath(template, []string{offerData.StudentEmail}, letterPath, "Offer Letter.pdf")
+
+	errf = c.Notify.NewNotification(ctx, parties.StudentUserID, &dto.NotificationData{
+		Title: "Offered !!",
+		Description: fmt.Sprintf("Congratulations! New job offer received for %s at %s, %s. (ID: %d) Respond by %s.",
+			offerData.Title, offerData.CompanyName, terms.Display(), applicationID, terms.RespondBy.Format("03:04 PM 02-01-2006")),
+		Category: notify.CategoryOffer,
+		RefType: notify.RefApplication,
+		RefID: applicationID,
+	})
+	if errf != nil {
+		return errf
+	}
+
+	// no error
+	return nil
+}
+
+// checkOfferApplication checks the application exists and is to a job of the company, before its offer letter is written
+func (c *CompanyService) checkOfferApplication(ctx *gin.Context, userID int64, applicationID int64) *errs.Error {
+
+	parties, err := c.queries.ApplicationParties(ctx, applicationID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application : " + err.Error(),
+		}
+	}
+	if parties.CompanyUserID != userID {
+		return &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
+
+// checkOfferPolicy checks the placement policy allows offering the job of the application to its student,
+// within the transaction of queries that offers
+func checkOfferPolicy(ctx context.Context, queries *sqlc.Queries, parties *sqlc.ApplicationPartiesRow) *errs.Error {
+
+	reasons, errf := placementViolations(ctx, queries, parties.StudentUserID, parties.JobID)
+	if errf != nil {
+		return errf
+	}
+	if len(reasons) != 0 {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "The placement policy does not allow offering this job to the student. " + strings.Join(reasons, " "),
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
+
+// interviewEvent builds the calendar event of an interview, the UID stays the same across updates and cancellation.
+func interviewEvent(interviewID int64, sequence int32, start time.Time, duration int32, interviewType string, location string, notes string, companyName string, jobTitle string, studentEmail string) utils.ICalEvent {
+	return utils.ICalEvent{
+		UID: utils.ICalUID("interview", interviewID),
+		Sequence: sequence,
+		Start: start,
+		End: start.Add(time.Duration(duration) * time.Minute),
+		Summary: fmt.Sprintf("%s Interview : %s - %s", interviewType, companyName, jobTitle),
+		Description: notes,
+		Location: location,
+		Attendees: []string{studentEmail},
+	}
+}
+
+// sendInterviewCancelled emails the student of the application of data the cancellation of an interview,
+// along with the iCalendar CANCEL of its event at the given sequence
+func sendInterviewCancelled(data *sqlc.CancelInterviewEmailDataRow, interviewID int64, sequence int32, start time.Time, duration int32) *errs.Error {
+
+	template, err := utils.DynamicHTML("./template/emails/interviewCancelled.html", dto.CancelInterview{
+		StudentName: data.StudentName,
+		StudentEmail: data.StudentEmail,
+		JobTitle: data.Title,
+		CompanyName: data.CompanyName,
+		DateTime: start.In(time.Local).Format("03:04 PM 02-01-2006"),
+		RepresentativeEmail: data.RepresentativeEmail,
+		RepresentativeName: data.RepresentativeName,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get dynamic template for interview cancelled email : " + err.Error(),
+		}
+	}
+
+	cancel := interviewEvent(interviewID, sequence, start, duration, "", "", "", data.CompanyName, data.Title, data.StudentEmail)
+	cancel.Cancelled = true
+	invite := utils.ICalendar(utils.ICalCancel, cancel)
+	go utils.SendEmailHTMLWithInvite(template, []string{data.StudentEmail}, invite, utils.ICalCancel)
+
+	return nil
+}
+
+// emailCancelledInterviews sends the student of the application the cancellation of its interviews cancelled by
+// CancelScheduledInterview. The cancellation is already saved, failures are only logged.
+func emailCancelledInterviews(ctx context.Context, queries *sqlc.Queries, applicationID int64, cancelled []sqlc.CancelScheduledInterviewRow) {
+
+	data, err := queries.CancelInterviewEmailData(ctx, applicationID)
+	if err != nil {
+		fmt.Printf("Failed to get data to email the cancelled interviews of application %d : %v\n", applicationID, err)
+		return
+	}
+
+	for _, interview := range cancelled {
+		errf := sendInterviewCancelled(&data, interview.InterviewID, interview.IcalSequence, interview.DateTime.Time, interview.DurationMinutes)
+		if errf != nil {
+			fmt.Printf("Failed to email the cancelled interview %d : %s\n", interview.InterviewID, errf.Message)
+		}
+	}
+}
+
+// interviewConflicts returns the events overlapping an interview of the application from start for duration minutes,
+// see InterviewConflicts. interviewID is that of the interview being updated, it does not conflict with itself.
+// To be called within the transaction that schedules, the company and the student are locked until it ends.
+func interviewConflicts(ctx context.Context, queries *sqlc.Queries, applicationID int64, interviewID int64, start time.Time, duration int32, location string, panel string) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	err := queries.LockInterviewParties(ctx, applicationID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to lock interview parties : " + err.Error(),
+		}
+	}
+
+	conflicts, err := queries.InterviewConflicts(ctx, sqlc.InterviewConflictsParams{
+		ApplicationID: applicationID,
+		InterviewID: interviewID,
+		EndsAt: pgtype.Timestamptz{Time: start.Add(time.Duration(duration) * time.Minute), Valid: true},
+		StartsAt: pgtype.Timestamptz{Time: start, Valid: true},
+		Location: location,
+		Panel: panel,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to check interview conflicts : " + err.Error(),
+		}
+	}
+
+	return conflicts, nil
+}
+
+// errInterviewAborted rolls back an update of an interview, the reason is returned separately as an *errs.Error
+var errInterviewAborted = errors.New("interview update aborted")
+
+// conflictError is the error of an interview not scheduled for its conflicts
+func conflictError(conflicts []sqlc.InterviewConflictsRow) *errs.Error {
+	return &errs.Error{
+		Type: errs.PreconditionFailed,
+		Message: fmt.Sprintf("The interview overlaps %d other event(s) of the student or the company, pick another time or force it.", len(conflicts)),
+		ToRespondWith: true,
+	}
+}
+
+// ProposeReschedule proposes new times for a scheduled interview of the company, see proposeReschedule
+func (c *CompanyService) ProposeReschedule(ctx *gin.Context, userID int64, data *dto.RescheduleProposal) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+	return proposeReschedule(ctx, c.Notify, userID, appstatus.ActorCompany, data)
+}
+
+// RespondReschedule responds to the reschedule proposed by the student of an interview, see respondReschedule
+func (c *CompanyService) RespondReschedule(ctx *gin.Context, userID int64, data *dto.RescheduleResponse) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+	return respondReschedule(ctx, c.Notify, userID, appstatus.ActorCompany, data)
+}
+
+// Reschedules returns the reschedule proposals of an interview of the company, oldest first
+func (c *CompanyService) Reschedules(ctx *gin.Context, userID int64, interviewid string) ([]sqlc.InterviewRescheduleProposal, *errs.Error) {
+	return rescheduleHistory(ctx, c.queries, userID, appstatus.ActorCompany, interviewid)
+}
+
+// CancelInterview cancels the scheduled interview of the application, which goes back to ShortListed for another to be
+// scheduled. The student is emailed the cancellation.
+func (c *CompanyService) CancelInterview(ctx *gin.Context, userID int64, applicationid string) (*errs.Error) {
+
+	applicationId, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Failed to parse application ID : " + err.Error(),
+		}	}
+
+	jobOwnerID, err := c.queries.GetUserIDCompanyIDJobIDApplicationID(ctx, applicationId)
+	if err != nil {	
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job owner user ID : " + err.Error(),
+		}	
+	}
+	if userID != jobOwnerID {
+		return &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		} 
+	}
+
+	// the interview stays, Cancelled, along with its reschedule proposals
+	var cancelled []sqlc.CancelScheduledInterviewRow
+	cancelInterview := func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+		var err error
+		cancelled, err = queries.CancelScheduledInterview(ctx, sqlc.CancelScheduledInterviewParams{
+			ApplicationID: applicationId,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to cancel interview : " + err.Error(),
+			}
+		}
+		if len(cancelled) == 0 {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application has no scheduled interview to cancel.",
+				ToRespondWith: true,
+			}
+		}
+		return nil
+	}
+	// back to ShortListed to schedule another
+	_, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: applicationId,
+		To: appstatus.ShortListed,
+		ActorID: userID,
+		Actor: appstatus.ActorCompany,
+		Reason: "Interview cancelled.",
+		CancelsInterview: true,
+		Then: cancelInterview,
+	})
+	// not in Interview, eg. scheduled before interviews had a status, the interview alone is cancelled
+	if errf != nil && errf.Type == errs.InvalidState {
+		errf = cancelInterview(c.queries, nil)
+	}
+	if errf != nil {
+		return errf
+	}
+
+	emailCancelledInterviews(ctx, c.queries, applicationId, cancelled)
+
+	return nil
+}
+
+const (
+	GForm = "GForms"
+	CSVJSON = "CSVJSON"
+	Manual = "Manual"
+)
+
+func (c *CompanyService) NewTestPost(ctx *gin.Context, userID int64, newtestData *dto.NewTestPost) (*errs.Error) {
+
+	var formID string
+	var errf *errs.Error
+
+	switch newtestData.UploadMethod {
+	case GForm :
+		gformData := new(dto.NewTestGForms)
+		err := ctx.Bind(gformData)
+		if err != nil {
+			return &errs.Error{
+				Type: errs.IncompleteForm,
+				Message: "Failed to bind GForm : " + err.Error(),
+			}
+		}
+		formID, errf = c.NewTestPostGForm(ctx, gformData)
+		if errf != nil {
+			return errf
+		}
+	case CSVJSON:
+		// TODO: 
+	case Manual:
+		// TODO:
+	default:
+	}	
+
+	testID, err := c.queries.NewTest(ctx, sqlc.NewTestParams{
+		TestName: newtestData.Name,
+		Description: pgtype.Text{String: newtestData.Description, Valid: true},
+		Duration: newtestData.Duration,
+		QCount: newtestData.QuestionCount,
+		EndTime: pgtype.Timestamptz{Time: newtestData.EndDateTime, Valid: true},
+		Type: newtestData.Type,
+		UploadMethod: newtestData.UploadMethod,
+		JobID: pgtype.Int8{Int64: newtestData.BindedJobId, Valid: true},
+		UserID: userID,
+		FileID: formID,
+		Threshold: int32(newtestData.Threshold),
+	})
+	if err != nil {
+		var pgerr *pgconn.PgError
+		// TODO: this can also fail, have a fallback (else statement)
+		if errors.As(err, &pgerr) {
+			if pgerr.Code == errs.UniqueViolation {
+				return &errs.Error{
+					Type: errs.UniqueViolation,
+					Message: "The test already exists ! You cannot create multiple tests with the same test file.",
+					ToRespondWith: true,
+				}
+			} 
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to insert new test in db : " + err.Error(),
+			}
+		}
+	}
+
+	allEmails, err := c.queries.GetAllApplicantsEmailsForJob(ctx, newtestData.BindedJobId)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get all emails of applicants for job to send new test email to : " + err.Error(),
+		}
+	} else {
+
+		jobDetails, err := c.queries.GetJobDetails(ctx, newtestData.BindedJobId)
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to get job details : " + err.Error(),
+			}
+		} else {
+
+			newtestData.JobTitle = jobDetails.Title
+			newtestData.CompanyName = jobDetails.CompanyName
+			newtestData.FormattedEndDate = newtestData.EndDateTime.Format("2006-01-02")
+			newtestData.FormattedEndTime = newtestData.EndDateTime.Format("15:04")
+
+			template, err := utils.DynamicHTML("./template/emails/newTestEmail.html", newtestData)
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to generate template for new test email : " + err.Error(),
+				}
+			} else {
+				// the test window is blocked in the calendar as the last slot it can be taken in
+				invite := utils.ICalendar(utils.ICalRequest, utils.ICalEvent{
+					UID: utils.ICalUID("test", testID),
+					Start: newtestData.EndDateTime.Add(-time.Duration(newtestData.Duration) * time.Minute),
+					End: newtestData.EndDateTime,
+					Summary: fmt.Sprintf("Test : %s (%s - %s)", newtestData.Name, newtestData.CompanyName, newtestData.JobTitle),
+					Description: fmt.Sprintf("%d questions, %d mins. The test closes at %s.", newtestData.QuestionCount, newtestData.Duration, newtestData.EndDateTime.Format("15:04 2006-01-02")),
+				})
+				go utils.SendEmailHTMLWithInvite(template, allEmails, invite, utils.ICalRequest)
+			}
+		}
+	}
+
+	return errf
+}
+
+func (c *CompanyService) NewTestPostGForm(ctx *gin.Context, gformData *dto.NewTestGForms) (string, *errs.Error) {
+
+	// get the raw responders link from the link provided by the user 
+	paramIndex := strings.Index(gformData.ResponderLink, "?")
+	if paramIndex != -1 {
+		gformData.ResponderLink = gformData.ResponderLink[:paramIndex]
+	}
+	// check if we already have the form metadata in the Redis Cache
+	exists, err := c.RedisClient.Exists(ctx, gformData.ResponderLink).Result()
+	// if not
+	if exists == 0 {
+		// call drive change api to get file changes from the last start token
+		newList, err := c.GAPIService.DriveChanges()
+		if err != nil {
+			return "", &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to get GDrive changes : " + err.Error(),
+			}
+		}
+		changes := newList.Changes
+
+		// loop over every changed object
+		for _, change := range changes {
+			// check if it is not null and has a mimetype of apps.form
+			if change.File != nil && change.File.MimeType == "application/vnd.google-apps.form" {
+				// get the metadata from the forms api
+				formData, err := c.GAPIService.GetFormMetadata(change.FileId)
+				if err != nil {
+					return "", &errs.Error{
+						Type: errs.Internal,
+						Message: "Failed to get GForm metadata : " + err.Error(),
+					}
+				}
+				// Set the key:value in the Redis Cache {responderUri : formId}
+				err = c.RedisClient.Set(ctx, formData.ResponderUri, formData.FormId, 0).Err()
+				if err != nil {
+					return "", &errs.Error{
+						Type: errs.Internal,
+						Message: "Failed to insert into redis : " + err.Error(),
+					}
+				}
+
+				// Do this for every form 
+				// We do not consider if we have already found our result
+				// We continue for every updated form to keep Cache up to date
+				// This can be changed later
+				//TODO:
+			}
+		}
+	} else if err != nil {
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get from redis : " + err.Error(),
+		}
+	}
+
+	formID, err := c.RedisClient.Get(ctx, gformData.ResponderLink).Result()
+	if err == redis.Nil {
+		// the result still does not exist
+		// the user has not provided you with the access
+		return "", &errs.Error{
+			Type: errs.IncompleteAction,
+			Message: "The 'Editor Access' has not been shared with the given collaborator email.",
+			ToRespondWith: true,
+		}
+	} else if err != nil {
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get from redis : " + err.Error(),
+		}	
+	}
+	
+	return formID, nil
+}
+
+func (c *CompanyService) ScheduledData(ctx *gin.Context, userID int64, eventtype string) (*dto.Upcoming, *errs.Error) {
+	// switch between event types
+	switch eventtype {
+	case "interviews":
+		uInts, err := c.queries.ScheduledInterviewsCompany(ctx, userID)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		return &dto.Upcoming{
+			Data: uInts,
+		}, nil
+	case "tests":
+		uTests, err := c.queries.ScheduledTestsCompany(ctx, userID)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		return &dto.Upcoming{
+			Data: uTests,
+		}, nil
+	default:
+		return nil, &errs.Error{
+			Type: errs.NotFound,
+			Message: "No such event type exists",
+		}
+	}
+}
+
+
+func (c *CompanyService) CompletedData(ctx *gin.Context, userID int64, eventtype string) (*dto.Completed, *errs.Error) {
+	// switch betweem event type
+	switch eventtype {
+	case "interviews":
+		uInts, err := c.queries.CompletedInterviewsCompany(ctx, userID)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		return &dto.Completed{
+			Data: uInts,
+		}, nil
+	case "tests":
+		uTests, err := c.queries.CompletedTestsCompany(ctx, userID)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		return &dto.Completed{
+			Data: uTests,
+		}, nil
+	default:
+		return nil, &errs.Error{
+			Type: errs.NotFound,
+			Message: "Invalid argument, no such event type exists",
+		}
+	}
+}
+
+
+// UpdateInterview updates a scheduled interview of the company. As when scheduling, an update overlapping other events
+// returns the conflicts unless forced, the override then recorded in the audit log along with the update.
+// The time is not changed here, it is proposed to the student with ProposeReschedule.
+func (c *CompanyService) UpdateInterview(ctx *gin.Context, userID int64, data *dto.UpdateInterview) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	current, err := c.queries.CompanyInterview(ctx, sqlc.CompanyInterviewParams{
+		InterviewID: data.InterviewID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "An interview for the given interview_ID and user ID was not found.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get interview : " + err.Error(),
+		}
+	}
+	if current.Status != reschedule.Scheduled && current.Status != reschedule.Requested {
+		return nil, &errs.Error{
+			Type: errs.InvalidState,
+			Message: fmt.Sprintf("The interview is %s, it cannot be updated anymore.", strings.ToLower(current.Status)),
+			ToRespondWith: true,
+		}
+	}
+	// the time is changed by a reschedule the student accepts, see proposeReschedule
+	if data.DateTime.IsZero() {
+		data.DateTime = current.DateTime.Time
+	}
+	if !data.DateTime.Equal(current.DateTime.Time) {
+		message := "Propose the new time with a reschedule of the interview instead, the student is asked to accept it."
+		if current.Status == reschedule.Requested {
+			message = "A reschedule of the interview is requested, respond to it instead of changing the time."
+		}
+		return nil, &errs.Error{
+			Type: errs.InvalidState,
+			Message: message,
+			ToRespondWith: true,
+		}
+	}
+	if data.DurationMinutes == 0 {
+		data.DurationMinutes = current.DurationMinutes
+	}
+	if data.DurationMinutes < 0 || data.DurationMinutes > config.InterviewMaxDuration {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("Interview duration must be between 1 and %d minutes.", config.InterviewMaxDuration),
+			ToRespondWith: true,
+		}
+	}
+
+	var newData sqlc.UpdateInterviewRow
+	var conflicts []sqlc.InterviewConflictsRow
+	var abort *errs.Error
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		conflicts, abort = interviewConflicts(ctx, queries, current.ApplicationID, current.InterviewID, data.DateTime, data.DurationMinutes, data.Location, data.Panel)
+		if abort != nil {
+			return errInterviewAborted
+		}
+		if len(conflicts) != 0 && !data.Force {
+			abort = conflictError(conflicts)
+			return errInterviewAborted
+		}
+
+		var err error
+		newData, err = queries.UpdateInterview(ctx, sqlc.UpdateInterviewParams{
+			UserID: userID,
+			InterviewID: data.InterviewID,
+			DateTime: pgtype.Timestamptz{Time: data.DateTime, Valid: true},
+			Type: data.Type,
+			Notes: pgtype.Text{String: data.Notes, Valid: true},
+			Location: data.Location,
+			DurationMinutes: data.DurationMinutes,
+			Panel: data.Panel,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update interview details : %v", err)
+		}
+		if len(conflicts) != 0 {
+			err = audit.Record(ctx, queries, userID, audit.InterviewConflictOverridden, audit.EntityInterview, data.InterviewID, &dto.InterviewConflictOverride{
+				ApplicationID: current.ApplicationID,
+				DateTime: data.DateTime,
+				DurationMinutes: data.DurationMinutes,
+				Location: data.Location,
+				Panel: data.Panel,
+				Conflicts: conflicts,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record conflict override in audit log : %v", err)
+			}
+		}
+		return nil
+	})
+	if abort != nil {
+		return conflicts, abort
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to update interview : " + err.Error(),
+		}
+	}
+
+	stdData, err := c.queries.GetScheduleInterviewData(ctx, newData.ApplicationID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get student data for interview-updated email : " + err.Error(),
+		}
+	}
+	data.StudentName = stdData.StudentName
+	data.CompanyName = stdData.CompanyName
+	data.JobTitle = stdData.Title
+	data.DT = newData.DateTime
+
+	// execute email template
+	template, err := utils.DynamicHTML("./template/emails/interviewRescheduled.html", data)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to generate template for interview-updated email : " + err.Error(),
+		}
+	}
+	// same UID with the incremented sequence, so the existing calendar event is updated
+	invite := utils.ICalendar(utils.ICalRequest, interviewEvent(data.InterviewID, newData.IcalSequence, data.DateTime, data.DurationMinutes, data.Type, data.Location, data.Notes, data.CompanyName, data.JobTitle, stdData.StudentEmail))
+	// send new interview email to student
+	go utils.SendEmailHTMLWithInvite(template, []string{stdData.StudentEmail}, invite, utils.ICalRequest)
+
+	return nil, nil
+}
+
+
+
+
+
+func (c *CompanyService) EditCutOff(ctx *gin.Context, userID int64, newData *dto.UpdateTest) (*errs.Error) {
+
+	if newData.Threshold > 99 || newData.Threshold < 1 {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Cutoff threshold must be between 1 and 99.",
+		}
+	}
+
+	published, err := c.queries.IsTestPublished(ctx, newData.TestID)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+	if published {
+		return &errs.Error{
+			Type: errs.CheckViolation,
+			Message: "This test is already published. Cannot edit now.",
+		}
+	}
+
+	// update the new thresholds in the db
+	err = c.queries.UpdateTest(ctx, sqlc.UpdateTestParams{
+		TestID: newData.TestID,
+		UserID: userID,
+		Threshold: pgtype.Int4{Int32: int32(newData.Threshold), Valid: true},
+		Published: pgtype.Bool{Valid: false},
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	// call utils to generate the cumulative result draft
+	_, err = utils.GenerateTestResultDraft(c.queries, c.GAPIService, newData.TestID)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	// all ok
+	return nil
+}
+
+
+func (c *CompanyService) PublishTestResults(ctx *gin.Context, userID int64, testid string) (*errs.Error) {
+
+	testID, err := strconv.ParseInt(testid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: fmt.Sprintf("unable to parse test id : %v", err),
+		}
+	}
+
+	_, err = c.queries.TestAuthorization(ctx, sqlc.TestAuthorizationParams{
+		UserID: userID,
+		TestID: testID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "You are not authorized to operate on this Test. This test belongs to a different user. Or the test does not exist.",
+			}
+		}
+	}
+
+	published, err := c.queries.IsTestPublished(ctx, testID)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+	if published {
+		return &errs.Error{
+			Type: errs.CheckViolation,
+			Message: "This test is already published. Cannot publish again.",
+		}
+	}
+
+	go func() {
+		err = utils.PublishTestResults(c.queries, c.GAPIService, testID)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			err := c.queries.UpdateTest(ctx, sqlc.UpdateTestParams{
+				TestID: testID,
+				UserID: userID,
+				Threshold: pgtype.Int4{Valid: false},
+				Published: pgtype.Bool{Bool: true, Valid: true},
+			})
+			if err != nil {
+				fmt.Println(err)
+			}
+			fmt.Printf("Test results for %d have been published.", testID)
+		}
+	} ()
+
+	return nil
+}
+
+
+func (s *CompanyService) ProfileData(ctx *gin.Context, userID int64) (*dto.CompanyProfileData, *errs.Error) {
+	
+
+	// alljobIds, err := s.queries.GetAllJobsID(ctx, userID)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+
+	overData, err := s.queries.ApplicantsCount(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+	// jobs with a pipeline are charted through its stages, the rest by status
+	flows, err := s.queries.CompanyPipelineFlows(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+	pipelines := make(map[int64][]sqlc.CompanyPipelineFlowsRow)
+	for _, f := range flows {
+		pipelines[f.JobID] = append(pipelines[f.JobID], f)
+	}
+
+	var sankeyCharts []*charts.Sankey
+	for _, o := range overData {
+		if stages, exists := pipelines[o.JobID]; exists {
+			sankeyCharts = append(sankeyCharts, gocharts.SankeyPipeline(o.JobID, stages))
+			continue
+		}
+		sankeyChrt := gocharts.SankeyApplicants(&o)
+		sankeyCharts = append(sankeyCharts, sankeyChrt)
+	}
+
+
+	// total jobs posted >  offered
+	
+	userData, err := s.queries.UsersTableData(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}	}
+
+	data, err := s.queries.CompanyProfileData(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}	}
+
+	// appsHistory, err := s.queries.ApplicationHistory(ctx, userID)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	// intsHistory, err := s.queries.InterviewHistory(ctx, userID)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	// testHistory, err := s.queries.TestHistory(ctx, userID)
+	// if err != nil {
+	// 	return nil, err
+	// }
+
+	// let us try to send a 'Sankey' type graph
+	// sankeyChrt := gocharts.SankeyApplicants(&overData)
+	
+	return &dto.CompanyProfileData{
+		UsersData: &userData,
+		ProData: &data,
+		SankeyChrt: sankeyCharts,
+	}, nil
+}
+
+func (s *CompanyService) GetCompanyFile(ctx *gin.Context, userID int64, fileType string) (string, *errs.Error) {
+	// get paths to all available files
+	filePaths, err := s.queries.GetAllFilePathsCompany(ctx, userID)
+	if err != nil {
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "failed to fetch file paths for user ID",
+		}
+	}
+
+	// check what type is requested
+	filepath := filePaths.PictureUrl.String
+	// TODO: returns profile file even if requested file type is invalid
+	// add switches if other files types are requested
+
+    // Check if the file exists
+    if _, err := os.Stat(filepath); err != nil {
+        return "", &errs.Error{
+			Type: errs.NotFound,
+			Message: "could not find any file for given path",
+		}
+    }
+	// return the requested file's path
+	return filepath, nil
+}
+func (s *CompanyService) UpdateProfileDetails(ctx *gin.Context, userID int64, details *dto.UpdateCompanyDetails) (*errs.Error) {
+	// TODO: verify incoming data
+	err := s.queries.UpdateCompanyDetails(ctx, sqlc.UpdateCompanyDetailsParams{
+		CompanyName: details.CompanyName,
+		RepresentativeEmail: details.RepresentativeEmail,
+		RepresentativeContact: details.RepresentativeContact,
+		RepresentativeName: details.RepresentativeName,
+		Address: details.CompanyAddress,
+		Website: pgtype.Text{String: details.CompanyWebsite, Valid: true},
+		Description: pgtype.Text{String: details.CompanyDescription, Valid: true},
+		Industry: details.IndustryType,
+		UserID: userID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func (s *CompanyService) UpdateFile(ctx *gin.Context, userID int64, file *multipart.FileHeader, fileType string) (*errs.Error) {
+	var err error
+	// get the file size and content-type
+	size := file.Size
+	ext := file.Header.Get("Content-Type")
+	// get the expected size for the content type 
+	expected := config.FileSizeForContentType[ext] 
+	if (expected == 0) {
+		// invalid file content type
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Invalid file type.",
+		}
+	}
+	if (expected < size) {
+		// file size more than expected
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "The file size exceeds the limit.",
+		}
+	}
+
+	userUUID, err := s.queries.GetUserUUIDFromUserID(ctx, userID)
+	if err != nil {
+		return nil
+	}
+	strUUID := hex.EncodeToString(userUUID.Bytes[:])
+
+	nameType := strings.ToLower(fileType)
+	storageDir := fmt.Sprintf("%sStorageDir", fileType)
+
+	fileStoragePath := fmt.Sprintf("%s%s&%d&%s%s", os.Getenv(storageDir), strUUID, time.Now().Unix(), nameType, filepath.Ext(file.Filename))
+	fileSavePath, err := utils.SaveFile(ctx, fileStoragePath, file)
+	if err != nil {
+		return nil
+	}
+
+	switch fileType {
+	case "ProfilePic":
+		err = s.queries.UpdateCompanyProfilePic(ctx, sqlc.UpdateCompanyProfilePicParams{
+			PictureUrl: pgtype.Text{String: fileSavePath, Valid: true},
+			UserID: userID,
+		})
+	default:
+		return &errs.Error{
+			Type: errs.NotFound,
+			Message: "No such file type found. Use valid file type in url.",
+		}
 	}
-
-	allEmails, err := c.queries.GetAllApplicantsEmailsForJob(ctx, newtestData.BindedJobId)
 	if err != nil {
 		return &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to get all emails of applicants for job to send new test email to : " + err.Error(),
-		}
-	} else {
-
-		jobDetails, err := c.queries.GetJobDetails(ctx, newtestData.BindedJobId)
-		if err != nil {
-			return &errs.Error{
-				Type: errs.Internal,
-				Message: "Failed to get job details : " + err.Error(),
-			}
-		} else {
-
-			newtestData.JobTitle = jobDetails.Title
-			newtestData.CompanyName = jobDetails.CompanyName
-			newtestData.FormattedEndDate = newtestData.EndDateTime.Format("2006-01-02")
-			newtestData.FormattedEndTime = newtestData.EndDateTime.Format("15:04")
-
-			template, err := utils.DynamicHTML("./template/emails/newTestEmail.html", newtestData)
-			if err != nil {
-				return &errs.Error{
-					Type: errs.Internal,
-					Message: "Failed to generate template for new test email : " + err.Error(),
-				}
-			} else {
-				go utils.SendEmailHTML(template, allEmails)
-			}
+			Message: err.Error(),
 		}
 	}
 
-	return errf
+	return nil
 }
 
-func (c *CompanyService) NewTestPostGForm(ctx *gin.Context, gformData *dto.NewTestGForms) (string, *errs.Error) {
 
-	// get the raw responders link from the link provided by the user 
-	paramIndex := strings.Index(gformData.ResponderLink, "?")
-	if paramIndex != -1 {
-		gformData.ResponderLink = gformData.ResponderLink[:paramIndex]
-	}
-	// check if we already have the form metadata in the Redis Cache
-	exists, err := c.RedisClient.Exists(ctx, gformData.ResponderLink).Result()
-	// if not
-	if exists == 0 {
-		// call drive change api to get file changes from the last start token
-		newList, err := c.GAPIService.DriveChanges()
-		if err != nil {
-			return "", &errs.Error{
-				Type: errs.Internal,
-				Message: "Failed to get GDrive changes : " + err.Error(),
-			}
-		}
-		changes := newList.Changes
 
-		// loop over every changed object
-		for _, change := range changes {
-			// check if it is not null and has a mimetype of apps.form
-			if change.File != nil && change.File.MimeType == "application/vnd.google-apps.form" {
-				// get the metadata from the forms api
-				formData, err := c.GAPIService.GetFormMetadata(change.FileId)
-				if err != nil {
-					return "", &errs.Error{
-						Type: errs.Internal,
-						Message: "Failed to get GForm metadata : " + err.Error(),
-					}
-				}
-				// Set the key:value in the Redis Cache {responderUri : formId}
-				err = c.RedisClient.Set(ctx, formData.ResponderUri, formData.FormId, 0).Err()
-				if err != nil {
-					return "", &errs.Error{
-						Type: errs.Internal,
-						Message: "Failed to insert into redis : " + err.Error(),
-					}
-				}
 
-				// Do this for every form 
-				// We do not consider if we have already found our result
-				// We continue for every updated form to keep Cache up to date
-				// This can be changed later
-				//TODO:
-			}
-		}
-	} else if err != nil {
-		return "", &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get from redis : " + err.Error(),
-		}
-	}
 
-	formID, err := c.RedisClient.Get(ctx, gformData.ResponderLink).Result()
-	if err == redis.Nil {
-		// the result still does not exist
-		// the user has not provided you with the access
-		return "", &errs.Error{
-			Type: errs.IncompleteAction,
-			Message: "The 'Editor Access' has not been shared with the given collaborator email.",
-			ToRespondWith: true,
-		}
-	} else if err != nil {
-		return "", &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get from redis : " + err.Error(),
-		}	
-	}
-	
-	return formID, nil
-}
 
-func (c *CompanyService) ScheduledData(ctx *gin.Context, userID int64, eventtype string) (*dto.Upcoming, *errs.Error) {
-	// switch between event types
-	switch eventtype {
-	case "interviews":
-		uInts, err := c.queries.ScheduledInterviewsCompany(ctx, userID)
+
+func (s *CompanyService) FeedbacksData(ctx *gin.Context, userID int64, tab string) (any, *errs.Error) {
+
+	switch tab {
+	case "tostudents":
+		data, err := s.queries.FeedbacksDataForAndByCompany(ctx, userID)
 		if err != nil {
 			return nil, &errs.Error{
 				Type: errs.Internal,
 				Message: err.Error(),
 			}
 		}
-		return &dto.Upcoming{
-			Data: uInts,
-		}, nil
-	case "tests":
-		uTests, err := c.queries.ScheduledTestsCompany(ctx, userID)
+		return &data, nil
+	case "bystudents":
+		data, err := s.queries.FeedbacksDataForAndToCompany(ctx, userID)
 		if err != nil {
 			return nil, &errs.Error{
 				Type: errs.Internal,
 				Message: err.Error(),
 			}
 		}
-		return &dto.Upcoming{
-			Data: uTests,
-		}, nil
+		return &data, nil
 	default:
 		return nil, &errs.Error{
-			Type: errs.NotFound,
-			Message: "No such event type exists",
+			Type: errs.MissingRequiredField,
+			Message: "No such field as given in url parameter.",
+			ToRespondWith: true,
 		}
 	}
 }
 
 
-func (c *CompanyService) CompletedData(ctx *gin.Context, userID int64, eventtype string) (*dto.Completed, *errs.Error) {
-	// switch betweem event type
-	switch eventtype {
-	case "interviews":
-		uInts, err := c.queries.CompletedInterviewsCompany(ctx, userID)
-		if err != nil {
-			return nil, &errs.Error{
-				Type: errs.Internal,
-				Message: err.Error(),
-			}
+
+
+func (s *CompanyService) Feedback(ctx *gin.Context, userID int64, data *dto.Feedback) *errs.Error {
+
+	err := s.queries.InsertFeedbackByCompany(ctx, sqlc.InsertFeedbackByCompanyParams{
+		ApplicationID: pgtype.Int8{Int64: data.ApplicationID, Valid: data.ApplicationID != 0},
+		InterviewID: pgtype.Int8{Int64: data.InterviewID, Valid: data.InterviewID != 0},
+		UserID: userID,
+		Message: pgtype.Text{String: data.Message, Valid: true},
+
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to insert new feedback : " + err.Error(),
 		}
-		return &dto.Completed{
-			Data: uInts,
-		}, nil
-	case "tests":
-		uTests, err := c.queries.CompletedTestsCompany(ctx, userID)
-		if err != nil {
-			return nil, &errs.Error{
-				Type: errs.Internal,
-				Message: err.Error(),
-			}
+	}
+
+	return nil
+}
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+
+func (c *CompanyService) StudentProfileData(ctx *gin.Context, userID int64, studentid string) (*sqlc.StudentProfileForCompanyRow, *errs.Error) {
+
+	studentID, err := strconv.ParseInt(studentid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
 		}
-		return &dto.Completed{
-			Data: uTests,
-		}, nil
-	default:
+	}
+
+	data, err := c.queries.StudentProfileForCompany(ctx, sqlc.StudentProfileForCompanyParams{
+		UserID: userID,
+		StudentID: studentID,
+	})
+	if err != nil {
 		return nil, &errs.Error{
-			Type: errs.NotFound,
-			Message: "Invalid argument, no such event type exists",
+			Type: errs.Internal,
+			Message: err.Error(),
 		}
 	}
-}
 
+	return &data, nil
+}
 
-func (c *CompanyService) UpdateInterview(ctx *gin.Context, userID int64, data *dto.UpdateInterview) (*errs.Error) {
+// SetJobEligibility replaces the eligibility rules of a job of the company of the user.
+// A change of the rules is recorded as a material revision of the job in the same transaction,
+// resubmitting an approved job for review as any edit of a reviewed field, see UpdateJob.
+func (c *CompanyService) SetJobEligibility(ctx *gin.Context, data *dto.JobEligibility, userID int64) *errs.Error {
 
-	newData, err := c.queries.UpdateInterview(ctx, sqlc.UpdateInterviewParams{
+	job, err := c.queries.GetJobForUpdate(ctx, sqlc.GetJobForUpdateParams{
+		JobID: data.JobId,
 		UserID: userID,
-		InterviewID: data.InterviewID,
-		DateTime: pgtype.Timestamptz{Time: data.DateTime, Valid: true},
-		Type: data.Type,
-		Notes: pgtype.Text{String: data.Notes, Valid: true},
-		Location: data.Location,
 	})
 	if err != nil {
 		if err.Error() == errs.NoRowsMatch {
 			return &errs.Error{
 				Type: errs.Unauthorized,
-				Message: "An interview for the given interview_ID and user ID was not found.",
+				Message: "You are not allowed to alter this job, or it does not exist.",
 				ToRespondWith: true,
 			}
 		}
 		return &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to update interview details : " + err.Error(),
+			Message: "Failed to get job : " + err.Error(),
+		}
+	}
+
+	rules := &eligibility.Rules{
+		MinCGPA: data.MinCGPA,
+		Courses: data.Courses,
+		Departments: data.Departments,
+		YearsOfStudy: data.YearsOfStudy,
+		MaxBacklogs: data.MaxBacklogs,
+		Custom: data.Custom,
+	}
+	errf := validEligibility(rules)
+	if errf != nil {
+		return errf
+	}
+
+	previous, errf := c.jobEligibility(ctx, data.JobId)
+	if errf != nil {
+		return errf
+	}
+
+	params, err := eligibilityParams(data.JobId, rules)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	changed := !reflect.DeepEqual(previous, rules)
+	var material []string
+	var resubmitted int64
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		err := queries.UpsertJobEligibility(ctx, params)
+		if err != nil || !changed {
+			return err
+		}
+
+		revision, err := queries.BumpJobRevision(ctx, sqlc.BumpJobRevisionParams{
+			JobID: data.JobId,
+			UserID: userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to bump job revision : %v", err)
+		}
+
+		material, err = recordJobRevision(ctx, queries, data.JobId, revision, userID, map[string]dto.JobChange{
+			"Eligibility": {From: previous, To: rules},
+		})
+		if err != nil {
+			return err
+		}
+
+		resubmitted, err = queries.ResubmitApprovedJob(ctx, data.JobId)
+		if err != nil {
+			return fmt.Errorf("failed to resubmit job for review : %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to save eligibility rules : " + err.Error(),
 		}
 	}
 
-	stdData, err := c.queries.GetScheduleInterviewData(ctx, newData.ApplicationID)
+	c.notifyJobEdited(ctx, data.JobId, job.Title, userID, material, resubmitted != 0)
+
+	return nil
+}
+
+// validEligibility fills in the empty lists of the rules and validates them, the error is meant for the user
+func validEligibility(rules *eligibility.Rules) *errs.Error {
+
+	rules.Courses = nonNil(rules.Courses)
+	rules.Departments = nonNil(rules.Departments)
+	rules.YearsOfStudy = nonNil(rules.YearsOfStudy)
+	if rules.Custom == nil {
+		rules.Custom = []eligibility.Constraint{}
+	}
+
+	err := rules.Validate()
 	if err != nil {
 		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to get student data for interview-updated email : " + err.Error(),
+			Type: errs.InvalidFormat,
+			Message: "Invalid eligibility rules : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
-	data.StudentName = stdData.StudentName
-	data.CompanyName = stdData.CompanyName
-	data.JobTitle = stdData.Title
-	data.DT = newData.DateTime
 
-	// execute email template
-	template, err := utils.DynamicHTML("./template/emails/interviewRescheduled.html", data)
+	return nil
+}
+
+// importedEligibility reads the eligibility rules of an imported job given as a JSON object, see eligibility.Rules
+func importedEligibility(raw string) (*eligibility.Rules, *errs.Error) {
+
+	rules := &eligibility.Rules{}
+	err := json.Unmarshal([]byte(raw), rules)
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: "Failed to generate template for interview-updated email : " + err.Error(),
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Job eligibility must be a JSON object : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
-	// send new interview email to student
-	go utils.SendEmailHTML(template, []string{stdData.StudentEmail})
 
-	return nil
+	errf := validEligibility(rules)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return rules, nil
 }
 
+// eligibilityParams are the job_eligibility columns of the rules of a job
+func eligibilityParams(jobID int64, rules *eligibility.Rules) (sqlc.UpsertJobEligibilityParams, error) {
 
+	customJson, err := json.Marshal(rules.Custom)
+	if err != nil {
+		return sqlc.UpsertJobEligibilityParams{}, fmt.Errorf("failed to marshal custom eligibility constraints : %v", err)
+	}
 
+	maxBacklogs := pgtype.Int4{}
+	if rules.MaxBacklogs != nil {
+		maxBacklogs = pgtype.Int4{Int32: *rules.MaxBacklogs, Valid: true}
+	}
 
+	return sqlc.UpsertJobEligibilityParams{
+		JobID: jobID,
+		MinCgpa: pgtype.Float8{Float64: rules.MinCGPA, Valid: rules.MinCGPA != 0},
+		Courses: rules.Courses,
+		Departments: rules.Departments,
+		YearsOfStudy: rules.YearsOfStudy,
+		MaxBacklogs: maxBacklogs,
+		Custom: customJson,
+	}, nil
+}
 
-func (c *CompanyService) EditCutOff(ctx *gin.Context, userID int64, newData *dto.UpdateTest) (*errs.Error) {
+// JobEligibility returns the eligibility rules of a job of the company of the user.
+func (c *CompanyService) JobEligibility(ctx *gin.Context, jobid string, userID int64) (*eligibility.Rules, *errs.Error) {
 
-	if newData.Threshold > 99 || newData.Threshold < 1 {
-		return &errs.Error{
-			Type: errs.PreconditionFailed,
-			Message: "Cutoff threshold must be between 1 and 99.",
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
 
-	published, err := c.queries.IsTestPublished(ctx, newData.TestID)
+	_, err = c.queries.GetJobForUpdate(ctx, sqlc.GetJobForUpdateParams{
+		JobID: jobID,
+		UserID: userID,
+	})
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: err.Error(),
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "You are not allowed to view this job, or it does not exist.",
+				ToRespondWith: true,
+			}
 		}
-	}
-	if published {
-		return &errs.Error{
-			Type: errs.CheckViolation,
-			Message: "This test is already published. Cannot edit now.",
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job : " + err.Error(),
 		}
 	}
 
-	// update the new thresholds in the db
-	err = c.queries.UpdateTest(ctx, sqlc.UpdateTestParams{
-		TestID: newData.TestID,
-		UserID: userID,
-		Threshold: pgtype.Int4{Int32: int32(newData.Threshold), Valid: true},
-		Published: pgtype.Bool{Valid: false},
-	})
+	return c.jobEligibility(ctx, jobID)
+}
+
+// jobEligibility returns the eligibility rules of a job, empty (not nil) if it has none
+func (c *CompanyService) jobEligibility(ctx *gin.Context, jobID int64) (*eligibility.Rules, *errs.Error) {
+
+	row, err := c.queries.GetJobEligibility(ctx, jobID)
 	if err != nil {
-		return &errs.Error{
+		if err.Error() == errs.NoRowsMatch {
+			return &eligibility.Rules{
+				Courses: []string{},
+				Departments: []string{},
+				YearsOfStudy: []string{},
+				Custom: []eligibility.Constraint{},
+			}, nil
+		}
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
+			Message: "Failed to get eligibility rules : " + err.Error(),
 		}
 	}
 
-	// call utils to generate the cumulative result draft
-	_, err = utils.GenerateTestResultDraft(c.queries, c.GAPIService, newData.TestID)
+	rules, err := eligibility.FromColumns(row.MinCgpa, row.Courses, row.Departments, row.YearsOfStudy, row.MaxBacklogs, row.Custom)
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
 			Message: err.Error(),
 		}
 	}
 
-	// all ok
-	return nil
+	return rules, nil
+}
+
+// nonNil returns an empty slice for nil, so the rules compare and store the same either way
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
 }
 
+// optionalTime returns nil for a NULL timestamp, so it is serialised as null instead of the zero time
+func optionalTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
 
-func (c *CompanyService) PublishTestResults(ctx *gin.Context, userID int64, testid string) (*errs.Error) {
+// compensationFromRow is the structured compensation stored for a job
+func compensationFromRow(row *sqlc.JobCompensation) *compensation.Compensation {
+	return &compensation.Compensation{
+		Currency: row.Currency,
+		CTCMin: row.CtcMin,
+		CTCMax: row.CtcMax,
+		Fixed: row.FixedPay,
+		Variable: row.VariablePay,
+		StipendMonthly: row.StipendMonthly,
+		BondMonths: row.BondMonths,
+		BondTerms: row.BondTerms,
+	}
+}
 
-	testID, err := strconv.ParseInt(testid, 10, 64)
+// compensationParams are the params to store the structured compensation of a job
+func compensationParams(jobID int64, comp *compensation.Compensation, parsed bool) sqlc.UpsertJobCompensationParams {
+	return sqlc.UpsertJobCompensationParams{
+		JobID: jobID,
+		Currency: comp.Currency,
+		CtcMin: comp.CTCMin,
+		CtcMax: comp.CTCMax,
+		FixedPay: comp.Fixed,
+		VariablePay: comp.Variable,
+		StipendMonthly: comp.StipendMonthly,
+		BondMonths: comp.BondMonths,
+		BondTerms: comp.BondTerms,
+		Parsed: parsed,
+	}
+}
+
+// ApplicationTimeline returns the status history of an application to a job of the company, see applicationTimeline.
+func (c *CompanyService) ApplicationTimeline(ctx *gin.Context, userID int64, applicationid string) (*dto.ApplicationTimeline, *errs.Error) {
+
+	applicationID, err := strconv.ParseInt(applicationid, 10, 64)
 	if err != nil {
-		return &errs.Error{
-			Type: errs.Internal,
-			Message: fmt.Sprintf("unable to parse test id : %v", err),
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
 
-	_, err = c.queries.TestAuthorization(ctx, sqlc.TestAuthorizationParams{
+	return applicationTimeline(ctx, c.queries, applicationID, userID, appstatus.ActorCompany)
+}
+
+// ownJob checks the job is of the company of the user, returns its title
+func (c *CompanyService) ownJob(ctx *gin.Context, jobID int64, userID int64) (string, *errs.Error) {
+
+	job, err := c.queries.GetJobForUpdate(ctx, sqlc.GetJobForUpdateParams{
+		JobID: jobID,
 		UserID: userID,
-		TestID: testID,
 	})
 	if err != nil {
 		if err.Error() == errs.NoRowsMatch {
-			return &errs.Error{
+			return "", &errs.Error{
 				Type: errs.Unauthorized,
-				Message: "You are not authorized to operate on this Test. This test belongs to a different user. Or the test does not exist.",
+				Message: "You are not allowed to access this job, or it does not exist.",
+				ToRespondWith: true,
 			}
 		}
-	}
-
-	published, err := c.queries.IsTestPublished(ctx, testID)
-	if err != nil {
-		return &errs.Error{
+		return "", &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
-		}
-	}
-	if published {
-		return &errs.Error{
-			Type: errs.CheckViolation,
-			Message: "This test is already published. Cannot publish again.",
+			Message: "Failed to get job : " + err.Error(),
 		}
 	}
 
-	go func() {
-		err = utils.PublishTestResults(c.queries, c.GAPIService, testID)
-		if err != nil {
-			fmt.Println(err)
-		} else {
-			err := c.queries.UpdateTest(ctx, sqlc.UpdateTestParams{
-				TestID: testID,
-				UserID: userID,
-				Threshold: pgtype.Int4{Valid: false},
-				Published: pgtype.Bool{Bool: true, Valid: true},
-			})
-			if err != nil {
-				fmt.Println(err)
-			}
-			fmt.Printf("Test results for %d have been published.", testID)
-		}
-	} ()
-
-	return nil
+	return job.Title, nil
 }
 
+// JobPipeline returns the stages of the hiring pipeline of a job of the company, none if it has no pipeline
+func (c *CompanyService) JobPipeline(ctx *gin.Context, jobid string, userID int64) ([]pipeline.Stage, *errs.Error) {
 
-func (s *CompanyService) ProfileData(ctx *gin.Context, userID int64) (*dto.CompanyProfileData, *errs.Error) {
-	
-
-	// alljobIds, err := s.queries.GetAllJobsID(ctx, userID)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-
-	overData, err := s.queries.ApplicantsCount(ctx, userID)
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
 	if err != nil {
 		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: err.Error(),
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
 		}
 	}
-	var sankeyCharts []*charts.Sankey
-	for _, o := range overData {
-		sankeyChrt := gocharts.SankeyApplicants(&o)
-		sankeyCharts = append(sankeyCharts, sankeyChrt)
+
+	_, errf := c.ownJob(ctx, jobID, userID)
+	if errf != nil {
+		return nil, errf
 	}
 
+	return jobPipeline(ctx, c.queries, jobID)
+}
 
-	// total jobs posted >  offered
-	
-	userData, err := s.queries.UsersTableData(ctx, userID)
-	if err != nil {
-		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: err.Error(),
-		}	}
+// SetJobPipeline sets the hiring pipeline of a job of the company of the user, see dto.JobPipeline. Stages with
+// applicants still in the process cannot be removed, they are to be moved to another stage first.
+// Returns the stages as set.
+func (c *CompanyService) SetJobPipeline(ctx *gin.Context, userID int64, data *dto.JobPipeline) ([]pipeline.Stage, *errs.Error) {
 
-	data, err := s.queries.CompanyProfileData(ctx, userID)
+	_, errf := c.ownJob(ctx, data.JobID, userID)
+	if errf != nil {
+		return nil, errf
+	}
+
+	err := pipeline.Validate(data.Stages)
 	if err != nil {
 		return nil, &errs.Error{
-			Type: errs.Internal,
-			Message: err.Error(),
-		}	}
-
-	// appsHistory, err := s.queries.ApplicationHistory(ctx, userID)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// intsHistory, err := s.queries.InterviewHistory(ctx, userID)
-	// if err != nil {
-	// 	return nil, err
-	// }
+			Type: errs.InvalidFormat,
+			Message: "Invalid pipeline, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
 
-	// testHistory, err := s.queries.TestHistory(ctx, userID)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	current, errf := jobPipeline(ctx, c.queries, data.JobID)
+	if errf != nil {
+		return nil, errf
+	}
+	names := make(map[int64]string)
+	for _, stage := range current {
+		names[stage.StageID] = stage.Name
+	}
 
-	// let us try to send a 'Sankey' type graph
-	// sankeyChrt := gocharts.SankeyApplicants(&overData)
-	
-	return &dto.CompanyProfileData{
-		UsersData: &userData,
-		ProData: &data,
-		SankeyChrt: sankeyCharts,
-	}, nil
-}
+	kept := []int64{}
+	for _, stage := range data.Stages {
+		if stage.StageID != 0 {
+			if _, exists := names[stage.StageID]; !exists {
+				return nil, &errs.Error{
+					Type: errs.InvalidFormat,
+					Message: fmt.Sprintf("Stage ID %d is not a stage of the job.", stage.StageID),
+					ToRespondWith: true,
+				}
+			}
+			kept = append(kept, stage.StageID)
+		}
+		if stage.Kind == pipeline.KindTest {
+			exists, err := c.queries.JobTestExists(ctx, sqlc.JobTestExistsParams{
+				TestID: stage.TestID,
+				JobID: data.JobID,
+			})
+			if err != nil {
+				return nil, &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to get test : " + err.Error(),
+				}
+			}
+			if !exists {
+				return nil, &errs.Error{
+					Type: errs.InvalidFormat,
+					Message: fmt.Sprintf("Test %d of stage %s is not a test of the job.", stage.TestID, stage.Name),
+					ToRespondWith: true,
+				}
+			}
+		}
+	}
 
-func (s *CompanyService) GetCompanyFile(ctx *gin.Context, userID int64, fileType string) (string, *errs.Error) {
-	// get paths to all available files
-	filePaths, err := s.queries.GetAllFilePathsCompany(ctx, userID)
+	counts, err := c.queries.PipelineStageApplicants(ctx, data.JobID)
 	if err != nil {
-		return "", &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "failed to fetch file paths for user ID",
+			Message: "Failed to get applicants of the pipeline : " + err.Error(),
+		}
+	}
+	for _, count := range counts {
+		removed := true
+		for _, id := range kept {
+			removed = removed && id != count.StageID.Int64
+		}
+		if removed {
+			return nil, &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: fmt.Sprintf("Stage %s has %d applicant(s) in the process, move them to another stage before removing it.", names[count.StageID.Int64], count.Applicants),
+				ToRespondWith: true,
+			}
 		}
 	}
 
-	// check what type is requested
-	filepath := filePaths.PictureUrl.String
-	// TODO: returns profile file even if requested file type is invalid
-	// add switches if other files types are requested
-
-    // Check if the file exists
-    if _, err := os.Stat(filepath); err != nil {
-        return "", &errs.Error{
-			Type: errs.NotFound,
-			Message: "could not find any file for given path",
+	// positions are unique per job once committed, stages can swap places in between
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		err := queries.DeletePipelineStages(ctx, sqlc.DeletePipelineStagesParams{
+			JobID: data.JobID,
+			KeepStageIds: kept,
+		})
+		if err != nil {
+			return err
+		}
+		for _, stage := range data.Stages {
+			testID := pgtype.Int8{Int64: stage.TestID, Valid: stage.TestID != 0}
+			if stage.StageID != 0 {
+				_, err = queries.UpdatePipelineStage(ctx, sqlc.UpdatePipelineStageParams{
+					Position: stage.Position,
+					Name: stage.Name,
+					Kind: stage.Kind,
+					TestID: testID,
+					StageID: stage.StageID,
+					JobID: data.JobID,
+				})
+			} else {
+				_, err = queries.InsertPipelineStage(ctx, sqlc.InsertPipelineStageParams{
+					JobID: data.JobID,
+					Position: stage.Position,
+					Name: stage.Name,
+					Kind: stage.Kind,
+					TestID: testID,
+				})
+			}
+			if err != nil {
+				return err
+			}
 		}
-    }
-	// return the requested file's path
-	return filepath, nil
-}
-func (s *CompanyService) UpdateProfileDetails(ctx *gin.Context, userID int64, details *dto.UpdateCompanyDetails) (*errs.Error) {
-	// TODO: verify incoming data
-	err := s.queries.UpdateCompanyDetails(ctx, sqlc.UpdateCompanyDetailsParams{
-		CompanyName: details.CompanyName,
-		RepresentativeEmail: details.RepresentativeEmail,
-		RepresentativeContact: details.RepresentativeContact,
-		RepresentativeName: details.RepresentativeName,
-		Address: details.CompanyAddress,
-		Website: pgtype.Text{String: details.CompanyWebsite, Valid: true},
-		Description: pgtype.Text{String: details.CompanyDescription, Valid: true},
-		Industry: details.IndustryType,
-		UserID: userID,
+		return nil
 	})
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
+			Message: "Failed to set job pipeline : " + err.Error(),
 		}
 	}
 
-	return nil
+	return jobPipeline(ctx, c.queries, data.JobID)
 }
 
-func (s *CompanyService) UpdateFile(ctx *gin.Context, userID int64, file *multipart.FileHeader, fileType string) (*errs.Error) {
-	var err error
-	// get the file size and content-type
-	size := file.Size
-	ext := file.Header.Get("Content-Type")
-	// get the expected size for the content type 
-	expected := config.FileSizeForContentType[ext] 
-	if (expected == 0) {
-		// invalid file content type
-		return &errs.Error{
-			Type: errs.PreconditionFailed,
-			Message: "Invalid file type.",
-		}
-	}
-	if (expected < size) {
-		// file size more than expected
-		return &errs.Error{
-			Type: errs.PreconditionFailed,
-			Message: "The file size exceeds the limit.",
-		}
-	}
+// PipelineBoard returns the pipeline of a job of the company with its applicants in their stages, see dto.PipelineBoard
+func (c *CompanyService) PipelineBoard(ctx *gin.Context, jobid string, userID int64) (*dto.PipelineBoard, *errs.Error) {
 
-	userUUID, err := s.queries.GetUserUUIDFromUserID(ctx, userID)
+	jobID, err := strconv.ParseInt(jobid, 10, 64)
 	if err != nil {
-		return nil
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
 	}
-	strUUID := hex.EncodeToString(userUUID.Bytes[:])
 
-	nameType := strings.ToLower(fileType)
-	storageDir := fmt.Sprintf("%sStorageDir", fileType)
-
-	fileStoragePath := fmt.Sprintf("%s%s&%d&%s%s", os.Getenv(storageDir), strUUID, time.Now().Unix(), nameType, filepath.Ext(file.Filename))
-	fileSavePath, err := utils.SaveFile(ctx, fileStoragePath, file)
-	if err != nil {
-		return nil
+	title, errf := c.ownJob(ctx, jobID, userID)
+	if errf != nil {
+		return nil, errf
 	}
 
-	switch fileType {
-	case "ProfilePic":
-		err = s.queries.UpdateCompanyProfilePic(ctx, sqlc.UpdateCompanyProfilePicParams{
-			PictureUrl: pgtype.Text{String: fileSavePath, Valid: true},
-			UserID: userID,
-		})
-	default:
-		return &errs.Error{
+	stages, errf := jobPipeline(ctx, c.queries, jobID)
+	if errf != nil {
+		return nil, errf
+	}
+	if len(stages) == 0 {
+		return nil, &errs.Error{
 			Type: errs.NotFound,
-			Message: "No such file type found. Use valid file type in url.",
+			Message: "The job has no pipeline, set its stages first.",
+			ToRespondWith: true,
 		}
 	}
+
+	rows, err := c.queries.PipelineBoard(ctx, jobID)
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
+			Message: "Failed to get applicants of the pipeline : " + err.Error(),
 		}
 	}
 
-	return nil
-}
-
-
-
-
+	board := &dto.PipelineBoard{
+		JobID: jobID,
+		Title: title,
+		Stages: make([]dto.PipelineColumn, len(stages)),
+	}
+	for i, stage := range stages {
+		board.Stages[i] = dto.PipelineColumn{
+			Stage: stage,
+			Applicants: []dto.PipelineCard{},
+			Left: []dto.PipelineCard{},
+		}
+	}
+	for _, row := range rows {
+		card := dto.PipelineCard{
+			ApplicationID: row.ApplicationID,
+			StudentUserID: row.StudentUserID,
+			StudentName: row.StudentName,
+			RollNumber: row.RollNumber,
+			Status: row.Status,
+			InStageSince: row.InStageSince.Time,
+			InterviewStatus: row.InterviewStatus,
+			InterviewAt: optionalTime(row.InterviewAt),
+		}
+		if row.Score.Valid {
+			card.Score = &row.Score.Int64
+		}
+
+		column := &board.Stages[stageOf(stages, row.StageID)]
+		if row.Status == appstatus.Rejected || row.Status == appstatus.Withdrawn || row.Status == appstatus.Declined {
+			column.Left = append(column.Left, card)
+		} else {
+			column.Applicants = append(column.Applicants, card)
+		}
+	}
 
+	return board, nil
+}
 
+// MoveStage moves applications to the jobs of the company of the user to a stage of the pipeline of their job in one
+// transaction, shortlisting them if they move past the first stage, see pipeline.StatusOnMove. Those that cannot be
+// moved, eg. rejected, are skipped and reported, unless strict in which case none are moved.
+// Moving out of Interview completes the interviews that took place and cancels those still to, students are notified
+// of being shortlisted and emailed the cancellations once committed.
+func (c *CompanyService) MoveStage(ctx *gin.Context, userID int64, data *dto.StageMove) (*dto.BulkActionReport, *errs.Error) {
 
-func (s *CompanyService) FeedbacksData(ctx *gin.Context, userID int64, tab string) (any, *errs.Error) {
+	if len(data.ApplicationIDs) == 0 || data.StageID == 0 {
+		return nil, &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Application IDs and the stage ID are required.",
+			ToRespondWith: true,
+		}
+	}
+	applicationIDs, errf := c.bulkApplicationIDs(ctx, userID, &dto.BulkApplicationAction{ApplicationIDs: data.ApplicationIDs})
+	if errf != nil {
+		return nil, errf
+	}
+	rows, errf := c.ownedApplications(ctx, userID, applicationIDs)
+	if errf != nil {
+		return nil, errf
+	}
 
-	switch tab {
-	case "tostudents":
-		data, err := s.queries.FeedbacksDataForAndByCompany(ctx, userID)
-		if err != nil {
+	jobID := rows[0].JobID
+	for _, row := range rows {
+		if row.JobID != jobID {
 			return nil, &errs.Error{
-				Type: errs.Internal,
-				Message: err.Error(),
+				Type: errs.InvalidFormat,
+				Message: "No changes made, the applications must all be to the same job.",
+				ToRespondWith: true,
 			}
 		}
-		return &data, nil
-	case "bystudents":
-		data, err := s.queries.FeedbacksDataForAndToCompany(ctx, userID)
-		if err != nil {
-			return nil, &errs.Error{
-				Type: errs.Internal,
-				Message: err.Error(),
-			}
+	}
+	stages, errf := jobPipeline(ctx, c.queries, jobID)
+	if errf != nil {
+		return nil, errf
+	}
+	var to *pipeline.Stage
+	for i := range stages {
+		if stages[i].StageID == data.StageID {
+			to = &stages[i]
 		}
-		return &data, nil
-	default:
+	}
+	if to == nil {
 		return nil, &errs.Error{
-			Type: errs.MissingRequiredField,
-			Message: "No such field as given in url parameter.",
+			Type: errs.NotFound,
+			Message: "No changes made, the stage is not a stage of the pipeline of the job of the applications.",
 			ToRespondWith: true,
 		}
 	}
-}
 
+	report := &dto.BulkActionReport{
+		To: to.Name,
+		Total: len(rows),
+		Results: make([]dto.BulkActionResult, 0, len(rows)),
+	}
 
+	var abort *errs.Error
+	shortlisted := []sqlc.BulkApplicationPartiesRow{}
+	cancelled := make(map[int64][]sqlc.CancelScheduledInterviewRow)
+	err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		for _, row := range rows {
+			current, err := queries.LockApplicationStage(ctx, row.ApplicationID)
+			if err != nil {
+				return err
+			}
+			from := &stages[stageOf(stages, current.StageID)]
+			result := dto.BulkActionResult{
+				ApplicationID: row.ApplicationID,
+				From: from.Name,
+			}
 
+			// why it cannot be moved, checked before anything is written so it alone can be skipped
+			message := ""
+			status, err := pipeline.StatusOnMove(current.Status, to)
+			if err != nil {
+				message = "Cannot move the application, " + err.Error() + "."
+			} else if from.StageID == to.StageID {
+				message = "The application is already in stage " + to.Name + "."
+			}
 
-func (s *CompanyService) Feedback(ctx *gin.Context, userID int64, data *dto.Feedback) *errs.Error {
+			if message == "" && status != "" {
+				parties := sqlc.ApplicationPartiesRow{
+					Status: current.Status,
+					JobID: row.JobID,
+					Title: row.Title,
+					StudentUserID: row.StudentUserID,
+					CompanyUserID: row.CompanyUserID,
+				}
+				errf, err := applyStatusChange(ctx, queries, &statusChange{
+					ApplicationID: row.ApplicationID,
+					To: status,
+					ActorID: userID,
+					Actor: appstatus.ActorCompany,
+					Reason: "Moved to stage " + to.Name + ".",
+					// the interview round is over with the move, the interviews still to take place are cancelled
+					CancelsInterview: true,
+					Then: func(queries *sqlc.Queries, parties *sqlc.ApplicationPartiesRow) *errs.Error {
+						if parties.Status != appstatus.Interview {
+							return nil
+						}
+						upcoming, err := queries.CancelScheduledInterview(ctx, sqlc.CancelScheduledInterviewParams{
+							ApplicationID: row.ApplicationID,
+							UpcomingOnly: true,
+						})
+						if err != nil {
+							return &errs.Error{
+								Type: errs.Internal,
+								Message: "Failed to cancel interview : " + err.Error(),
+							}
+						}
+						if len(upcoming) != 0 {
+							cancelled[row.ApplicationID] = upcoming
+						}
+						err = queries.InterviewStatusTo(ctx, sqlc.InterviewStatusToParams{
+							Status: "Completed",
+							ApplicationID: row.ApplicationID,
+						})
+						if err != nil {
+							return &errs.Error{
+								Type: errs.Internal,
+								Message: "Failed to change interview status : " + err.Error(),
+							}
+						}
+						return nil
+					},
+				}, &parties)
+				if err != nil {
+					return err
+				}
+				if errf != nil {
+					if errf.Type != errs.InvalidState {
+						abort = &errs.Error{
+							Type: errf.Type,
+							Message: fmt.Sprintf("No changes made, application %d : %s", row.ApplicationID, errf.Message),
+							ToRespondWith: errf.ToRespondWith,
+						}
+						return errStatusChangeAborted
+					}
+					message = errf.Message
+				} else if status == appstatus.ShortListed && current.Status != appstatus.Interview {
+					shortlisted = append(shortlisted, row)
+				}
+			}
 
-	err := s.queries.InsertFeedbackByCompany(ctx, sqlc.InsertFeedbackByCompanyParams{
-		ApplicationID: pgtype.Int8{Int64: data.ApplicationID, Valid: data.ApplicationID != 0},
-		InterviewID: pgtype.Int8{Int64: data.InterviewID, Valid: data.InterviewID != 0},
-		UserID: userID,
-		Message: pgtype.Text{String: data.Message, Valid: true},
+			if message != "" {
+				if data.Strict {
+					abort = &errs.Error{
+						Type: errs.InvalidState,
+						Message: fmt.Sprintf("No changes made, application %d : %s", row.ApplicationID, message),
+						ToRespondWith: true,
+					}
+					return errStatusChangeAborted
+				}
+				result.Message = message
+				report.Results = append(report.Results, result)
+				continue
+			}
+
+			err = queries.SetApplicationStage(ctx, sqlc.SetApplicationStageParams{
+				StageID: pgtype.Int8{Int64: to.StageID, Valid: true},
+				ApplicationID: row.ApplicationID,
+			})
+			if err != nil {
+				return err
+			}
+			err = queries.InsertApplicationStageMove(ctx, sqlc.InsertApplicationStageMoveParams{
+				ApplicationID: row.ApplicationID,
+				FromStageID: pgtype.Int8{Int64: from.StageID, Valid: true},
+				ToStageID: pgtype.Int8{Int64: to.StageID, Valid: true},
+				MovedBy: pgtype.Int8{Int64: userID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
 
+			result.Changed = true
+			report.Changed++
+			report.Results = append(report.Results, result)
+		}
+		return nil
 	})
+	if abort != nil {
+		return nil, abort
+	}
 	if err != nil {
-		return &errs.Error{
+		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: "Failed to insert new feedback : " + err.Error(),
+			Message: "Failed to move applications : " + err.Error(),
 		}
 	}
 
-	return nil
-}
-
-
-
-
-
-
-
-
-
+	c.notifyBulkStatusChange(shortlisted, appstatus.ShortListed, "")
+	for applicationID, interviews := range cancelled {
+		emailCancelledInterviews(ctx, c.queries, applicationID, interviews)
+	}
 
+	return report, nil
+}
 
+// OfferLetterTemplates lists the offer letter templates of the company of the user by name
+func (c *CompanyService) OfferLetterTemplates(ctx *gin.Context, userID int64) (*dto.OfferLetterTemplates, *errs.Error) {
 
+	templates, err := c.queries.OfferLetterTemplates(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get offer letter templates : " + err.Error(),
+		}
+	}
 
+	return &dto.OfferLetterTemplates{
+		Templates: templates,
+		Placeholders: letter.Placeholders,
+	}, nil
+}
 
+// SaveOfferLetterTemplate creates an offer letter template of the company of the user, or updates it if it has an ID.
+// Offers made from a template keep their letters as generated.
+func (c *CompanyService) SaveOfferLetterTemplate(ctx *gin.Context, userID int64, data *letter.Template) (int64, *errs.Error) {
 
+	err := data.Validate()
+	if err != nil {
+		return 0, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid offer letter template, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
 
+	templateID := data.TemplateID
+	if templateID == 0 {
+		templateID, err = c.queries.InsertOfferLetterTemplate(ctx, sqlc.InsertOfferLetterTemplateParams{
+			UserID: userID,
+			Name: data.Name,
+			Title: data.Title,
+			Body: data.Body,
+		})
+	} else {
+		var updated int64
+		updated, err = c.queries.UpdateOfferLetterTemplate(ctx, sqlc.UpdateOfferLetterTemplateParams{
+			Name: data.Name,
+			Title: data.Title,
+			Body: data.Body,
+			TemplateID: templateID,
+			UserID: userID,
+		})
+		if err == nil && updated == 0 {
+			return 0, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The offer letter template does not exist.",
+				ToRespondWith: true,
+			}
+		}
+	}
+	if err != nil {
+		var pgerr *pgconn.PgError
+		if errors.As(err, &pgerr) && pgerr.Code == errs.UniqueViolation {
+			return 0, &errs.Error{
+				Type: errs.UniqueViolation,
+				Message: fmt.Sprintf("An offer letter template named %s already exists.", data.Name),
+				ToRespondWith: true,
+			}
+		}
+		return 0, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to save offer letter template : " + err.Error(),
+		}
+	}
 
+	return templateID, nil
+}
 
+// DeleteOfferLetterTemplate deletes an offer letter template of the company of the user, the letters generated from
+// it are kept
+func (c *CompanyService) DeleteOfferLetterTemplate(ctx *gin.Context, userID int64, templateid string) *errs.Error {
 
+	templateID, err := strconv.ParseInt(templateid, 10, 64)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid template ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
 
+	deleted, err := c.queries.DeleteOfferLetterTemplate(ctx, sqlc.DeleteOfferLetterTemplateParams{
+		TemplateID: templateID,
+		UserID: userID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to delete offer letter template : " + err.Error(),
+		}
+	}
+	if deleted == 0 {
+		return &errs.Error{
+			Type: errs.NotFound,
+			Message: "The offer letter template does not exist.",
+			ToRespondWith: true,
+		}
+	}
 
+	return nil
+}
 
+// PreviewOfferLetterTemplate returns the PDF of an offer letter template of the company of the user, filled in with
+// sample fields and the letterhead of the company
+func (c *CompanyService) PreviewOfferLetterTemplate(ctx *gin.Context, userID int64, templateid string) ([]byte, *errs.Error) {
 
+	templateID, err := strconv.ParseInt(templateid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid template ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
 
-func (c *CompanyService) StudentProfileData(ctx *gin.Context, userID int64, studentid string) (*sqlc.StudentProfileForCompanyRow, *errs.Error) {
+	template, errf := c.offerLetterTemplate(ctx, userID, templateID)
+	if errf != nil {
+		return nil, errf
+	}
 
-	studentID, err := strconv.ParseInt(studentid, 10, 64)
+	head, err := c.queries.CompanyLetterhead(ctx, userID)
 	if err != nil {
 		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
+			Message: "Failed to get company details : " + err.Error(),
 		}
 	}
 
-	data, err := c.queries.StudentProfileForCompany(ctx, sqlc.StudentProfileForCompanyParams{
-		UserID: userID,
-		StudentID: studentID,
-	})
+	fields := letter.Sample
+	fields.CompanyName = head.CompanyName
+	fields.CompanyAddress = head.Address
+	fields.RepresentativeName = head.RepresentativeName
+	fields.RepresentativeEmail = head.RepresentativeEmail
+
+	var pdf bytes.Buffer
+	err = letter.WritePDF(&pdf, &letter.Letterhead{
+		Logo: head.PictureUrl.String,
+		CompanyName: head.CompanyName,
+		Address: head.Address,
+	}, letter.Fill(template.Title, &fields), letter.Fill(template.Body, &fields))
+	var unencodable *letter.UnencodableError
+	if errors.As(err, &unencodable) {
+		return nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "The offer letter cannot be previewed, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
 	if err != nil {
 		return nil, &errs.Error{
 			Type: errs.Internal,
-			Message: err.Error(),
+			Message: "Failed to write offer letter preview : " + err.Error(),
 		}
 	}
 
-	return &data, nil
+	return pdf.Bytes(), nil
 }
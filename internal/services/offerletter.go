@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	errs "go.mod/internal/const"
+	"go.mod/internal/letter"
+	"go.mod/internal/offer"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// offerLetterWriter writes the offer letter of an application to the path
+type offerLetterWriter func(applicationID int64, path string) error
+
+// offerLetterTemplate returns an offer letter template of the company of the user
+func (c *CompanyService) offerLetterTemplate(ctx *gin.Context, userID int64, templateID int64) (*sqlc.OfferLetterTemplateRow, *errs.Error) {
+
+	template, err := c.queries.OfferLetterTemplate(ctx, sqlc.OfferLetterTemplateParams{
+		TemplateID: templateID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The offer letter template does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get offer letter template : " + err.Error(),
+		}
+	}
+
+	return &template, nil
+}
+
+// generatedOfferLetter writes offer letters generated from the template on the terms, with the letterhead of the
+// company. The location is that of the job unless given.
+func (c *CompanyService) generatedOfferLetter(ctx *gin.Context, template *sqlc.OfferLetterTemplateRow, terms *offer.Terms, location string) offerLetterWriter {
+	return func(applicationID int64, path string) error {
+
+		row, err := c.queries.OfferLetterFields(ctx, applicationID)
+		if err != nil {
+			return fmt.Errorf("failed to get offer letter fields : %v", err)
+		}
+
+		fields := &letter.Fields{
+			StudentName: row.StudentName,
+			RollNumber: row.RollNumber,
+			Course: row.Course,
+			Department: row.Department,
+			Role: row.Title,
+			JobType: row.Type,
+			Location: row.Location,
+			CompanyName: row.CompanyName,
+			CompanyAddress: row.Address,
+			CTC: terms.Display(),
+			JoiningDate: terms.JoiningDate.Format("02-01-2006"),
+			RespondBy: terms.RespondBy.Format("03:04 PM 02-01-2006"),
+			Date: time.Now().Format("02-01-2006"),
+			RepresentativeName: row.RepresentativeName,
+			RepresentativeEmail: row.RepresentativeEmail,
+		}
+		if location != "" {
+			fields.Location = location
+		}
+
+		return writeLetterFile(path, &letter.Letterhead{
+			Logo: row.PictureUrl.String,
+			CompanyName: row.CompanyName,
+			Address: row.Address,
+		}, letter.Fill(template.Title, fields), letter.Fill(template.Body, fields))
+	}
+}
+
+// writeLetterFile writes a letter as a PDF file, removed again if it cannot be written in full
+func writeLetterFile(path string, head *letter.Letterhead, title string, body string) error {
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = letter.WritePDF(file, head, title, body)
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mod/internal/config"
@@ -95,4 +96,111 @@ func (s *OpenService) EditDiscussion(ctx *gin.Context, userID int64, data *dto.E
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+func (s *OpenService) DigestPreferences(ctx *gin.Context, userID int64) (*sqlc.GetDigestPreferencesRow, *errs.Error) {
+
+	prefs, err := s.queries.GetDigestPreferences(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get digest preferences : " + err.Error(),
+		}
+	}
+
+	return &prefs, nil
+}
+
+func (s *OpenService) UpdateDigestPreferences(ctx *gin.Context, userID int64, data *dto.DigestPreferences) *errs.Error {
+
+	if data.Frequency != "daily" && data.Frequency != "weekly" && data.Frequency != "off" {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Digest frequency must be one of daily, weekly or off.",
+			ToRespondWith: true,
+		}
+	}
+	if data.SendHour < 0 || data.SendHour > 23 || data.Weekday < 0 || data.Weekday > 6 {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Digest send hour must be within 0-23 and weekday within 0-6.",
+			ToRespondWith: true,
+		}
+	}
+	_, err := time.LoadLocation(data.Timezone)
+	if err != nil || data.Timezone == "" {
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid timezone, expected an IANA name like Asia/Kolkata.",
+			ToRespondWith: true,
+		}
+	}
+
+	err = s.queries.UpsertDigestPreferences(ctx, sqlc.UpsertDigestPreferencesParams{
+		UserID: userID,
+		Frequency: data.Frequency,
+		SendHour: data.SendHour,
+		Weekday: data.Weekday,
+		Timezone: data.Timezone,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to update digest preferences : " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// NoticeBoard returns a page of the announcements delivered to the user, latest first, with their read receipts.
+func (s *OpenService) NoticeBoard(ctx *gin.Context, userID int64, page string) (*[]sqlc.NoticeBoardRow, *errs.Error) {
+
+	pageNo, err := strconv.ParseInt(page, 10, 64)
+	if err != nil || pageNo < 1 {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Page number must be an integer greater than 0.",
+			ToRespondWith: true,
+		}
+	}
+
+	limit := int64(config.NoticeBoardPageLimit)
+
+	announcements, err := s.queries.NoticeBoard(ctx, sqlc.NoticeBoardParams{
+		UserID: userID,
+		Limit: int32(limit),
+		Offset: int32((pageNo - 1) * limit),
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get notice board : " + err.Error(),
+		}
+	}
+
+	return &announcements, nil
+}
+
+// ReadAnnouncement records the read receipt of the user for an announcement, the first read is kept.
+func (s *OpenService) ReadAnnouncement(ctx *gin.Context, userID int64, data *dto.AnnouncementRead) *errs.Error {
+
+	count, err := s.queries.MarkAnnouncementRead(ctx, sqlc.MarkAnnouncementReadParams{
+		AnnouncementID: data.AnnouncementID,
+		UserID: userID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to mark announcement as read : " + err.Error(),
+		}
+	}
+	if count == 0 {
+		return &errs.Error{
+			Type: errs.NotFound,
+			Message: "No such announcement on the notice board of the user.",
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	errs "go.mod/internal/const"
+	"go.mod/internal/pipeline"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// jobPipeline returns the stages of the hiring pipeline of a job in order, empty (not nil) if it has none
+func jobPipeline(ctx context.Context, queries *sqlc.Queries, jobID int64) ([]pipeline.Stage, *errs.Error) {
+
+	rows, err := queries.JobPipelineStages(ctx, jobID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job pipeline : " + err.Error(),
+		}
+	}
+
+	stages := make([]pipeline.Stage, 0, len(rows))
+	for _, row := range rows {
+		stages = append(stages, pipeline.Stage{
+			StageID: row.StageID,
+			Position: row.Position,
+			Name: row.Name,
+			Kind: row.Kind,
+			TestID: row.TestID.Int64,
+			TestName: row.TestName.String,
+		})
+	}
+
+	return stages, nil
+}
+
+// stageOf is the index of the stage an application is in, applications without one are in the first stage
+func stageOf(stages []pipeline.Stage, stageID pgtype.Int8) int {
+	if stageID.Valid {
+		for i, stage := range stages {
+			if stage.StageID == stageID.Int64 {
+				return i
+			}
+		}
+	}
+	return 0
+}
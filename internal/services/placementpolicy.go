@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+
+	errs "go.mod/internal/const"
+	"go.mod/internal/policy"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// placementPolicy returns the placement policy in effect
+func placementPolicy(ctx context.Context, queries *sqlc.Queries) (*policy.Policy, *errs.Error) {
+
+	row, err := queries.GetPlacementPolicy(ctx)
+	if err != nil {
+		// never configured, only acceptance blocks
+		if err.Error() == errs.NoRowsMatch {
+			return &policy.Policy{BlockAfterAcceptance: true, Tiers: []policy.Tier{}}, nil
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get placement policy : " + err.Error(),
+		}
+	}
+
+	p, err := policy.FromColumns(row.MaxOffers, row.BlockAfterAcceptance, row.Tiers)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	return p, nil
+}
+
+// placementViolations checks the placement policy for the student of the given user ID applying to, or being offered,
+// the job. Returns the reasons the policy does not allow it, none if it does. To be called within the transaction
+// that applies or offers, see checkPlacementPolicy.
+func placementViolations(ctx context.Context, queries *sqlc.Queries, studentUserID int64, jobID int64) ([]string, *errs.Error) {
+	return checkPlacementPolicy(ctx, queries, studentUserID, jobID, (*policy.Policy).Check)
+}
+
+// acceptanceViolations checks the placement policy for the student of the given user ID accepting the offer for the
+// job. Returns the reasons the policy does not allow it, none if it does. To be called within the transaction that
+// accepts, see checkPlacementPolicy.
+func acceptanceViolations(ctx context.Context, queries *sqlc.Queries, studentUserID int64, jobID int64) ([]string, *errs.Error) {
+	return checkPlacementPolicy(ctx, queries, studentUserID, jobID, (*policy.Policy).CheckAcceptance)
+}
+
+// checkPlacementPolicy checks the job against the offers of the student with the given check of the policy. The student
+// is locked until the transaction of queries ends, so concurrent checks of the student see the placements it leaves.
+func checkPlacementPolicy(ctx context.Context, queries *sqlc.Queries, studentUserID int64, jobID int64, check func(p *policy.Policy, job policy.Job, placements []policy.Placement, exceptions []string) []policy.Violation) ([]string, *errs.Error) {
+
+	p, errf := placementPolicy(ctx, queries)
+	if errf != nil {
+		return nil, errf
+	}
+
+	facts, err := queries.JobPolicyFacts(ctx, jobID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The job does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job for placement policy : " + err.Error(),
+		}
+	}
+	job := policy.JobFrom(facts.Title, "", facts.Type, facts.Currency, facts.CtcMin, facts.CtcMax)
+	if job.Internship {
+		return nil, nil
+	}
+
+	err = queries.LockStudent(ctx, studentUserID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to lock student : " + err.Error(),
+		}
+	}
+
+	rows, err := queries.StudentPlacements(ctx, studentUserID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get student offers : " + err.Error(),
+		}
+	}
+	placements := make([]policy.Placement, 0, len(rows))
+	for _, row := range rows {
+		placements = append(placements, policy.Placement{
+			Job: policy.JobFrom(row.Title, row.CompanyName, row.Type, row.Currency, row.CtcMin, row.CtcMax),
+			Status: row.Status,
+		})
+	}
+
+	exceptions, err := queries.StudentPolicyExceptions(ctx, sqlc.StudentPolicyExceptionsParams{
+		UserID: studentUserID,
+		JobID: jobID,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get placement policy exceptions : " + err.Error(),
+		}
+	}
+
+	return policy.Reasons(check(p, job, placements, exceptions)), nil
+}
@@ -561,5 +561,62 @@ func (s *PublicService) ExtraInfoPostCompany(ctx *gin.Context, claims jwt.MapCla
 	return &companyData, nil
 }
 
+// CalendarFeed builds the iCal feed of all the upcoming interviews and tests of the student owning the feed token.
+// Tests are listed as the last slot in which they can be taken, ie. [end_time - duration, end_time].
+func (s *PublicService) CalendarFeed(ctx *gin.Context, token string) ([]byte, *errs.Error) {
 
+	user, err := s.queries.CalendarFeedUser(ctx, token)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "No calendar feed found for the given token.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get calendar feed user : " + err.Error(),
+		}
+	}
+
+	var events []utils.ICalEvent
+
+	interviews, err := s.queries.CalendarFeedInterviewsStudent(ctx, user.UserID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get interviews for calendar feed : " + err.Error(),
+		}
+	}
+	for _, i := range interviews {
+		events = append(events, utils.ICalEvent{
+			UID: utils.ICalUID("interview", i.InterviewID),
+			Sequence: i.IcalSequence,
+			Start: i.DateTime.Time,
+			End: i.DateTime.Time.Add(time.Duration(i.DurationMinutes) * time.Minute),
+			Summary: fmt.Sprintf("%s Interview : %s - %s", i.InterviewsType, i.CompanyName, i.Title),
+			Description: i.Notes.String,
+			Location: i.Location,
+		})
+	}
+
+	tests, err := s.queries.CalendarFeedTestsStudent(ctx, user.UserID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get tests for calendar feed : " + err.Error(),
+		}
+	}
+	for _, t := range tests {
+		events = append(events, utils.ICalEvent{
+			UID: utils.ICalUID("test", t.TestID),
+			Start: t.EndTime.Time.Add(-time.Duration(t.Duration) * time.Minute),
+			End: t.EndTime.Time,
+			Summary: fmt.Sprintf("Test : %s (%s - %s)", t.TestName, t.CompanyName, t.Title),
+			Description: fmt.Sprintf("%d mins. The test closes at %s.", t.Duration, t.EndTime.Time.Format("15:04 2006-01-02")),
+		})
+	}
 
+	return utils.ICalendar(utils.ICalPublish, events...), nil
+}
@@ -0,0 +1,534 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/appstatus"
+	"go.mod/internal/audit"
+	"go.mod/internal/config"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/notify"
+	"go.mod/internal/reschedule"
+	sqlc "go.mod/internal/sqlc/generate"
+	"go.mod/internal/utils"
+)
+
+// errRescheduleAborted rolls back a reschedule action, the reason is returned separately as an *errs.Error
+var errRescheduleAborted = errors.New("reschedule aborted")
+
+// lockedInterview runs fn in a transaction with the interview locked, once the user is checked to be the side of the
+// interview acting, appstatus.ActorCompany or appstatus.ActorStudent. An *errs.Error from fn rolls the transaction back.
+// Returns the interview as it was locked.
+func lockedInterview(ctx context.Context, interviewID int64, userID int64, actor string, fn func(queries *sqlc.Queries, interview *sqlc.LockInterviewRescheduleRow) *errs.Error) (*sqlc.LockInterviewRescheduleRow, *errs.Error) {
+
+	var interview sqlc.LockInterviewRescheduleRow
+	var errf *errs.Error
+	err := config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		var err error
+		interview, err = queries.LockInterviewReschedule(ctx, interviewID)
+		if err != nil {
+			if err.Error() == errs.NoRowsMatch {
+				errf = &errs.Error{
+					Type: errs.NotFound,
+					Message: "The interview does not exist.",
+					ToRespondWith: true,
+				}
+				return errRescheduleAborted
+			}
+			return err
+		}
+		if (actor == appstatus.ActorCompany && interview.CompanyUserID != userID) ||
+			(actor == appstatus.ActorStudent && interview.StudentUserID != userID) {
+			errf = &errs.Error{
+				Type: errs.Unauthorized,
+				Message: "The given user ID is not authorized to access requested interview.",
+				ToRespondWith: true,
+			}
+			return errRescheduleAborted
+		}
+
+		errf = fn(queries, &interview)
+		if errf != nil {
+			return errRescheduleAborted
+		}
+		return nil
+	})
+	if errf != nil {
+		return nil, errf
+	}
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to reschedule interview : " + err.Error(),
+		}
+	}
+
+	return &interview, nil
+}
+
+// proposeReschedule requests a reschedule of a scheduled interview to one of the proposed times, by either side.
+// The interview is Reschedule Requested until the other side responds, see respondReschedule.
+// The times proposed by the company are checked for conflicts as when scheduling, see ScheduleInterview.
+func proposeReschedule(ctx context.Context, n *notify.Notify, userID int64, actor string, data *dto.RescheduleProposal) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	proposal := &reschedule.Proposal{Times: data.Times, Reason: data.Reason}
+	err := proposal.Validate(time.Now())
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid reschedule proposal, " + err.Error() + ".",
+			ToRespondWith: true,
+		}
+	}
+
+	var conflicts []sqlc.InterviewConflictsRow
+	interview, errf := lockedInterview(ctx, data.InterviewID, userID, actor, func(queries *sqlc.Queries, interview *sqlc.LockInterviewRescheduleRow) *errs.Error {
+		switch {
+		case interview.InterviewsStatus == reschedule.Requested:
+			return &errs.Error{
+				Type: errs.InvalidState,
+				Message: "A reschedule of the interview is already requested, respond to it instead.",
+				ToRespondWith: true,
+			}
+		case interview.InterviewsStatus != reschedule.Scheduled:
+			return &errs.Error{
+				Type: errs.InvalidState,
+				Message: fmt.Sprintf("The interview is %s, it cannot be rescheduled.", strings.ToLower(interview.InterviewsStatus)),
+				ToRespondWith: true,
+			}
+		case !interview.DateTime.Time.After(time.Now()):
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The interview has already started, it cannot be rescheduled.",
+				ToRespondWith: true,
+			}
+		}
+
+		found, errf := insertRescheduleProposal(ctx, queries, interview, userID, actor, proposal, data.Force)
+		if errf != nil {
+			conflicts = found
+			return errf
+		}
+		err := queries.SetInterviewStatus(ctx, sqlc.SetInterviewStatusParams{
+			Status: reschedule.Requested,
+			InterviewID: interview.InterviewID,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to change interview status : " + err.Error(),
+			}
+		}
+		conflicts = found
+		return nil
+	})
+	if errf != nil {
+		return conflicts, errf
+	}
+
+	errf = notifyRescheduleSide(ctx, n, interview, actor, "Interview reschedule requested", fmt.Sprintf(
+		"%s asked to reschedule the %s interview for %s (ID: %d) from %s to one of %s. Reason : %s",
+		rescheduleActorName(interview, actor), interview.InterviewsType, interview.Title, interview.InterviewID,
+		interview.DateTime.Time.Format("03:04 PM 02-01-2006"), formatTimes(proposal.Times), proposal.Reason,
+	))
+	if errf != nil {
+		return conflicts, errf
+	}
+
+	return conflicts, nil
+}
+
+// respondReschedule responds to the pending reschedule proposal of an interview by the other side. An accepted time
+// is agreed, the interview is moved to it and the calendar event of the student updated. A rejected proposal leaves
+// the interview at its time, and a counter-proposal awaits the response of the side that proposed.
+func respondReschedule(ctx context.Context, n *notify.Notify, userID int64, actor string, data *dto.RescheduleResponse) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	status := reschedule.Status(data.Action)
+	if status == "" {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("Respond to the reschedule with %s, %s or %s.", reschedule.Accept, reschedule.Reject, reschedule.Counter),
+			ToRespondWith: true,
+		}
+	}
+	reason := strings.TrimSpace(data.Reason)
+	if len([]rune(reason)) > config.RescheduleReasonMaxLength {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: fmt.Sprintf("The reason is longer than %d characters.", config.RescheduleReasonMaxLength),
+			ToRespondWith: true,
+		}
+	}
+
+	var counter *reschedule.Proposal
+	switch data.Action {
+	case reschedule.Accept:
+		if data.Time.IsZero() {
+			return nil, &errs.Error{
+				Type: errs.MissingRequiredField,
+				Message: "Pick one of the proposed times to accept.",
+				ToRespondWith: true,
+			}
+		}
+	case reschedule.Counter:
+		counter = &reschedule.Proposal{Times: data.Times, Reason: reason}
+		err := counter.Validate(time.Now())
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid reschedule proposal, " + err.Error() + ".",
+				ToRespondWith: true,
+			}
+		}
+	}
+
+	var conflicts []sqlc.InterviewConflictsRow
+	var proposal sqlc.InterviewRescheduleProposal
+	var sequence int32
+	interview, errf := lockedInterview(ctx, data.InterviewID, userID, actor, func(queries *sqlc.Queries, interview *sqlc.LockInterviewRescheduleRow) *errs.Error {
+		if interview.InterviewsStatus != reschedule.Requested {
+			return &errs.Error{
+				Type: errs.InvalidState,
+				Message: "No reschedule of the interview awaits a response.",
+				ToRespondWith: true,
+			}
+		}
+		var err error
+		proposal, err = queries.PendingRescheduleProposal(ctx, interview.InterviewID)
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to get pending reschedule proposal : " + err.Error(),
+			}
+		}
+		if proposal.ProposedBy == actor {
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The reschedule was proposed by your side, it awaits the response of the other side.",
+				ToRespondWith: true,
+			}
+		}
+
+		var agreed pgtype.Timestamptz
+		var found []sqlc.InterviewConflictsRow
+		if data.Action == reschedule.Accept {
+			if !proposedTime(proposal.Times, data.Time) {
+				return &errs.Error{
+					Type: errs.InvalidFormat,
+					Message: "The accepted time is not one of the proposed times.",
+					ToRespondWith: true,
+				}
+			}
+			if !data.Time.After(time.Now()) {
+				return &errs.Error{
+					Type: errs.PreconditionFailed,
+					Message: "The accepted time has passed, counter-propose new times instead.",
+					ToRespondWith: true,
+				}
+			}
+			if actor == appstatus.ActorCompany {
+				var errf *errs.Error
+				found, errf = interviewConflicts(ctx, queries, interview.ApplicationID, interview.InterviewID, data.Time, interview.DurationMinutes, interview.Location, interview.Panel)
+				if errf != nil {
+					return errf
+				}
+				if len(found) != 0 && !data.Force {
+					conflicts = found
+					return conflictError(found)
+				}
+			}
+			agreed = pgtype.Timestamptz{Time: data.Time, Valid: true}
+		}
+
+		_, err = queries.RespondRescheduleProposal(ctx, sqlc.RespondRescheduleProposalParams{
+			Status: status,
+			AgreedTime: agreed,
+			ResponseReason: reason,
+			RespondedBy: pgtype.Int8{Int64: userID, Valid: true},
+			ProposalID: proposal.ProposalID,
+		})
+		if err != nil {
+			return &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to respond to reschedule proposal : " + err.Error(),
+			}
+		}
+
+		switch data.Action {
+		case reschedule.Accept:
+			sequence, err = queries.RescheduleInterviewTo(ctx, sqlc.RescheduleInterviewToParams{
+				DateTime: agreed,
+				InterviewID: interview.InterviewID,
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to reschedule interview : " + err.Error(),
+				}
+			}
+			if len(found) != 0 {
+				err = recordConflictOverride(ctx, queries, userID, interview, data.Time, found)
+				if err != nil {
+					return &errs.Error{
+						Type: errs.Internal,
+						Message: "Failed to record conflict override in audit log : " + err.Error(),
+					}
+				}
+			}
+		case reschedule.Reject:
+			err = queries.SetInterviewStatus(ctx, sqlc.SetInterviewStatusParams{
+				Status: reschedule.Scheduled,
+				InterviewID: interview.InterviewID,
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to change interview status : " + err.Error(),
+				}
+			}
+		case reschedule.Counter:
+			// the interview stays Reschedule Requested, now awaiting the side that proposed
+			var errf *errs.Error
+			found, errf = insertRescheduleProposal(ctx, queries, interview, userID, actor, counter, data.Force)
+			if errf != nil {
+				conflicts = found
+				return errf
+			}
+		}
+		conflicts = found
+		return nil
+	})
+	if errf != nil {
+		return conflicts, errf
+	}
+
+	name := rescheduleActorName(interview, actor)
+	when := interview.DateTime.Time.Format("03:04 PM 02-01-2006")
+	var title, description string
+	switch data.Action {
+	case reschedule.Accept:
+		title = "Interview rescheduled"
+		description = fmt.Sprintf("%s accepted to reschedule the %s interview for %s (ID: %d) from %s to %s.",
+			name, interview.InterviewsType, interview.Title, interview.InterviewID, when, data.Time.Format("03:04 PM 02-01-2006"))
+	case reschedule.Reject:
+		title = "Interview reschedule rejected"
+		description = fmt.Sprintf("%s rejected to reschedule the %s interview for %s (ID: %d), it stays at %s.",
+			name, interview.InterviewsType, interview.Title, interview.InterviewID, when)
+		if reason != "" {
+			description += " Reason : " + reason
+		}
+	case reschedule.Counter:
+		title = "Interview reschedule counter-proposed"
+		description = fmt.Sprintf("%s proposed instead to reschedule the %s interview for %s (ID: %d) from %s to one of %s. Reason : %s",
+			name, interview.InterviewsType, interview.Title, interview.InterviewID, when, formatTimes(counter.Times), counter.Reason)
+	}
+	errf = notifyRescheduleSide(ctx, n, interview, actor, title, description)
+	if errf != nil {
+		return conflicts, errf
+	}
+
+	if data.Action == reschedule.Accept {
+		errf = sendRescheduledInterview(interview, data.Time, sequence)
+		if errf != nil {
+			return conflicts, errf
+		}
+	}
+
+	return conflicts, nil
+}
+
+// insertRescheduleProposal records a proposal of the actor for the interview, those of the company are checked for
+// conflicts at each time, and are not made unless forced, in which case the override is recorded in the audit log.
+// Returns the conflicts found, with an error if they were not forced.
+func insertRescheduleProposal(ctx context.Context, queries *sqlc.Queries, interview *sqlc.LockInterviewRescheduleRow, userID int64, actor string, proposal *reschedule.Proposal, force bool) ([]sqlc.InterviewConflictsRow, *errs.Error) {
+
+	var conflicts []sqlc.InterviewConflictsRow
+	// conflicts of each proposed time, by its index
+	overridden := map[int][]sqlc.InterviewConflictsRow{}
+	if actor == appstatus.ActorCompany {
+		for i, t := range proposal.Times {
+			found, errf := interviewConflicts(ctx, queries, interview.ApplicationID, interview.InterviewID, t, interview.DurationMinutes, interview.Location, interview.Panel)
+			if errf != nil {
+				return nil, errf
+			}
+			if len(found) != 0 {
+				conflicts = append(conflicts, found...)
+				overridden[i] = found
+			}
+		}
+		if len(conflicts) != 0 && !force {
+			return conflicts, conflictError(conflicts)
+		}
+	}
+
+	times := make([]pgtype.Timestamptz, len(proposal.Times))
+	for i, t := range proposal.Times {
+		times[i] = pgtype.Timestamptz{Time: t, Valid: true}
+	}
+	_, err := queries.InsertRescheduleProposal(ctx, sqlc.InsertRescheduleProposalParams{
+		InterviewID: interview.InterviewID,
+		ProposedBy: actor,
+		ProposerID: pgtype.Int8{Int64: userID, Valid: true},
+		Times: times,
+		Reason: proposal.Reason,
+		PreviousTime: interview.DateTime,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to insert reschedule proposal : " + err.Error(),
+		}
+	}
+
+	for i, t := range proposal.Times {
+		if found, exists := overridden[i]; exists {
+			err = recordConflictOverride(ctx, queries, userID, interview, t, found)
+			if err != nil {
+				return nil, &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to record conflict override in audit log : " + err.Error(),
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
+
+// recordConflictOverride records in the audit log the interview being rescheduled to t despite the conflicts
+func recordConflictOverride(ctx context.Context, queries *sqlc.Queries, userID int64, interview *sqlc.LockInterviewRescheduleRow, t time.Time, conflicts []sqlc.InterviewConflictsRow) error {
+	return audit.Record(ctx, queries, userID, audit.InterviewConflictOverridden, audit.EntityInterview, interview.InterviewID, &dto.InterviewConflictOverride{
+		ApplicationID: interview.ApplicationID,
+		DateTime: t,
+		DurationMinutes: interview.DurationMinutes,
+		Location: interview.Location,
+		Panel: interview.Panel,
+		Conflicts: conflicts,
+	})
+}
+
+// rescheduleHistory returns every reschedule proposal of an interview, oldest first, to either of its sides
+func rescheduleHistory(ctx context.Context, queries *sqlc.Queries, userID int64, actor string, interviewid string) ([]sqlc.InterviewRescheduleProposal, *errs.Error) {
+
+	interviewID, err := strconv.ParseInt(interviewid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid interview ID.",
+			ToRespondWith: true,
+		}
+	}
+
+	parties, err := queries.InterviewParties(ctx, interviewID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The interview does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get interview : " + err.Error(),
+		}
+	}
+	if (actor == appstatus.ActorCompany && parties.CompanyUserID != userID) ||
+		(actor == appstatus.ActorStudent && parties.StudentUserID != userID) {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested interview.",
+			ToRespondWith: true,
+		}
+	}
+
+	proposals, err := queries.RescheduleProposals(ctx, interviewID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get reschedule proposals : " + err.Error(),
+		}
+	}
+
+	return proposals, nil
+}
+
+// sendRescheduledInterview emails the student the agreed time of the interview, with the calendar event updated
+func sendRescheduledInterview(interview *sqlc.LockInterviewRescheduleRow, t time.Time, sequence int32) *errs.Error {
+
+	data := &dto.UpdateInterview{
+		InterviewID: interview.InterviewID,
+		DateTime: t,
+		DurationMinutes: interview.DurationMinutes,
+		Type: interview.InterviewsType,
+		Location: interview.Location,
+		Panel: interview.Panel,
+		Notes: interview.Notes.String,
+		StudentName: interview.StudentName,
+		JobTitle: interview.Title,
+		CompanyName: interview.CompanyName,
+		DT: t.Format("03:04 PM 02-01-2006"),
+	}
+	template, err := utils.DynamicHTML("./template/emails/interviewRescheduled.html", data)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to generate template for interview-rescheduled email : " + err.Error(),
+		}
+	}
+	// same UID with the incremented sequence, so the existing calendar event is updated
+	invite := utils.ICalendar(utils.ICalRequest, interviewEvent(interview.InterviewID, sequence, t, interview.DurationMinutes, interview.InterviewsType, interview.Location, interview.Notes.String, interview.CompanyName, interview.Title, interview.StudentEmail))
+	go utils.SendEmailHTMLWithInvite(template, []string{interview.StudentEmail}, invite, utils.ICalRequest)
+
+	return nil
+}
+
+// notifyRescheduleSide notifies the side of the interview other than the actor
+func notifyRescheduleSide(ctx context.Context, n *notify.Notify, interview *sqlc.LockInterviewRescheduleRow, actor string, title string, description string) *errs.Error {
+
+	to := interview.StudentUserID
+	if actor == appstatus.ActorStudent {
+		to = interview.CompanyUserID
+	}
+
+	return n.NewNotification(ctx, to, &dto.NotificationData{
+		Title: title,
+		Description: description,
+		Category: notify.CategoryInterview,
+		RefType: notify.RefInterview,
+		RefID: interview.InterviewID,
+	})
+}
+
+// rescheduleActorName is the name of the side of the interview acting, as shown to the other side
+func rescheduleActorName(interview *sqlc.LockInterviewRescheduleRow, actor string) string {
+	if actor == appstatus.ActorStudent {
+		return interview.StudentName
+	}
+	return interview.CompanyName
+}
+
+// proposedTime reports whether t is one of the proposed times
+func proposedTime(times []pgtype.Timestamptz, t time.Time) bool {
+	for _, proposed := range times {
+		if proposed.Time.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTimes(times []time.Time) string {
+	formatted := make([]string, len(times))
+	for i, t := range times {
+		formatted[i] = t.Format("03:04 PM 02-01-2006")
+	}
+	return strings.Join(formatted, ", ")
+}
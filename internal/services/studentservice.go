@@ -17,11 +17,17 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/redis/go-redis/v9"
 	"go.mod/internal/apicalls"
+	"go.mod/internal/appstatus"
+	"go.mod/internal/compensation"
 	"go.mod/internal/config"
 	errs "go.mod/internal/const"
 	"go.mod/internal/dto"
+	"go.mod/internal/eligibility"
 	gocharts "go.mod/internal/go-charts"
 	"go.mod/internal/notify"
+	"go.mod/internal/offer"
+	"go.mod/internal/questions"
+	"go.mod/internal/recommend"
 	sqlc "go.mod/internal/sqlc/generate"
 	"go.mod/internal/utils"
 	"google.golang.org/api/forms/v1"
@@ -55,7 +61,9 @@ func (c *StudentService) DashboardData(ctx *gin.Context, userID int64) (*sqlc.St
 }
 
 
-func (s *StudentService) GetApplicableJobs(ctx *gin.Context, jobType string) (*[]sqlc.GetApplicableJobsTypeFilterRow, error) {
+// GetApplicableJobs lists the jobs the student has not applied to yet, each annotated with whether the student
+// meets its eligibility rules and the reasons if not. With eligibleOnly the ineligible ones are left out.
+func (s *StudentService) GetApplicableJobs(ctx *gin.Context, jobType string, eligibleOnly bool) (*[]dto.ApplicableJob, error) {
 
 	// TODO: apply all filters here
 	userID, exists := ctx.Get("ID")
@@ -71,47 +79,653 @@ func (s *StudentService) GetApplicableJobs(ctx *gin.Context, jobType string) (*[
 		return nil, errors.New("unable to get all jobs from database")
 	}
 
-	return &allapplicablejobsData, nil
+	// job ID 0, the override flag is per job and already in the listing
+	st, err := s.queries.StudentEligibilityProfile(ctx, sqlc.StudentEligibilityProfileParams{
+		JobID: 0,
+		UserID: userID.(int64),
+	})
+	if err != nil {
+		return nil, errors.New("unable to get student profile from database")
+	}
+	profile := eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras)
+
+	jobs := make([]dto.ApplicableJob, 0, len(allapplicablejobsData))
+	for _, j := range allapplicablejobsData {
+		rules, err := eligibility.FromColumns(j.MinCgpa, j.Courses, j.Departments, j.YearsOfStudy, j.MaxBacklogs, j.Custom)
+		if err != nil {
+			return nil, err
+		}
+
+		reasons := []string{}
+		if !j.Overridden {
+			reasons = rules.Check(profile)
+		}
+		if eligibleOnly && len(reasons) != 0 {
+			continue
+		}
+
+		qs, err := questions.Parse(j.Questions)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, dto.ApplicableJob{
+			JobID: j.JobID,
+			Title: j.Title,
+			Location: j.Location,
+			Type: j.Type,
+			Salary: j.Salary,
+			Position: j.Position,
+			Skills: j.Skills,
+			CompanyID: j.CompanyID,
+			ActiveStatus: j.ActiveStatus,
+			Deadline: optionalTime(j.Deadline),
+			Questions: qs,
+			Compensation: listedCompensation(&j),
+			CompanyName: j.CompanyName,
+			Eligible: len(reasons) == 0,
+			Reasons: reasons,
+			Overridden: j.Overridden,
+		})
+	}
+
+	return &jobs, nil
 }
 
-func (s *StudentService) NewApplication(ctx *gin.Context, userId int64, jobid string) (error) {
+// errApplicationAborted rolls back a new application, the reason is returned separately as an *errs.Error
+var errApplicationAborted = errors.New("application aborted")
+
+// NewApplication applies the student to the job, provided they meet its eligibility rules or an admin overrode them.
+// answers are the answers to the questions of the job by question ID, files the uploaded answers to its file questions.
+func (s *StudentService) NewApplication(ctx *gin.Context, userId int64, jobid string, answers map[string]string, files map[string]*multipart.FileHeader) (*errs.Error) {
 
 	jobID, err := strconv.ParseInt(jobid, 10, 64)
 	if err != nil {
-		return errors.New("unable to parse job id from string to int")
+		return &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid job ID, failed to parse to int.",
+			ToRespondWith: true,
+		}
 	}
 
-	err = s.queries.InsertNewApplication(ctx, sqlc.InsertNewApplicationParams{
-		JobID: jobID,
-		UserID: userId,
-		DataUrl: pgtype.Text{String: "", Valid: true},
+	errf := s.checkApplicationWindow(ctx, jobID)
+	if errf != nil {
+		return errf
+	}
+
+	errf = s.checkEligibility(ctx, userId, jobID)
+	if errf != nil {
+		return errf
+	}
+
+	answersJson, savedFiles, errf := s.applicationAnswers(ctx, userId, jobID, answers, files)
+	if errf != nil {
+		return errf
+	}
+
+	// the job is locked for the count of its applicants to hold until the insert,
+	// and the student for its placements to, see checkPlacementPolicy
+	var inserted int64
+	err = config.WithTx(ctx, func(queries *sqlc.Queries) error {
+		err := queries.LockJobApplications(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		var reasons []string
+		reasons, errf = placementViolations(ctx, queries, userId, jobID)
+		if errf == nil && len(reasons) != 0 {
+			errf = &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The placement policy does not allow you to apply to this job. " + strings.Join(reasons, " ") + " Contact the placement cell if you need an exception.",
+				ToRespondWith: true,
+			}
+		}
+		if errf != nil {
+			return errApplicationAborted
+		}
+		inserted, err = queries.InsertNewApplication(ctx, sqlc.InsertNewApplicationParams{
+			JobID: jobID,
+			UserID: userId,
+			DataUrl: pgtype.Text{String: "", Valid: true},
+			Answers: answersJson,
+		})
+		return err
 	})
+	if err != nil || inserted == 0 {
+		// the uploaded answers belong to no application
+		for _, path := range savedFiles {
+			os.Remove(path)
+		}
+	}
+	if errf != nil {
+		return errf
+	}
 	if err != nil {
-		fmt.Println(err)
-		return errors.New("unable to insert new application into database")
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Unable to insert new application into database : " + err.Error(),
+		}
+	}
+	// closed, passed its deadline or filled up since the check
+	if inserted == 0 {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "This job is no longer accepting applications.",
+			ToRespondWith: true,
+		}
 	}
 
 	return nil
 }
 
-func (s *StudentService) CancelApplication(ctx *gin.Context, userID int64, jobid string)  (error) {
-	
-	jobID, err := strconv.ParseInt(jobid, 10, 64)
+// applicationAnswers validates the answers to the questions of the job and stores the uploaded files,
+// it returns the answers as JSON along with the paths of the stored files.
+func (s *StudentService) applicationAnswers(ctx *gin.Context, userID int64, jobID int64, answers map[string]string, files map[string]*multipart.FileHeader) ([]byte, []string, *errs.Error) {
+
+	raw, err := s.queries.JobQuestions(ctx, jobID)
 	if err != nil {
-		return err
+		return nil, nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job questions : " + err.Error(),
+		}
+	}
+	qs, err := questions.Parse(raw)
+	if err != nil {
+		return nil, nil, &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
 	}
 
-	err = s.queries.CancelApplication(ctx, sqlc.CancelApplicationParams{
-		UserID: userID,
+	uploaded := make(map[string]bool)
+	for id := range files {
+		uploaded[id] = true
+	}
+	checked, reasons := questions.Check(qs, answers, uploaded)
+	for id, file := range files {
+		expected := config.FileSizeForContentType[file.Header.Get("Content-Type")]
+		if expected == 0 {
+			reasons = append(reasons, fmt.Sprintf("Invalid file type for %s, upload a pdf or an image.", id))
+		} else if expected < file.Size {
+			reasons = append(reasons, fmt.Sprintf("The file for %s exceeds the size limit.", id))
+		}
+	}
+	if len(reasons) != 0 {
+		return nil, nil, &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "Your answers were not accepted. " + strings.Join(reasons, " "),
+			ToRespondWith: true,
+		}
+	}
+
+	saved := []string{}
+	if len(files) != 0 {
+		userUUID, err := s.queries.GetUserUUIDFromUserID(ctx, userID)
+		if err != nil {
+			return nil, nil, &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to get user UUID : " + err.Error(),
+			}
+		}
+		strUUID := hex.EncodeToString(userUUID.Bytes[:])
+
+		for id, file := range files {
+			fileStoragePath := fmt.Sprintf("%s%s&%d&%d-%s%s", os.Getenv("AnswerStorageDir"), strUUID, time.Now().Unix(), jobID, id, filepath.Ext(file.Filename))
+			fileSavePath, err := utils.SaveFile(ctx, fileStoragePath, file)
+			if err != nil {
+				for _, path := range saved {
+					os.Remove(path)
+				}
+				return nil, nil, &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to save answer file : " + err.Error(),
+				}
+			}
+			saved = append(saved, fileSavePath)
+			checked[id] = fileSavePath
+		}
+	}
+
+	answersJson, err := json.Marshal(checked)
+	if err != nil {
+		return nil, nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to marshal answers : " + err.Error(),
+		}
+	}
+
+	return answersJson, saved, nil
+}
+
+// checkApplicationWindow returns a PreconditionFailed error if the job is closed, past its deadline or has reached its maximum applicants
+func (s *StudentService) checkApplicationWindow(ctx *gin.Context, jobID int64) *errs.Error {
+
+	window, err := s.queries.JobApplicationWindow(ctx, jobID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "The job does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job application window : " + err.Error(),
+		}
+	}
+
+	message := ""
+	switch {
+	case window.ApprovalStatus != jobApproved:
+		message = "This job is not open for applications."
+	case !window.ActiveStatus:
+		message = "This job is closed and no longer accepting applications."
+	case window.Deadline.Valid && !window.Deadline.Time.After(time.Now()):
+		message = "The application deadline of this job has passed."
+	case window.MaxApplicants.Valid && window.Applicants >= int64(window.MaxApplicants.Int32):
+		message = "This job has reached its maximum number of applicants."
+	}
+	if message != "" {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: message,
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
+
+// checkEligibility returns a PreconditionFailed error listing the reasons if the student is not eligible for the job
+func (s *StudentService) checkEligibility(ctx *gin.Context, userID int64, jobID int64) *errs.Error {
+
+	row, err := s.queries.GetJobEligibility(ctx, jobID)
+	if err != nil {
+		// no rules
+		if err.Error() == errs.NoRowsMatch {
+			return nil
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get eligibility rules : " + err.Error(),
+		}
+	}
+
+	rules, err := eligibility.FromColumns(row.MinCgpa, row.Courses, row.Departments, row.YearsOfStudy, row.MaxBacklogs, row.Custom)
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: err.Error(),
+		}
+	}
+
+	st, err := s.queries.StudentEligibilityProfile(ctx, sqlc.StudentEligibilityProfileParams{
 		JobID: jobID,
+		UserID: userID,
+	})
+	if err != nil {
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get student profile : " + err.Error(),
+		}
+	}
+	if st.Overridden {
+		return nil
+	}
+
+	reasons := rules.Check(eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras))
+	if len(reasons) != 0 {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: "You are not eligible for this job. " + strings.Join(reasons, " "),
+			ToRespondWith: true,
+		}
+	}
+
+	return nil
+}
+
+// WithdrawApplication withdraws the student's application to a job with a reason, the application and its history
+// are kept. Once shortlisted or interviewing it has to be confirmed, a scheduled interview is cancelled along with it
+// and the student is sent the cancellation of its calendar event. An offer cannot be withdrawn from, it is declined instead.
+// The company of the job is notified.
+func (s *StudentService) WithdrawApplication(ctx *gin.Context, userID int64, data *dto.WithdrawApplication) *errs.Error {
+
+	if data.JobID == 0 || strings.TrimSpace(data.Reason) == "" {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Job ID and a reason are required to withdraw an application.",
+			ToRespondWith: true,
+		}
+	}
+
+	application, err := s.queries.StudentJobApplication(ctx, sqlc.StudentJobApplicationParams{
+		UserID: userID,
+		JobID: data.JobID,
 	})
 	if err != nil {
-		return err	
+		if err.Error() == errs.NoRowsMatch {
+			return &errs.Error{
+				Type: errs.NotFound,
+				Message: "You have not applied to this job.",
+				ToRespondWith: true,
+			}
+		}
+		return &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get application : " + err.Error(),
+		}
+	}
+
+	if application.Status == appstatus.Offered {
+		return &errs.Error{
+			Type: errs.InvalidState,
+			Message: "You have been offered this job, decline the offer instead of withdrawing.",
+			ToRespondWith: true,
+		}
+	}
+	if appstatus.Late(application.Status) && !data.Confirm {
+		return &errs.Error{
+			Type: errs.PreconditionFailed,
+			Message: fmt.Sprintf("Your application is %s, withdrawing cannot be undone and any scheduled interview is cancelled. Confirm to withdraw.", application.Status),
+			ToRespondWith: true,
+		}
+	}
+
+	var cancelled []sqlc.CancelScheduledInterviewRow
+	parties, errf := changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: application.ApplicationID,
+		To: appstatus.Withdrawn,
+		ActorID: userID,
+		Actor: appstatus.ActorStudent,
+		Reason: strings.TrimSpace(data.Reason),
+		Then: func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+			var err error
+			cancelled, err = queries.CancelScheduledInterview(ctx, sqlc.CancelScheduledInterviewParams{
+				ApplicationID: application.ApplicationID,
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to cancel interview : " + err.Error(),
+				}
+			}
+			return nil
+		},
+	})
+	if errf != nil {
+		return errf
+	}
+
+	if len(cancelled) != 0 {
+		emailCancelledInterviews(ctx, s.queries, application.ApplicationID, cancelled)
+	}
+
+	errf = s.Notify.NewNotification(ctx, parties.CompanyUserID, &dto.NotificationData{
+		Title: "Application withdrawn",
+		Description: fmt.Sprintf("An applicant withdrew their application to %s (ID: %d). Reason : %s", parties.Title, application.ApplicationID, strings.TrimSpace(data.Reason)),
+		Category: notify.CategoryApplication,
+		RefType: notify.RefApplication,
+		RefID: application.ApplicationID,
+	})
+	if errf != nil {
+		return errf
 	}
 
 	return nil
 }
 
+// studentOffer returns the offer of an application of the student, along with the application
+func (s *StudentService) studentOffer(ctx *gin.Context, userID int64, applicationID int64) (*sqlc.ApplicationOfferRow, *errs.Error) {
+
+	row, err := s.queries.ApplicationOffer(ctx, applicationID)
+	if err != nil {
+		if err.Error() == errs.NoRowsMatch {
+			return nil, &errs.Error{
+				Type: errs.NotFound,
+				Message: "The application does not exist.",
+				ToRespondWith: true,
+			}
+		}
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get offer : " + err.Error(),
+		}
+	}
+	if row.StudentUserID != userID {
+		return nil, &errs.Error{
+			Type: errs.Unauthorized,
+			Message: "The given user ID is not authorized to access requested application.",
+			ToRespondWith: true,
+		}
+	}
+	if !row.OfferID.Valid && row.ApplicationStatus != appstatus.Offered && row.ApplicationStatus != appstatus.Hired && row.ApplicationStatus != appstatus.Declined {
+		return nil, &errs.Error{
+			Type: errs.NotFound,
+			Message: "You have not been offered this job.",
+			ToRespondWith: true,
+		}
+	}
+
+	return &row, nil
+}
+
+// Offer returns the offer of an application of the student
+func (s *StudentService) Offer(ctx *gin.Context, userID int64, applicationid string) (*dto.OfferDetails, *errs.Error) {
+
+	applicationID, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	row, errf := s.studentOffer(ctx, userID, applicationID)
+	if errf != nil {
+		return nil, errf
+	}
+
+	details := &dto.OfferDetails{
+		ApplicationID: row.ApplicationID,
+		JobID: row.JobID,
+		Title: row.Title,
+		CompanyName: row.CompanyName,
+		ApplicationStatus: row.ApplicationStatus,
+		Status: row.Status.String,
+		RespondBy: optionalTime(row.RespondBy),
+		RespondedAt: optionalTime(row.RespondedAt),
+		Reason: row.ResponseReason.String,
+		HasLetter: row.LetterUrl.Valid,
+	}
+	if row.Ctc.Valid {
+		details.CTC = offer.Display(row.Ctc.Int64, row.Currency.String)
+	}
+	if row.JoiningDate.Valid {
+		details.JoiningDate = &row.JoiningDate.Time
+	}
+
+	return details, nil
+}
+
+// OfferLetterPath returns the stored path of the offer letter of an application of the student
+func (s *StudentService) OfferLetterPath(ctx *gin.Context, userID int64, applicationid string) (string, *errs.Error) {
+
+	applicationID, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return "", &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	row, errf := s.studentOffer(ctx, userID, applicationID)
+	if errf != nil {
+		return "", errf
+	}
+	if !row.LetterUrl.Valid {
+		return "", &errs.Error{
+			Type: errs.NotFound,
+			Message: "The offer letter of this offer was sent by email only.",
+			ToRespondWith: true,
+		}
+	}
+	if _, err := os.Stat(row.LetterUrl.String); err != nil {
+		return "", &errs.Error{
+			Type: errs.NotFound,
+			Message: "could not find any file for given path",
+		}
+	}
+
+	return row.LetterUrl.String, nil
+}
+
+// AcceptOffer accepts the offer of an application of the student, the application is Hired.
+// The placement policy may not allow accepting once another offer was accepted.
+func (s *StudentService) AcceptOffer(ctx *gin.Context, userID int64, data *dto.OfferResponse) *errs.Error {
+	return s.respondOffer(ctx, userID, data, true)
+}
+
+// DeclineOffer declines the offer of an application of the student with an optional reason, the application is Declined.
+func (s *StudentService) DeclineOffer(ctx *gin.Context, userID int64, data *dto.OfferResponse) *errs.Error {
+	return s.respondOffer(ctx, userID, data, false)
+}
+
+// respondOffer accepts or declines an offer before its deadline, the company and the placement cell are notified.
+// Offers made before they were recorded are responded to by the status of the application alone.
+func (s *StudentService) respondOffer(ctx *gin.Context, userID int64, data *dto.OfferResponse, accept bool) *errs.Error {
+
+	if data.ApplicationID == 0 {
+		return &errs.Error{
+			Type: errs.MissingRequiredField,
+			Message: "Application ID is required to respond to an offer.",
+			ToRespondWith: true,
+		}
+	}
+
+	row, errf := s.studentOffer(ctx, userID, data.ApplicationID)
+	if errf != nil {
+		return errf
+	}
+	if row.OfferID.Valid {
+		if row.Status.String != offer.Pending {
+			return &errs.Error{
+				Type: errs.InvalidState,
+				Message: fmt.Sprintf("The offer is %s, it cannot be responded to anymore.", strings.ToLower(row.Status.String)),
+				ToRespondWith: true,
+			}
+		}
+		if !row.RespondBy.Time.After(time.Now()) {
+			return &errs.Error{
+				Type: errs.PreconditionFailed,
+				Message: "The offer expired at " + row.RespondBy.Time.Format("03:04 PM 02-01-2006") + ".",
+				ToRespondWith: true,
+			}
+		}
+	}
+
+	to, status, verb := appstatus.Declined, offer.Declined, "declined"
+	if accept {
+		to, status, verb = appstatus.Hired, offer.Accepted, "accepted"
+	}
+	reason := strings.TrimSpace(data.Reason)
+
+	_, errf = changeApplicationStatus(ctx, &statusChange{
+		ApplicationID: data.ApplicationID,
+		To: to,
+		ActorID: userID,
+		Actor: appstatus.ActorStudent,
+		Reason: reason,
+		Then: func(queries *sqlc.Queries, _ *sqlc.ApplicationPartiesRow) *errs.Error {
+			if accept {
+				reasons, errf := acceptanceViolations(ctx, queries, userID, row.JobID)
+				if errf != nil {
+					return errf
+				}
+				if len(reasons) != 0 {
+					return &errs.Error{
+						Type: errs.PreconditionFailed,
+						Message: "The placement policy does not allow you to accept this offer. " + strings.Join(reasons, " ") + " Contact the placement cell if you need an exception.",
+						ToRespondWith: true,
+					}
+				}
+			}
+			if !row.OfferID.Valid {
+				return nil
+			}
+			// the deadline may have passed, or the task expired it, since it was checked
+			responded, err := queries.RespondOffer(ctx, sqlc.RespondOfferParams{
+				Status: status,
+				ResponseReason: reason,
+				ApplicationID: data.ApplicationID,
+			})
+			if err != nil {
+				return &errs.Error{
+					Type: errs.Internal,
+					Message: "Failed to respond to offer : " + err.Error(),
+				}
+			}
+			if responded == 0 {
+				return &errs.Error{
+					Type: errs.InvalidState,
+					Message: "The offer expired, it cannot be responded to anymore.",
+					ToRespondWith: true,
+				}
+			}
+			return nil
+		},
+	})
+	if errf != nil {
+		return errf
+	}
+
+	description := fmt.Sprintf("%s %s the offer for %s (ID: %d).", row.StudentName, verb, row.Title, data.ApplicationID)
+	if reason != "" {
+		description += " Reason : " + reason
+	}
+	errf = s.Notify.NewNotification(ctx, row.CompanyUserID, &dto.NotificationData{
+		Title: "Offer " + verb,
+		Description: description,
+		Category: notify.CategoryOffer,
+		RefType: notify.RefApplication,
+		RefID: data.ApplicationID,
+	})
+	if errf != nil {
+		return errf
+	}
+
+	return notifyAdmins(ctx, s.queries, s.Notify, 0, &dto.NotificationData{
+		Title: "Offer " + verb,
+		Description: fmt.Sprintf("%s %s the offer for %s at %s (ID: %d).", row.StudentName, verb, row.Title, row.CompanyName, data.ApplicationID),
+		Category: notify.CategoryOffer,
+		RefType: notify.RefApplication,
+		RefID: data.ApplicationID,
+	})
+}
+
+// ProposeReschedule proposes new times for a scheduled interview of the student, see proposeReschedule.
+// Times proposed by students are not checked for conflicts of the company, those are not disclosed to them.
+func (s *StudentService) ProposeReschedule(ctx *gin.Context, userID int64, data *dto.RescheduleProposal) *errs.Error {
+	data.Force = false
+	_, errf := proposeReschedule(ctx, s.Notify, userID, appstatus.ActorStudent, data)
+	return errf
+}
+
+// RespondReschedule responds to the reschedule proposed by the company of an interview, see respondReschedule
+func (s *StudentService) RespondReschedule(ctx *gin.Context, userID int64, data *dto.RescheduleResponse) *errs.Error {
+	data.Force = false
+	_, errf := respondReschedule(ctx, s.Notify, userID, appstatus.ActorStudent, data)
+	return errf
+}
+
+// Reschedules returns the reschedule proposals of an interview of the student, oldest first
+func (s *StudentService) Reschedules(ctx *gin.Context, userID int64, interviewid string) ([]sqlc.InterviewRescheduleProposal, *errs.Error) {
+	return rescheduleHistory(ctx, s.queries, userID, appstatus.ActorStudent, interviewid)
+}
+
 func (s *StudentService) MyApplications(ctx *gin.Context, userId any, status string) (*[]sqlc.GetMyApplicationsStatusFilterRow, error) {
 
 	applicationsData, err := s.queries.GetMyApplicationsStatusFilter(ctx, sqlc.GetMyApplicationsStatusFilterParams{
@@ -693,3 +1307,319 @@ func (s *StudentService) FeedbacksData(ctx *gin.Context, userID int64) (*[]sqlc.
 	return &data, nil
 }
 
+// CalendarFeed returns the secret iCal feed url of the user. A token is created if none exists,
+// or replaced if rotate is set, invalidating the previous url.
+func (s *StudentService) CalendarFeed(ctx *gin.Context, userID int64, rotate bool) (string, *errs.Error) {
+
+	token, err := s.queries.GetCalendarFeedToken(ctx, userID)
+	if err != nil && err.Error() != errs.NoRowsMatch {
+		return "", &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get calendar feed token : " + err.Error(),
+		}
+	}
+
+	if err != nil || rotate {
+		newToken, err := utils.RandomToken(config.CalendarFeedTokenBytes)
+		if err != nil {
+			return "", &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to generate calendar feed token : " + err.Error(),
+			}
+		}
+
+		token, err = s.queries.UpsertCalendarFeedToken(ctx, sqlc.UpsertCalendarFeedTokenParams{
+			UserID: userID,
+			Token: newToken,
+		})
+		if err != nil {
+			return "", &errs.Error{
+				Type: errs.Internal,
+				Message: "Failed to save calendar feed token : " + err.Error(),
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s/public/calendarfeed/%s", os.Getenv("Domain"), token), nil
+}
+
+// postedWindows maps the posted filter of the job search to how far back it goes
+var postedWindows = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d": 7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// SearchJobs full-text searches the active jobs the student is eligible for with the facet filters,
+// sorted and paginated by a keyset cursor. The facet counts of every facet are computed with the filters
+// of all the other facets applied, so the UI can show what selecting another value of a facet would give.
+func (s *StudentService) SearchJobs(ctx *gin.Context, userID int64, search *dto.JobSearch) (*dto.JobSearchResult, *errs.Error) {
+
+	if search.Sort == "" {
+		search.Sort = "relevance"
+		if search.Query == "" {
+			search.Sort = "newest"
+		}
+	}
+	if search.Sort != "relevance" && search.Sort != "newest" && search.Sort != "salary" {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Sort must be one of relevance, newest or salary.",
+			ToRespondWith: true,
+		}
+	}
+
+	limit := search.Limit
+	if limit <= 0 {
+		limit = config.JobSearchPageLimit
+	}
+	limit = min(limit, config.JobSearchMaxPageLimit)
+
+	postedAfter := pgtype.Timestamptz{}
+	if search.Posted != "" {
+		window, ok := postedWindows[search.Posted]
+		if !ok {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Posted must be one of 24h, 7d or 30d.",
+				ToRespondWith: true,
+			}
+		}
+		postedAfter = pgtype.Timestamptz{Time: time.Now().Add(-window), Valid: true}
+	}
+
+	salaryMin, salaryMax := pgtype.Float8{}, pgtype.Float8{}
+	if search.SalaryMin != nil {
+		salaryMin = pgtype.Float8{Float64: *search.SalaryMin, Valid: true}
+	}
+	if search.SalaryMax != nil {
+		salaryMax = pgtype.Float8{Float64: *search.SalaryMax, Valid: true}
+	}
+
+	cursorKey, cursorID := pgtype.Float8{}, pgtype.Int8{}
+	if search.Cursor != "" {
+		key, id, err := decodeSearchCursor(search.Cursor, search.Sort)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Invalid cursor : " + err.Error(),
+				ToRespondWith: true,
+			}
+		}
+		cursorKey = pgtype.Float8{Float64: key, Valid: true}
+		cursorID = pgtype.Int8{Int64: id, Valid: true}
+	}
+
+	ineligible, errf := s.ineligibleJobs(ctx, userID)
+	if errf != nil {
+		return nil, errf
+	}
+
+	// a NULL array would not match anything
+	locations, types := nonNil(search.Locations), nonNil(search.Types)
+	positions, industries := nonNil(search.Positions), nonNil(search.Industries)
+
+	jobs, err := s.queries.SearchJobs(ctx, sqlc.SearchJobsParams{
+		Query: strings.TrimSpace(search.Query),
+		Locations: locations,
+		Types: types,
+		Positions: positions,
+		Industries: industries,
+		PostedAfter: postedAfter,
+		SalaryMin: salaryMin,
+		SalaryMax: salaryMax,
+		IneligibleJobIds: ineligible,
+		Sort: search.Sort,
+		UserID: userID,
+		CursorKey: cursorKey,
+		CursorID: cursorID,
+		PageLimit: limit,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to search jobs : " + err.Error(),
+		}
+	}
+
+	facets, err := s.queries.SearchJobFacets(ctx, sqlc.SearchJobFacetsParams{
+		Query: strings.TrimSpace(search.Query),
+		Locations: locations,
+		Types: types,
+		Positions: positions,
+		Industries: industries,
+		PostedAfter: postedAfter,
+		SalaryMin: salaryMin,
+		SalaryMax: salaryMax,
+		IneligibleJobIds: ineligible,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get job search facets : " + err.Error(),
+		}
+	}
+
+	result := &dto.JobSearchResult{
+		Jobs: jobs,
+		Facets: make(map[string][]dto.FacetCount),
+	}
+	if result.Jobs == nil {
+		result.Jobs = []sqlc.SearchJobsRow{}
+	}
+	for _, f := range facets {
+		result.Facets[f.Facet] = append(result.Facets[f.Facet], dto.FacetCount{
+			Value: f.Value,
+			Count: f.Count,
+		})
+	}
+	// a full page may have more after it
+	if len(jobs) == int(limit) {
+		last := jobs[len(jobs) - 1]
+		result.NextCursor = encodeSearchCursor(search.Sort, last.SortKey, last.JobID)
+	}
+
+	return result, nil
+}
+
+// ineligibleJobs returns the open jobs whose eligibility rules the student does not meet, unless overridden,
+// checked as when listing the applicable jobs, see GetApplicableJobs
+func (s *StudentService) ineligibleJobs(ctx *gin.Context, userID int64) ([]int64, *errs.Error) {
+
+	rows, err := s.queries.OpenJobsEligibility(ctx, userID)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get eligibility rules : " + err.Error(),
+		}
+	}
+
+	ineligible := []int64{}
+	if len(rows) == 0 {
+		return ineligible, nil
+	}
+
+	// job ID 0, the override flag is per job and already in the rules
+	st, err := s.queries.StudentEligibilityProfile(ctx, sqlc.StudentEligibilityProfileParams{
+		JobID: 0,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get student profile : " + err.Error(),
+		}
+	}
+	profile := eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras)
+
+	for _, r := range rows {
+		if r.Overridden {
+			continue
+		}
+		rules, err := eligibility.FromColumns(r.MinCgpa, r.Courses, r.Departments, r.YearsOfStudy, r.MaxBacklogs, r.Custom)
+		if err != nil {
+			return nil, &errs.Error{
+				Type: errs.Internal,
+				Message: err.Error(),
+			}
+		}
+		if len(rules.Check(profile)) != 0 {
+			ineligible = append(ineligible, r.JobID)
+		}
+	}
+
+	return ineligible, nil
+}
+
+// encodeSearchCursor encodes the position after the last job of a page, tied to the sort it was made for
+func encodeSearchCursor(sort string, key float64, jobID int64) string {
+	raw := sort + "|" + strconv.FormatFloat(key, 'g', -1, 64) + "|" + strconv.FormatInt(jobID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSearchCursor(cursor string, sort string) (float64, int64, error) {
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return 0, 0, errors.New("malformed cursor")
+	}
+	if parts[0] != sort {
+		return 0, 0, errors.New("cursor is for a different sort")
+	}
+
+	key, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	jobID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return key, jobID, nil
+}
+
+// Recommendations returns the open jobs the student is eligible for and has not applied to, best fitting first,
+// each with the reasons it was recommended. See recommend.Score for how jobs are scored.
+func (s *StudentService) Recommendations(ctx *gin.Context, userID int64, limitStr string) (*[]dto.Recommendation, *errs.Error) {
+
+	limit := config.RecommendationsLimit
+	if limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			return nil, &errs.Error{
+				Type: errs.InvalidFormat,
+				Message: "Limit must be a positive number.",
+				ToRespondWith: true,
+			}
+		}
+		limit = min(l, config.RecommendationsMaxLimit)
+	}
+
+	recommendations, err := recommend.ForStudent(ctx, s.queries, userID, time.Time{}, limit)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.Internal,
+			Message: "Failed to get recommendations : " + err.Error(),
+		}
+	}
+
+	return &recommendations, nil
+}
+
+// listedCompensation is the structured compensation of a listed job, nil if it has none
+func listedCompensation(j *sqlc.GetApplicableJobsTypeFilterRow) *compensation.Compensation {
+	if !j.Currency.Valid {
+		return nil
+	}
+	return &compensation.Compensation{
+		Currency: j.Currency.String,
+		CTCMin: j.CtcMin.Int64,
+		CTCMax: j.CtcMax.Int64,
+		Fixed: j.FixedPay.Int64,
+		Variable: j.VariablePay.Int64,
+		StipendMonthly: j.StipendMonthly.Int64,
+		BondMonths: j.BondMonths.Int32,
+		BondTerms: j.BondTerms.String,
+	}
+}
+
+// ApplicationTimeline returns the status history of an application of the student, see applicationTimeline.
+func (s *StudentService) ApplicationTimeline(ctx *gin.Context, userID int64, applicationid string) (*dto.ApplicationTimeline, *errs.Error) {
+
+	applicationID, err := strconv.ParseInt(applicationid, 10, 64)
+	if err != nil {
+		return nil, &errs.Error{
+			Type: errs.InvalidFormat,
+			Message: "Invalid application ID, failed to parse to int : " + err.Error(),
+			ToRespondWith: true,
+		}
+	}
+
+	return applicationTimeline(ctx, s.queries, applicationID, userID, appstatus.ActorStudent)
+}
@@ -0,0 +1,232 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// formats of a file written from a sheet
+const (
+	FormatCSV = "csv"
+	FormatXLSX = "xlsx"
+	FormatZIP = "zip"
+)
+
+var ContentTypes = map[string]string{
+	FormatCSV: "text/csv",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatZIP: "application/zip",
+}
+
+// Column is a column of a sheet, the cells of a Number column are numbers in an XLSX, text otherwise.
+type Column struct {
+	Name string
+	Number bool
+}
+
+// Sheet is a table with a header row of its column names, rows are as long as the columns.
+type Sheet struct {
+	Name string // of the worksheet of an XLSX, at most 31 characters
+	Columns []Column
+	Rows [][]string
+}
+
+// File is an export written once the response headers are sent, any errors of it are to be found before.
+type File struct {
+	Name string
+	ContentType string
+	Write func(w io.Writer) error
+}
+
+var number = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Entry is a file on disk added to a ZIP under Name.
+type Entry struct {
+	Name string
+	Path string
+}
+
+// WriteCSV writes the sheet as a CSV. Cells that a spreadsheet would take for a formula are escaped, see csvCell.
+func (s *Sheet) WriteCSV(w io.Writer) error {
+
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		header[i] = c.Name
+	}
+	err := cw.Write(header)
+	if err != nil {
+		return err
+	}
+	for _, row := range s.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = csvCell(cell)
+		}
+		err = cw.Write(cells)
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// csvCell prefixes a cell starting with =, +, -, @, a tab or a carriage return with a quote, so a spreadsheet opening
+// the CSV shows it as text instead of running it as a formula. Numbers are left as they are, "-1.5" is not a formula.
+func csvCell(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) || number.MatchString(cell) {
+		return cell
+	}
+	return "'" + cell
+}
+
+// WriteXLSX writes the sheet as an XLSX workbook of a single worksheet, with inline strings and no styles.
+func (s *Sheet) WriteXLSX(w io.Writer) error {
+
+	// characters worksheet names cannot have
+	name := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return ' '
+		}
+		return r
+	}, s.Name))
+	if name == "" {
+		name = "Sheet1"
+	}
+	if len([]rune(name)) > 31 {
+		name = string([]rune(name)[:31])
+	}
+
+	var sheetXml bytes.Buffer
+	sheetXml.WriteString(xml.Header)
+	sheetXml.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		header[i] = c.Name
+	}
+	writeRow(&sheetXml, 1, header, nil)
+	for i, row := range s.Rows {
+		writeRow(&sheetXml, i + 2, row, s.Columns)
+	}
+	sheetXml.WriteString(`</sheetData></worksheet>`)
+
+	var workbook bytes.Buffer
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(&workbook, []byte(name))
+	workbook.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+
+	parts := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`)},
+		{"_rels/.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`)},
+		{"xl/worksheets/sheet1.xml", sheetXml.Bytes()},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		_, err = fw.Write(part.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeRow writes a row of cells, numbers of Number columns as numbers and anything else as inline strings
+func writeRow(buf *bytes.Buffer, r int, cells []string, columns []Column) {
+
+	fmt.Fprintf(buf, `<row r="%d">`, r)
+	for i, cell := range cells {
+		if cell == "" {
+			continue
+		}
+		ref := cellRef(i, r)
+		if columns != nil && columns[i].Number && number.MatchString(cell) {
+			fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, cell)
+			continue
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		xml.EscapeText(buf, []byte(cell))
+		buf.WriteString(`</t></is></c>`)
+	}
+	buf.WriteString(`</row>`)
+}
+
+// cellRef is the A1 reference of the cell in the zero based column and one based row, eg. (27, 3) is AB3
+func cellRef(col int, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A' + (col - 1) % 26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+// WriteZIP writes the files as a ZIP, names made unique by numbering the repeats, eg. 21CS001.pdf and 21CS001-2.pdf.
+func WriteZIP(w io.Writer, entries []Entry) error {
+
+	zw := zip.NewWriter(w)
+	used := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name
+		ext := filepath.Ext(name)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			name = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(entry.Name, ext), n, ext)
+		}
+		used[strings.ToLower(name)] = true
+
+		err := addFile(zw, name, entry.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func addFile(zw *zip.Writer, name string, path string) error {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, f)
+
+	return err
+}
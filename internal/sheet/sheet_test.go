@@ -0,0 +1,30 @@
+package sheet
+
+import "testing"
+
+func TestCSVCell(t *testing.T) {
+
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"", ""},
+		{"Asha Rao", "Asha Rao"},
+		{"-1.5", "-1.5"},
+		{"42", "42"},
+		{"=1+1", "'=1+1"},
+		{"+91 98450 12345", "'+91 98450 12345"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=cmd", "'\t=cmd"},
+		{"\r=cmd", "'\r=cmd"},
+		{"a=b", "a=b"},
+	}
+
+	for _, tt := range tests {
+		got := csvCell(tt.cell)
+		if got != tt.want {
+			t.Errorf("csvCell(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Announcement struct {
+	AnnouncementID int64
+	AuthorID       int64
+	Title          string
+	Message        string
+	TargetRole     pgtype.Int8
+	Department     pgtype.Text
+	Course         pgtype.Text
+	YearOfStudy    pgtype.Text
+	JobID          pgtype.Int8
+	SendEmail      bool
+	ScheduledAt    pgtype.Timestamptz
+	DeliveredAt    pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+}
+
+type AnnouncementRecipient struct {
+	AnnouncementID int64
+	UserID         int64
+	ReadAt         pgtype.Timestamptz
+	NotifiedAt     pgtype.Timestamptz
+	EmailedAt      pgtype.Timestamptz
+}
+
 type Application struct {
 	ApplicationID int64
 	JobID         int64
@@ -15,6 +39,45 @@ type Application struct {
 	DataUrl       pgtype.Text
 	CreatedAt     pgtype.Timestamptz
 	Status        interface{}
+	JobRevision   int32
+	Answers       []byte
+	StageID       pgtype.Int8
+}
+
+type ApplicationStageMove struct {
+	MoveID        int64
+	ApplicationID int64
+	FromStageID   pgtype.Int8
+	ToStageID     pgtype.Int8
+	MovedBy       pgtype.Int8
+	CreatedAt     pgtype.Timestamptz
+}
+
+type ApplicationStatusHistory struct {
+	HistoryID     int64
+	ApplicationID int64
+	FromStatus    pgtype.Text
+	ToStatus      string
+	ActorID       pgtype.Int8
+	ActorRole     string
+	Reason        string
+	CreatedAt     pgtype.Timestamptz
+}
+
+type AuditLog struct {
+	AuditID    int64
+	ActorID    int64
+	Action     string
+	EntityType string
+	EntityID   int64
+	Details    []byte
+	CreatedAt  pgtype.Timestamptz
+}
+
+type CalendarFeed struct {
+	UserID    int64
+	Token     string
+	CreatedAt pgtype.Timestamptz
 }
 
 type Company struct {
@@ -32,6 +95,23 @@ type Company struct {
 	Industry              string
 }
 
+type DeviceToken struct {
+	Token      string
+	UserID     int64
+	Platform   string
+	CreatedAt  pgtype.Timestamptz
+	LastSeenAt pgtype.Timestamptz
+}
+
+type DigestPreference struct {
+	UserID     int64
+	Frequency  string
+	SendHour   int32
+	Weekday    int32
+	Timezone   string
+	LastSentAt pgtype.Timestamptz
+}
+
 type Discussion struct {
 	PostID    int64
 	UserID    int64
@@ -40,6 +120,14 @@ type Discussion struct {
 	Content   string
 }
 
+type EligibilityOverride struct {
+	JobID     int64
+	StudentID int64
+	GrantedBy int64
+	Reason    string
+	CreatedAt pgtype.Timestamptz
+}
+
 type Feedback struct {
 	FeedbackID    int64
 	CreatedAt     pgtype.Timestamptz
@@ -50,32 +138,116 @@ type Feedback struct {
 }
 
 type Interview struct {
-	InterviewID   int64
-	ApplicationID int64
-	CompanyID     int64
-	DateTime      pgtype.Timestamptz
-	Type          interface{}
-	Status        interface{}
-	Notes         pgtype.Text
-	Location      string
-	CreatedAt     pgtype.Timestamptz
-	Extras        []byte
+	InterviewID     int64
+	ApplicationID   int64
+	CompanyID       int64
+	DateTime        pgtype.Timestamptz
+	Type            interface{}
+	Status          interface{}
+	Notes           pgtype.Text
+	Location        string
+	CreatedAt       pgtype.Timestamptz
+	Extras          []byte
+	IcalSequence    int32
+	StageID         pgtype.Int8
+	DurationMinutes int32
+	Panel           string
+}
+
+type InterviewRescheduleProposal struct {
+	ProposalID     int64
+	InterviewID    int64
+	ProposedBy     string
+	ProposerID     pgtype.Int8
+	Times          []pgtype.Timestamptz
+	Reason         string
+	PreviousTime   pgtype.Timestamptz
+	Status         string
+	AgreedTime     pgtype.Timestamptz
+	ResponseReason string
+	RespondedBy    pgtype.Int8
+	RespondedAt    pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
 }
 
 type Job struct {
+	JobID                int64
+	DataUrl              pgtype.Text
+	CreatedAt            pgtype.Timestamp
+	CompanyID            int64
+	Title                string
+	Location             string
+	Type                 string
+	Salary               string
+	Skills               []string
+	Position             string
+	Extras               []byte
+	ActiveStatus         bool
+	Description          pgtype.Text
+	Revision             int32
+	UpdatedAt            pgtype.Timestamptz
+	SearchVector         interface{}
+	Deadline             pgtype.Timestamptz
+	MaxApplicants        pgtype.Int4
+	DeadlineReminderSent bool
+	Questions            []byte
+	ApprovalStatus       string
+	SubmittedAt          pgtype.Timestamptz
+}
+
+type JobCompensation struct {
+	JobID          int64
+	Currency       string
+	CtcMin         int64
+	CtcMax         int64
+	FixedPay       int64
+	VariablePay    int64
+	StipendMonthly int64
+	BondMonths     int32
+	BondTerms      string
+	Parsed         bool
+	UpdatedAt      pgtype.Timestamptz
+}
+
+type JobEligibility struct {
 	JobID        int64
-	DataUrl      pgtype.Text
-	CreatedAt    pgtype.Timestamp
-	CompanyID    int64
-	Title        string
-	Location     string
-	Type         string
-	Salary       string
-	Skills       []string
-	Position     string
-	Extras       []byte
-	ActiveStatus bool
-	Description  pgtype.Text
+	MinCgpa      pgtype.Float8
+	Courses      []string
+	Departments  []string
+	YearsOfStudy []string
+	MaxBacklogs  pgtype.Int4
+	Custom       []byte
+	UpdatedAt    pgtype.Timestamptz
+}
+
+type JobPipelineStage struct {
+	StageID   int64
+	JobID     int64
+	Position  int32
+	Name      string
+	Kind      string
+	TestID    pgtype.Int8
+	CreatedAt pgtype.Timestamptz
+}
+
+type JobReview struct {
+	ReviewID   int64
+	JobID      int64
+	Revision   int32
+	ReviewerID int64
+	Decision   string
+	Comment    string
+	CreatedAt  pgtype.Timestamptz
+}
+
+type JobRevision struct {
+	JobID         int64
+	Revision      int32
+	ChangedBy     int64
+	ChangedFields []string
+	Changes       []byte
+	Material      bool
+	CreatedAt     pgtype.Timestamptz
 }
 
 type Notification struct {
@@ -85,6 +257,55 @@ type Notification struct {
 	Description pgtype.Text
 	ReadStatus  bool
 	Timestamp   int64
+	Category    string
+	Archived    bool
+	RefType     pgtype.Text
+	RefID       pgtype.Int8
+}
+
+type Offer struct {
+	OfferID        int64
+	ApplicationID  int64
+	Ctc            int64
+	Currency       string
+	JoiningDate    pgtype.Date
+	RespondBy      pgtype.Timestamptz
+	LetterUrl      string
+	Status         string
+	ResponseReason string
+	RespondedAt    pgtype.Timestamptz
+	OfferedBy      pgtype.Int8
+	TemplateID     pgtype.Int8
+	CreatedAt      pgtype.Timestamptz
+}
+
+type OfferLetterTemplate struct {
+	TemplateID int64
+	CompanyID  int64
+	Name       string
+	Title      string
+	Body       string
+	CreatedAt  pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type PlacementPolicy struct {
+	PolicyID             int32
+	MaxOffers            int32
+	BlockAfterAcceptance bool
+	Tiers                []byte
+	UpdatedBy            pgtype.Int8
+	UpdatedAt            pgtype.Timestamptz
+}
+
+type PolicyException struct {
+	ExceptionID int64
+	StudentID   int64
+	JobID       pgtype.Int8
+	Rule        string
+	Reason      string
+	GrantedBy   int64
+	CreatedAt   pgtype.Timestamptz
 }
 
 type Student struct {
@@ -106,6 +327,7 @@ type Student struct {
 	UserID       int64
 	Extras       []byte
 	PictureUrl   pgtype.Text
+	Backlogs     int32
 }
 
 type TempCorrectAnswer struct {
@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const adminUserIDs = `-- name: AdminUserIDs :many
+SELECT users.user_id FROM users WHERE users.role = 3
+`
+
+func (q *Queries) AdminUserIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.Query(ctx, adminUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const applicantsCount = `-- name: ApplicantsCount :many
 WITH ji AS (
     SELECT
@@ -26,7 +50,8 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'ShortListed' THEN 1 END), 0) AS BIGINT) AS shortlisted_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Rejected' THEN 1 END), 0) AS BIGINT) AS rejected_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Offered' THEN 1 END), 0) AS BIGINT) AS offered_count,
-    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count,
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Withdrawn' THEN 1 END), 0) AS BIGINT) AS withdrawn_count
 FROM applications
 JOIN ji ON ji.job_id = applications.job_id
 GROUP BY applications.job_id
@@ -40,6 +65,7 @@ type ApplicantsCountRow struct {
 	RejectedCount    int64
 	OfferedCount     int64
 	HiredCount       int64
+	WithdrawnCount   int64
 }
 
 func (q *Queries) ApplicantsCount(ctx context.Context, userID int64) ([]ApplicantsCountRow, error) {
@@ -59,6 +85,7 @@ func (q *Queries) ApplicantsCount(ctx context.Context, userID int64) ([]Applican
 			&i.RejectedCount,
 			&i.OfferedCount,
 			&i.HiredCount,
+			&i.WithdrawnCount,
 		); err != nil {
 			return nil, err
 		}
@@ -70,6 +97,33 @@ func (q *Queries) ApplicantsCount(ctx context.Context, userID int64) ([]Applican
 	return items, nil
 }
 
+const applicationAnswers = `-- name: ApplicationAnswers :one
+SELECT
+    jobs.questions,
+    applications.answers
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+WHERE applications.application_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2)
+`
+
+type ApplicationAnswersParams struct {
+	ApplicationID int64
+	UserID        int64
+}
+
+type ApplicationAnswersRow struct {
+	Questions []byte
+	Answers   []byte
+}
+
+func (q *Queries) ApplicationAnswers(ctx context.Context, arg ApplicationAnswersParams) (ApplicationAnswersRow, error) {
+	row := q.db.QueryRow(ctx, applicationAnswers, arg.ApplicationID, arg.UserID)
+	var i ApplicationAnswersRow
+	err := row.Scan(&i.Questions, &i.Answers)
+	return i, err
+}
+
 const applicationHistory = `-- name: ApplicationHistory :many
 SELECT 
     applications.application_id,
@@ -84,14 +138,14 @@ ORDER BY applications.created_at DESC
 `
 
 type ApplicationHistoryRow struct {
-	ApplicationID int64
-	CreatedAt     string
-	Title         string
-	CompanyName   string
+	Skills   []string
+	Type     string
+	Position string
+	Status   string
 }
 
-func (q *Queries) ApplicationHistory(ctx context.Context, userID int64) ([]ApplicationHistoryRow, error) {
-	rows, err := q.db.Query(ctx, applicationHistory, userID)
+func (q *Queries) ApplicationHistory(ctx context.Context, studentID int64) ([]ApplicationHistoryRow, error) {
+	rows, err := q.db.Query(ctx, applicationHistory, studentID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +154,10 @@ func (q *Queries) ApplicationHistory(ctx context.Context, userID int64) ([]Appli
 	for rows.Next() {
 		var i ApplicationHistoryRow
 		if err := rows.Scan(
-			&i.ApplicationID,
-			&i.CreatedAt,
-			&i.Title,
-			&i.CompanyName,
+			&i.Skills,
+			&i.Type,
+			&i.Position,
+			&i.Status,
 		); err != nil {
 			return nil, err
 		}
@@ -115,55 +169,160 @@ func (q *Queries) ApplicationHistory(ctx context.Context, userID int64) ([]Appli
 	return items, nil
 }
 
-const applicationStatusTo = `-- name: ApplicationStatusTo :one
-WITH upd AS (
-    UPDATE applications
-    SET status = $1
-    WHERE application_id = $2
-    RETURNING student_id
-)
+const applicationOffer = `-- name: ApplicationOffer :one
 SELECT
-    students.user_id
-FROM students 
-JOIN upd ON students.student_id = upd.student_id
+    applications.application_id,
+    applications.job_id,
+    applications.status::TEXT AS application_status,
+    jobs.title,
+    companies.company_name,
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id,
+    students.student_name,
+    offers.offer_id,
+    offers.ctc,
+    offers.currency,
+    offers.joining_date,
+    offers.respond_by,
+    offers.letter_url,
+    offers.status,
+    offers.response_reason,
+    offers.responded_at,
+    offers.created_at
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN offers ON applications.application_id = offers.application_id
+WHERE applications.application_id = $1
 `
 
-type ApplicationStatusToParams struct {
-	Status        interface{}
-	ApplicationID int64
+type ApplicationOfferRow struct {
+	ApplicationID     int64
+	JobID             int64
+	ApplicationStatus string
+	Title             string
+	CompanyName       string
+	CompanyUserID     int64
+	StudentUserID     int64
+	StudentName       string
+	OfferID           pgtype.Int8
+	Ctc               pgtype.Int8
+	Currency          pgtype.Text
+	JoiningDate       pgtype.Date
+	RespondBy         pgtype.Timestamptz
+	LetterUrl         pgtype.Text
+	Status            pgtype.Text
+	ResponseReason    pgtype.Text
+	RespondedAt       pgtype.Timestamptz
+	CreatedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) ApplicationOffer(ctx context.Context, applicationID int64) (ApplicationOfferRow, error) {
+	row := q.db.QueryRow(ctx, applicationOffer, applicationID)
+	var i ApplicationOfferRow
+	err := row.Scan(
+		&i.ApplicationID,
+		&i.JobID,
+		&i.ApplicationStatus,
+		&i.Title,
+		&i.CompanyName,
+		&i.CompanyUserID,
+		&i.StudentUserID,
+		&i.StudentName,
+		&i.OfferID,
+		&i.Ctc,
+		&i.Currency,
+		&i.JoiningDate,
+		&i.RespondBy,
+		&i.LetterUrl,
+		&i.Status,
+		&i.ResponseReason,
+		&i.RespondedAt,
+		&i.CreatedAt,
+	)
+	return i, err
 }
 
-func (q *Queries) ApplicationStatusTo(ctx context.Context, arg ApplicationStatusToParams) (int64, error) {
-	row := q.db.QueryRow(ctx, applicationStatusTo, arg.Status, arg.ApplicationID)
-	var user_id int64
-	err := row.Scan(&user_id)
-	return user_id, err
+const applicationParties = `-- name: ApplicationParties :one
+SELECT
+    applications.status::TEXT AS status,
+    applications.job_id,
+    jobs.title,
+    students.user_id AS student_user_id,
+    companies.user_id AS company_user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = $1
+`
+
+type ApplicationPartiesRow struct {
+	Status        string
+	JobID         int64
+	Title         string
+	StudentUserID int64
+	CompanyUserID int64
 }
 
-const applicationStatusToAnd = `-- name: ApplicationStatusToAnd :one
-WITH upd AS (
-    UPDATE applications
-    SET status = $1
-    WHERE application_id = $2 AND status = $3
-    RETURNING student_id
-)
+func (q *Queries) ApplicationParties(ctx context.Context, applicationID int64) (ApplicationPartiesRow, error) {
+	row := q.db.QueryRow(ctx, applicationParties, applicationID)
+	var i ApplicationPartiesRow
+	err := row.Scan(
+		&i.Status,
+		&i.JobID,
+		&i.Title,
+		&i.StudentUserID,
+		&i.CompanyUserID,
+	)
+	return i, err
+}
+
+const applicationTimeline = `-- name: ApplicationTimeline :many
 SELECT
-    students.user_id
-FROM students 
-JOIN upd ON students.student_id = upd.student_id
+    application_status_history.from_status,
+    application_status_history.to_status,
+    application_status_history.actor_role,
+    application_status_history.reason,
+    application_status_history.created_at
+FROM application_status_history
+WHERE application_status_history.application_id = $1
+ORDER BY application_status_history.created_at, application_status_history.history_id
 `
 
-type ApplicationStatusToAndParams struct {
-	Status        interface{}
-	ApplicationID int64
-	Status_2      interface{}
+type ApplicationTimelineRow struct {
+	FromStatus pgtype.Text
+	ToStatus   string
+	ActorRole  string
+	Reason     string
+	CreatedAt  pgtype.Timestamptz
 }
 
-func (q *Queries) ApplicationStatusToAnd(ctx context.Context, arg ApplicationStatusToAndParams) (int64, error) {
-	row := q.db.QueryRow(ctx, applicationStatusToAnd, arg.Status, arg.ApplicationID, arg.Status_2)
-	var user_id int64
-	err := row.Scan(&user_id)
-	return user_id, err
+func (q *Queries) ApplicationTimeline(ctx context.Context, applicationID int64) ([]ApplicationTimelineRow, error) {
+	rows, err := q.db.Query(ctx, applicationTimeline, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApplicationTimelineRow
+	for rows.Next() {
+		var i ApplicationTimelineRow
+		if err := rows.Scan(
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.ActorRole,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const applicationsStatusCounts = `-- name: ApplicationsStatusCounts :one
@@ -173,7 +332,8 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'ShortListed' THEN 1 END), 0) AS BIGINT) AS shortlisted_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Rejected' THEN 1 END), 0) AS BIGINT) AS rejected_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Offered' THEN 1 END), 0) AS BIGINT) AS offered_count,
-    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count,
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Withdrawn' THEN 1 END), 0) AS BIGINT) AS withdrawn_count
 FROM applications
 WHERE applications.student_id = (SELECT students.student_id FROM students WHERE students.user_id = $1)
 `
@@ -185,6 +345,7 @@ type ApplicationsStatusCountsRow struct {
 	RejectedCount    int64
 	OfferedCount     int64
 	HiredCount       int64
+	WithdrawnCount   int64
 }
 
 func (q *Queries) ApplicationsStatusCounts(ctx context.Context, userID int64) (ApplicationsStatusCountsRow, error) {
@@ -197,236 +358,215 @@ func (q *Queries) ApplicationsStatusCounts(ctx context.Context, userID int64) (A
 		&i.RejectedCount,
 		&i.OfferedCount,
 		&i.HiredCount,
+		&i.WithdrawnCount,
 	)
 	return i, err
 }
 
-const cancelApplication = `-- name: CancelApplication :exec
-DELETE FROM applications 
-WHERE student_id = (SELECT student_id FROM students WHERE students.user_id = $1) 
-AND job_id = $2
+const archiveNotifications = `-- name: ArchiveNotifications :execrows
+UPDATE notifications
+SET archived = $1
+WHERE user_id = $2
+AND notif_id = ANY($3::BIGINT[])
 `
 
-type CancelApplicationParams struct {
-	UserID int64
-	JobID  int64
+type ArchiveNotificationsParams struct {
+	Archived bool
+	UserID   int64
+	NotifIds []int64
 }
 
-func (q *Queries) CancelApplication(ctx context.Context, arg CancelApplicationParams) error {
-	_, err := q.db.Exec(ctx, cancelApplication, arg.UserID, arg.JobID)
-	return err
+func (q *Queries) ArchiveNotifications(ctx context.Context, arg ArchiveNotificationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveNotifications, arg.Archived, arg.UserID, arg.NotifIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const cancelInterviewEmailData = `-- name: CancelInterviewEmailData :one
-SELECT 
-    students.student_name, 
-    students.student_email,
-    j.title,
-    c.company_name,
-    TO_CHAR(t.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
-    c.representative_name,
-    c.representative_email
-FROM students
-JOIN (
-    SELECT 
-        job_id, 
-        student_id, 
-        interviews.date_time
-    FROM applications 
-    JOIN interviews ON interviews.application_id = applications.application_id 
-    WHERE applications.application_id = $1) AS t 
-ON t.student_id = students.student_id
-JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
-JOIN (SELECT company_id, company_name, representative_name, representative_email FROM companies) AS c ON j.company_id = c.company_id
+const auditLogForEntity = `-- name: AuditLogForEntity :many
+SELECT
+    audit_log.audit_id,
+    audit_log.actor_id,
+    audit_log.action,
+    audit_log.details,
+    audit_log.created_at
+FROM audit_log
+WHERE audit_log.entity_type = $1
+AND audit_log.entity_id = $2
+ORDER BY audit_log.created_at DESC
 `
 
-type CancelInterviewEmailDataRow struct {
-	StudentName         string
-	StudentEmail        string
-	Title               string
-	CompanyName         string
-	DateTime            string
-	RepresentativeName  string
-	RepresentativeEmail string
+type AuditLogForEntityParams struct {
+	EntityType string
+	EntityID   int64
 }
 
-func (q *Queries) CancelInterviewEmailData(ctx context.Context, applicationID int64) (CancelInterviewEmailDataRow, error) {
-	row := q.db.QueryRow(ctx, cancelInterviewEmailData, applicationID)
-	var i CancelInterviewEmailDataRow
-	err := row.Scan(
-		&i.StudentName,
-		&i.StudentEmail,
-		&i.Title,
-		&i.CompanyName,
-		&i.DateTime,
-		&i.RepresentativeName,
-		&i.RepresentativeEmail,
-	)
-	return i, err
+type AuditLogForEntityRow struct {
+	AuditID   int64
+	ActorID   int64
+	Action    string
+	Details   []byte
+	CreatedAt pgtype.Timestamptz
 }
 
-const clearAnswersTable = `-- name: ClearAnswersTable :exec
-DELETE FROM temp_correct_answers
+func (q *Queries) AuditLogForEntity(ctx context.Context, arg AuditLogForEntityParams) ([]AuditLogForEntityRow, error) {
+	rows, err := q.db.Query(ctx, auditLogForEntity, arg.EntityType, arg.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLogForEntityRow
+	for rows.Next() {
+		var i AuditLogForEntityRow
+		if err := rows.Scan(
+			&i.AuditID,
+			&i.ActorID,
+			&i.Action,
+			&i.Details,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const bulkApplicationParties = `-- name: BulkApplicationParties :many
+SELECT
+    applications.application_id,
+    applications.status::TEXT AS status,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_email,
+    companies.user_id AS company_user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = ANY($1::BIGINT[])
+ORDER BY applications.application_id
 `
 
-func (q *Queries) ClearAnswersTable(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, clearAnswersTable)
-	return err
+type BulkApplicationPartiesRow struct {
+	ApplicationID int64
+	Status        string
+	JobID         int64
+	Title         string
+	CompanyName   string
+	StudentUserID int64
+	StudentEmail  string
+	CompanyUserID int64
 }
 
-const closeJob = `-- name: CloseJob :exec
+func (q *Queries) BulkApplicationParties(ctx context.Context, applicationIds []int64) ([]BulkApplicationPartiesRow, error) {
+	rows, err := q.db.Query(ctx, bulkApplicationParties, applicationIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BulkApplicationPartiesRow
+	for rows.Next() {
+		var i BulkApplicationPartiesRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.Status,
+			&i.JobID,
+			&i.Title,
+			&i.CompanyName,
+			&i.StudentUserID,
+			&i.StudentEmail,
+			&i.CompanyUserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const bumpJobRevision = `-- name: BumpJobRevision :one
 UPDATE jobs
-SET active_status = false
-WHERE jobs.job_id = $1 
-AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE user_id = $2)
+SET revision = revision + 1,
+    updated_at = NOW()
+WHERE job_id = $1
+AND company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2)
+RETURNING revision
 `
 
-type CloseJobParams struct {
+type BumpJobRevisionParams struct {
 	JobID  int64
 	UserID int64
 }
 
-func (q *Queries) CloseJob(ctx context.Context, arg CloseJobParams) error {
-	_, err := q.db.Exec(ctx, closeJob, arg.JobID, arg.UserID)
-	return err
+func (q *Queries) BumpJobRevision(ctx context.Context, arg BumpJobRevisionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, bumpJobRevision, arg.JobID, arg.UserID)
+	var revision int32
+	err := row.Scan(&revision)
+	return revision, err
 }
 
-const companyDashboardData = `-- name: CompanyDashboardData :one
-WITH company AS (
-    SELECT 
-        company_id, 
-        company_name,
-        representative_name 
-    FROM companies WHERE user_id = $1
-),
-jc AS (
-    SELECT COUNT(jobs.job_id) AS jobs_count
-    FROM jobs
-    WHERE jobs.company_id = (SELECT company_id FROM company)
-),
-ac AS (
-    SELECT COUNT(applications.application_id) AS applications_count
-    FROM applications
-    JOIN jobs ON applications.job_id = jobs.job_id
-    WHERE jobs.company_id = (SELECT company_id FROM company)
-),
-ic AS (
-    SELECT COUNT(interviews.interview_id) AS interviews_count
-    FROM interviews
-    WHERE interviews.company_id = (SELECT company_id FROM company)
-    AND interviews.status = 'Scheduled'
-)
-SELECT company_id, company_name, representative_name, jobs_count, applications_count, interviews_count 
-FROM company
-CROSS JOIN jc
-CROSS JOIN ac
-CROSS JOIN ic
-`
-
-type CompanyDashboardDataRow struct {
-	CompanyID          int64
-	CompanyName        string
-	RepresentativeName string
-	JobsCount          int64
-	ApplicationsCount  int64
-	InterviewsCount    int64
-}
-
-func (q *Queries) CompanyDashboardData(ctx context.Context, userID int64) (CompanyDashboardDataRow, error) {
-	row := q.db.QueryRow(ctx, companyDashboardData, userID)
-	var i CompanyDashboardDataRow
-	err := row.Scan(
-		&i.CompanyID,
-		&i.CompanyName,
-		&i.RepresentativeName,
-		&i.JobsCount,
-		&i.ApplicationsCount,
-		&i.InterviewsCount,
-	)
-	return i, err
-}
-
-const companyProfileData = `-- name: CompanyProfileData :one
-SELECT 
-    companies.company_name,
-    companies.representative_email,
-    companies.representative_contact,
-    companies.representative_name,
-    companies.address,
-    companies.website,
-    companies.description,
-    companies.industry
-FROM companies
-WHERE companies.user_id = $1
-`
-
-type CompanyProfileDataRow struct {
-	CompanyName           string
-	RepresentativeEmail   string
-	RepresentativeContact string
-	RepresentativeName    string
-	Address               string
-	Website               pgtype.Text
-	Description           pgtype.Text
-	Industry              string
-}
-
-func (q *Queries) CompanyProfileData(ctx context.Context, userID int64) (CompanyProfileDataRow, error) {
-	row := q.db.QueryRow(ctx, companyProfileData, userID)
-	var i CompanyProfileDataRow
-	err := row.Scan(
-		&i.CompanyName,
-		&i.RepresentativeEmail,
-		&i.RepresentativeContact,
-		&i.RepresentativeName,
-		&i.Address,
-		&i.Website,
-		&i.Description,
-		&i.Industry,
-	)
-	return i, err
-}
-
-const completedInterviewsCompany = `-- name: CompletedInterviewsCompany :many
-SELECT 
-    students.student_name,
-    students.student_id,
+const calendarFeedInterviewsStudent = `-- name: CalendarFeedInterviewsStudent :many
+SELECT
     interviews.interview_id,
-    interviews.application_id,
-    TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
-    interviews.extras
-FROM interviews
-JOIN applications ON interviews.application_id = applications.application_id
-JOIN students ON students.student_id = applications.student_id
-WHERE interviews.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $1)
-AND interviews.status = 'Completed'
+    interviews.date_time,
+    interviews.ical_sequence,
+    interviews.type::TEXT,
+    interviews.location,
+    interviews.notes,
+    interviews.duration_minutes,
+    companies.company_name,
+    jobs.title
+FROM applications
+JOIN interviews ON applications.application_id = interviews.application_id
+                AND interviews.status NOT IN ('Completed', 'Cancelled')
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND interviews.date_time > NOW()
+ORDER BY interviews.date_time
 `
 
-type CompletedInterviewsCompanyRow struct {
-	StudentName   string
-	StudentID     int64
-	InterviewID   int64
-	ApplicationID int64
-	DateTime      string
-	Extras        []byte
+type CalendarFeedInterviewsStudentRow struct {
+	InterviewID     int64
+	DateTime        pgtype.Timestamptz
+	IcalSequence    int32
+	InterviewsType  string
+	Location        string
+	Notes           pgtype.Text
+	DurationMinutes int32
+	CompanyName     string
+	Title           string
 }
 
-func (q *Queries) CompletedInterviewsCompany(ctx context.Context, userID int64) ([]CompletedInterviewsCompanyRow, error) {
-	rows, err := q.db.Query(ctx, completedInterviewsCompany, userID)
+func (q *Queries) CalendarFeedInterviewsStudent(ctx context.Context, userID int64) ([]CalendarFeedInterviewsStudentRow, error) {
+	rows, err := q.db.Query(ctx, calendarFeedInterviewsStudent, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CompletedInterviewsCompanyRow
+	var items []CalendarFeedInterviewsStudentRow
 	for rows.Next() {
-		var i CompletedInterviewsCompanyRow
+		var i CalendarFeedInterviewsStudentRow
 		if err := rows.Scan(
-			&i.StudentName,
-			&i.StudentID,
 			&i.InterviewID,
-			&i.ApplicationID,
 			&i.DateTime,
-			&i.Extras,
+			&i.IcalSequence,
+			&i.InterviewsType,
+			&i.Location,
+			&i.Notes,
+			&i.DurationMinutes,
+			&i.CompanyName,
+			&i.Title,
 		); err != nil {
 			return nil, err
 		}
@@ -438,47 +578,49 @@ func (q *Queries) CompletedInterviewsCompany(ctx context.Context, userID int64)
 	return items, nil
 }
 
-const completedInterviewsStudent = `-- name: CompletedInterviewsStudent :many
-SELECT 
-    jobs.title AS job_title,
+const calendarFeedTestsStudent = `-- name: CalendarFeedTestsStudent :many
+SELECT
+    tests.test_id,
+    tests.test_name,
+    tests.duration,
+    tests.end_time,
     companies.company_name,
-    interviews.interview_id,
-    interviews.application_id,
-    TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS interview_date_time,
-    interviews.extras
-FROM interviews
-JOIN applications ON interviews.application_id = applications.application_id
+    jobs.title
+FROM applications
+JOIN tests ON applications.job_id = tests.job_id
 JOIN jobs ON applications.job_id = jobs.job_id
 JOIN companies ON jobs.company_id = companies.company_id
 WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
-AND interviews.status = 'Completed'
+AND NOT EXISTS (SELECT 1 FROM testresults WHERE testresults.test_id = tests.test_id AND testresults.user_id = $1)
+AND tests.end_time > NOW()
+ORDER BY tests.end_time
 `
 
-type CompletedInterviewsStudentRow struct {
-	JobTitle          string
-	CompanyName       string
-	InterviewID       int64
-	ApplicationID     int64
-	InterviewDateTime string
-	Extras            []byte
+type CalendarFeedTestsStudentRow struct {
+	TestID      int64
+	TestName    string
+	Duration    int64
+	EndTime     pgtype.Timestamptz
+	CompanyName string
+	Title       string
 }
 
-func (q *Queries) CompletedInterviewsStudent(ctx context.Context, userID int64) ([]CompletedInterviewsStudentRow, error) {
-	rows, err := q.db.Query(ctx, completedInterviewsStudent, userID)
+func (q *Queries) CalendarFeedTestsStudent(ctx context.Context, userID int64) ([]CalendarFeedTestsStudentRow, error) {
+	rows, err := q.db.Query(ctx, calendarFeedTestsStudent, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CompletedInterviewsStudentRow
+	var items []CalendarFeedTestsStudentRow
 	for rows.Next() {
-		var i CompletedInterviewsStudentRow
+		var i CalendarFeedTestsStudentRow
 		if err := rows.Scan(
-			&i.JobTitle,
+			&i.TestID,
+			&i.TestName,
+			&i.Duration,
+			&i.EndTime,
 			&i.CompanyName,
-			&i.InterviewID,
-			&i.ApplicationID,
-			&i.InterviewDateTime,
-			&i.Extras,
+			&i.Title,
 		); err != nil {
 			return nil, err
 		}
@@ -490,44 +632,115 @@ func (q *Queries) CompletedInterviewsStudent(ctx context.Context, userID int64)
 	return items, nil
 }
 
-const completedTestsCompany = `-- name: CompletedTestsCompany :many
+const calendarFeedUser = `-- name: CalendarFeedUser :one
+SELECT
+    users.user_id,
+    users.role
+FROM calendar_feeds
+JOIN users ON calendar_feeds.user_id = users.user_id
+WHERE calendar_feeds.token = $1
+`
+
+type CalendarFeedUserRow struct {
+	UserID int64
+	Role   int64
+}
+
+func (q *Queries) CalendarFeedUser(ctx context.Context, token string) (CalendarFeedUserRow, error) {
+	row := q.db.QueryRow(ctx, calendarFeedUser, token)
+	var i CalendarFeedUserRow
+	err := row.Scan(&i.UserID, &i.Role)
+	return i, err
+}
+
+const cancelInterviewEmailData = `-- name: CancelInterviewEmailData :one
 SELECT 
-    tests.test_id,
-    tests.test_name,
-    TO_CHAR(tests.end_time, 'HH12:MI AM DD-MM-YYYY') AS end_time,
-    tests.threshold,
-    tests.published,
-    TO_CHAR(tests.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at
-FROM tests
-WHERE tests.end_time < NOW()
-AND tests.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $1)
+    students.student_name, 
+    students.student_email,
+    j.title,
+    c.company_name,
+    c.representative_name,
+    c.representative_email
+FROM students
+JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
+JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
+JOIN (SELECT company_id, company_name, representative_name, representative_email FROM companies) AS c ON j.company_id = c.company_id
 `
 
-type CompletedTestsCompanyRow struct {
-	TestID    int64
-	TestName  string
-	EndTime   string
-	Threshold int32
-	Published bool
-	CreatedAt string
+type CancelInterviewEmailDataRow struct {
+	StudentName         string
+	StudentEmail        string
+	Title               string
+	CompanyName         string
+	RepresentativeName  string
+	RepresentativeEmail string
 }
 
-func (q *Queries) CompletedTestsCompany(ctx context.Context, userID int64) ([]CompletedTestsCompanyRow, error) {
-	rows, err := q.db.Query(ctx, completedTestsCompany, userID)
+func (q *Queries) CancelInterviewEmailData(ctx context.Context, applicationID int64) (CancelInterviewEmailDataRow, error) {
+	row := q.db.QueryRow(ctx, cancelInterviewEmailData, applicationID)
+	var i CancelInterviewEmailDataRow
+	err := row.Scan(
+		&i.StudentName,
+		&i.StudentEmail,
+		&i.Title,
+		&i.CompanyName,
+		&i.RepresentativeName,
+		&i.RepresentativeEmail,
+	)
+	return i, err
+}
+
+const cancelScheduledInterview = `-- name: CancelScheduledInterview :many
+WITH cancelled AS (
+    SELECT interviews.interview_id
+    FROM interviews
+    WHERE interviews.application_id = $1
+    AND interviews.status IN ('Scheduled', 'Reschedule Requested')
+    AND (NOT $2::BOOLEAN OR interviews.date_time > NOW())
+),
+closed AS (
+    UPDATE interview_reschedule_proposals
+    SET status = 'Cancelled', responded_at = NOW()
+    WHERE interview_reschedule_proposals.status = 'Pending'
+    AND interview_id IN (SELECT interview_id FROM cancelled)
+)
+UPDATE interviews
+SET status = 'Cancelled',
+    ical_sequence = ical_sequence + 1
+WHERE interview_id IN (SELECT interview_id FROM cancelled)
+RETURNING
+    interviews.interview_id,
+    interviews.ical_sequence,
+    interviews.date_time,
+    interviews.duration_minutes
+`
+
+type CancelScheduledInterviewParams struct {
+	ApplicationID int64
+	UpcomingOnly  bool
+}
+
+type CancelScheduledInterviewRow struct {
+	InterviewID     int64
+	IcalSequence    int32
+	DateTime        pgtype.Timestamptz
+	DurationMinutes int32
+}
+
+func (q *Queries) CancelScheduledInterview(ctx context.Context, arg CancelScheduledInterviewParams) ([]CancelScheduledInterviewRow, error) {
+	rows, err := q.db.Query(ctx, cancelScheduledInterview, arg.ApplicationID, arg.UpcomingOnly)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CompletedTestsCompanyRow
+	var items []CancelScheduledInterviewRow
 	for rows.Next() {
-		var i CompletedTestsCompanyRow
+		var i CancelScheduledInterviewRow
 		if err := rows.Scan(
-			&i.TestID,
-			&i.TestName,
-			&i.EndTime,
-			&i.Threshold,
-			&i.Published,
-			&i.CreatedAt,
+			&i.InterviewID,
+			&i.IcalSequence,
+			&i.DateTime,
+			&i.DurationMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -539,56 +752,42 @@ func (q *Queries) CompletedTestsCompany(ctx context.Context, userID int64) ([]Co
 	return items, nil
 }
 
-const completedTestsStudent = `-- name: CompletedTestsStudent :many
-SELECT 
-    tests.test_id,
-    tests.test_name,
-    tests.published,
-
-    testresults.result_id,
-    TO_CHAR(testresults.start_time, 'HH12:MI AM DD-MM-YYYY') AS start_time,
-    TO_CHAR(testresults.end_time, 'HH12:MI AM DD-MM-YYYY') AS end_time,
+const claimDeadlineReminders = `-- name: ClaimDeadlineReminders :many
+UPDATE jobs
+SET deadline_reminder_sent = true
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND NOT jobs.deadline_reminder_sent
+AND jobs.deadline > NOW()
+AND jobs.deadline <= $1
+RETURNING
+    jobs.job_id,
+    jobs.title,
+    jobs.deadline,
+    (SELECT companies.company_name FROM companies WHERE companies.company_id = jobs.company_id) AS company_name
+`
 
-    companies.company_name,
-    jobs.title
-FROM testresults
-JOIN tests ON testresults.test_id = tests.test_id
-JOIN companies ON tests.company_id = companies.company_id
-JOIN jobs ON tests.job_id = jobs.job_id
-WHERE testresults.user_id = $1
-AND testresults.end_time IS NOT NULL
-ORDER BY testresults.result_id
-`
-
-type CompletedTestsStudentRow struct {
-	TestID      int64
-	TestName    string
-	Published   bool
-	ResultID    int64
-	StartTime   string
-	EndTime     string
-	CompanyName string
+type ClaimDeadlineRemindersRow struct {
+	JobID       int64
 	Title       string
+	Deadline    pgtype.Timestamptz
+	CompanyName string
 }
 
-func (q *Queries) CompletedTestsStudent(ctx context.Context, userID int64) ([]CompletedTestsStudentRow, error) {
-	rows, err := q.db.Query(ctx, completedTestsStudent, userID)
+func (q *Queries) ClaimDeadlineReminders(ctx context.Context, remindUntil pgtype.Timestamptz) ([]ClaimDeadlineRemindersRow, error) {
+	rows, err := q.db.Query(ctx, claimDeadlineReminders, remindUntil)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CompletedTestsStudentRow
+	var items []ClaimDeadlineRemindersRow
 	for rows.Next() {
-		var i CompletedTestsStudentRow
+		var i ClaimDeadlineRemindersRow
 		if err := rows.Scan(
-			&i.TestID,
-			&i.TestName,
-			&i.Published,
-			&i.ResultID,
-			&i.StartTime,
-			&i.EndTime,
-			&i.CompanyName,
+			&i.JobID,
 			&i.Title,
+			&i.Deadline,
+			&i.CompanyName,
 		); err != nil {
 			return nil, err
 		}
@@ -600,59 +799,56 @@ func (q *Queries) CompletedTestsStudent(ctx context.Context, userID int64) ([]Co
 	return items, nil
 }
 
-const cumulativeResultData = `-- name: CumulativeResultData :many
-WITH tr AS (
-    SELECT 
-        result_id,
-        SUM(time_taken) AS total_time_taken,
-        COUNT(result_id) AS questions_attempted
-    FROM testresponses
-    GROUP BY result_id
-),
-main AS (
-    SELECT 
-        testresults.result_id,
-        testresults.user_id,
-        TO_CHAR(testresults.start_time, 'HH12:MI:SS AM DD-MM-YYYY') AS start_time,
-        TO_CHAR(testresults.end_time, 'HH12:MI:SS AM DD-MM-YYYY') AS end_time,
-        testresults.score,
-        tr.total_time_taken,
-        tr.questions_attempted
-    FROM testresults 
-    JOIN tr ON testresults.result_id = tr.result_id
-    WHERE testresults.test_id = $1
-)
-SELECT result_id, user_id, start_time, end_time, score, total_time_taken, questions_attempted 
-FROM main
+const clearAnswersTable = `-- name: ClearAnswersTable :exec
+DELETE FROM temp_correct_answers
 `
 
-type CumulativeResultDataRow struct {
-	ResultID           int64
-	UserID             int64
-	StartTime          string
-	EndTime            string
-	Score              pgtype.Int8
-	TotalTimeTaken     int64
-	QuestionsAttempted int64
+func (q *Queries) ClearAnswersTable(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, clearAnswersTable)
+	return err
 }
 
-func (q *Queries) CumulativeResultData(ctx context.Context, testID int64) ([]CumulativeResultDataRow, error) {
-	rows, err := q.db.Query(ctx, cumulativeResultData, testID)
+const closeDueJobs = `-- name: CloseDueJobs :many
+UPDATE jobs
+SET active_status = false
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND (jobs.deadline <= NOW()
+    OR jobs.max_applicants <= (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn'))
+RETURNING
+    jobs.job_id,
+    jobs.title,
+    jobs.deadline,
+    jobs.max_applicants,
+    (SELECT companies.user_id FROM companies WHERE companies.company_id = jobs.company_id) AS company_user_id,
+    (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn') AS applicants
+`
+
+type CloseDueJobsRow struct {
+	JobID         int64
+	Title         string
+	Deadline      pgtype.Timestamptz
+	MaxApplicants pgtype.Int4
+	CompanyUserID int64
+	Applicants    int64
+}
+
+func (q *Queries) CloseDueJobs(ctx context.Context) ([]CloseDueJobsRow, error) {
+	rows, err := q.db.Query(ctx, closeDueJobs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CumulativeResultDataRow
+	var items []CloseDueJobsRow
 	for rows.Next() {
-		var i CumulativeResultDataRow
+		var i CloseDueJobsRow
 		if err := rows.Scan(
-			&i.ResultID,
-			&i.UserID,
-			&i.StartTime,
-			&i.EndTime,
-			&i.Score,
-			&i.TotalTimeTaken,
-			&i.QuestionsAttempted,
+			&i.JobID,
+			&i.Title,
+			&i.Deadline,
+			&i.MaxApplicants,
+			&i.CompanyUserID,
+			&i.Applicants,
 		); err != nil {
 			return nil, err
 		}
@@ -664,73 +860,202 @@ func (q *Queries) CumulativeResultData(ctx context.Context, testID int64) ([]Cum
 	return items, nil
 }
 
-const deleteInterview = `-- name: DeleteInterview :exec
-DELETE FROM interviews
-WHERE application_id = $1
+const closeJob = `-- name: CloseJob :exec
+UPDATE jobs
+SET active_status = false
+WHERE jobs.job_id = $1 
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE user_id = $2)
 `
 
-func (q *Queries) DeleteInterview(ctx context.Context, applicationID int64) error {
-	_, err := q.db.Exec(ctx, deleteInterview, applicationID)
+type CloseJobParams struct {
+	JobID  int64
+	UserID int64
+}
+
+func (q *Queries) CloseJob(ctx context.Context, arg CloseJobParams) error {
+	_, err := q.db.Exec(ctx, closeJob, arg.JobID, arg.UserID)
 	return err
 }
 
-const deleteJob = `-- name: DeleteJob :exec
-DELETE FROM jobs 
-WHERE jobs.job_id = $1
-AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE user_id = $2)
+const companyDashboardData = `-- name: CompanyDashboardData :one
+WITH company AS (
+    SELECT 
+        company_id, 
+        company_name,
+        representative_name 
+    FROM companies WHERE user_id = $1
+),
+jc AS (
+    SELECT COUNT(jobs.job_id) AS jobs_count
+    FROM jobs
+    WHERE jobs.company_id = (SELECT company_id FROM company)
+),
+ac AS (
+    SELECT COUNT(applications.application_id) AS applications_count
+    FROM applications
+    JOIN jobs ON applications.job_id = jobs.job_id
+    WHERE jobs.company_id = (SELECT company_id FROM company)
+),
+ic AS (
+    SELECT COUNT(interviews.interview_id) AS interviews_count
+    FROM interviews
+    WHERE interviews.company_id = (SELECT company_id FROM company)
+    AND interviews.status = 'Scheduled'
+)
+SELECT company_id, company_name, representative_name, jobs_count, applications_count, interviews_count 
+FROM company
+CROSS JOIN jc
+CROSS JOIN ac
+CROSS JOIN ic
 `
 
-type DeleteJobParams struct {
-	JobID  int64
-	UserID int64
+type CompanyDashboardDataRow struct {
+	CompanyID          int64
+	CompanyName        string
+	RepresentativeName string
+	JobsCount          int64
+	ApplicationsCount  int64
+	InterviewsCount    int64
 }
 
-func (q *Queries) DeleteJob(ctx context.Context, arg DeleteJobParams) error {
-	_, err := q.db.Exec(ctx, deleteJob, arg.JobID, arg.UserID)
-	return err
+func (q *Queries) CompanyDashboardData(ctx context.Context, userID int64) (CompanyDashboardDataRow, error) {
+	row := q.db.QueryRow(ctx, companyDashboardData, userID)
+	var i CompanyDashboardDataRow
+	err := row.Scan(
+		&i.CompanyID,
+		&i.CompanyName,
+		&i.RepresentativeName,
+		&i.JobsCount,
+		&i.ApplicationsCount,
+		&i.InterviewsCount,
+	)
+	return i, err
 }
 
-const discussionsData = `-- name: DiscussionsData :many
-SELECT 
-    discussions.content,
-    TO_CHAR(discussions.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS created_at,
+const companyInterview = `-- name: CompanyInterview :one
+SELECT
+    interviews.interview_id,
+    interviews.application_id,
+    interviews.date_time,
+    interviews.duration_minutes,
+    interviews.location,
+    interviews.panel,
+    interviews.status::TEXT AS status
+FROM interviews
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = $1
+AND companies.user_id = $2
+`
 
-    companies.company_name,
-    students.student_name
+type CompanyInterviewParams struct {
+	InterviewID int64
+	UserID      int64
+}
 
-FROM discussions 
-LEFT JOIN students ON discussions.user_id = students.user_id
-LEFT JOIN companies ON discussions.user_id = companies.user_id
-ORDER BY discussions.created_at DESC
-OFFSET $1 LIMIT $2
+type CompanyInterviewRow struct {
+	InterviewID     int64
+	ApplicationID   int64
+	DateTime        pgtype.Timestamptz
+	DurationMinutes int32
+	Location        string
+	Panel           string
+	Status          string
+}
+
+func (q *Queries) CompanyInterview(ctx context.Context, arg CompanyInterviewParams) (CompanyInterviewRow, error) {
+	row := q.db.QueryRow(ctx, companyInterview, arg.InterviewID, arg.UserID)
+	var i CompanyInterviewRow
+	err := row.Scan(
+		&i.InterviewID,
+		&i.ApplicationID,
+		&i.DateTime,
+		&i.DurationMinutes,
+		&i.Location,
+		&i.Panel,
+		&i.Status,
+	)
+	return i, err
+}
+
+const companyLetterhead = `-- name: CompanyLetterhead :one
+SELECT company_name, address, picture_url, representative_name, representative_email
+FROM companies
+WHERE user_id = $1
 `
 
-type DiscussionsDataParams struct {
-	Offset int32
-	Limit  int32
+type CompanyLetterheadRow struct {
+	CompanyName         string
+	Address             string
+	PictureUrl          pgtype.Text
+	RepresentativeName  string
+	RepresentativeEmail string
 }
 
-type DiscussionsDataRow struct {
-	Content     string
-	CreatedAt   string
-	CompanyName pgtype.Text
-	StudentName pgtype.Text
+func (q *Queries) CompanyLetterhead(ctx context.Context, userID int64) (CompanyLetterheadRow, error) {
+	row := q.db.QueryRow(ctx, companyLetterhead, userID)
+	var i CompanyLetterheadRow
+	err := row.Scan(
+		&i.CompanyName,
+		&i.Address,
+		&i.PictureUrl,
+		&i.RepresentativeName,
+		&i.RepresentativeEmail,
+	)
+	return i, err
 }
 
-func (q *Queries) DiscussionsData(ctx context.Context, arg DiscussionsDataParams) ([]DiscussionsDataRow, error) {
-	rows, err := q.db.Query(ctx, discussionsData, arg.Offset, arg.Limit)
+const companyPipelineFlows = `-- name: CompanyPipelineFlows :many
+SELECT
+    job_pipeline_stages.job_id,
+    job_pipeline_stages.stage_id,
+    job_pipeline_stages.position,
+    job_pipeline_stages.name,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) >= job_pipeline_stages.position) AS reached,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Rejected') AS rejected,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Withdrawn') AS withdrawn,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Declined') AS declined,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Hired') AS hired
+FROM job_pipeline_stages
+JOIN jobs ON job_pipeline_stages.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN applications ON applications.job_id = job_pipeline_stages.job_id
+LEFT JOIN job_pipeline_stages current_stage ON applications.stage_id = current_stage.stage_id
+WHERE companies.user_id = $1
+GROUP BY job_pipeline_stages.job_id, job_pipeline_stages.stage_id, job_pipeline_stages.position, job_pipeline_stages.name
+ORDER BY job_pipeline_stages.job_id, job_pipeline_stages.position
+`
+
+type CompanyPipelineFlowsRow struct {
+	JobID     int64
+	StageID   int64
+	Position  int32
+	Name      string
+	Reached   int64
+	Rejected  int64
+	Withdrawn int64
+	Declined  int64
+	Hired     int64
+}
+
+func (q *Queries) CompanyPipelineFlows(ctx context.Context, userID int64) ([]CompanyPipelineFlowsRow, error) {
+	rows, err := q.db.Query(ctx, companyPipelineFlows, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []DiscussionsDataRow
+	var items []CompanyPipelineFlowsRow
 	for rows.Next() {
-		var i DiscussionsDataRow
+		var i CompanyPipelineFlowsRow
 		if err := rows.Scan(
-			&i.Content,
-			&i.CreatedAt,
-			&i.CompanyName,
-			&i.StudentName,
+			&i.JobID,
+			&i.StageID,
+			&i.Position,
+			&i.Name,
+			&i.Reached,
+			&i.Rejected,
+			&i.Withdrawn,
+			&i.Declined,
+			&i.Hired,
 		); err != nil {
 			return nil, err
 		}
@@ -742,85 +1067,40 @@ func (q *Queries) DiscussionsData(ctx context.Context, arg DiscussionsDataParams
 	return items, nil
 }
 
-const evaluateTestResult = `-- name: EvaluateTestResult :one
-WITH tr AS (
-    UPDATE testresponses
-    SET points = temp_correct_answers.points
-    FROM temp_correct_answers
-    WHERE testresponses.question_id = temp_correct_answers.question_id
-    AND testresponses.response = temp_correct_answers.correct_answer
-    RETURNING testresponses.points, testresponses.result_id
-),
-rs AS (
-    SELECT 
-        result_id, 
-        SUM(points) AS score 
-    FROM tr
-    GROUP BY result_id
-),
-up AS (
-    UPDATE testresults
-    SET score = rs.score
-    FROM rs
-    WHERE testresults.result_id = rs.result_id
-)
+const companyProfileData = `-- name: CompanyProfileData :one
 SELECT 
-    SUM(temp_correct_answers.points) AS totalpoints
-FROM temp_correct_answers
-`
-
-func (q *Queries) EvaluateTestResult(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, evaluateTestResult)
-	var totalpoints int64
-	err := row.Scan(&totalpoints)
-	return totalpoints, err
-}
-
-const extraInfoCompany = `-- name: ExtraInfoCompany :one
-INSERT INTO companies (company_name, representative_email, representative_contact, representative_name, data_url, user_id, address, picture_url, website, description, industry)
-VALUES ($1, $2, $3, $4, $5, (SELECT user_id FROM users WHERE email = $6), $7, $8, $9, $10, $11)
-RETURNING company_id, company_name, representative_email, representative_contact, representative_name, data_url, user_id, address, picture_url, website, description, industry
+    companies.company_name,
+    companies.representative_email,
+    companies.representative_contact,
+    companies.representative_name,
+    companies.address,
+    companies.website,
+    companies.description,
+    companies.industry
+FROM companies
+WHERE companies.user_id = $1
 `
 
-type ExtraInfoCompanyParams struct {
+type CompanyProfileDataRow struct {
 	CompanyName           string
 	RepresentativeEmail   string
 	RepresentativeContact string
 	RepresentativeName    string
-	DataUrl               pgtype.Text
-	Email                 string
 	Address               string
-	PictureUrl            pgtype.Text
 	Website               pgtype.Text
 	Description           pgtype.Text
 	Industry              string
 }
 
-func (q *Queries) ExtraInfoCompany(ctx context.Context, arg ExtraInfoCompanyParams) (Company, error) {
-	row := q.db.QueryRow(ctx, extraInfoCompany,
-		arg.CompanyName,
-		arg.RepresentativeEmail,
-		arg.RepresentativeContact,
-		arg.RepresentativeName,
-		arg.DataUrl,
-		arg.Email,
-		arg.Address,
-		arg.PictureUrl,
-		arg.Website,
-		arg.Description,
-		arg.Industry,
-	)
-	var i Company
+func (q *Queries) CompanyProfileData(ctx context.Context, userID int64) (CompanyProfileDataRow, error) {
+	row := q.db.QueryRow(ctx, companyProfileData, userID)
+	var i CompanyProfileDataRow
 	err := row.Scan(
-		&i.CompanyID,
 		&i.CompanyName,
 		&i.RepresentativeEmail,
 		&i.RepresentativeContact,
 		&i.RepresentativeName,
-		&i.DataUrl,
-		&i.UserID,
 		&i.Address,
-		&i.PictureUrl,
 		&i.Website,
 		&i.Description,
 		&i.Industry,
@@ -828,130 +1108,46 @@ func (q *Queries) ExtraInfoCompany(ctx context.Context, arg ExtraInfoCompanyPara
 	return i, err
 }
 
-const extraInfoStudent = `-- name: ExtraInfoStudent :one
-INSERT INTO students (student_name, roll_number, student_dob, gender, course, department, year_of_study, resume_url, result_url, cgpa, contact_no, student_email, address, skills, user_id, extras, picture_url)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, (SELECT user_id FROM users WHERE email = $15), $16, $17)
-RETURNING student_id, student_name, roll_number, student_dob, gender, course, department, year_of_study, resume_url, result_url, cgpa, contact_no, student_email, address, skills, user_id, extras, picture_url
-`
-
-type ExtraInfoStudentParams struct {
-	StudentName  string
-	RollNumber   string
-	StudentDob   pgtype.Date
-	Gender       string
-	Course       string
-	Department   string
-	YearOfStudy  string
-	ResumeUrl    pgtype.Text
-	ResultUrl    string
-	Cgpa         pgtype.Float8
-	ContactNo    string
-	StudentEmail string
-	Address      pgtype.Text
-	Skills       pgtype.Text
-	Email        string
-	Extras       []byte
-	PictureUrl   pgtype.Text
-}
-
-func (q *Queries) ExtraInfoStudent(ctx context.Context, arg ExtraInfoStudentParams) (Student, error) {
-	row := q.db.QueryRow(ctx, extraInfoStudent,
-		arg.StudentName,
-		arg.RollNumber,
-		arg.StudentDob,
-		arg.Gender,
-		arg.Course,
-		arg.Department,
-		arg.YearOfStudy,
-		arg.ResumeUrl,
-		arg.ResultUrl,
-		arg.Cgpa,
-		arg.ContactNo,
-		arg.StudentEmail,
-		arg.Address,
-		arg.Skills,
-		arg.Email,
-		arg.Extras,
-		arg.PictureUrl,
-	)
-	var i Student
-	err := row.Scan(
-		&i.StudentID,
-		&i.StudentName,
-		&i.RollNumber,
-		&i.StudentDob,
-		&i.Gender,
-		&i.Course,
-		&i.Department,
-		&i.YearOfStudy,
-		&i.ResumeUrl,
-		&i.ResultUrl,
-		&i.Cgpa,
-		&i.ContactNo,
-		&i.StudentEmail,
-		&i.Address,
-		&i.Skills,
-		&i.UserID,
-		&i.Extras,
-		&i.PictureUrl,
-	)
-	return i, err
-}
-
-const feedbacksDataForAndByCompany = `-- name: FeedbacksDataForAndByCompany :many
+const completedInterviewsCompany = `-- name: CompletedInterviewsCompany :many
 SELECT 
-    feedbacks.feedback_id,
-    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
-    feedbacks.message,
-
-    applications.application_id,
-    applications.status::TEXT AS application_status,
-
-    interviews.interview_id,
-    TO_CHAR(interviews.date_time, 'HH12:MI:SS AM DD-MM-YYYY') AS interview_date_time,
-    
+    students.student_name,
     students.student_id,
-    students.student_name
-
-FROM feedbacks
-LEFT JOIN interviews ON interviews.interview_id = feedbacks.interview_id
-LEFT JOIN applications ON (applications.application_id = feedbacks.application_id OR 
-                            applications.application_id = interviews.application_id)
+    interviews.interview_id,
+    interviews.application_id,
+    TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
+    interviews.extras
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
 JOIN students ON students.student_id = applications.student_id
-WHERE feedbacks.user_id = $1
+WHERE interviews.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $1)
+AND interviews.status = 'Completed'
 `
 
-type FeedbacksDataForAndByCompanyRow struct {
-	FeedbackID        int64
-	FeedbackTime      string
-	Message           pgtype.Text
-	ApplicationID     pgtype.Int8
-	ApplicationStatus string
-	InterviewID       pgtype.Int8
-	InterviewDateTime string
-	StudentID         int64
-	StudentName       string
+type CompletedInterviewsCompanyRow struct {
+	StudentName   string
+	StudentID     int64
+	InterviewID   int64
+	ApplicationID int64
+	DateTime      string
+	Extras        []byte
 }
 
-func (q *Queries) FeedbacksDataForAndByCompany(ctx context.Context, userID int64) ([]FeedbacksDataForAndByCompanyRow, error) {
-	rows, err := q.db.Query(ctx, feedbacksDataForAndByCompany, userID)
+func (q *Queries) CompletedInterviewsCompany(ctx context.Context, userID int64) ([]CompletedInterviewsCompanyRow, error) {
+	rows, err := q.db.Query(ctx, completedInterviewsCompany, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FeedbacksDataForAndByCompanyRow
+	var items []CompletedInterviewsCompanyRow
 	for rows.Next() {
-		var i FeedbacksDataForAndByCompanyRow
+		var i CompletedInterviewsCompanyRow
 		if err := rows.Scan(
-			&i.FeedbackID,
-			&i.FeedbackTime,
-			&i.Message,
-			&i.ApplicationID,
-			&i.ApplicationStatus,
-			&i.InterviewID,
-			&i.InterviewDateTime,
-			&i.StudentID,
 			&i.StudentName,
+			&i.StudentID,
+			&i.InterviewID,
+			&i.ApplicationID,
+			&i.DateTime,
+			&i.Extras,
 		); err != nil {
 			return nil, err
 		}
@@ -963,39 +1159,48 @@ func (q *Queries) FeedbacksDataForAndByCompany(ctx context.Context, userID int64
 	return items, nil
 }
 
-const feedbacksDataForAndToCompany = `-- name: FeedbacksDataForAndToCompany :many
-WITH notuser AS (
-    SELECT 
-        interviews.interview_id 
-    FROM interviews 
-    WHERE interviews.company_id = 
-        (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
-)
+const completedInterviewsStudent = `-- name: CompletedInterviewsStudent :many
 SELECT 
-    feedbacks.feedback_id,
-    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
-    feedbacks.message
-FROM feedbacks
-LEFT JOIN notuser ON notuser.interview_id = feedbacks.interview_id
-WHERE feedbacks.user_id != $1 AND notuser.interview_id IS NOT NULL
+    jobs.title AS job_title,
+    companies.company_name,
+    interviews.interview_id,
+    interviews.application_id,
+    TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS interview_date_time,
+    interviews.extras
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND interviews.status = 'Completed'
 `
 
-type FeedbacksDataForAndToCompanyRow struct {
-	FeedbackID   int64
-	FeedbackTime string
-	Message      pgtype.Text
+type CompletedInterviewsStudentRow struct {
+	JobTitle          string
+	CompanyName       string
+	InterviewID       int64
+	ApplicationID     int64
+	InterviewDateTime string
+	Extras            []byte
 }
 
-func (q *Queries) FeedbacksDataForAndToCompany(ctx context.Context, userID int64) ([]FeedbacksDataForAndToCompanyRow, error) {
-	rows, err := q.db.Query(ctx, feedbacksDataForAndToCompany, userID)
+func (q *Queries) CompletedInterviewsStudent(ctx context.Context, userID int64) ([]CompletedInterviewsStudentRow, error) {
+	rows, err := q.db.Query(ctx, completedInterviewsStudent, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FeedbacksDataForAndToCompanyRow
+	var items []CompletedInterviewsStudentRow
 	for rows.Next() {
-		var i FeedbacksDataForAndToCompanyRow
-		if err := rows.Scan(&i.FeedbackID, &i.FeedbackTime, &i.Message); err != nil {
+		var i CompletedInterviewsStudentRow
+		if err := rows.Scan(
+			&i.JobTitle,
+			&i.CompanyName,
+			&i.InterviewID,
+			&i.ApplicationID,
+			&i.InterviewDateTime,
+			&i.Extras,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1006,45 +1211,44 @@ func (q *Queries) FeedbacksDataForAndToCompany(ctx context.Context, userID int64
 	return items, nil
 }
 
-const feedbacksDataForStudent = `-- name: FeedbacksDataForStudent :many
+const completedTestsCompany = `-- name: CompletedTestsCompany :many
 SELECT 
-    feedbacks.feedback_id,
-    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
-    feedbacks.message,
-
-    applications.application_id,
-
-    interviews.interview_id
-
-FROM feedbacks
-LEFT JOIN applications ON applications.application_id = feedbacks.application_id
-LEFT JOIN interviews ON interviews.interview_id = feedbacks.interview_id
-WHERE feedbacks.user_id = $1
+    tests.test_id,
+    tests.test_name,
+    TO_CHAR(tests.end_time, 'HH12:MI AM DD-MM-YYYY') AS end_time,
+    tests.threshold,
+    tests.published,
+    TO_CHAR(tests.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at
+FROM tests
+WHERE tests.end_time < NOW()
+AND tests.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $1)
 `
 
-type FeedbacksDataForStudentRow struct {
-	FeedbackID    int64
-	FeedbackTime  string
-	Message       pgtype.Text
-	ApplicationID pgtype.Int8
-	InterviewID   pgtype.Int8
+type CompletedTestsCompanyRow struct {
+	TestID    int64
+	TestName  string
+	EndTime   string
+	Threshold int32
+	Published bool
+	CreatedAt string
 }
 
-func (q *Queries) FeedbacksDataForStudent(ctx context.Context, userID int64) ([]FeedbacksDataForStudentRow, error) {
-	rows, err := q.db.Query(ctx, feedbacksDataForStudent, userID)
+func (q *Queries) CompletedTestsCompany(ctx context.Context, userID int64) ([]CompletedTestsCompanyRow, error) {
+	rows, err := q.db.Query(ctx, completedTestsCompany, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FeedbacksDataForStudentRow
+	var items []CompletedTestsCompanyRow
 	for rows.Next() {
-		var i FeedbacksDataForStudentRow
+		var i CompletedTestsCompanyRow
 		if err := rows.Scan(
-			&i.FeedbackID,
-			&i.FeedbackTime,
-			&i.Message,
-			&i.ApplicationID,
-			&i.InterviewID,
+			&i.TestID,
+			&i.TestName,
+			&i.EndTime,
+			&i.Threshold,
+			&i.Published,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1056,28 +1260,56 @@ func (q *Queries) FeedbacksDataForStudent(ctx context.Context, userID int64) ([]
 	return items, nil
 }
 
-const getAll = `-- name: GetAll :many
-SELECT user_id, email, password, role, user_uuid, created_at, confirmed, is_verified FROM users
+const completedTestsStudent = `-- name: CompletedTestsStudent :many
+SELECT 
+    tests.test_id,
+    tests.test_name,
+    tests.published,
+
+    testresults.result_id,
+    TO_CHAR(testresults.start_time, 'HH12:MI AM DD-MM-YYYY') AS start_time,
+    TO_CHAR(testresults.end_time, 'HH12:MI AM DD-MM-YYYY') AS end_time,
+
+    companies.company_name,
+    jobs.title
+FROM testresults
+JOIN tests ON testresults.test_id = tests.test_id
+JOIN companies ON tests.company_id = companies.company_id
+JOIN jobs ON tests.job_id = jobs.job_id
+WHERE testresults.user_id = $1
+AND testresults.end_time IS NOT NULL
+ORDER BY testresults.result_id
 `
 
-func (q *Queries) GetAll(ctx context.Context) ([]User, error) {
-	rows, err := q.db.Query(ctx, getAll)
+type CompletedTestsStudentRow struct {
+	TestID      int64
+	TestName    string
+	Published   bool
+	ResultID    int64
+	StartTime   string
+	EndTime     string
+	CompanyName string
+	Title       string
+}
+
+func (q *Queries) CompletedTestsStudent(ctx context.Context, userID int64) ([]CompletedTestsStudentRow, error) {
+	rows, err := q.db.Query(ctx, completedTestsStudent, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []User
+	var items []CompletedTestsStudentRow
 	for rows.Next() {
-		var i User
+		var i CompletedTestsStudentRow
 		if err := rows.Scan(
-			&i.UserID,
-			&i.Email,
-			&i.Password,
-			&i.Role,
-			&i.UserUuid,
-			&i.CreatedAt,
-			&i.Confirmed,
-			&i.IsVerified,
+			&i.TestID,
+			&i.TestName,
+			&i.Published,
+			&i.ResultID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.CompanyName,
+			&i.Title,
 		); err != nil {
 			return nil, err
 		}
@@ -1089,27 +1321,63 @@ func (q *Queries) GetAll(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
-const getAllApplicantsEmailsForJob = `-- name: GetAllApplicantsEmailsForJob :many
-SELECT 
-    students.student_email
-FROM applications
-JOIN students ON applications.student_id = students.student_id
-WHERE applications.job_id = $1
+const cumulativeResultData = `-- name: CumulativeResultData :many
+WITH tr AS (
+    SELECT 
+        result_id,
+        SUM(time_taken) AS total_time_taken,
+        COUNT(result_id) AS questions_attempted
+    FROM testresponses
+    GROUP BY result_id
+),
+main AS (
+    SELECT 
+        testresults.result_id,
+        testresults.user_id,
+        TO_CHAR(testresults.start_time, 'HH12:MI:SS AM DD-MM-YYYY') AS start_time,
+        TO_CHAR(testresults.end_time, 'HH12:MI:SS AM DD-MM-YYYY') AS end_time,
+        testresults.score,
+        tr.total_time_taken,
+        tr.questions_attempted
+    FROM testresults 
+    JOIN tr ON testresults.result_id = tr.result_id
+    WHERE testresults.test_id = $1
+)
+SELECT result_id, user_id, start_time, end_time, score, total_time_taken, questions_attempted 
+FROM main
 `
 
-func (q *Queries) GetAllApplicantsEmailsForJob(ctx context.Context, jobID int64) ([]string, error) {
-	rows, err := q.db.Query(ctx, getAllApplicantsEmailsForJob, jobID)
+type CumulativeResultDataRow struct {
+	ResultID           int64
+	UserID             int64
+	StartTime          string
+	EndTime            string
+	Score              pgtype.Int8
+	TotalTimeTaken     int64
+	QuestionsAttempted int64
+}
+
+func (q *Queries) CumulativeResultData(ctx context.Context, testID int64) ([]CumulativeResultDataRow, error) {
+	rows, err := q.db.Query(ctx, cumulativeResultData, testID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []CumulativeResultDataRow
 	for rows.Next() {
-		var student_email string
-		if err := rows.Scan(&student_email); err != nil {
+		var i CumulativeResultDataRow
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.UserID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Score,
+			&i.TotalTimeTaken,
+			&i.QuestionsAttempted,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, student_email)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -1117,68 +1385,61 @@ func (q *Queries) GetAllApplicantsEmailsForJob(ctx context.Context, jobID int64)
 	return items, nil
 }
 
-const getAllFilePathsCompany = `-- name: GetAllFilePathsCompany :one
-SELECT 
-    companies.picture_url,
-    companies.picture_url
-FROM companies
-WHERE user_id = $1
-`
-
-type GetAllFilePathsCompanyRow struct {
-	PictureUrl   pgtype.Text
-	PictureUrl_2 pgtype.Text
-}
-
-func (q *Queries) GetAllFilePathsCompany(ctx context.Context, userID int64) (GetAllFilePathsCompanyRow, error) {
-	row := q.db.QueryRow(ctx, getAllFilePathsCompany, userID)
-	var i GetAllFilePathsCompanyRow
-	err := row.Scan(&i.PictureUrl, &i.PictureUrl_2)
-	return i, err
-}
-
-const getAllFilePathsStudent = `-- name: GetAllFilePathsStudent :one
-SELECT 
-    students.resume_url,
-    students.result_url,
-    students.picture_url
+const deadlineReminderCandidates = `-- name: DeadlineReminderCandidates :many
+SELECT
+    students.user_id,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = $1
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
 FROM students
-WHERE user_id = $1
+WHERE NOT EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = $1
+    AND applications.student_id = students.student_id
+)
 `
 
-type GetAllFilePathsStudentRow struct {
-	ResumeUrl  pgtype.Text
-	ResultUrl  string
-	PictureUrl pgtype.Text
-}
-
-func (q *Queries) GetAllFilePathsStudent(ctx context.Context, userID int64) (GetAllFilePathsStudentRow, error) {
-	row := q.db.QueryRow(ctx, getAllFilePathsStudent, userID)
-	var i GetAllFilePathsStudentRow
-	err := row.Scan(&i.ResumeUrl, &i.ResultUrl, &i.PictureUrl)
-	return i, err
+type DeadlineReminderCandidatesRow struct {
+	UserID      int64
+	Course      string
+	Department  string
+	YearOfStudy string
+	Cgpa        pgtype.Float8
+	Backlogs    int32
+	Extras      []byte
+	Overridden  bool
 }
 
-const getAllJobsID = `-- name: GetAllJobsID :many
-SELECT
-    jobs.job_id
-FROM jobs
-WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
-`
-
-func (q *Queries) GetAllJobsID(ctx context.Context, userID int64) ([]int64, error) {
-	rows, err := q.db.Query(ctx, getAllJobsID, userID)
+func (q *Queries) DeadlineReminderCandidates(ctx context.Context, jobID int64) ([]DeadlineReminderCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, deadlineReminderCandidates, jobID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []int64
+	var items []DeadlineReminderCandidatesRow
 	for rows.Next() {
-		var job_id int64
-		if err := rows.Scan(&job_id); err != nil {
+		var i DeadlineReminderCandidatesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Course,
+			&i.Department,
+			&i.YearOfStudy,
+			&i.Cgpa,
+			&i.Backlogs,
+			&i.Extras,
+			&i.Overridden,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, job_id)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -1186,149 +1447,174 @@ func (q *Queries) GetAllJobsID(ctx context.Context, userID int64) ([]int64, erro
 	return items, nil
 }
 
-const getApplicableJobsTypeFilter = `-- name: GetApplicableJobsTypeFilter :many
-SELECT 
-    jobs.job_id,
-    jobs.title, 
-    jobs.location,
-    jobs.type,
-    jobs.salary,
-    jobs.position,
-    jobs.skills,
-    jobs.company_id,
-    jobs.active_status,
-    companies.company_name 
-FROM jobs
-JOIN companies ON jobs.company_id = companies.company_id 
-LEFT JOIN (SELECT applications.job_id FROM applications WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)) AS t 
-ON jobs.job_id = t.job_id
-WHERE t.job_id IS NULL 
-AND (jobs.type = $2 OR $2 = 'All')
+const deleteDeviceTokens = `-- name: DeleteDeviceTokens :exec
+DELETE FROM device_tokens
+WHERE token = ANY($1::TEXT[])
 `
 
-type GetApplicableJobsTypeFilterParams struct {
-	UserID int64
-	Type   string
+func (q *Queries) DeleteDeviceTokens(ctx context.Context, tokens []string) error {
+	_, err := q.db.Exec(ctx, deleteDeviceTokens, tokens)
+	return err
 }
 
-type GetApplicableJobsTypeFilterRow struct {
-	JobID        int64
-	Title        string
-	Location     string
-	Type         string
-	Salary       string
-	Position     string
-	Skills       []string
-	CompanyID    int64
-	ActiveStatus bool
-	CompanyName  string
+const deleteEligibilityOverride = `-- name: DeleteEligibilityOverride :execrows
+DELETE FROM eligibility_overrides
+WHERE job_id = $1
+AND student_id = $2
+`
+
+type DeleteEligibilityOverrideParams struct {
+	JobID     int64
+	StudentID int64
 }
 
-func (q *Queries) GetApplicableJobsTypeFilter(ctx context.Context, arg GetApplicableJobsTypeFilterParams) ([]GetApplicableJobsTypeFilterRow, error) {
-	rows, err := q.db.Query(ctx, getApplicableJobsTypeFilter, arg.UserID, arg.Type)
+func (q *Queries) DeleteEligibilityOverride(ctx context.Context, arg DeleteEligibilityOverrideParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEligibilityOverride, arg.JobID, arg.StudentID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []GetApplicableJobsTypeFilterRow
-	for rows.Next() {
-		var i GetApplicableJobsTypeFilterRow
-		if err := rows.Scan(
-			&i.JobID,
-			&i.Title,
-			&i.Location,
-			&i.Type,
-			&i.Salary,
-			&i.Position,
-			&i.Skills,
-			&i.CompanyID,
-			&i.ActiveStatus,
-			&i.CompanyName,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected(), nil
+}
+
+const deleteJob = `-- name: DeleteJob :exec
+DELETE FROM jobs 
+WHERE jobs.job_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE user_id = $2)
+`
+
+type DeleteJobParams struct {
+	JobID  int64
+	UserID int64
+}
+
+func (q *Queries) DeleteJob(ctx context.Context, arg DeleteJobParams) error {
+	_, err := q.db.Exec(ctx, deleteJob, arg.JobID, arg.UserID)
+	return err
+}
+
+const deleteJobCompensation = `-- name: DeleteJobCompensation :exec
+DELETE FROM job_compensation
+WHERE job_id = $1
+`
+
+func (q *Queries) DeleteJobCompensation(ctx context.Context, jobID int64) error {
+	_, err := q.db.Exec(ctx, deleteJobCompensation, jobID)
+	return err
+}
+
+const deleteNotifications = `-- name: DeleteNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = $1
+AND notif_id = ANY($2::BIGINT[])
+`
+
+type DeleteNotificationsParams struct {
+	UserID   int64
+	NotifIds []int64
+}
+
+func (q *Queries) DeleteNotifications(ctx context.Context, arg DeleteNotificationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotifications, arg.UserID, arg.NotifIds)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected(), nil
+}
+
+const deleteOfferLetterTemplate = `-- name: DeleteOfferLetterTemplate :execrows
+DELETE FROM offer_letter_templates
+WHERE template_id = $1
+AND company_id = (SELECT company_id FROM companies WHERE user_id = $2)
+`
+
+type DeleteOfferLetterTemplateParams struct {
+	TemplateID int64
+	UserID     int64
+}
+
+func (q *Queries) DeleteOfferLetterTemplate(ctx context.Context, arg DeleteOfferLetterTemplateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOfferLetterTemplate, arg.TemplateID, arg.UserID)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
-const getApplicants = `-- name: GetApplicants :many
-SELECT
-    students.student_id,
-    students.student_name,
-    students.roll_number,
-    students.gender,
-    students.department,
-    students.student_email,
-    students.contact_no,
-    students.cgpa,
-    students.skills,
-    jobs.job_id, 
-    jobs.title, 
-    applications.status::TEXT AS status,
-    COALESCE(interviews.status::TEXT, '') AS interview_status,
-    applications.application_id
-FROM applications
-JOIN jobs ON applications.job_id = jobs.job_id
-JOIN students ON applications.student_id = students.student_id
-LEFT JOIN interviews ON applications.application_id = interviews.application_id
-WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
-AND (jobs.job_id = $2 OR $2 = 0)
-AND (applications.application_id = $3 OR $3 = 0)
-AND (applications.status != 'Rejected')
-ORDER BY jobs.job_id
+const deletePipelineStages = `-- name: DeletePipelineStages :exec
+DELETE FROM job_pipeline_stages
+WHERE job_id = $1
+AND NOT (stage_id = ANY($2::BIGINT[]))
 `
 
-type GetApplicantsParams struct {
-	UserID        int64
-	JobID         int64
-	ApplicationID int64
+type DeletePipelineStagesParams struct {
+	JobID        int64
+	KeepStageIds []int64
 }
 
-type GetApplicantsRow struct {
-	StudentID       int64
-	StudentName     string
-	RollNumber      string
-	Gender          string
-	Department      string
-	StudentEmail    string
-	ContactNo       string
-	Cgpa            pgtype.Float8
-	Skills          pgtype.Text
-	JobID           int64
-	Title           string
-	Status          string
-	InterviewStatus interface{}
-	ApplicationID   int64
+func (q *Queries) DeletePipelineStages(ctx context.Context, arg DeletePipelineStagesParams) error {
+	_, err := q.db.Exec(ctx, deletePipelineStages, arg.JobID, arg.KeepStageIds)
+	return err
 }
 
-func (q *Queries) GetApplicants(ctx context.Context, arg GetApplicantsParams) ([]GetApplicantsRow, error) {
-	rows, err := q.db.Query(ctx, getApplicants, arg.UserID, arg.JobID, arg.ApplicationID)
+const deletePolicyException = `-- name: DeletePolicyException :execrows
+DELETE FROM policy_exceptions
+WHERE student_id = $1
+AND COALESCE(job_id, 0) = COALESCE($2, 0)
+AND rule = $3
+`
+
+type DeletePolicyExceptionParams struct {
+	StudentID int64
+	JobID     pgtype.Int8
+	Rule      string
+}
+
+func (q *Queries) DeletePolicyException(ctx context.Context, arg DeletePolicyExceptionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePolicyException, arg.StudentID, arg.JobID, arg.Rule)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const departmentPlacementStats = `-- name: DepartmentPlacementStats :many
+SELECT
+    students.department,
+    COUNT(DISTINCT students.student_id) AS students,
+    COUNT(DISTINCT students.student_id) FILTER (WHERE offers.status = 'Accepted' AND jobs.type NOT ILIKE '%intern%') AS placed,
+    COUNT(offers.offer_id) AS offers,
+    COALESCE(AVG(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS average_ctc
+FROM students
+LEFT JOIN applications ON applications.student_id = students.student_id
+LEFT JOIN offers ON offers.application_id = applications.application_id
+LEFT JOIN jobs ON applications.job_id = jobs.job_id
+GROUP BY students.department
+ORDER BY students.department
+`
+
+type DepartmentPlacementStatsRow struct {
+	Department string
+	Students   int64
+	Placed     int64
+	Offers     int64
+	AverageCtc int64
+}
+
+func (q *Queries) DepartmentPlacementStats(ctx context.Context) ([]DepartmentPlacementStatsRow, error) {
+	rows, err := q.db.Query(ctx, departmentPlacementStats)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetApplicantsRow
+	var items []DepartmentPlacementStatsRow
 	for rows.Next() {
-		var i GetApplicantsRow
+		var i DepartmentPlacementStatsRow
 		if err := rows.Scan(
-			&i.StudentID,
-			&i.StudentName,
-			&i.RollNumber,
-			&i.Gender,
 			&i.Department,
-			&i.StudentEmail,
-			&i.ContactNo,
-			&i.Cgpa,
-			&i.Skills,
-			&i.JobID,
-			&i.Title,
-			&i.Status,
-			&i.InterviewStatus,
-			&i.ApplicationID,
+			&i.Students,
+			&i.Placed,
+			&i.Offers,
+			&i.AverageCtc,
 		); err != nil {
 			return nil, err
 		}
@@ -1340,95 +1626,52 @@ func (q *Queries) GetApplicants(ctx context.Context, arg GetApplicantsParams) ([
 	return items, nil
 }
 
-const getJobDetails = `-- name: GetJobDetails :one
-SELECT 
-    jobs.title,
-    companies.company_name
-FROM jobs 
-JOIN companies ON jobs.company_id = companies.company_id
-WHERE jobs.job_id = $1
-`
-
-type GetJobDetailsRow struct {
-	Title       string
-	CompanyName string
-}
-
-func (q *Queries) GetJobDetails(ctx context.Context, jobID int64) (GetJobDetailsRow, error) {
-	row := q.db.QueryRow(ctx, getJobDetails, jobID)
-	var i GetJobDetailsRow
-	err := row.Scan(&i.Title, &i.CompanyName)
-	return i, err
-}
-
-const getJobListings = `-- name: GetJobListings :many
-SELECT 
-    jobs.job_id,
-    jobs.created_at::DATE as created_at,
-    jobs.title,
-    jobs.location,
-    jobs.type,
-    jobs.salary,
-    jobs.skills,
-    jobs.position,
-    jobs.active_status,
-    COALESCE(t.no_of_applications, 0),
-    jobs.description,
-    jobs.extras    
-FROM jobs
-LEFT JOIN (
-    SELECT 
-        job_id, 
-        COUNT(job_id) AS no_of_applications 
-    FROM applications 
-    WHERE status != 'Rejected' 
-    GROUP BY job_id ) AS t
-ON jobs.job_id = t.job_id
-WHERE jobs.company_id = (
-    SELECT 
-        companies.company_id 
-    FROM companies 
-    WHERE companies.user_id = $1)
-ORDER BY jobs.job_id
+const digestRecipients = `-- name: DigestRecipients :many
+SELECT
+    users.user_id,
+    users.email,
+    users.role,
+    CAST(COALESCE(digest_preferences.frequency, 'daily') AS TEXT) AS frequency,
+    CAST(COALESCE(digest_preferences.send_hour, 8) AS INTEGER) AS send_hour,
+    CAST(COALESCE(digest_preferences.weekday, 1) AS INTEGER) AS weekday,
+    CAST(COALESCE(digest_preferences.timezone, 'Asia/Kolkata') AS TEXT) AS timezone,
+    digest_preferences.last_sent_at
+FROM users
+LEFT JOIN digest_preferences ON users.user_id = digest_preferences.user_id
+WHERE users.confirmed = true
+AND users.role IN (1, 2)
+AND COALESCE(digest_preferences.frequency, 'daily') != 'off'
 `
 
-type GetJobListingsRow struct {
-	JobID            int64
-	CreatedAt        pgtype.Date
-	Title            string
-	Location         string
-	Type             string
-	Salary           string
-	Skills           []string
-	Position         string
-	ActiveStatus     bool
-	NoOfApplications int64
-	Description      pgtype.Text
-	Extras           []byte
+type DigestRecipientsRow struct {
+	UserID     int64
+	Email      string
+	Role       int64
+	Frequency  string
+	SendHour   int32
+	Weekday    int32
+	Timezone   string
+	LastSentAt pgtype.Timestamptz
 }
 
-func (q *Queries) GetJobListings(ctx context.Context, userID int64) ([]GetJobListingsRow, error) {
-	rows, err := q.db.Query(ctx, getJobListings, userID)
+func (q *Queries) DigestRecipients(ctx context.Context) ([]DigestRecipientsRow, error) {
+	rows, err := q.db.Query(ctx, digestRecipients)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetJobListingsRow
+	var items []DigestRecipientsRow
 	for rows.Next() {
-		var i GetJobListingsRow
+		var i DigestRecipientsRow
 		if err := rows.Scan(
-			&i.JobID,
-			&i.CreatedAt,
-			&i.Title,
-			&i.Location,
-			&i.Type,
-			&i.Salary,
-			&i.Skills,
-			&i.Position,
-			&i.ActiveStatus,
-			&i.NoOfApplications,
-			&i.Description,
-			&i.Extras,
+			&i.UserID,
+			&i.Email,
+			&i.Role,
+			&i.Frequency,
+			&i.SendHour,
+			&i.Weekday,
+			&i.Timezone,
+			&i.LastSentAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1440,71 +1683,47 @@ func (q *Queries) GetJobListings(ctx context.Context, userID int64) ([]GetJobLis
 	return items, nil
 }
 
-const getMyApplicationsStatusFilter = `-- name: GetMyApplicationsStatusFilter :many
+const discussionsData = `-- name: DiscussionsData :many
 SELECT 
-    jobs.job_id,
-    jobs.title, 
-    jobs.location,
-    jobs.type,
-    jobs.salary,
-    jobs.position,
-    jobs.skills,
-    jobs.company_id,
+    discussions.content,
+    TO_CHAR(discussions.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS created_at,
+
     companies.company_name,
-    companies.representative_email,
-    companies.representative_name,
-    applications.status::TEXT AS status
-FROM applications
-JOIN students ON applications.student_id = students.student_id
-JOIN jobs ON applications.job_id = jobs.job_id
-JOIN companies ON jobs.company_id = companies.company_id
-WHERE students.user_id = $1 
-  AND ($2 = 'All' OR applications.status::TEXT = $2)
-ORDER BY jobs.job_id
+    students.student_name
+
+FROM discussions 
+LEFT JOIN students ON discussions.user_id = students.user_id
+LEFT JOIN companies ON discussions.user_id = companies.user_id
+ORDER BY discussions.created_at DESC
+OFFSET $1 LIMIT $2
 `
 
-type GetMyApplicationsStatusFilterParams struct {
-	UserID  int64
-	Column2 interface{}
+type DiscussionsDataParams struct {
+	Offset int32
+	Limit  int32
 }
 
-type GetMyApplicationsStatusFilterRow struct {
-	JobID               int64
-	Title               string
-	Location            string
-	Type                string
-	Salary              string
-	Position            string
-	Skills              []string
-	CompanyID           int64
-	CompanyName         string
-	RepresentativeEmail string
-	RepresentativeName  string
-	Status              string
+type DiscussionsDataRow struct {
+	Content     string
+	CreatedAt   string
+	CompanyName pgtype.Text
+	StudentName pgtype.Text
 }
 
-func (q *Queries) GetMyApplicationsStatusFilter(ctx context.Context, arg GetMyApplicationsStatusFilterParams) ([]GetMyApplicationsStatusFilterRow, error) {
-	rows, err := q.db.Query(ctx, getMyApplicationsStatusFilter, arg.UserID, arg.Column2)
+func (q *Queries) DiscussionsData(ctx context.Context, arg DiscussionsDataParams) ([]DiscussionsDataRow, error) {
+	rows, err := q.db.Query(ctx, discussionsData, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMyApplicationsStatusFilterRow
+	var items []DiscussionsDataRow
 	for rows.Next() {
-		var i GetMyApplicationsStatusFilterRow
+		var i DiscussionsDataRow
 		if err := rows.Scan(
-			&i.JobID,
-			&i.Title,
-			&i.Location,
-			&i.Type,
-			&i.Salary,
-			&i.Position,
-			&i.Skills,
-			&i.CompanyID,
+			&i.Content,
+			&i.CreatedAt,
 			&i.CompanyName,
-			&i.RepresentativeEmail,
-			&i.RepresentativeName,
-			&i.Status,
+			&i.StudentName,
 		); err != nil {
 			return nil, err
 		}
@@ -1516,38 +1735,39 @@ func (q *Queries) GetMyApplicationsStatusFilter(ctx context.Context, arg GetMyAp
 	return items, nil
 }
 
-const getNotifications = `-- name: GetNotifications :many
+const dueAnnouncements = `-- name: DueAnnouncements :many
 SELECT
-    notif_id, user_id, title, description, read_status, timestamp
-FROM notifications
-WHERE user_id = $1
-ORDER BY timestamp DESC
-LIMIT $2
-OFFSET $3
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.send_email
+FROM announcements
+WHERE announcements.delivered_at IS NULL
+AND announcements.scheduled_at <= NOW()
+ORDER BY announcements.scheduled_at
 `
 
-type GetNotificationsParams struct {
-	UserID int64
-	Limit  int32
-	Offset int32
+type DueAnnouncementsRow struct {
+	AnnouncementID int64
+	Title          string
+	Message        string
+	SendEmail      bool
 }
 
-func (q *Queries) GetNotifications(ctx context.Context, arg GetNotificationsParams) ([]Notification, error) {
-	rows, err := q.db.Query(ctx, getNotifications, arg.UserID, arg.Limit, arg.Offset)
+func (q *Queries) DueAnnouncements(ctx context.Context) ([]DueAnnouncementsRow, error) {
+	rows, err := q.db.Query(ctx, dueAnnouncements)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Notification
+	var items []DueAnnouncementsRow
 	for rows.Next() {
-		var i Notification
+		var i DueAnnouncementsRow
 		if err := rows.Scan(
-			&i.NotifID,
-			&i.UserID,
+			&i.AnnouncementID,
 			&i.Title,
-			&i.Description,
-			&i.ReadStatus,
-			&i.Timestamp,
+			&i.Message,
+			&i.SendEmail,
 		); err != nil {
 			return nil, err
 		}
@@ -1559,349 +1779,3944 @@ func (q *Queries) GetNotifications(ctx context.Context, arg GetNotificationsPara
 	return items, nil
 }
 
-const getOfferLetterData = `-- name: GetOfferLetterData :one
-SELECT 
-    students.student_name, 
-    students.student_email,
-    j.title,
-    c.company_name,
-    c.representative_contact,
-    c.representative_email
-FROM students
-JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
-JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
-JOIN (SELECT company_id, company_name, representative_contact, representative_email FROM companies) AS c ON j.company_id = c.company_id
+const dueOffers = `-- name: DueOffers :many
+SELECT
+    offers.application_id,
+    offers.respond_by,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id,
+    students.student_name
+FROM offers
+JOIN applications ON offers.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+WHERE offers.status = 'Pending'
+AND offers.respond_by <= CURRENT_TIMESTAMP
+ORDER BY offers.respond_by
 `
 
-type GetOfferLetterDataRow struct {
-	StudentName           string
-	StudentEmail          string
-	Title                 string
-	CompanyName           string
-	RepresentativeContact string
-	RepresentativeEmail   string
+type DueOffersRow struct {
+	ApplicationID int64
+	RespondBy     pgtype.Timestamptz
+	JobID         int64
+	Title         string
+	CompanyName   string
+	CompanyUserID int64
+	StudentUserID int64
+	StudentName   string
 }
 
-func (q *Queries) GetOfferLetterData(ctx context.Context, applicationID int64) (GetOfferLetterDataRow, error) {
-	row := q.db.QueryRow(ctx, getOfferLetterData, applicationID)
-	var i GetOfferLetterDataRow
-	err := row.Scan(
-		&i.StudentName,
-		&i.StudentEmail,
-		&i.Title,
-		&i.CompanyName,
-		&i.RepresentativeContact,
-		&i.RepresentativeEmail,
-	)
-	return i, err
+func (q *Queries) DueOffers(ctx context.Context) ([]DueOffersRow, error) {
+	rows, err := q.db.Query(ctx, dueOffers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DueOffersRow
+	for rows.Next() {
+		var i DueOffersRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.RespondBy,
+			&i.JobID,
+			&i.Title,
+			&i.CompanyName,
+			&i.CompanyUserID,
+			&i.StudentUserID,
+			&i.StudentName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getResumeAndResultPath = `-- name: GetResumeAndResultPath :one
+const evaluateTestResult = `-- name: EvaluateTestResult :one
+WITH tr AS (
+    UPDATE testresponses
+    SET points = temp_correct_answers.points
+    FROM temp_correct_answers
+    WHERE testresponses.question_id = temp_correct_answers.question_id
+    AND testresponses.response = temp_correct_answers.correct_answer
+    RETURNING testresponses.points, testresponses.result_id
+),
+rs AS (
+    SELECT 
+        result_id, 
+        SUM(points) AS score 
+    FROM tr
+    GROUP BY result_id
+),
+up AS (
+    UPDATE testresults
+    SET score = rs.score
+    FROM rs
+    WHERE testresults.result_id = rs.result_id
+)
 SELECT 
-    resume_url, 
-    result_url
-FROM students 
-JOIN applications 
-ON applications.student_id = students.student_id
-JOIN jobs ON applications.job_id = jobs.job_id
-JOIN companies ON jobs.company_id = companies.company_id
-WHERE applications.application_id = $1
-AND companies.user_id = $2
+    SUM(temp_correct_answers.points) AS totalpoints
+FROM temp_correct_answers
 `
 
-type GetResumeAndResultPathParams struct {
-	ApplicationID int64
-	UserID        int64
+func (q *Queries) EvaluateTestResult(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, evaluateTestResult)
+	var totalpoints int64
+	err := row.Scan(&totalpoints)
+	return totalpoints, err
 }
 
-type GetResumeAndResultPathRow struct {
-	ResumeUrl pgtype.Text
-	ResultUrl string
-}
+const expireOffer = `-- name: ExpireOffer :execrows
+UPDATE offers
+SET status = 'Expired', responded_at = CURRENT_TIMESTAMP
+WHERE application_id = $1
+AND status = 'Pending'
+AND respond_by <= CURRENT_TIMESTAMP
+`
 
-func (q *Queries) GetResumeAndResultPath(ctx context.Context, arg GetResumeAndResultPathParams) (GetResumeAndResultPathRow, error) {
-	row := q.db.QueryRow(ctx, getResumeAndResultPath, arg.ApplicationID, arg.UserID)
-	var i GetResumeAndResultPathRow
-	err := row.Scan(&i.ResumeUrl, &i.ResultUrl)
-	return i, err
+func (q *Queries) ExpireOffer(ctx context.Context, applicationID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, expireOffer, applicationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const getScheduleInterviewData = `-- name: GetScheduleInterviewData :one
-SELECT 
-    students.student_name, 
+const exportApplicants = `-- name: ExportApplicants :many
+SELECT
+    applications.application_id,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.roll_number,
+    students.gender,
+    students.course,
+    students.department,
+    students.year_of_study,
     students.student_email,
-    students.user_id,
-    j.title,
-    c.company_name
-FROM students
-JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
-JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
-JOIN (SELECT company_id, company_name FROM companies) AS c ON j.company_id = c.company_id
+    students.contact_no,
+    students.cgpa,
+    students.skills,
+    students.resume_url,
+    applications.status::TEXT AS status,
+    applications.created_at,
+    COALESCE(interviews.status::TEXT, '') AS interview_status,
+    interviews.date_time AS interview_at,
+    jobs.questions,
+    applications.answers
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN interviews ON applications.application_id = interviews.application_id
+                    AND interviews.status != 'Cancelled'
+WHERE ($1::BIGINT = 0 OR companies.user_id = $1)
+AND ($2::BIGINT = 0 OR applications.job_id = $2)
+AND (CARDINALITY($3::TEXT[]) = 0 OR applications.status::TEXT = ANY($3::TEXT[]))
+AND (CARDINALITY($4::BIGINT[]) = 0 OR applications.application_id = ANY($4::BIGINT[]))
+ORDER BY companies.company_name, jobs.job_id, students.roll_number
 `
 
-type GetScheduleInterviewDataRow struct {
-	StudentName  string
-	StudentEmail string
-	UserID       int64
-	Title        string
-	CompanyName  string
+type ExportApplicantsParams struct {
+	CompanyUserID  int64
+	JobID          int64
+	Statuses       []string
+	ApplicationIds []int64
 }
 
-func (q *Queries) GetScheduleInterviewData(ctx context.Context, applicationID int64) (GetScheduleInterviewDataRow, error) {
-	row := q.db.QueryRow(ctx, getScheduleInterviewData, applicationID)
-	var i GetScheduleInterviewDataRow
-	err := row.Scan(
-		&i.StudentName,
-		&i.StudentEmail,
-		&i.UserID,
-		&i.Title,
-		&i.CompanyName,
-	)
-	return i, err
+type ExportApplicantsRow struct {
+	ApplicationID   int64
+	JobID           int64
+	Title           string
+	CompanyName     string
+	StudentUserID   int64
+	StudentName     string
+	RollNumber      string
+	Gender          string
+	Course          string
+	Department      string
+	YearOfStudy     string
+	StudentEmail    string
+	ContactNo       string
+	Cgpa            pgtype.Float8
+	Skills          pgtype.Text
+	ResumeUrl       pgtype.Text
+	Status          string
+	CreatedAt       pgtype.Timestamptz
+	InterviewStatus string
+	InterviewAt     pgtype.Timestamptz
+	Questions       []byte
+	Answers         []byte
 }
 
-const getUserData = `-- name: GetUserData :one
-SELECT user_id, email, password, role, user_uuid, created_at, confirmed, is_verified FROM users WHERE email = $1
-`
+func (q *Queries) ExportApplicants(ctx context.Context, arg ExportApplicantsParams) ([]ExportApplicantsRow, error) {
+	rows, err := q.db.Query(ctx, exportApplicants,
+		arg.CompanyUserID,
+		arg.JobID,
+		arg.Statuses,
+		arg.ApplicationIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportApplicantsRow
+	for rows.Next() {
+		var i ExportApplicantsRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.JobID,
+			&i.Title,
+			&i.CompanyName,
+			&i.StudentUserID,
+			&i.StudentName,
+			&i.RollNumber,
+			&i.Gender,
+			&i.Course,
+			&i.Department,
+			&i.YearOfStudy,
+			&i.StudentEmail,
+			&i.ContactNo,
+			&i.Cgpa,
+			&i.Skills,
+			&i.ResumeUrl,
+			&i.Status,
+			&i.CreatedAt,
+			&i.InterviewStatus,
+			&i.InterviewAt,
+			&i.Questions,
+			&i.Answers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportCompanyJobs = `-- name: ExportCompanyJobs :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.description,
+    jobs.type,
+    jobs.salary,
+    jobs.skills,
+    jobs.position,
+    jobs.extras,
+    jobs.deadline,
+    jobs.max_applicants,
+    jobs.questions,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max,
+    job_compensation.fixed_pay,
+    job_compensation.variable_pay,
+    job_compensation.stipend_monthly,
+    job_compensation.bond_months,
+    job_compensation.bond_terms,
+    job_compensation.parsed,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+WHERE companies.user_id = $1
+AND ($2::TIMESTAMPTZ IS NULL OR jobs.created_at >= $2::TIMESTAMPTZ)
+AND ($3::TIMESTAMPTZ IS NULL OR jobs.created_at < $3::TIMESTAMPTZ)
+ORDER BY jobs.job_id
+`
+
+type ExportCompanyJobsParams struct {
+	UserID     int64
+	PostedFrom pgtype.Timestamptz
+	PostedTo   pgtype.Timestamptz
+}
+
+type ExportCompanyJobsRow struct {
+	JobID          int64
+	Title          string
+	Location       string
+	Description    pgtype.Text
+	Type           string
+	Salary         string
+	Skills         []string
+	Position       string
+	Extras         []byte
+	Deadline       pgtype.Timestamptz
+	MaxApplicants  pgtype.Int4
+	Questions      []byte
+	Currency       pgtype.Text
+	CtcMin         pgtype.Int8
+	CtcMax         pgtype.Int8
+	FixedPay       pgtype.Int8
+	VariablePay    pgtype.Int8
+	StipendMonthly pgtype.Int8
+	BondMonths     pgtype.Int4
+	BondTerms      pgtype.Text
+	Parsed         pgtype.Bool
+	MinCgpa        pgtype.Float8
+	Courses        []string
+	Departments    []string
+	YearsOfStudy   []string
+	MaxBacklogs    pgtype.Int4
+	Custom         []byte
+}
+
+func (q *Queries) ExportCompanyJobs(ctx context.Context, arg ExportCompanyJobsParams) ([]ExportCompanyJobsRow, error) {
+	rows, err := q.db.Query(ctx, exportCompanyJobs, arg.UserID, arg.PostedFrom, arg.PostedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportCompanyJobsRow
+	for rows.Next() {
+		var i ExportCompanyJobsRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Title,
+			&i.Location,
+			&i.Description,
+			&i.Type,
+			&i.Salary,
+			&i.Skills,
+			&i.Position,
+			&i.Extras,
+			&i.Deadline,
+			&i.MaxApplicants,
+			&i.Questions,
+			&i.Currency,
+			&i.CtcMin,
+			&i.CtcMax,
+			&i.FixedPay,
+			&i.VariablePay,
+			&i.StipendMonthly,
+			&i.BondMonths,
+			&i.BondTerms,
+			&i.Parsed,
+			&i.MinCgpa,
+			&i.Courses,
+			&i.Departments,
+			&i.YearsOfStudy,
+			&i.MaxBacklogs,
+			&i.Custom,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportTestScores = `-- name: ExportTestScores :many
+SELECT
+    tests.job_id,
+    tests.test_id,
+    tests.test_name,
+    testresults.user_id,
+    COALESCE(testresults.score, 0) AS score
+FROM tests
+JOIN companies ON tests.company_id = companies.company_id
+JOIN testresults ON tests.test_id = testresults.test_id
+WHERE ($1::BIGINT = 0 OR companies.user_id = $1)
+AND ($2::BIGINT = 0 OR tests.job_id = $2)
+AND tests.job_id IS NOT NULL
+ORDER BY tests.test_id
+`
+
+type ExportTestScoresParams struct {
+	CompanyUserID int64
+	JobID         int64
+}
+
+type ExportTestScoresRow struct {
+	JobID    pgtype.Int8
+	TestID   int64
+	TestName string
+	UserID   int64
+	Score    int64
+}
+
+func (q *Queries) ExportTestScores(ctx context.Context, arg ExportTestScoresParams) ([]ExportTestScoresRow, error) {
+	rows, err := q.db.Query(ctx, exportTestScores, arg.CompanyUserID, arg.JobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportTestScoresRow
+	for rows.Next() {
+		var i ExportTestScoresRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.TestID,
+			&i.TestName,
+			&i.UserID,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const extraInfoCompany = `-- name: ExtraInfoCompany :one
+INSERT INTO companies (company_name, representative_email, representative_contact, representative_name, data_url, user_id, address, picture_url, website, description, industry)
+VALUES ($1, $2, $3, $4, $5, (SELECT user_id FROM users WHERE email = $6), $7, $8, $9, $10, $11)
+RETURNING company_id, company_name, representative_email, representative_contact, representative_name, data_url, user_id, address, picture_url, website, description, industry
+`
+
+type ExtraInfoCompanyParams struct {
+	CompanyName           string
+	RepresentativeEmail   string
+	RepresentativeContact string
+	RepresentativeName    string
+	DataUrl               pgtype.Text
+	Email                 string
+	Address               string
+	PictureUrl            pgtype.Text
+	Website               pgtype.Text
+	Description           pgtype.Text
+	Industry              string
+}
+
+func (q *Queries) ExtraInfoCompany(ctx context.Context, arg ExtraInfoCompanyParams) (Company, error) {
+	row := q.db.QueryRow(ctx, extraInfoCompany,
+		arg.CompanyName,
+		arg.RepresentativeEmail,
+		arg.RepresentativeContact,
+		arg.RepresentativeName,
+		arg.DataUrl,
+		arg.Email,
+		arg.Address,
+		arg.PictureUrl,
+		arg.Website,
+		arg.Description,
+		arg.Industry,
+	)
+	var i Company
+	err := row.Scan(
+		&i.CompanyID,
+		&i.CompanyName,
+		&i.RepresentativeEmail,
+		&i.RepresentativeContact,
+		&i.RepresentativeName,
+		&i.DataUrl,
+		&i.UserID,
+		&i.Address,
+		&i.PictureUrl,
+		&i.Website,
+		&i.Description,
+		&i.Industry,
+	)
+	return i, err
+}
+
+const extraInfoStudent = `-- name: ExtraInfoStudent :one
+INSERT INTO students (student_name, roll_number, student_dob, gender, course, department, year_of_study, resume_url, result_url, cgpa, contact_no, student_email, address, skills, user_id, extras, picture_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, (SELECT user_id FROM users WHERE email = $15), $16, $17)
+RETURNING student_id, student_name, roll_number, student_dob, gender, course, department, year_of_study, resume_url, result_url, cgpa, contact_no, student_email, address, skills, user_id, extras, picture_url, backlogs
+`
+
+type ExtraInfoStudentParams struct {
+	StudentName  string
+	RollNumber   string
+	StudentDob   pgtype.Date
+	Gender       string
+	Course       string
+	Department   string
+	YearOfStudy  string
+	ResumeUrl    pgtype.Text
+	ResultUrl    string
+	Cgpa         pgtype.Float8
+	ContactNo    string
+	StudentEmail string
+	Address      pgtype.Text
+	Skills       pgtype.Text
+	Email        string
+	Extras       []byte
+	PictureUrl   pgtype.Text
+}
+
+func (q *Queries) ExtraInfoStudent(ctx context.Context, arg ExtraInfoStudentParams) (Student, error) {
+	row := q.db.QueryRow(ctx, extraInfoStudent,
+		arg.StudentName,
+		arg.RollNumber,
+		arg.StudentDob,
+		arg.Gender,
+		arg.Course,
+		arg.Department,
+		arg.YearOfStudy,
+		arg.ResumeUrl,
+		arg.ResultUrl,
+		arg.Cgpa,
+		arg.ContactNo,
+		arg.StudentEmail,
+		arg.Address,
+		arg.Skills,
+		arg.Email,
+		arg.Extras,
+		arg.PictureUrl,
+	)
+	var i Student
+	err := row.Scan(
+		&i.StudentID,
+		&i.StudentName,
+		&i.RollNumber,
+		&i.StudentDob,
+		&i.Gender,
+		&i.Course,
+		&i.Department,
+		&i.YearOfStudy,
+		&i.ResumeUrl,
+		&i.ResultUrl,
+		&i.Cgpa,
+		&i.ContactNo,
+		&i.StudentEmail,
+		&i.Address,
+		&i.Skills,
+		&i.UserID,
+		&i.Extras,
+		&i.PictureUrl,
+		&i.Backlogs,
+	)
+	return i, err
+}
+
+const feedbacksDataForAndByCompany = `-- name: FeedbacksDataForAndByCompany :many
+SELECT 
+    feedbacks.feedback_id,
+    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
+    feedbacks.message,
+
+    applications.application_id,
+    applications.status::TEXT AS application_status,
+
+    interviews.interview_id,
+    TO_CHAR(interviews.date_time, 'HH12:MI:SS AM DD-MM-YYYY') AS interview_date_time,
+    
+    students.student_id,
+    students.student_name
+
+FROM feedbacks
+LEFT JOIN interviews ON interviews.interview_id = feedbacks.interview_id
+LEFT JOIN applications ON (applications.application_id = feedbacks.application_id OR 
+                            applications.application_id = interviews.application_id)
+JOIN students ON students.student_id = applications.student_id
+WHERE feedbacks.user_id = $1
+`
+
+type FeedbacksDataForAndByCompanyRow struct {
+	FeedbackID        int64
+	FeedbackTime      string
+	Message           pgtype.Text
+	ApplicationID     pgtype.Int8
+	ApplicationStatus string
+	InterviewID       pgtype.Int8
+	InterviewDateTime string
+	StudentID         int64
+	StudentName       string
+}
+
+func (q *Queries) FeedbacksDataForAndByCompany(ctx context.Context, userID int64) ([]FeedbacksDataForAndByCompanyRow, error) {
+	rows, err := q.db.Query(ctx, feedbacksDataForAndByCompany, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedbacksDataForAndByCompanyRow
+	for rows.Next() {
+		var i FeedbacksDataForAndByCompanyRow
+		if err := rows.Scan(
+			&i.FeedbackID,
+			&i.FeedbackTime,
+			&i.Message,
+			&i.ApplicationID,
+			&i.ApplicationStatus,
+			&i.InterviewID,
+			&i.InterviewDateTime,
+			&i.StudentID,
+			&i.StudentName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const feedbacksDataForAndToCompany = `-- name: FeedbacksDataForAndToCompany :many
+WITH notuser AS (
+    SELECT 
+        interviews.interview_id 
+    FROM interviews 
+    WHERE interviews.company_id = 
+        (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
+)
+SELECT 
+    feedbacks.feedback_id,
+    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
+    feedbacks.message
+FROM feedbacks
+LEFT JOIN notuser ON notuser.interview_id = feedbacks.interview_id
+WHERE feedbacks.user_id != $1 AND notuser.interview_id IS NOT NULL
+`
+
+type FeedbacksDataForAndToCompanyRow struct {
+	FeedbackID   int64
+	FeedbackTime string
+	Message      pgtype.Text
+}
+
+func (q *Queries) FeedbacksDataForAndToCompany(ctx context.Context, userID int64) ([]FeedbacksDataForAndToCompanyRow, error) {
+	rows, err := q.db.Query(ctx, feedbacksDataForAndToCompany, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedbacksDataForAndToCompanyRow
+	for rows.Next() {
+		var i FeedbacksDataForAndToCompanyRow
+		if err := rows.Scan(&i.FeedbackID, &i.FeedbackTime, &i.Message); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const feedbacksDataForStudent = `-- name: FeedbacksDataForStudent :many
+SELECT 
+    feedbacks.feedback_id,
+    TO_CHAR(feedbacks.created_at, 'HH12:MI:SS AM DD-MM-YYYY') AS feedback_time,
+    feedbacks.message,
+
+    applications.application_id,
+
+    interviews.interview_id
+
+FROM feedbacks
+LEFT JOIN applications ON applications.application_id = feedbacks.application_id
+LEFT JOIN interviews ON interviews.interview_id = feedbacks.interview_id
+WHERE feedbacks.user_id = $1
+`
+
+type FeedbacksDataForStudentRow struct {
+	FeedbackID    int64
+	FeedbackTime  string
+	Message       pgtype.Text
+	ApplicationID pgtype.Int8
+	InterviewID   pgtype.Int8
+}
+
+func (q *Queries) FeedbacksDataForStudent(ctx context.Context, userID int64) ([]FeedbacksDataForStudentRow, error) {
+	rows, err := q.db.Query(ctx, feedbacksDataForStudent, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedbacksDataForStudentRow
+	for rows.Next() {
+		var i FeedbacksDataForStudentRow
+		if err := rows.Scan(
+			&i.FeedbackID,
+			&i.FeedbackTime,
+			&i.Message,
+			&i.ApplicationID,
+			&i.InterviewID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const filterJobApplications = `-- name: FilterJobApplications :many
+SELECT applications.application_id
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN testresults ON testresults.test_id = $1 AND testresults.user_id = students.user_id
+WHERE jobs.job_id = $2
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $3)
+AND ($4::BIGINT IS NULL OR testresults.score >= $4)
+AND ($5::BIGINT IS NULL OR COALESCE(testresults.score, 0) < $5)
+AND (CARDINALITY($6::TEXT[]) = 0 OR applications.status::TEXT = ANY($6::TEXT[]))
+ORDER BY applications.application_id
+`
+
+type FilterJobApplicationsParams struct {
+	TestID     int64
+	JobID      int64
+	UserID     int64
+	MinScore   pgtype.Int8
+	BelowScore pgtype.Int8
+	Statuses   []string
+}
+
+func (q *Queries) FilterJobApplications(ctx context.Context, arg FilterJobApplicationsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, filterJobApplications,
+		arg.TestID,
+		arg.JobID,
+		arg.UserID,
+		arg.MinScore,
+		arg.BelowScore,
+		arg.Statuses,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var application_id int64
+		if err := rows.Scan(&application_id); err != nil {
+			return nil, err
+		}
+		items = append(items, application_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAll = `-- name: GetAll :many
+SELECT user_id, email, password, role, user_uuid, created_at, confirmed, is_verified FROM users
+`
+
+func (q *Queries) GetAll(ctx context.Context) ([]User, error) {
+	rows, err := q.db.Query(ctx, getAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Email,
+			&i.Password,
+			&i.Role,
+			&i.UserUuid,
+			&i.CreatedAt,
+			&i.Confirmed,
+			&i.IsVerified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllApplicantsEmailsForJob = `-- name: GetAllApplicantsEmailsForJob :many
+SELECT 
+    students.student_email
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+WHERE applications.job_id = $1
+`
+
+func (q *Queries) GetAllApplicantsEmailsForJob(ctx context.Context, jobID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAllApplicantsEmailsForJob, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var student_email string
+		if err := rows.Scan(&student_email); err != nil {
+			return nil, err
+		}
+		items = append(items, student_email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllFilePathsCompany = `-- name: GetAllFilePathsCompany :one
+SELECT 
+    companies.picture_url,
+    companies.picture_url
+FROM companies
+WHERE user_id = $1
+`
+
+type GetAllFilePathsCompanyRow struct {
+	PictureUrl   pgtype.Text
+	PictureUrl_2 pgtype.Text
+}
+
+func (q *Queries) GetAllFilePathsCompany(ctx context.Context, userID int64) (GetAllFilePathsCompanyRow, error) {
+	row := q.db.QueryRow(ctx, getAllFilePathsCompany, userID)
+	var i GetAllFilePathsCompanyRow
+	err := row.Scan(&i.PictureUrl, &i.PictureUrl_2)
+	return i, err
+}
+
+const getAllFilePathsStudent = `-- name: GetAllFilePathsStudent :one
+SELECT 
+    students.resume_url,
+    students.result_url,
+    students.picture_url
+FROM students
+WHERE user_id = $1
+`
+
+type GetAllFilePathsStudentRow struct {
+	ResumeUrl  pgtype.Text
+	ResultUrl  string
+	PictureUrl pgtype.Text
+}
+
+func (q *Queries) GetAllFilePathsStudent(ctx context.Context, userID int64) (GetAllFilePathsStudentRow, error) {
+	row := q.db.QueryRow(ctx, getAllFilePathsStudent, userID)
+	var i GetAllFilePathsStudentRow
+	err := row.Scan(&i.ResumeUrl, &i.ResultUrl, &i.PictureUrl)
+	return i, err
+}
+
+const getAllJobsID = `-- name: GetAllJobsID :many
+SELECT
+    jobs.job_id
+FROM jobs
+WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
+`
+
+func (q *Queries) GetAllJobsID(ctx context.Context, userID int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getAllJobsID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var job_id int64
+		if err := rows.Scan(&job_id); err != nil {
+			return nil, err
+		}
+		items = append(items, job_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicableJobsTypeFilter = `-- name: GetApplicableJobsTypeFilter :many
+SELECT 
+    jobs.job_id,
+    jobs.title, 
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.company_id,
+    jobs.active_status,
+    jobs.deadline,
+    jobs.questions,
+    companies.company_name,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = jobs.job_id
+        AND eligibility_overrides.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+    ) AS overridden,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max,
+    job_compensation.fixed_pay,
+    job_compensation.variable_pay,
+    job_compensation.stipend_monthly,
+    job_compensation.bond_months,
+    job_compensation.bond_terms
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id 
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+LEFT JOIN (SELECT applications.job_id FROM applications WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)) AS t 
+ON jobs.job_id = t.job_id
+WHERE t.job_id IS NULL 
+AND jobs.approval_status = 'approved'
+AND (jobs.type = $2 OR $2 = 'All')
+`
+
+type GetApplicableJobsTypeFilterParams struct {
+	UserID int64
+	Type   string
+}
+
+type GetApplicableJobsTypeFilterRow struct {
+	JobID          int64
+	Title          string
+	Location       string
+	Type           string
+	Salary         string
+	Position       string
+	Skills         []string
+	CompanyID      int64
+	ActiveStatus   bool
+	Deadline       pgtype.Timestamptz
+	Questions      []byte
+	CompanyName    string
+	MinCgpa        pgtype.Float8
+	Courses        []string
+	Departments    []string
+	YearsOfStudy   []string
+	MaxBacklogs    pgtype.Int4
+	Custom         []byte
+	Overridden     bool
+	Currency       pgtype.Text
+	CtcMin         pgtype.Int8
+	CtcMax         pgtype.Int8
+	FixedPay       pgtype.Int8
+	VariablePay    pgtype.Int8
+	StipendMonthly pgtype.Int8
+	BondMonths     pgtype.Int4
+	BondTerms      pgtype.Text
+}
+
+func (q *Queries) GetApplicableJobsTypeFilter(ctx context.Context, arg GetApplicableJobsTypeFilterParams) ([]GetApplicableJobsTypeFilterRow, error) {
+	rows, err := q.db.Query(ctx, getApplicableJobsTypeFilter, arg.UserID, arg.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApplicableJobsTypeFilterRow
+	for rows.Next() {
+		var i GetApplicableJobsTypeFilterRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Position,
+			&i.Skills,
+			&i.CompanyID,
+			&i.ActiveStatus,
+			&i.Deadline,
+			&i.Questions,
+			&i.CompanyName,
+			&i.MinCgpa,
+			&i.Courses,
+			&i.Departments,
+			&i.YearsOfStudy,
+			&i.MaxBacklogs,
+			&i.Custom,
+			&i.Overridden,
+			&i.Currency,
+			&i.CtcMin,
+			&i.CtcMax,
+			&i.FixedPay,
+			&i.VariablePay,
+			&i.StipendMonthly,
+			&i.BondMonths,
+			&i.BondTerms,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApplicants = `-- name: GetApplicants :many
+SELECT
+    students.student_id,
+    students.student_name,
+    students.roll_number,
+    students.gender,
+    students.department,
+    students.student_email,
+    students.contact_no,
+    students.cgpa,
+    students.skills,
+    jobs.job_id, 
+    jobs.title, 
+    applications.status::TEXT AS status,
+    COALESCE(interviews.status::TEXT, '') AS interview_status,
+    applications.application_id,
+    jobs.questions,
+    applications.answers
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN interviews ON applications.application_id = interviews.application_id
+                    AND interviews.status != 'Cancelled'
+WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
+AND (jobs.job_id = $2 OR $2 = 0)
+AND (applications.application_id = $3 OR $3 = 0)
+AND (applications.status != 'Rejected')
+ORDER BY jobs.job_id
+`
+
+type GetApplicantsParams struct {
+	UserID        int64
+	JobID         int64
+	ApplicationID int64
+}
+
+type GetApplicantsRow struct {
+	StudentID       int64
+	StudentName     string
+	RollNumber      string
+	Gender          string
+	Department      string
+	StudentEmail    string
+	ContactNo       string
+	Cgpa            pgtype.Float8
+	Skills          pgtype.Text
+	JobID           int64
+	Title           string
+	Status          string
+	InterviewStatus interface{}
+	ApplicationID   int64
+	Questions       []byte
+	Answers         []byte
+}
+
+func (q *Queries) GetApplicants(ctx context.Context, arg GetApplicantsParams) ([]GetApplicantsRow, error) {
+	rows, err := q.db.Query(ctx, getApplicants, arg.UserID, arg.JobID, arg.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetApplicantsRow
+	for rows.Next() {
+		var i GetApplicantsRow
+		if err := rows.Scan(
+			&i.StudentID,
+			&i.StudentName,
+			&i.RollNumber,
+			&i.Gender,
+			&i.Department,
+			&i.StudentEmail,
+			&i.ContactNo,
+			&i.Cgpa,
+			&i.Skills,
+			&i.JobID,
+			&i.Title,
+			&i.Status,
+			&i.InterviewStatus,
+			&i.ApplicationID,
+			&i.Questions,
+			&i.Answers,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCalendarFeedToken = `-- name: GetCalendarFeedToken :one
+SELECT
+    calendar_feeds.token
+FROM calendar_feeds
+WHERE calendar_feeds.user_id = $1
+`
+
+func (q *Queries) GetCalendarFeedToken(ctx context.Context, userID int64) (string, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedToken, userID)
+	var token string
+	err := row.Scan(&token)
+	return token, err
+}
+
+const getDeviceTokens = `-- name: GetDeviceTokens :many
+SELECT
+    device_tokens.token
+FROM device_tokens
+WHERE device_tokens.user_id = $1
+`
+
+func (q *Queries) GetDeviceTokens(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getDeviceTokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var token string
+		if err := rows.Scan(&token); err != nil {
+			return nil, err
+		}
+		items = append(items, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDigestPreferences = `-- name: GetDigestPreferences :one
+SELECT
+    CAST(COALESCE(digest_preferences.frequency, 'daily') AS TEXT) AS frequency,
+    CAST(COALESCE(digest_preferences.send_hour, 8) AS INTEGER) AS send_hour,
+    CAST(COALESCE(digest_preferences.weekday, 1) AS INTEGER) AS weekday,
+    CAST(COALESCE(digest_preferences.timezone, 'Asia/Kolkata') AS TEXT) AS timezone
+FROM users
+LEFT JOIN digest_preferences ON users.user_id = digest_preferences.user_id
+WHERE users.user_id = $1
+`
+
+type GetDigestPreferencesRow struct {
+	Frequency string
+	SendHour  int32
+	Weekday   int32
+	Timezone  string
+}
+
+func (q *Queries) GetDigestPreferences(ctx context.Context, userID int64) (GetDigestPreferencesRow, error) {
+	row := q.db.QueryRow(ctx, getDigestPreferences, userID)
+	var i GetDigestPreferencesRow
+	err := row.Scan(
+		&i.Frequency,
+		&i.SendHour,
+		&i.Weekday,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const getJobCompensation = `-- name: GetJobCompensation :one
+SELECT * FROM job_compensation
+WHERE job_id = $1
+`
+
+func (q *Queries) GetJobCompensation(ctx context.Context, jobID int64) (JobCompensation, error) {
+	row := q.db.QueryRow(ctx, getJobCompensation, jobID)
+	var i JobCompensation
+	err := row.Scan(
+		&i.JobID,
+		&i.Currency,
+		&i.CtcMin,
+		&i.CtcMax,
+		&i.FixedPay,
+		&i.VariablePay,
+		&i.StipendMonthly,
+		&i.BondMonths,
+		&i.BondTerms,
+		&i.Parsed,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getJobDetails = `-- name: GetJobDetails :one
+SELECT 
+    jobs.title,
+    companies.company_name
+FROM jobs 
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE jobs.job_id = $1
+`
+
+type GetJobDetailsRow struct {
+	Title       string
+	CompanyName string
+}
+
+func (q *Queries) GetJobDetails(ctx context.Context, jobID int64) (GetJobDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getJobDetails, jobID)
+	var i GetJobDetailsRow
+	err := row.Scan(&i.Title, &i.CompanyName)
+	return i, err
+}
+
+const getJobEligibility = `-- name: GetJobEligibility :one
+SELECT
+    job_eligibility.job_id,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    job_eligibility.updated_at
+FROM job_eligibility
+WHERE job_eligibility.job_id = $1
+`
+
+func (q *Queries) GetJobEligibility(ctx context.Context, jobID int64) (JobEligibility, error) {
+	row := q.db.QueryRow(ctx, getJobEligibility, jobID)
+	var i JobEligibility
+	err := row.Scan(
+		&i.JobID,
+		&i.MinCgpa,
+		&i.Courses,
+		&i.Departments,
+		&i.YearsOfStudy,
+		&i.MaxBacklogs,
+		&i.Custom,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getJobForUpdate = `-- name: GetJobForUpdate :one
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.description,
+    jobs.type,
+    jobs.salary,
+    jobs.skills,
+    jobs.position,
+    jobs.extras,
+    jobs.revision,
+    jobs.deadline,
+    jobs.max_applicants,
+    jobs.questions,
+    jobs.approval_status
+FROM jobs
+WHERE jobs.job_id = $1
+AND jobs.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2)
+`
+
+type GetJobForUpdateParams struct {
+	JobID  int64
+	UserID int64
+}
+
+type GetJobForUpdateRow struct {
+	JobID          int64
+	Title          string
+	Location       string
+	Description    pgtype.Text
+	Type           string
+	Salary         string
+	Skills         []string
+	Position       string
+	Extras         []byte
+	Revision       int32
+	Deadline       pgtype.Timestamptz
+	MaxApplicants  pgtype.Int4
+	Questions      []byte
+	ApprovalStatus string
+}
+
+func (q *Queries) GetJobForUpdate(ctx context.Context, arg GetJobForUpdateParams) (GetJobForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getJobForUpdate, arg.JobID, arg.UserID)
+	var i GetJobForUpdateRow
+	err := row.Scan(
+		&i.JobID,
+		&i.Title,
+		&i.Location,
+		&i.Description,
+		&i.Type,
+		&i.Salary,
+		&i.Skills,
+		&i.Position,
+		&i.Extras,
+		&i.Revision,
+		&i.Deadline,
+		&i.MaxApplicants,
+		&i.Questions,
+		&i.ApprovalStatus,
+	)
+	return i, err
+}
+
+const getJobListings = `-- name: GetJobListings :many
+SELECT 
+    jobs.job_id,
+    jobs.created_at::DATE as created_at,
+    jobs.title,
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.skills,
+    jobs.position,
+    jobs.active_status,
+    jobs.approval_status,
+    COALESCE(t.no_of_applications, 0),
+    jobs.description,
+    jobs.extras    
+FROM jobs
+LEFT JOIN (
+    SELECT 
+        job_id, 
+        COUNT(job_id) AS no_of_applications 
+    FROM applications 
+    WHERE status != 'Rejected' 
+    GROUP BY job_id ) AS t
+ON jobs.job_id = t.job_id
+WHERE jobs.company_id = (
+    SELECT 
+        companies.company_id 
+    FROM companies 
+    WHERE companies.user_id = $1)
+ORDER BY jobs.job_id
+`
+
+type GetJobListingsRow struct {
+	JobID            int64
+	CreatedAt        pgtype.Date
+	Title            string
+	Location         string
+	Type             string
+	Salary           string
+	Skills           []string
+	Position         string
+	ActiveStatus     bool
+	ApprovalStatus   string
+	NoOfApplications int64
+	Description      pgtype.Text
+	Extras           []byte
+}
+
+func (q *Queries) GetJobListings(ctx context.Context, userID int64) ([]GetJobListingsRow, error) {
+	rows, err := q.db.Query(ctx, getJobListings, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetJobListingsRow
+	for rows.Next() {
+		var i GetJobListingsRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.CreatedAt,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Skills,
+			&i.Position,
+			&i.ActiveStatus,
+			&i.ApprovalStatus,
+			&i.NoOfApplications,
+			&i.Description,
+			&i.Extras,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMyApplicationsStatusFilter = `-- name: GetMyApplicationsStatusFilter :many
+SELECT 
+    jobs.job_id,
+    jobs.title, 
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.company_id,
+    companies.company_name,
+    companies.representative_email,
+    companies.representative_name,
+    applications.status::TEXT AS status,
+    (jobs.revision > applications.job_revision) AS changed_since_applied,
+    CAST(ARRAY(
+        SELECT DISTINCT UNNEST(job_revisions.changed_fields)
+        FROM job_revisions
+        WHERE job_revisions.job_id = jobs.job_id
+        AND job_revisions.revision > applications.job_revision
+    ) AS TEXT[]) AS changed_fields
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE students.user_id = $1 
+  AND ($2 = 'All' OR applications.status::TEXT = $2)
+ORDER BY jobs.job_id
+`
+
+type GetMyApplicationsStatusFilterParams struct {
+	UserID  int64
+	Column2 interface{}
+}
+
+type GetMyApplicationsStatusFilterRow struct {
+	JobID               int64
+	Title               string
+	Location            string
+	Type                string
+	Salary              string
+	Position            string
+	Skills              []string
+	CompanyID           int64
+	CompanyName         string
+	RepresentativeEmail string
+	RepresentativeName  string
+	Status              string
+	ChangedSinceApplied bool
+	ChangedFields       []string
+}
+
+func (q *Queries) GetMyApplicationsStatusFilter(ctx context.Context, arg GetMyApplicationsStatusFilterParams) ([]GetMyApplicationsStatusFilterRow, error) {
+	rows, err := q.db.Query(ctx, getMyApplicationsStatusFilter, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMyApplicationsStatusFilterRow
+	for rows.Next() {
+		var i GetMyApplicationsStatusFilterRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Position,
+			&i.Skills,
+			&i.CompanyID,
+			&i.CompanyName,
+			&i.RepresentativeEmail,
+			&i.RepresentativeName,
+			&i.Status,
+			&i.ChangedSinceApplied,
+			&i.ChangedFields,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotifications = `-- name: GetNotifications :many
+SELECT
+    notif_id, user_id, title, description, read_status, timestamp, category, archived, ref_type, ref_id
+FROM notifications
+WHERE user_id = $1
+AND archived = $2
+AND ($3::TEXT IS NULL OR category = $3)
+AND ($4::BIGINT IS NULL OR timestamp >= $4)
+AND ($5::BIGINT IS NULL OR timestamp <= $5)
+ORDER BY timestamp DESC
+LIMIT $6
+OFFSET $7
+`
+
+type GetNotificationsParams struct {
+	UserID     int64
+	Archived   bool
+	Category   pgtype.Text
+	FromTs     pgtype.Int8
+	ToTs       pgtype.Int8
+	PageLimit  int32
+	PageOffset int32
+}
+
+func (q *Queries) GetNotifications(ctx context.Context, arg GetNotificationsParams) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, getNotifications,
+		arg.UserID,
+		arg.Archived,
+		arg.Category,
+		arg.FromTs,
+		arg.ToTs,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Notification
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.NotifID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.ReadStatus,
+			&i.Timestamp,
+			&i.Category,
+			&i.Archived,
+			&i.RefType,
+			&i.RefID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOfferLetterData = `-- name: GetOfferLetterData :one
+SELECT 
+    students.student_name, 
+    students.student_email,
+    j.title,
+    c.company_name,
+    c.representative_contact,
+    c.representative_email
+FROM students
+JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
+JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
+JOIN (SELECT company_id, company_name, representative_contact, representative_email FROM companies) AS c ON j.company_id = c.company_id
+`
+
+type GetOfferLetterDataRow struct {
+	StudentName           string
+	StudentEmail          string
+	Title                 string
+	CompanyName           string
+	RepresentativeContact string
+	RepresentativeEmail   string
+}
+
+func (q *Queries) GetOfferLetterData(ctx context.Context, applicationID int64) (GetOfferLetterDataRow, error) {
+	row := q.db.QueryRow(ctx, getOfferLetterData, applicationID)
+	var i GetOfferLetterDataRow
+	err := row.Scan(
+		&i.StudentName,
+		&i.StudentEmail,
+		&i.Title,
+		&i.CompanyName,
+		&i.RepresentativeContact,
+		&i.RepresentativeEmail,
+	)
+	return i, err
+}
+
+const getPlacementPolicy = `-- name: GetPlacementPolicy :one
+SELECT max_offers, block_after_acceptance, tiers, updated_by, updated_at
+FROM placement_policy
+WHERE policy_id = 1
+`
+
+type GetPlacementPolicyRow struct {
+	MaxOffers            int32
+	BlockAfterAcceptance bool
+	Tiers                []byte
+	UpdatedBy            pgtype.Int8
+	UpdatedAt            pgtype.Timestamptz
+}
+
+func (q *Queries) GetPlacementPolicy(ctx context.Context) (GetPlacementPolicyRow, error) {
+	row := q.db.QueryRow(ctx, getPlacementPolicy)
+	var i GetPlacementPolicyRow
+	err := row.Scan(
+		&i.MaxOffers,
+		&i.BlockAfterAcceptance,
+		&i.Tiers,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getResumeAndResultPath = `-- name: GetResumeAndResultPath :one
+SELECT 
+    resume_url, 
+    result_url
+FROM students 
+JOIN applications 
+ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = $1
+AND companies.user_id = $2
+`
+
+type GetResumeAndResultPathParams struct {
+	ApplicationID int64
+	UserID        int64
+}
+
+type GetResumeAndResultPathRow struct {
+	ResumeUrl pgtype.Text
+	ResultUrl string
+}
+
+func (q *Queries) GetResumeAndResultPath(ctx context.Context, arg GetResumeAndResultPathParams) (GetResumeAndResultPathRow, error) {
+	row := q.db.QueryRow(ctx, getResumeAndResultPath, arg.ApplicationID, arg.UserID)
+	var i GetResumeAndResultPathRow
+	err := row.Scan(&i.ResumeUrl, &i.ResultUrl)
+	return i, err
+}
+
+const getScheduleInterviewData = `-- name: GetScheduleInterviewData :one
+SELECT 
+    students.student_name, 
+    students.student_email,
+    students.user_id,
+    j.title,
+    c.company_name
+FROM students
+JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
+JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
+JOIN (SELECT company_id, company_name FROM companies) AS c ON j.company_id = c.company_id
+`
+
+type GetScheduleInterviewDataRow struct {
+	StudentName  string
+	StudentEmail string
+	UserID       int64
+	Title        string
+	CompanyName  string
+}
+
+func (q *Queries) GetScheduleInterviewData(ctx context.Context, applicationID int64) (GetScheduleInterviewDataRow, error) {
+	row := q.db.QueryRow(ctx, getScheduleInterviewData, applicationID)
+	var i GetScheduleInterviewDataRow
+	err := row.Scan(
+		&i.StudentName,
+		&i.StudentEmail,
+		&i.UserID,
+		&i.Title,
+		&i.CompanyName,
+	)
+	return i, err
+}
+
+const getUserData = `-- name: GetUserData :one
+SELECT user_id, email, password, role, user_uuid, created_at, confirmed, is_verified FROM users WHERE email = $1
+`
+
+func (q *Queries) GetUserData(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserData, email)
+	var i User
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.Password,
+		&i.Role,
+		&i.UserUuid,
+		&i.CreatedAt,
+		&i.Confirmed,
+		&i.IsVerified,
+	)
+	return i, err
+}
+
+const getUserIDCompanyIDJobIDApplicationID = `-- name: GetUserIDCompanyIDJobIDApplicationID :one
+SELECT 
+    companies.user_id
+FROM companies
+JOIN jobs ON jobs.company_id = companies.company_id
+JOIN applications ON applications.job_id = jobs.job_id
+WHERE applications.application_id = $1
+`
+
+func (q *Queries) GetUserIDCompanyIDJobIDApplicationID(ctx context.Context, applicationID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getUserIDCompanyIDJobIDApplicationID, applicationID)
+	var user_id int64
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const getUserUUIDFromEmail = `-- name: GetUserUUIDFromEmail :one
+SELECT 
+    users.user_uuid
+FROM users 
+WHERE users.email = $1
+`
+
+func (q *Queries) GetUserUUIDFromEmail(ctx context.Context, email string) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getUserUUIDFromEmail, email)
+	var user_uuid pgtype.UUID
+	err := row.Scan(&user_uuid)
+	return user_uuid, err
+}
+
+const getUserUUIDFromUserID = `-- name: GetUserUUIDFromUserID :one
+SELECT 
+    users.user_uuid
+FROM users 
+WHERE users.user_id = $1
+`
+
+func (q *Queries) GetUserUUIDFromUserID(ctx context.Context, userID int64) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getUserUUIDFromUserID, userID)
+	var user_uuid pgtype.UUID
+	err := row.Scan(&user_uuid)
+	return user_uuid, err
+}
+
+const insertAnnouncement = `-- name: InsertAnnouncement :one
+INSERT INTO announcements (author_id, title, message, target_role, department, course, year_of_study, job_id, send_email, scheduled_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING announcement_id
+`
+
+type InsertAnnouncementParams struct {
+	AuthorID    int64
+	Title       string
+	Message     string
+	TargetRole  pgtype.Int8
+	Department  pgtype.Text
+	Course      pgtype.Text
+	YearOfStudy pgtype.Text
+	JobID       pgtype.Int8
+	SendEmail   bool
+	ScheduledAt pgtype.Timestamptz
+}
+
+func (q *Queries) InsertAnnouncement(ctx context.Context, arg InsertAnnouncementParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertAnnouncement,
+		arg.AuthorID,
+		arg.Title,
+		arg.Message,
+		arg.TargetRole,
+		arg.Department,
+		arg.Course,
+		arg.YearOfStudy,
+		arg.JobID,
+		arg.SendEmail,
+		arg.ScheduledAt,
+	)
+	var announcement_id int64
+	err := row.Scan(&announcement_id)
+	return announcement_id, err
+}
+
+const insertAnnouncementRecipients = `-- name: InsertAnnouncementRecipients :exec
+INSERT INTO announcement_recipients (announcement_id, user_id)
+SELECT
+    announcements.announcement_id,
+    users.user_id
+FROM announcements
+JOIN users ON announcements.target_role IS NULL OR users.role = announcements.target_role
+LEFT JOIN students ON students.user_id = users.user_id
+WHERE announcements.announcement_id = $1
+AND users.role != 5
+AND (announcements.department IS NULL OR students.department = announcements.department)
+AND (announcements.course IS NULL OR students.course = announcements.course)
+AND (announcements.year_of_study IS NULL OR students.year_of_study = announcements.year_of_study)
+AND (announcements.job_id IS NULL OR EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = announcements.job_id
+    AND applications.student_id = students.student_id
+))
+ON CONFLICT (announcement_id, user_id) DO NOTHING
+`
+
+func (q *Queries) InsertAnnouncementRecipients(ctx context.Context, announcementID int64) error {
+	_, err := q.db.Exec(ctx, insertAnnouncementRecipients, announcementID)
+	return err
+}
+
+const insertAnswers = `-- name: InsertAnswers :exec
+INSERT INTO temp_correct_answers (question_id, correct_answer, points)
+VALUES ($1, $2, $3)
+`
+
+type InsertAnswersParams struct {
+	QuestionID    string
+	CorrectAnswer []string
+	Points        pgtype.Int4
+}
+
+func (q *Queries) InsertAnswers(ctx context.Context, arg InsertAnswersParams) error {
+	_, err := q.db.Exec(ctx, insertAnswers, arg.QuestionID, arg.CorrectAnswer, arg.Points)
+	return err
+}
+
+const insertApplicationStageMove = `-- name: InsertApplicationStageMove :exec
+INSERT INTO application_stage_moves (application_id, from_stage_id, to_stage_id, moved_by)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertApplicationStageMoveParams struct {
+	ApplicationID int64
+	FromStageID   pgtype.Int8
+	ToStageID     pgtype.Int8
+	MovedBy       pgtype.Int8
+}
+
+func (q *Queries) InsertApplicationStageMove(ctx context.Context, arg InsertApplicationStageMoveParams) error {
+	_, err := q.db.Exec(ctx, insertApplicationStageMove,
+		arg.ApplicationID,
+		arg.FromStageID,
+		arg.ToStageID,
+		arg.MovedBy,
+	)
+	return err
+}
+
+const insertApplicationStatusHistory = `-- name: InsertApplicationStatusHistory :exec
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role, reason)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertApplicationStatusHistoryParams struct {
+	ApplicationID int64
+	FromStatus    pgtype.Text
+	ToStatus      string
+	ActorID       pgtype.Int8
+	ActorRole     string
+	Reason        string
+}
+
+func (q *Queries) InsertApplicationStatusHistory(ctx context.Context, arg InsertApplicationStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertApplicationStatusHistory,
+		arg.ApplicationID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ActorID,
+		arg.ActorRole,
+		arg.Reason,
+	)
+	return err
+}
+
+const insertAuditLog = `-- name: InsertAuditLog :exec
+INSERT INTO audit_log (actor_id, action, entity_type, entity_id, details)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertAuditLogParams struct {
+	ActorID    int64
+	Action     string
+	EntityType string
+	EntityID   int64
+	Details    []byte
+}
+
+func (q *Queries) InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) error {
+	_, err := q.db.Exec(ctx, insertAuditLog,
+		arg.ActorID,
+		arg.Action,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Details,
+	)
+	return err
+}
+
+const insertDiscussion = `-- name: InsertDiscussion :exec
+INSERT INTO discussions (user_id, role, content)
+VALUES ($1, (SELECT role FROM users WHERE users.user_id = $1), $2)
+`
+
+type InsertDiscussionParams struct {
+	UserID  int64
+	Content string
+}
+
+func (q *Queries) InsertDiscussion(ctx context.Context, arg InsertDiscussionParams) error {
+	_, err := q.db.Exec(ctx, insertDiscussion, arg.UserID, arg.Content)
+	return err
+}
+
+const insertFeedbackByCompany = `-- name: InsertFeedbackByCompany :exec
+INSERT INTO feedbacks (application_id, interview_id, user_id, message)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertFeedbackByCompanyParams struct {
+	ApplicationID pgtype.Int8
+	InterviewID   pgtype.Int8
+	UserID        int64
+	Message       pgtype.Text
+}
+
+func (q *Queries) InsertFeedbackByCompany(ctx context.Context, arg InsertFeedbackByCompanyParams) error {
+	_, err := q.db.Exec(ctx, insertFeedbackByCompany,
+		arg.ApplicationID,
+		arg.InterviewID,
+		arg.UserID,
+		arg.Message,
+	)
+	return err
+}
+
+const insertFeedbackByStudent = `-- name: InsertFeedbackByStudent :exec
+INSERT INTO feedbacks (application_id, interview_id, user_id, message)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertFeedbackByStudentParams struct {
+	ApplicationID pgtype.Int8
+	InterviewID   pgtype.Int8
+	UserID        int64
+	Message       pgtype.Text
+}
+
+func (q *Queries) InsertFeedbackByStudent(ctx context.Context, arg InsertFeedbackByStudentParams) error {
+	_, err := q.db.Exec(ctx, insertFeedbackByStudent,
+		arg.ApplicationID,
+		arg.InterviewID,
+		arg.UserID,
+		arg.Message,
+	)
+	return err
+}
+
+const insertJobReview = `-- name: InsertJobReview :exec
+INSERT INTO job_reviews (job_id, revision, reviewer_id, decision, comment)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertJobReviewParams struct {
+	JobID      int64
+	Revision   int32
+	ReviewerID int64
+	Decision   string
+	Comment    string
+}
+
+func (q *Queries) InsertJobReview(ctx context.Context, arg InsertJobReviewParams) error {
+	_, err := q.db.Exec(ctx, insertJobReview,
+		arg.JobID,
+		arg.Revision,
+		arg.ReviewerID,
+		arg.Decision,
+		arg.Comment,
+	)
+	return err
+}
+
+const insertJobRevision = `-- name: InsertJobRevision :exec
+INSERT INTO job_revisions (job_id, revision, changed_by, changed_fields, changes, material)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertJobRevisionParams struct {
+	JobID         int64
+	Revision      int32
+	ChangedBy     int64
+	ChangedFields []string
+	Changes       []byte
+	Material      bool
+}
+
+func (q *Queries) InsertJobRevision(ctx context.Context, arg InsertJobRevisionParams) error {
+	_, err := q.db.Exec(ctx, insertJobRevision,
+		arg.JobID,
+		arg.Revision,
+		arg.ChangedBy,
+		arg.ChangedFields,
+		arg.Changes,
+		arg.Material,
+	)
+	return err
+}
+
+const insertNewApplication = `-- name: InsertNewApplication :execrows
+WITH ins AS (
+    INSERT INTO applications (job_id, student_id, data_url, job_revision, answers) 
+    SELECT jobs.job_id, (SELECT student_id FROM students WHERE user_id = $2), $3, jobs.revision, $4
+    FROM jobs
+    WHERE jobs.job_id = $1
+    AND jobs.active_status
+    AND jobs.approval_status = 'approved'
+    AND (jobs.deadline IS NULL OR jobs.deadline > NOW())
+    AND (jobs.max_applicants IS NULL OR jobs.max_applicants > (SELECT COUNT(*) FROM applications WHERE applications.job_id = $1 AND applications.status != 'Withdrawn'))
+    RETURNING application_id
+)
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role)
+SELECT ins.application_id, NULL, 'Applied', $2, 'student'
+FROM ins
+`
+
+type InsertNewApplicationParams struct {
+	JobID   int64
+	UserID  int64
+	DataUrl pgtype.Text
+	Answers []byte
+}
+
+func (q *Queries) InsertNewApplication(ctx context.Context, arg InsertNewApplicationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertNewApplication,
+		arg.JobID,
+		arg.UserID,
+		arg.DataUrl,
+		arg.Answers,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertNewJob = `-- name: InsertNewJob :one
+
+INSERT INTO jobs (data_url, company_id, title, location, type, salary, skills, position, extras, description, deadline, max_applicants, questions)
+VALUES ($1, (SELECT company_id FROM companies WHERE companies.user_id = $2), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING job_id
+`
+
+type InsertNewJobParams struct {
+	DataUrl       pgtype.Text
+	UserID        int64
+	Title         string
+	Location      string
+	Type          string
+	Salary        string
+	Skills        []string
+	Position      string
+	Extras        []byte
+	Description   pgtype.Text
+	Deadline      pgtype.Timestamptz
+	MaxApplicants pgtype.Int4
+	Questions     []byte
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+// Company queries
+func (q *Queries) InsertNewJob(ctx context.Context, arg InsertNewJobParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertNewJob,
+		arg.DataUrl,
+		arg.UserID,
+		arg.Title,
+		arg.Location,
+		arg.Type,
+		arg.Salary,
+		arg.Skills,
+		arg.Position,
+		arg.Extras,
+		arg.Description,
+		arg.Deadline,
+		arg.MaxApplicants,
+		arg.Questions,
+	)
+	var job_id int64
+	err := row.Scan(&job_id)
+	return job_id, err
+}
+
+const insertNotifications = `-- name: InsertNotifications :one
+INSERT INTO notifications (user_id, title, description, timestamp, category, ref_type, ref_id)
+VALUES($1, $2, $3, $4, $5, $6, $7)
+RETURNING notif_id
+`
+
+type InsertNotificationsParams struct {
+	UserID      int64
+	Title       pgtype.Text
+	Description pgtype.Text
+	Timestamp   int64
+	Category    string
+	RefType     pgtype.Text
+	RefID       pgtype.Int8
+}
+
+func (q *Queries) InsertNotifications(ctx context.Context, arg InsertNotificationsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertNotifications,
+		arg.UserID,
+		arg.Title,
+		arg.Description,
+		arg.Timestamp,
+		arg.Category,
+		arg.RefType,
+		arg.RefID,
+	)
+	var notif_id int64
+	err := row.Scan(&notif_id)
+	return notif_id, err
+}
+
+const insertOffer = `-- name: InsertOffer :one
+INSERT INTO offers (application_id, ctc, currency, joining_date, respond_by, letter_url, offered_by, template_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING offer_id
+`
+
+type InsertOfferParams struct {
+	ApplicationID int64
+	Ctc           int64
+	Currency      string
+	JoiningDate   pgtype.Date
+	RespondBy     pgtype.Timestamptz
+	LetterUrl     string
+	OfferedBy     pgtype.Int8
+	TemplateID    pgtype.Int8
+}
+
+func (q *Queries) InsertOffer(ctx context.Context, arg InsertOfferParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertOffer,
+		arg.ApplicationID,
+		arg.Ctc,
+		arg.Currency,
+		arg.JoiningDate,
+		arg.RespondBy,
+		arg.LetterUrl,
+		arg.OfferedBy,
+		arg.TemplateID,
+	)
+	var offer_id int64
+	err := row.Scan(&offer_id)
+	return offer_id, err
+}
+
+const insertOfferLetterTemplate = `-- name: InsertOfferLetterTemplate :one
+INSERT INTO offer_letter_templates (company_id, name, title, body)
+VALUES ((SELECT company_id FROM companies WHERE user_id = $1), $2, $3, $4)
+RETURNING template_id
+`
+
+type InsertOfferLetterTemplateParams struct {
+	UserID int64
+	Name   string
+	Title  string
+	Body   string
+}
+
+func (q *Queries) InsertOfferLetterTemplate(ctx context.Context, arg InsertOfferLetterTemplateParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertOfferLetterTemplate,
+		arg.UserID,
+		arg.Name,
+		arg.Title,
+		arg.Body,
+	)
+	var template_id int64
+	err := row.Scan(&template_id)
+	return template_id, err
+}
+
+const insertPipelineStage = `-- name: InsertPipelineStage :one
+INSERT INTO job_pipeline_stages (job_id, position, name, kind, test_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING stage_id
+`
+
+type InsertPipelineStageParams struct {
+	JobID    int64
+	Position int32
+	Name     string
+	Kind     string
+	TestID   pgtype.Int8
+}
+
+func (q *Queries) InsertPipelineStage(ctx context.Context, arg InsertPipelineStageParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertPipelineStage,
+		arg.JobID,
+		arg.Position,
+		arg.Name,
+		arg.Kind,
+		arg.TestID,
+	)
+	var stage_id int64
+	err := row.Scan(&stage_id)
+	return stage_id, err
+}
+
+const insertRescheduleProposal = `-- name: InsertRescheduleProposal :one
+INSERT INTO interview_reschedule_proposals (interview_id, proposed_by, proposer_id, times, reason, previous_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *
+`
+
+type InsertRescheduleProposalParams struct {
+	InterviewID  int64
+	ProposedBy   string
+	ProposerID   pgtype.Int8
+	Times        []pgtype.Timestamptz
+	Reason       string
+	PreviousTime pgtype.Timestamptz
+}
+
+func (q *Queries) InsertRescheduleProposal(ctx context.Context, arg InsertRescheduleProposalParams) (InterviewRescheduleProposal, error) {
+	row := q.db.QueryRow(ctx, insertRescheduleProposal,
+		arg.InterviewID,
+		arg.ProposedBy,
+		arg.ProposerID,
+		arg.Times,
+		arg.Reason,
+		arg.PreviousTime,
+	)
+	var i InterviewRescheduleProposal
+	err := row.Scan(
+		&i.ProposalID,
+		&i.InterviewID,
+		&i.ProposedBy,
+		&i.ProposerID,
+		&i.Times,
+		&i.Reason,
+		&i.PreviousTime,
+		&i.Status,
+		&i.AgreedTime,
+		&i.ResponseReason,
+		&i.RespondedBy,
+		&i.RespondedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const interviewConflicts = `-- name: InterviewConflicts :many
+WITH target AS (
+    SELECT applications.student_id, students.user_id, jobs.company_id
+    FROM applications
+    JOIN students ON applications.student_id = students.student_id
+    JOIN jobs ON applications.job_id = jobs.job_id
+    WHERE applications.application_id = $1
+)
+SELECT
+    'student'::TEXT AS party,
+    'interview'::TEXT AS kind,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.interview_id ELSE 0 END::BIGINT AS event_id,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN companies.company_name || ' - ' || jobs.title ELSE 'Interview with another company' END::TEXT AS title,
+    interviews.date_time AS starts_at,
+    (interviews.date_time + make_interval(mins => interviews.duration_minutes))::TIMESTAMPTZ AS ends_at,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.location ELSE '' END::TEXT AS location,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.panel ELSE '' END::TEXT AS panel
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM target)
+AND interviews.interview_id != $2
+AND interviews.status = 'Scheduled'
+AND interviews.date_time < $3
+AND interviews.date_time + make_interval(mins => interviews.duration_minutes) > $4
+UNION ALL
+SELECT
+    'student'::TEXT,
+    'test'::TEXT,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN tests.test_id ELSE 0 END::BIGINT,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN tests.test_name || ' (' || companies.company_name || ' - ' || jobs.title || ')' ELSE 'Test of another company' END::TEXT,
+    (tests.end_time - make_interval(mins => tests.duration::INTEGER))::TIMESTAMPTZ,
+    tests.end_time,
+    '',
+    ''
+FROM tests
+JOIN applications ON tests.job_id = applications.job_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM target)
+AND applications.status::TEXT NOT IN ('Rejected', 'Withdrawn', 'Declined')
+AND tests.end_time - make_interval(mins => tests.duration::INTEGER) < $3
+AND tests.end_time > $4
+AND NOT EXISTS (SELECT 1 FROM testresults WHERE testresults.test_id = tests.test_id AND testresults.user_id = (SELECT user_id FROM target))
+UNION ALL
+SELECT
+    'company'::TEXT,
+    'interview'::TEXT,
+    interviews.interview_id,
+    (students.student_name || ' - ' || jobs.title)::TEXT,
+    interviews.date_time,
+    (interviews.date_time + make_interval(mins => interviews.duration_minutes))::TIMESTAMPTZ,
+    interviews.location,
+    interviews.panel
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+WHERE interviews.company_id = (SELECT company_id FROM target)
+AND interviews.interview_id != $2
+AND interviews.status = 'Scheduled'
+AND interviews.date_time < $3
+AND interviews.date_time + make_interval(mins => interviews.duration_minutes) > $4
+AND ((LOWER(TRIM($5::TEXT)) NOT IN ('', 'online', 'campus') AND LOWER(TRIM(interviews.location)) = LOWER(TRIM($5::TEXT)))
+    OR (TRIM($6::TEXT) != '' AND LOWER(TRIM(interviews.panel)) = LOWER(TRIM($6::TEXT))))
+ORDER BY starts_at
+`
+
+type InterviewConflictsParams struct {
+	ApplicationID int64
+	InterviewID   int64
+	EndsAt        pgtype.Timestamptz
+	StartsAt      pgtype.Timestamptz
+	Location      string
+	Panel         string
+}
+
+type InterviewConflictsRow struct {
+	Party    string
+	Kind     string
+	EventID  int64
+	Title    string
+	StartsAt pgtype.Timestamptz
+	EndsAt   pgtype.Timestamptz
+	Location string
+	Panel    string
+}
+
+func (q *Queries) InterviewConflicts(ctx context.Context, arg InterviewConflictsParams) ([]InterviewConflictsRow, error) {
+	rows, err := q.db.Query(ctx, interviewConflicts,
+		arg.ApplicationID,
+		arg.InterviewID,
+		arg.EndsAt,
+		arg.StartsAt,
+		arg.Location,
+		arg.Panel,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InterviewConflictsRow
+	for rows.Next() {
+		var i InterviewConflictsRow
+		if err := rows.Scan(
+			&i.Party,
+			&i.Kind,
+			&i.EventID,
+			&i.Title,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Location,
+			&i.Panel,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const interviewHistory = `-- name: InterviewHistory :many
+SELECT 
+    interviews.interview_id,
+    TO_CHAR(interviews.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at,
+    jobs.title
+FROM interviews
+JOIN applications ON applications.application_id = interviews.application_id
+JOIN jobs ON jobs.job_id = applications.job_id
+WHERE applications.student_id = (SELECT students.student_id FROM students WHERE students.user_id = $1)
+ORDER BY interviews.created_at DESC
+`
+
+type InterviewHistoryRow struct {
+	InterviewID int64
+	CreatedAt   string
+	Title       string
+}
+
+func (q *Queries) InterviewHistory(ctx context.Context, userID int64) ([]InterviewHistoryRow, error) {
+	rows, err := q.db.Query(ctx, interviewHistory, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InterviewHistoryRow
+	for rows.Next() {
+		var i InterviewHistoryRow
+		if err := rows.Scan(&i.InterviewID, &i.CreatedAt, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const interviewParties = `-- name: InterviewParties :one
+SELECT
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = $1
+`
+
+type InterviewPartiesRow struct {
+	CompanyUserID int64
+	StudentUserID int64
+}
+
+func (q *Queries) InterviewParties(ctx context.Context, interviewID int64) (InterviewPartiesRow, error) {
+	row := q.db.QueryRow(ctx, interviewParties, interviewID)
+	var i InterviewPartiesRow
+	err := row.Scan(&i.CompanyUserID, &i.StudentUserID)
+	return i, err
+}
+
+const interviewStatusTo = `-- name: InterviewStatusTo :exec
+WITH closed AS (
+    UPDATE interview_reschedule_proposals
+    SET status = 'Cancelled', responded_at = NOW()
+    WHERE interview_reschedule_proposals.status = 'Pending'
+    AND interview_id IN (SELECT interview_id FROM interviews WHERE application_id = $2)
+)
+UPDATE interviews
+SET status = $1
+WHERE application_id = $2
+AND status != 'Cancelled'
+`
+
+type InterviewStatusToParams struct {
+	Status        interface{}
+	ApplicationID int64
+}
+
+func (q *Queries) InterviewStatusTo(ctx context.Context, arg InterviewStatusToParams) error {
+	_, err := q.db.Exec(ctx, interviewStatusTo, arg.Status, arg.ApplicationID)
+	return err
+}
+
+const isTestGiven = `-- name: IsTestGiven :one
+SELECT 
+    testresults.result_id,
+    testresults.test_id,
+    testresults.start_time,
+    testresults.end_time
+FROM testresults
+WHERE testresults.test_id = $1 
+AND testresults.user_id = $2
+`
+
+type IsTestGivenParams struct {
+	TestID int64
+	UserID int64
+}
+
+type IsTestGivenRow struct {
+	ResultID  int64
+	TestID    int64
+	StartTime pgtype.Timestamptz
+	EndTime   pgtype.Timestamptz
+}
+
+func (q *Queries) IsTestGiven(ctx context.Context, arg IsTestGivenParams) (IsTestGivenRow, error) {
+	row := q.db.QueryRow(ctx, isTestGiven, arg.TestID, arg.UserID)
+	var i IsTestGivenRow
+	err := row.Scan(
+		&i.ResultID,
+		&i.TestID,
+		&i.StartTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const isTestPublished = `-- name: IsTestPublished :one
+SELECT 
+    tests.published
+FROM tests
+WHERE tests.test_id = $1
+`
+
+func (q *Queries) IsTestPublished(ctx context.Context, testID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, isTestPublished, testID)
+	var published bool
+	err := row.Scan(&published)
+	return published, err
+}
+
+const jobApplicantsUserIDs = `-- name: JobApplicantsUserIDs :many
+SELECT
+    students.user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+WHERE applications.job_id = $1
+AND applications.status NOT IN ('Rejected', 'Withdrawn')
+`
+
+func (q *Queries) JobApplicantsUserIDs(ctx context.Context, jobID int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, jobApplicantsUserIDs, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobApplicationWindow = `-- name: JobApplicationWindow :one
+SELECT
+    jobs.active_status,
+    jobs.approval_status,
+    jobs.deadline,
+    jobs.max_applicants,
+    (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn') AS applicants
+FROM jobs
+WHERE jobs.job_id = $1
+`
+
+type JobApplicationWindowRow struct {
+	ActiveStatus   bool
+	ApprovalStatus string
+	Deadline       pgtype.Timestamptz
+	MaxApplicants  pgtype.Int4
+	Applicants     int64
+}
+
+func (q *Queries) JobApplicationWindow(ctx context.Context, jobID int64) (JobApplicationWindowRow, error) {
+	row := q.db.QueryRow(ctx, jobApplicationWindow, jobID)
+	var i JobApplicationWindowRow
+	err := row.Scan(
+		&i.ActiveStatus,
+		&i.ApprovalStatus,
+		&i.Deadline,
+		&i.MaxApplicants,
+		&i.Applicants,
+	)
+	return i, err
+}
+
+const jobPipelineStages = `-- name: JobPipelineStages :many
+SELECT
+    job_pipeline_stages.stage_id,
+    job_pipeline_stages.position,
+    job_pipeline_stages.name,
+    job_pipeline_stages.kind,
+    job_pipeline_stages.test_id,
+    tests.test_name
+FROM job_pipeline_stages
+LEFT JOIN tests ON job_pipeline_stages.test_id = tests.test_id
+WHERE job_pipeline_stages.job_id = $1
+ORDER BY job_pipeline_stages.position
+`
+
+type JobPipelineStagesRow struct {
+	StageID  int64
+	Position int32
+	Name     string
+	Kind     string
+	TestID   pgtype.Int8
+	TestName pgtype.Text
+}
+
+func (q *Queries) JobPipelineStages(ctx context.Context, jobID int64) ([]JobPipelineStagesRow, error) {
+	rows, err := q.db.Query(ctx, jobPipelineStages, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobPipelineStagesRow
+	for rows.Next() {
+		var i JobPipelineStagesRow
+		if err := rows.Scan(
+			&i.StageID,
+			&i.Position,
+			&i.Name,
+			&i.Kind,
+			&i.TestID,
+			&i.TestName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobPolicyFacts = `-- name: JobPolicyFacts :one
+SELECT
+    jobs.title,
+    jobs.type,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max
+FROM jobs
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+WHERE jobs.job_id = $1
+`
+
+type JobPolicyFactsRow struct {
+	Title    string
+	Type     string
+	Currency pgtype.Text
+	CtcMin   pgtype.Int8
+	CtcMax   pgtype.Int8
+}
+
+func (q *Queries) JobPolicyFacts(ctx context.Context, jobID int64) (JobPolicyFactsRow, error) {
+	row := q.db.QueryRow(ctx, jobPolicyFacts, jobID)
+	var i JobPolicyFactsRow
+	err := row.Scan(
+		&i.Title,
+		&i.Type,
+		&i.Currency,
+		&i.CtcMin,
+		&i.CtcMax,
+	)
+	return i, err
+}
+
+const jobQuestions = `-- name: JobQuestions :one
+SELECT jobs.questions FROM jobs WHERE jobs.job_id = $1
+`
+
+func (q *Queries) JobQuestions(ctx context.Context, jobID int64) ([]byte, error) {
+	row := q.db.QueryRow(ctx, jobQuestions, jobID)
+	var questions []byte
+	err := row.Scan(&questions)
+	return questions, err
+}
+
+const jobReviewQueue = `-- name: JobReviewQueue :many
+SELECT
+    jobs.job_id,
+    jobs.revision,
+    jobs.title,
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.description,
+    jobs.deadline,
+    jobs.submitted_at,
+    companies.company_name,
+    (SELECT COUNT(*) FROM job_reviews WHERE job_reviews.job_id = jobs.job_id AND job_reviews.decision = 'rejected') AS rejections,
+    COALESCE((
+        SELECT job_reviews.comment FROM job_reviews
+        WHERE job_reviews.job_id = jobs.job_id
+        ORDER BY job_reviews.review_id DESC
+        LIMIT 1
+    ), '')::TEXT AS last_comment
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE jobs.approval_status = 'submitted'
+ORDER BY jobs.submitted_at, jobs.job_id
+`
+
+type JobReviewQueueRow struct {
+	JobID       int64
+	Revision    int32
+	Title       string
+	Location    string
+	Type        string
+	Salary      string
+	Position    string
+	Skills      []string
+	Description pgtype.Text
+	Deadline    pgtype.Timestamptz
+	SubmittedAt pgtype.Timestamptz
+	CompanyName string
+	Rejections  int64
+	LastComment string
+}
+
+func (q *Queries) JobReviewQueue(ctx context.Context) ([]JobReviewQueueRow, error) {
+	rows, err := q.db.Query(ctx, jobReviewQueue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobReviewQueueRow
+	for rows.Next() {
+		var i JobReviewQueueRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Revision,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Position,
+			&i.Skills,
+			&i.Description,
+			&i.Deadline,
+			&i.SubmittedAt,
+			&i.CompanyName,
+			&i.Rejections,
+			&i.LastComment,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobReviews = `-- name: JobReviews :many
+SELECT
+    job_reviews.revision,
+    job_reviews.decision,
+    job_reviews.comment,
+    job_reviews.created_at
+FROM job_reviews
+JOIN jobs ON job_reviews.job_id = jobs.job_id
+WHERE job_reviews.job_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2)
+ORDER BY job_reviews.review_id DESC
+`
+
+type JobReviewsParams struct {
+	JobID  int64
+	UserID int64
+}
+
+type JobReviewsRow struct {
+	Revision  int32
+	Decision  string
+	Comment   string
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) JobReviews(ctx context.Context, arg JobReviewsParams) ([]JobReviewsRow, error) {
+	rows, err := q.db.Query(ctx, jobReviews, arg.JobID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobReviewsRow
+	for rows.Next() {
+		var i JobReviewsRow
+		if err := rows.Scan(
+			&i.Revision,
+			&i.Decision,
+			&i.Comment,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobRevisions = `-- name: JobRevisions :many
+SELECT
+    job_revisions.revision,
+    job_revisions.changed_fields,
+    job_revisions.changes,
+    job_revisions.material,
+    job_revisions.created_at
+FROM job_revisions
+JOIN jobs ON job_revisions.job_id = jobs.job_id
+WHERE job_revisions.job_id = $1
+AND jobs.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2)
+ORDER BY job_revisions.revision DESC
+`
+
+type JobRevisionsParams struct {
+	JobID  int64
+	UserID int64
+}
+
+type JobRevisionsRow struct {
+	Revision      int32
+	ChangedFields []string
+	Changes       []byte
+	Material      bool
+	CreatedAt     pgtype.Timestamptz
+}
+
+func (q *Queries) JobRevisions(ctx context.Context, arg JobRevisionsParams) ([]JobRevisionsRow, error) {
+	rows, err := q.db.Query(ctx, jobRevisions, arg.JobID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobRevisionsRow
+	for rows.Next() {
+		var i JobRevisionsRow
+		if err := rows.Scan(
+			&i.Revision,
+			&i.ChangedFields,
+			&i.Changes,
+			&i.Material,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const jobTestExists = `-- name: JobTestExists :one
+SELECT EXISTS (
+    SELECT 1 FROM tests
+    WHERE tests.test_id = $1
+    AND tests.job_id = $2
+) AS exists
+`
+
+type JobTestExistsParams struct {
+	TestID int64
+	JobID  int64
+}
+
+func (q *Queries) JobTestExists(ctx context.Context, arg JobTestExistsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, jobTestExists, arg.TestID, arg.JobID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const jobsWithoutCompensation = `-- name: JobsWithoutCompensation :many
+SELECT jobs.job_id, jobs.type, jobs.salary
+FROM jobs
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+WHERE job_compensation.job_id IS NULL
+AND jobs.salary <> ''
+AND jobs.job_id > $1
+ORDER BY jobs.job_id
+LIMIT $2
+`
+
+type JobsWithoutCompensationParams struct {
+	AfterJobID int64
+	BatchSize  int32
+}
+
+type JobsWithoutCompensationRow struct {
+	JobID  int64
+	Type   string
+	Salary string
+}
+
+func (q *Queries) JobsWithoutCompensation(ctx context.Context, arg JobsWithoutCompensationParams) ([]JobsWithoutCompensationRow, error) {
+	rows, err := q.db.Query(ctx, jobsWithoutCompensation, arg.AfterJobID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JobsWithoutCompensationRow
+	for rows.Next() {
+		var i JobsWithoutCompensationRow
+		if err := rows.Scan(&i.JobID, &i.Type, &i.Salary); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const linkInterviewStage = `-- name: LinkInterviewStage :exec
+UPDATE interviews
+SET stage_id = (
+    SELECT job_pipeline_stages.stage_id
+    FROM applications
+    JOIN job_pipeline_stages ON applications.stage_id = job_pipeline_stages.stage_id
+    WHERE applications.application_id = interviews.application_id
+    AND job_pipeline_stages.kind = 'interview'
+)
+WHERE interview_id = $1
+`
+
+func (q *Queries) LinkInterviewStage(ctx context.Context, interviewID int64) error {
+	_, err := q.db.Exec(ctx, linkInterviewStage, interviewID)
+	return err
+}
+
+const listAnnouncements = `-- name: ListAnnouncements :many
+SELECT
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.target_role,
+    announcements.department,
+    announcements.course,
+    announcements.year_of_study,
+    announcements.job_id,
+    announcements.send_email,
+    announcements.scheduled_at,
+    announcements.delivered_at,
+    announcements.created_at,
+    COUNT(announcement_recipients.user_id) AS recipients,
+    COUNT(announcement_recipients.read_at) AS read_count
+FROM announcements
+LEFT JOIN announcement_recipients ON announcements.announcement_id = announcement_recipients.announcement_id
+GROUP BY announcements.announcement_id
+ORDER BY announcements.scheduled_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListAnnouncementsParams struct {
+	Limit  int32
+	Offset int32
+}
+
+type ListAnnouncementsRow struct {
+	AnnouncementID int64
+	Title          string
+	Message        string
+	TargetRole     pgtype.Int8
+	Department     pgtype.Text
+	Course         pgtype.Text
+	YearOfStudy    pgtype.Text
+	JobID          pgtype.Int8
+	SendEmail      bool
+	ScheduledAt    pgtype.Timestamptz
+	DeliveredAt    pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+	Recipients     int64
+	ReadCount      int64
+}
+
+func (q *Queries) ListAnnouncements(ctx context.Context, arg ListAnnouncementsParams) ([]ListAnnouncementsRow, error) {
+	rows, err := q.db.Query(ctx, listAnnouncements, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAnnouncementsRow
+	for rows.Next() {
+		var i ListAnnouncementsRow
+		if err := rows.Scan(
+			&i.AnnouncementID,
+			&i.Title,
+			&i.Message,
+			&i.TargetRole,
+			&i.Department,
+			&i.Course,
+			&i.YearOfStudy,
+			&i.JobID,
+			&i.SendEmail,
+			&i.ScheduledAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.Recipients,
+			&i.ReadCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPolicyExceptions = `-- name: ListPolicyExceptions :many
+SELECT
+    policy_exceptions.exception_id,
+    policy_exceptions.student_id,
+    students.student_name,
+    students.roll_number,
+    policy_exceptions.job_id,
+    jobs.title,
+    policy_exceptions.rule,
+    policy_exceptions.reason,
+    policy_exceptions.granted_by,
+    policy_exceptions.created_at
+FROM policy_exceptions
+JOIN students ON policy_exceptions.student_id = students.student_id
+LEFT JOIN jobs ON policy_exceptions.job_id = jobs.job_id
+WHERE ($1::BIGINT = 0 OR policy_exceptions.student_id = $1)
+ORDER BY policy_exceptions.created_at DESC
+`
+
+type ListPolicyExceptionsRow struct {
+	ExceptionID int64
+	StudentID   int64
+	StudentName string
+	RollNumber  string
+	JobID       pgtype.Int8
+	Title       pgtype.Text
+	Rule        string
+	Reason      string
+	GrantedBy   int64
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) ListPolicyExceptions(ctx context.Context, studentID int64) ([]ListPolicyExceptionsRow, error) {
+	rows, err := q.db.Query(ctx, listPolicyExceptions, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPolicyExceptionsRow
+	for rows.Next() {
+		var i ListPolicyExceptionsRow
+		if err := rows.Scan(
+			&i.ExceptionID,
+			&i.StudentID,
+			&i.StudentName,
+			&i.RollNumber,
+			&i.JobID,
+			&i.Title,
+			&i.Rule,
+			&i.Reason,
+			&i.GrantedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listToVerifyStudent = `-- name: ListToVerifyStudent :many
+
+
+
+
+SELECT 
+    users.user_id,
+    users.email,
+    TO_CHAR(users.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at,
+    users.confirmed
+FROM users
+WHERE users.confirmed = true
+AND users.is_verified = false
+AND users.role = 1
+`
+
+type ListToVerifyStudentRow struct {
+	UserID    int64
+	Email     string
+	CreatedAt string
+	Confirmed bool
+}
+
+// -- name: GetStudentProfile :one
+// SELECT
+//
+//	students.student_name,
+//	students.roll_number,
+//	students.student_dob,
+//	students.gender,
+//	students.course,
+//	students.department,
+//	students.year_of_study,
+//	students.cgpa,
+//	students.contact_no,
+//	students.student_email,
+//	students.address,
+//	students.skills,
+//	students.extras
+//
+// FROM students
+// WHERE students.student_id = $1;
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+// Admin Functions --------------------------------
+func (q *Queries) ListToVerifyStudent(ctx context.Context) ([]ListToVerifyStudentRow, error) {
+	rows, err := q.db.Query(ctx, listToVerifyStudent)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListToVerifyStudentRow
+	for rows.Next() {
+		var i ListToVerifyStudentRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Email,
+			&i.CreatedAt,
+			&i.Confirmed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
-func (q *Queries) GetUserData(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserData, email)
-	var i User
+const lockApplicationStage = `-- name: LockApplicationStage :one
+SELECT stage_id, status::TEXT AS status FROM applications
+WHERE application_id = $1
+FOR UPDATE
+`
+
+type LockApplicationStageRow struct {
+	StageID pgtype.Int8
+	Status  string
+}
+
+func (q *Queries) LockApplicationStage(ctx context.Context, applicationID int64) (LockApplicationStageRow, error) {
+	row := q.db.QueryRow(ctx, lockApplicationStage, applicationID)
+	var i LockApplicationStageRow
+	err := row.Scan(&i.StageID, &i.Status)
+	return i, err
+}
+
+const lockApplicationStatus = `-- name: LockApplicationStatus :one
+SELECT applications.status::TEXT AS status
+FROM applications
+WHERE applications.application_id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockApplicationStatus(ctx context.Context, applicationID int64) (string, error) {
+	row := q.db.QueryRow(ctx, lockApplicationStatus, applicationID)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
+const lockInterviewParties = `-- name: LockInterviewParties :exec
+SELECT companies.company_id, students.student_id
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+WHERE applications.application_id = $1
+FOR UPDATE OF companies, students
+`
+
+func (q *Queries) LockInterviewParties(ctx context.Context, applicationID int64) error {
+	_, err := q.db.Exec(ctx, lockInterviewParties, applicationID)
+	return err
+}
+
+const lockInterviewReschedule = `-- name: LockInterviewReschedule :one
+SELECT
+    interviews.interview_id,
+    interviews.application_id,
+    interviews.date_time,
+    interviews.duration_minutes,
+    interviews.type::TEXT,
+    interviews.location,
+    interviews.panel,
+    interviews.notes,
+    interviews.status::TEXT,
+    companies.user_id AS company_user_id,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.student_email,
+    jobs.title
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = $1
+FOR UPDATE OF interviews
+`
+
+type LockInterviewRescheduleRow struct {
+	InterviewID      int64
+	ApplicationID    int64
+	DateTime         pgtype.Timestamptz
+	DurationMinutes  int32
+	InterviewsType   string
+	Location         string
+	Panel            string
+	Notes            pgtype.Text
+	InterviewsStatus string
+	CompanyUserID    int64
+	CompanyName      string
+	StudentUserID    int64
+	StudentName      string
+	StudentEmail     string
+	Title            string
+}
+
+func (q *Queries) LockInterviewReschedule(ctx context.Context, interviewID int64) (LockInterviewRescheduleRow, error) {
+	row := q.db.QueryRow(ctx, lockInterviewReschedule, interviewID)
+	var i LockInterviewRescheduleRow
 	err := row.Scan(
-		&i.UserID,
-		&i.Email,
-		&i.Password,
-		&i.Role,
-		&i.UserUuid,
-		&i.CreatedAt,
-		&i.Confirmed,
-		&i.IsVerified,
+		&i.InterviewID,
+		&i.ApplicationID,
+		&i.DateTime,
+		&i.DurationMinutes,
+		&i.InterviewsType,
+		&i.Location,
+		&i.Panel,
+		&i.Notes,
+		&i.InterviewsStatus,
+		&i.CompanyUserID,
+		&i.CompanyName,
+		&i.StudentUserID,
+		&i.StudentName,
+		&i.StudentEmail,
+		&i.Title,
 	)
 	return i, err
 }
 
-const getUserIDCompanyIDJobIDApplicationID = `-- name: GetUserIDCompanyIDJobIDApplicationID :one
-SELECT 
-    companies.user_id
-FROM companies
-JOIN jobs ON jobs.company_id = companies.company_id
-JOIN applications ON applications.job_id = jobs.job_id
-WHERE applications.application_id = $1
+const lockJobApplications = `-- name: LockJobApplications :exec
+SELECT jobs.job_id
+FROM jobs
+WHERE jobs.job_id = $1
+FOR UPDATE
 `
 
-func (q *Queries) GetUserIDCompanyIDJobIDApplicationID(ctx context.Context, applicationID int64) (int64, error) {
-	row := q.db.QueryRow(ctx, getUserIDCompanyIDJobIDApplicationID, applicationID)
-	var user_id int64
-	err := row.Scan(&user_id)
-	return user_id, err
+func (q *Queries) LockJobApplications(ctx context.Context, jobID int64) error {
+	_, err := q.db.Exec(ctx, lockJobApplications, jobID)
+	return err
 }
 
-const getUserUUIDFromEmail = `-- name: GetUserUUIDFromEmail :one
-SELECT 
-    users.user_uuid
-FROM users 
-WHERE users.email = $1
+const lockStudent = `-- name: LockStudent :exec
+SELECT students.student_id FROM students
+WHERE students.user_id = $1
+FOR UPDATE
 `
 
-func (q *Queries) GetUserUUIDFromEmail(ctx context.Context, email string) (pgtype.UUID, error) {
-	row := q.db.QueryRow(ctx, getUserUUIDFromEmail, email)
-	var user_uuid pgtype.UUID
-	err := row.Scan(&user_uuid)
-	return user_uuid, err
+func (q *Queries) LockStudent(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, lockStudent, userID)
+	return err
 }
 
-const getUserUUIDFromUserID = `-- name: GetUserUUIDFromUserID :one
-SELECT 
-    users.user_uuid
-FROM users 
-WHERE users.user_id = $1
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_status = true
+WHERE user_id = $1
+AND read_status = false
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, markAllNotificationsRead, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markAnnouncementDelivered = `-- name: MarkAnnouncementDelivered :exec
+UPDATE announcements
+SET delivered_at = NOW()
+WHERE announcement_id = $1
+`
+
+func (q *Queries) MarkAnnouncementDelivered(ctx context.Context, announcementID int64) error {
+	_, err := q.db.Exec(ctx, markAnnouncementDelivered, announcementID)
+	return err
+}
+
+const markAnnouncementRead = `-- name: MarkAnnouncementRead :execrows
+UPDATE announcement_recipients
+SET read_at = COALESCE(read_at, NOW())
+WHERE announcement_id = $1
+AND user_id = $2
 `
 
-func (q *Queries) GetUserUUIDFromUserID(ctx context.Context, userID int64) (pgtype.UUID, error) {
-	row := q.db.QueryRow(ctx, getUserUUIDFromUserID, userID)
-	var user_uuid pgtype.UUID
-	err := row.Scan(&user_uuid)
-	return user_uuid, err
+type MarkAnnouncementReadParams struct {
+	AnnouncementID int64
+	UserID         int64
+}
+
+func (q *Queries) MarkAnnouncementRead(ctx context.Context, arg MarkAnnouncementReadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markAnnouncementRead, arg.AnnouncementID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markDigestSent = `-- name: MarkDigestSent :exec
+INSERT INTO digest_preferences (user_id, last_sent_at)
+VALUES ($1, $2)
+ON CONFLICT (user_id)
+DO UPDATE SET last_sent_at = $2
+`
+
+type MarkDigestSentParams struct {
+	UserID     int64
+	LastSentAt pgtype.Timestamptz
+}
+
+func (q *Queries) MarkDigestSent(ctx context.Context, arg MarkDigestSentParams) error {
+	_, err := q.db.Exec(ctx, markDigestSent, arg.UserID, arg.LastSentAt)
+	return err
+}
+
+const markNotificationsRead = `-- name: MarkNotificationsRead :execrows
+UPDATE notifications
+SET read_status = true
+WHERE user_id = $1
+AND notif_id = ANY($2::BIGINT[])
+`
+
+type MarkNotificationsReadParams struct {
+	UserID   int64
+	NotifIds []int64
+}
+
+func (q *Queries) MarkNotificationsRead(ctx context.Context, arg MarkNotificationsReadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markNotificationsRead, arg.UserID, arg.NotifIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markRecipientsEmailed = `-- name: MarkRecipientsEmailed :exec
+UPDATE announcement_recipients
+SET emailed_at = NOW()
+WHERE announcement_id = $1
+AND user_id = ANY($2::BIGINT[])
+`
+
+type MarkRecipientsEmailedParams struct {
+	AnnouncementID int64
+	UserIds        []int64
+}
+
+func (q *Queries) MarkRecipientsEmailed(ctx context.Context, arg MarkRecipientsEmailedParams) error {
+	_, err := q.db.Exec(ctx, markRecipientsEmailed, arg.AnnouncementID, arg.UserIds)
+	return err
+}
+
+const markRecipientsNotified = `-- name: MarkRecipientsNotified :exec
+UPDATE announcement_recipients
+SET notified_at = NOW()
+WHERE announcement_id = $1
+AND user_id = ANY($2::BIGINT[])
+`
+
+type MarkRecipientsNotifiedParams struct {
+	AnnouncementID int64
+	UserIds        []int64
+}
+
+func (q *Queries) MarkRecipientsNotified(ctx context.Context, arg MarkRecipientsNotifiedParams) error {
+	_, err := q.db.Exec(ctx, markRecipientsNotified, arg.AnnouncementID, arg.UserIds)
+	return err
+}
+
+const missedNotifications = `-- name: MissedNotifications :many
+SELECT
+    notif_id, title, description, timestamp, category, ref_type, ref_id
+FROM notifications
+WHERE user_id = $1
+AND timestamp >= $2
+AND ($3::BIGINT IS NULL OR notif_id < $3)
+ORDER BY notif_id DESC
+LIMIT $4
+`
+
+type MissedNotificationsParams struct {
+	UserID    int64
+	Since     int64
+	BeforeID  pgtype.Int8
+	PageLimit int32
+}
+
+type MissedNotificationsRow struct {
+	NotifID     int64
+	Title       pgtype.Text
+	Description pgtype.Text
+	Timestamp   int64
+	Category    string
+	RefType     pgtype.Text
+	RefID       pgtype.Int8
+}
+
+func (q *Queries) MissedNotifications(ctx context.Context, arg MissedNotificationsParams) ([]MissedNotificationsRow, error) {
+	rows, err := q.db.Query(ctx, missedNotifications,
+		arg.UserID,
+		arg.Since,
+		arg.BeforeID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MissedNotificationsRow
+	for rows.Next() {
+		var i MissedNotificationsRow
+		if err := rows.Scan(
+			&i.NotifID,
+			&i.Title,
+			&i.Description,
+			&i.Timestamp,
+			&i.Category,
+			&i.RefType,
+			&i.RefID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveToOfferStage = `-- name: MoveToOfferStage :exec
+WITH offer AS (
+    SELECT job_pipeline_stages.stage_id
+    FROM job_pipeline_stages
+    JOIN applications ON job_pipeline_stages.job_id = applications.job_id
+    WHERE applications.application_id = $1
+    AND job_pipeline_stages.kind = 'offer'
+    ORDER BY job_pipeline_stages.position
+    LIMIT 1
+),
+moved AS (
+    UPDATE applications
+    SET stage_id = offer.stage_id
+    FROM offer
+    WHERE applications.application_id = $1
+    AND applications.stage_id IS DISTINCT FROM offer.stage_id
+    RETURNING applications.application_id
+)
+INSERT INTO application_stage_moves (application_id, from_stage_id, to_stage_id, moved_by)
+SELECT
+    moved.application_id,
+    (SELECT applications.stage_id FROM applications WHERE applications.application_id = $1),
+    (SELECT offer.stage_id FROM offer),
+    $2
+FROM moved
+`
+
+type MoveToOfferStageParams struct {
+	ApplicationID int64
+	MovedBy       pgtype.Int8
+}
+
+func (q *Queries) MoveToOfferStage(ctx context.Context, arg MoveToOfferStageParams) error {
+	_, err := q.db.Exec(ctx, moveToOfferStage, arg.ApplicationID, arg.MovedBy)
+	return err
+}
+
+const newApplicantsPerJob = `-- name: NewApplicantsPerJob :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    COUNT(applications.application_id) AS new_applicants
+FROM jobs
+JOIN applications ON applications.job_id = jobs.job_id
+WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
+AND applications.created_at >= $2
+GROUP BY jobs.job_id, jobs.title
+ORDER BY new_applicants DESC
+`
+
+type NewApplicantsPerJobParams struct {
+	UserID    int64
+	CreatedAt pgtype.Timestamptz
+}
+
+type NewApplicantsPerJobRow struct {
+	JobID         int64
+	Title         string
+	NewApplicants int64
+}
+
+func (q *Queries) NewApplicantsPerJob(ctx context.Context, arg NewApplicantsPerJobParams) ([]NewApplicantsPerJobRow, error) {
+	rows, err := q.db.Query(ctx, newApplicantsPerJob, arg.UserID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NewApplicantsPerJobRow
+	for rows.Next() {
+		var i NewApplicantsPerJobRow
+		if err := rows.Scan(&i.JobID, &i.Title, &i.NewApplicants); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const newTest = `-- name: NewTest :one
+INSERT INTO tests (test_name, description, duration, q_count, end_time, type, upload_method, job_id, company_id, file_id, threshold)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT company_id FROM companies WHERE user_id = $9), $10, $11)
+RETURNING test_id
+`
+
+type NewTestParams struct {
+	TestName     string
+	Description  pgtype.Text
+	Duration     int64
+	QCount       int64
+	EndTime      pgtype.Timestamptz
+	Type         string
+	UploadMethod interface{}
+	JobID        pgtype.Int8
+	UserID       int64
+	FileID       string
+	Threshold    int32
+}
+
+func (q *Queries) NewTest(ctx context.Context, arg NewTestParams) (int64, error) {
+	row := q.db.QueryRow(ctx, newTest,
+		arg.TestName,
+		arg.Description,
+		arg.Duration,
+		arg.QCount,
+		arg.EndTime,
+		arg.Type,
+		arg.UploadMethod,
+		arg.JobID,
+		arg.UserID,
+		arg.FileID,
+		arg.Threshold,
+	)
+	var test_id int64
+	err := row.Scan(&test_id)
+	return test_id, err
 }
 
-const insertAnswers = `-- name: InsertAnswers :exec
-INSERT INTO temp_correct_answers (question_id, correct_answer, points)
+const newTestResult = `-- name: NewTestResult :exec
+INSERT INTO testresults (test_id, user_id, start_time)
 VALUES ($1, $2, $3)
 `
 
-type InsertAnswersParams struct {
-	QuestionID    string
-	CorrectAnswer []string
-	Points        pgtype.Int4
+type NewTestResultParams struct {
+	TestID    int64
+	UserID    int64
+	StartTime pgtype.Timestamptz
 }
 
-func (q *Queries) InsertAnswers(ctx context.Context, arg InsertAnswersParams) error {
-	_, err := q.db.Exec(ctx, insertAnswers, arg.QuestionID, arg.CorrectAnswer, arg.Points)
+func (q *Queries) NewTestResult(ctx context.Context, arg NewTestResultParams) error {
+	_, err := q.db.Exec(ctx, newTestResult, arg.TestID, arg.UserID, arg.StartTime)
 	return err
 }
 
-const insertDiscussion = `-- name: InsertDiscussion :exec
-INSERT INTO discussions (user_id, role, content)
-VALUES ($1, (SELECT role FROM users WHERE users.user_id = $1), $2)
+const noticeBoard = `-- name: NoticeBoard :many
+SELECT
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.delivered_at,
+    announcement_recipients.read_at
+FROM announcement_recipients
+JOIN announcements ON announcement_recipients.announcement_id = announcements.announcement_id
+WHERE announcement_recipients.user_id = $1
+ORDER BY announcements.delivered_at DESC
+LIMIT $2 OFFSET $3
 `
 
-type InsertDiscussionParams struct {
-	UserID  int64
-	Content string
+type NoticeBoardParams struct {
+	UserID int64
+	Limit  int32
+	Offset int32
 }
 
-func (q *Queries) InsertDiscussion(ctx context.Context, arg InsertDiscussionParams) error {
-	_, err := q.db.Exec(ctx, insertDiscussion, arg.UserID, arg.Content)
-	return err
+type NoticeBoardRow struct {
+	AnnouncementID int64
+	Title          string
+	Message        string
+	DeliveredAt    pgtype.Timestamptz
+	ReadAt         pgtype.Timestamptz
 }
 
-const insertFeedbackByCompany = `-- name: InsertFeedbackByCompany :exec
-INSERT INTO feedbacks (application_id, interview_id, user_id, message)
-VALUES ($1, $2, $3, $4)
+func (q *Queries) NoticeBoard(ctx context.Context, arg NoticeBoardParams) ([]NoticeBoardRow, error) {
+	rows, err := q.db.Query(ctx, noticeBoard, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NoticeBoardRow
+	for rows.Next() {
+		var i NoticeBoardRow
+		if err := rows.Scan(
+			&i.AnnouncementID,
+			&i.Title,
+			&i.Message,
+			&i.DeliveredAt,
+			&i.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const offerLetterFields = `-- name: OfferLetterFields :one
+SELECT
+    students.student_name,
+    students.roll_number,
+    students.course,
+    students.department,
+    jobs.title,
+    jobs.type,
+    jobs.location,
+    companies.company_name,
+    companies.address,
+    companies.picture_url,
+    companies.representative_name,
+    companies.representative_email
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = $1
 `
 
-type InsertFeedbackByCompanyParams struct {
-	ApplicationID pgtype.Int8
-	InterviewID   pgtype.Int8
-	UserID        int64
-	Message       pgtype.Text
+type OfferLetterFieldsRow struct {
+	StudentName         string
+	RollNumber          string
+	Course              string
+	Department          string
+	Title               string
+	Type                string
+	Location            string
+	CompanyName         string
+	Address             string
+	PictureUrl          pgtype.Text
+	RepresentativeName  string
+	RepresentativeEmail string
 }
 
-func (q *Queries) InsertFeedbackByCompany(ctx context.Context, arg InsertFeedbackByCompanyParams) error {
-	_, err := q.db.Exec(ctx, insertFeedbackByCompany,
-		arg.ApplicationID,
-		arg.InterviewID,
-		arg.UserID,
-		arg.Message,
+func (q *Queries) OfferLetterFields(ctx context.Context, applicationID int64) (OfferLetterFieldsRow, error) {
+	row := q.db.QueryRow(ctx, offerLetterFields, applicationID)
+	var i OfferLetterFieldsRow
+	err := row.Scan(
+		&i.StudentName,
+		&i.RollNumber,
+		&i.Course,
+		&i.Department,
+		&i.Title,
+		&i.Type,
+		&i.Location,
+		&i.CompanyName,
+		&i.Address,
+		&i.PictureUrl,
+		&i.RepresentativeName,
+		&i.RepresentativeEmail,
 	)
-	return err
+	return i, err
 }
 
-const insertFeedbackByStudent = `-- name: InsertFeedbackByStudent :exec
-INSERT INTO feedbacks (application_id, interview_id, user_id, message)
-VALUES ($1, $2, $3, $4)
+const offerLetterTemplate = `-- name: OfferLetterTemplate :one
+SELECT t.template_id, t.name, t.title, t.body, t.updated_at
+FROM offer_letter_templates t
+JOIN companies ON t.company_id = companies.company_id
+WHERE t.template_id = $1
+AND companies.user_id = $2
 `
 
-type InsertFeedbackByStudentParams struct {
-	ApplicationID pgtype.Int8
-	InterviewID   pgtype.Int8
-	UserID        int64
-	Message       pgtype.Text
+type OfferLetterTemplateParams struct {
+	TemplateID int64
+	UserID     int64
 }
 
-func (q *Queries) InsertFeedbackByStudent(ctx context.Context, arg InsertFeedbackByStudentParams) error {
-	_, err := q.db.Exec(ctx, insertFeedbackByStudent,
-		arg.ApplicationID,
-		arg.InterviewID,
-		arg.UserID,
-		arg.Message,
+type OfferLetterTemplateRow struct {
+	TemplateID int64
+	Name       string
+	Title      string
+	Body       string
+	UpdatedAt  pgtype.Timestamptz
+}
+
+func (q *Queries) OfferLetterTemplate(ctx context.Context, arg OfferLetterTemplateParams) (OfferLetterTemplateRow, error) {
+	row := q.db.QueryRow(ctx, offerLetterTemplate, arg.TemplateID, arg.UserID)
+	var i OfferLetterTemplateRow
+	err := row.Scan(
+		&i.TemplateID,
+		&i.Name,
+		&i.Title,
+		&i.Body,
+		&i.UpdatedAt,
 	)
-	return err
+	return i, err
 }
 
-const insertNewApplication = `-- name: InsertNewApplication :exec
-INSERT INTO applications (job_id, student_id, data_url) 
-VALUES ($1, (SELECT student_id FROM students WHERE user_id = $2), $3)
+const offerLetterTemplates = `-- name: OfferLetterTemplates :many
+SELECT t.template_id, t.name, t.title, t.body, t.updated_at
+FROM offer_letter_templates t
+JOIN companies ON t.company_id = companies.company_id
+WHERE companies.user_id = $1
+ORDER BY t.name
 `
 
-type InsertNewApplicationParams struct {
-	JobID   int64
-	UserID  int64
-	DataUrl pgtype.Text
+type OfferLetterTemplatesRow struct {
+	TemplateID int64
+	Name       string
+	Title      string
+	Body       string
+	UpdatedAt  pgtype.Timestamptz
 }
 
-func (q *Queries) InsertNewApplication(ctx context.Context, arg InsertNewApplicationParams) error {
-	_, err := q.db.Exec(ctx, insertNewApplication, arg.JobID, arg.UserID, arg.DataUrl)
-	return err
+func (q *Queries) OfferLetterTemplates(ctx context.Context, userID int64) ([]OfferLetterTemplatesRow, error) {
+	rows, err := q.db.Query(ctx, offerLetterTemplates, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OfferLetterTemplatesRow
+	for rows.Next() {
+		var i OfferLetterTemplatesRow
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.Name,
+			&i.Title,
+			&i.Body,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const insertNewJob = `-- name: InsertNewJob :exec
-
-INSERT INTO jobs (data_url, company_id, title, location, type, salary, skills, position, extras, description)
-VALUES ($1, (SELECT company_id FROM companies WHERE companies.user_id = $2), $3, $4, $5, $6, $7, $8, $9, $10)
+const openJobsEligibility = `-- name: OpenJobsEligibility :many
+SELECT
+    job_eligibility.job_id,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = job_eligibility.job_id
+        AND eligibility_overrides.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+    ) AS overridden
+FROM job_eligibility
+JOIN jobs ON job_eligibility.job_id = jobs.job_id
+WHERE jobs.active_status = true
+AND jobs.approval_status = 'approved'
 `
 
-type InsertNewJobParams struct {
-	DataUrl     pgtype.Text
-	UserID      int64
-	Title       string
-	Location    string
-	Type        string
-	Salary      string
-	Skills      []string
-	Position    string
-	Extras      []byte
-	Description pgtype.Text
+type OpenJobsEligibilityRow struct {
+	JobID        int64
+	MinCgpa      pgtype.Float8
+	Courses      []string
+	Departments  []string
+	YearsOfStudy []string
+	MaxBacklogs  pgtype.Int4
+	Custom       []byte
+	Overridden   bool
 }
 
-// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-// Company queries
-func (q *Queries) InsertNewJob(ctx context.Context, arg InsertNewJobParams) error {
-	_, err := q.db.Exec(ctx, insertNewJob,
-		arg.DataUrl,
-		arg.UserID,
-		arg.Title,
-		arg.Location,
-		arg.Type,
-		arg.Salary,
-		arg.Skills,
-		arg.Position,
-		arg.Extras,
-		arg.Description,
+func (q *Queries) OpenJobsEligibility(ctx context.Context, userID int64) ([]OpenJobsEligibilityRow, error) {
+	rows, err := q.db.Query(ctx, openJobsEligibility, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OpenJobsEligibilityRow
+	for rows.Next() {
+		var i OpenJobsEligibilityRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.MinCgpa,
+			&i.Courses,
+			&i.Departments,
+			&i.YearsOfStudy,
+			&i.MaxBacklogs,
+			&i.Custom,
+			&i.Overridden,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pendingRescheduleProposal = `-- name: PendingRescheduleProposal :one
+SELECT * FROM interview_reschedule_proposals
+WHERE interview_id = $1
+AND status = 'Pending'
+`
+
+func (q *Queries) PendingRescheduleProposal(ctx context.Context, interviewID int64) (InterviewRescheduleProposal, error) {
+	row := q.db.QueryRow(ctx, pendingRescheduleProposal, interviewID)
+	var i InterviewRescheduleProposal
+	err := row.Scan(
+		&i.ProposalID,
+		&i.InterviewID,
+		&i.ProposedBy,
+		&i.ProposerID,
+		&i.Times,
+		&i.Reason,
+		&i.PreviousTime,
+		&i.Status,
+		&i.AgreedTime,
+		&i.ResponseReason,
+		&i.RespondedBy,
+		&i.RespondedAt,
+		&i.CreatedAt,
 	)
-	return err
+	return i, err
 }
 
-const insertNotifications = `-- name: InsertNotifications :exec
-INSERT INTO notifications (user_id, title, description, timestamp)
-VALUES($1, $2, $3, $4)
+const pipelineBoard = `-- name: PipelineBoard :many
+SELECT
+    applications.application_id,
+    applications.stage_id,
+    applications.status::TEXT AS status,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.roll_number,
+    COALESCE(moved.created_at, applications.created_at)::TIMESTAMPTZ AS in_stage_since,
+    testresults.score,
+    COALESCE(stage_interview.status::TEXT, '') AS interview_status,
+    stage_interview.date_time AS interview_at
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN job_pipeline_stages ON applications.stage_id = job_pipeline_stages.stage_id
+LEFT JOIN testresults ON testresults.test_id = job_pipeline_stages.test_id
+    AND testresults.user_id = students.user_id
+LEFT JOIN LATERAL (
+    SELECT application_stage_moves.created_at
+    FROM application_stage_moves
+    WHERE application_stage_moves.application_id = applications.application_id
+    ORDER BY application_stage_moves.created_at DESC
+    LIMIT 1
+) moved ON TRUE
+LEFT JOIN LATERAL (
+    SELECT interviews.status, interviews.date_time
+    FROM interviews
+    WHERE interviews.application_id = applications.application_id
+    AND interviews.stage_id = applications.stage_id
+    ORDER BY interviews.created_at DESC
+    LIMIT 1
+) stage_interview ON TRUE
+WHERE applications.job_id = $1
+ORDER BY in_stage_since, applications.application_id
 `
 
-type InsertNotificationsParams struct {
-	UserID      int64
-	Title       pgtype.Text
-	Description pgtype.Text
-	Timestamp   int64
+type PipelineBoardRow struct {
+	ApplicationID   int64
+	StageID         pgtype.Int8
+	Status          string
+	StudentUserID   int64
+	StudentName     string
+	RollNumber      string
+	InStageSince    pgtype.Timestamptz
+	Score           pgtype.Int8
+	InterviewStatus string
+	InterviewAt     pgtype.Timestamptz
 }
 
-func (q *Queries) InsertNotifications(ctx context.Context, arg InsertNotificationsParams) error {
-	_, err := q.db.Exec(ctx, insertNotifications,
-		arg.UserID,
-		arg.Title,
-		arg.Description,
-		arg.Timestamp,
-	)
-	return err
+func (q *Queries) PipelineBoard(ctx context.Context, jobID int64) ([]PipelineBoardRow, error) {
+	rows, err := q.db.Query(ctx, pipelineBoard, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PipelineBoardRow
+	for rows.Next() {
+		var i PipelineBoardRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.StageID,
+			&i.Status,
+			&i.StudentUserID,
+			&i.StudentName,
+			&i.RollNumber,
+			&i.InStageSince,
+			&i.Score,
+			&i.InterviewStatus,
+			&i.InterviewAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const interviewHistory = `-- name: InterviewHistory :many
-SELECT 
-    interviews.interview_id,
-    TO_CHAR(interviews.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at,
-    jobs.title
-FROM interviews
-JOIN applications ON applications.application_id = interviews.application_id
-JOIN jobs ON jobs.job_id = applications.job_id
-WHERE applications.student_id = (SELECT students.student_id FROM students WHERE students.user_id = $1)
-ORDER BY interviews.created_at DESC
+const pipelineStageApplicants = `-- name: PipelineStageApplicants :many
+SELECT
+    applications.stage_id,
+    COUNT(applications.application_id) AS applicants
+FROM applications
+WHERE applications.job_id = $1
+AND applications.stage_id IS NOT NULL
+AND applications.status::TEXT NOT IN ('Hired', 'Declined', 'Rejected', 'Withdrawn')
+GROUP BY applications.stage_id
 `
 
-type InterviewHistoryRow struct {
-	InterviewID int64
-	CreatedAt   string
-	Title       string
+type PipelineStageApplicantsRow struct {
+	StageID    pgtype.Int8
+	Applicants int64
 }
 
-func (q *Queries) InterviewHistory(ctx context.Context, userID int64) ([]InterviewHistoryRow, error) {
-	rows, err := q.db.Query(ctx, interviewHistory, userID)
+func (q *Queries) PipelineStageApplicants(ctx context.Context, jobID int64) ([]PipelineStageApplicantsRow, error) {
+	rows, err := q.db.Query(ctx, pipelineStageApplicants, jobID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []InterviewHistoryRow
+	var items []PipelineStageApplicantsRow
 	for rows.Next() {
-		var i InterviewHistoryRow
-		if err := rows.Scan(&i.InterviewID, &i.CreatedAt, &i.Title); err != nil {
+		var i PipelineStageApplicantsRow
+		if err := rows.Scan(&i.StageID, &i.Applicants); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1912,129 +5727,261 @@ func (q *Queries) InterviewHistory(ctx context.Context, userID int64) ([]Intervi
 	return items, nil
 }
 
-const interviewStatusTo = `-- name: InterviewStatusTo :exec
-UPDATE interviews
-SET status = $1
-WHERE application_id = $2
+const placementStats = `-- name: PlacementStats :one
+SELECT
+    (SELECT COUNT(*) FROM students) AS students,
+    COUNT(DISTINCT applications.student_id) FILTER (WHERE offers.status = 'Accepted' AND jobs.type NOT ILIKE '%intern%') AS placed,
+    COUNT(offers.offer_id) AS offers,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Pending') AS pending,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Accepted') AS accepted,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Declined') AS declined,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Expired') AS expired,
+    COALESCE(MAX(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS highest_ctc,
+    COALESCE(AVG(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS average_ctc,
+    COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS median_ctc
+FROM offers
+JOIN applications ON offers.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
 `
 
-type InterviewStatusToParams struct {
-	Status        interface{}
-	ApplicationID int64
+type PlacementStatsRow struct {
+	Students   int64
+	Placed     int64
+	Offers     int64
+	Pending    int64
+	Accepted   int64
+	Declined   int64
+	Expired    int64
+	HighestCtc int64
+	AverageCtc int64
+	MedianCtc  int64
+}
+
+func (q *Queries) PlacementStats(ctx context.Context) (PlacementStatsRow, error) {
+	row := q.db.QueryRow(ctx, placementStats)
+	var i PlacementStatsRow
+	err := row.Scan(
+		&i.Students,
+		&i.Placed,
+		&i.Offers,
+		&i.Pending,
+		&i.Accepted,
+		&i.Declined,
+		&i.Expired,
+		&i.HighestCtc,
+		&i.AverageCtc,
+		&i.MedianCtc,
+	)
+	return i, err
 }
 
-func (q *Queries) InterviewStatusTo(ctx context.Context, arg InterviewStatusToParams) error {
-	_, err := q.db.Exec(ctx, interviewStatusTo, arg.Status, arg.ApplicationID)
-	return err
+const recommendableJobs = `-- name: RecommendableJobs :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.deadline,
+    companies.company_name,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = jobs.job_id
+        AND eligibility_overrides.student_id = $1
+    ) AS overridden
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND (jobs.deadline IS NULL OR jobs.deadline > NOW())
+AND ($2::TIMESTAMPTZ IS NULL OR jobs.created_at >= $2::TIMESTAMPTZ)
+AND NOT EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = jobs.job_id
+    AND applications.student_id = $1
+)
+`
+
+type RecommendableJobsParams struct {
+	StudentID   int64
+	PostedAfter pgtype.Timestamptz
 }
 
-const isTestGiven = `-- name: IsTestGiven :one
-SELECT 
-    testresults.result_id,
-    testresults.test_id,
-    testresults.start_time,
-    testresults.end_time
-FROM testresults
-WHERE testresults.test_id = $1 
-AND testresults.user_id = $2
-`
+type RecommendableJobsRow struct {
+	JobID        int64
+	Title        string
+	Location     string
+	Type         string
+	Salary       string
+	Position     string
+	Skills       []string
+	Deadline     pgtype.Timestamptz
+	CompanyName  string
+	MinCgpa      pgtype.Float8
+	Courses      []string
+	Departments  []string
+	YearsOfStudy []string
+	MaxBacklogs  pgtype.Int4
+	Custom       []byte
+	Overridden   bool
+}
 
-type IsTestGivenParams struct {
-	TestID int64
-	UserID int64
+func (q *Queries) RecommendableJobs(ctx context.Context, arg RecommendableJobsParams) ([]RecommendableJobsRow, error) {
+	rows, err := q.db.Query(ctx, recommendableJobs, arg.StudentID, arg.PostedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecommendableJobsRow
+	for rows.Next() {
+		var i RecommendableJobsRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Position,
+			&i.Skills,
+			&i.Deadline,
+			&i.CompanyName,
+			&i.MinCgpa,
+			&i.Courses,
+			&i.Departments,
+			&i.YearsOfStudy,
+			&i.MaxBacklogs,
+			&i.Custom,
+			&i.Overridden,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-type IsTestGivenRow struct {
-	ResultID  int64
-	TestID    int64
-	StartTime pgtype.Timestamptz
-	EndTime   pgtype.Timestamptz
+const recommendationProfile = `-- name: RecommendationProfile :one
+SELECT
+    students.student_id,
+    students.skills,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras
+FROM students
+WHERE students.user_id = $1
+`
+
+type RecommendationProfileRow struct {
+	StudentID   int64
+	Skills      pgtype.Text
+	Course      string
+	Department  string
+	YearOfStudy string
+	Cgpa        pgtype.Float8
+	Backlogs    int32
+	Extras      []byte
 }
 
-func (q *Queries) IsTestGiven(ctx context.Context, arg IsTestGivenParams) (IsTestGivenRow, error) {
-	row := q.db.QueryRow(ctx, isTestGiven, arg.TestID, arg.UserID)
-	var i IsTestGivenRow
+func (q *Queries) RecommendationProfile(ctx context.Context, userID int64) (RecommendationProfileRow, error) {
+	row := q.db.QueryRow(ctx, recommendationProfile, userID)
+	var i RecommendationProfileRow
 	err := row.Scan(
-		&i.ResultID,
-		&i.TestID,
-		&i.StartTime,
-		&i.EndTime,
+		&i.StudentID,
+		&i.Skills,
+		&i.Course,
+		&i.Department,
+		&i.YearOfStudy,
+		&i.Cgpa,
+		&i.Backlogs,
+		&i.Extras,
 	)
 	return i, err
 }
 
-const isTestPublished = `-- name: IsTestPublished :one
-SELECT 
-    tests.published
-FROM tests
-WHERE tests.test_id = $1
+const registerDeviceToken = `-- name: RegisterDeviceToken :exec
+INSERT INTO device_tokens (token, user_id, platform)
+VALUES ($1, $2, $3)
+ON CONFLICT (token)
+DO UPDATE SET user_id = $2, platform = $3, last_seen_at = NOW()
 `
 
-func (q *Queries) IsTestPublished(ctx context.Context, testID int64) (bool, error) {
-	row := q.db.QueryRow(ctx, isTestPublished, testID)
-	var published bool
-	err := row.Scan(&published)
-	return published, err
+type RegisterDeviceTokenParams struct {
+	Token    string
+	UserID   int64
+	Platform string
 }
 
-const listToVerifyStudent = `-- name: ListToVerifyStudent :many
+func (q *Queries) RegisterDeviceToken(ctx context.Context, arg RegisterDeviceTokenParams) error {
+	_, err := q.db.Exec(ctx, registerDeviceToken, arg.Token, arg.UserID, arg.Platform)
+	return err
+}
 
+const rescheduleInterviewTo = `-- name: RescheduleInterviewTo :one
+UPDATE interviews
+SET
+    date_time = $1,
+    status = 'Scheduled',
+    ical_sequence = ical_sequence + 1
+WHERE interview_id = $2
+RETURNING ical_sequence
+`
 
+type RescheduleInterviewToParams struct {
+	DateTime    pgtype.Timestamptz
+	InterviewID int64
+}
 
+func (q *Queries) RescheduleInterviewTo(ctx context.Context, arg RescheduleInterviewToParams) (int32, error) {
+	row := q.db.QueryRow(ctx, rescheduleInterviewTo, arg.DateTime, arg.InterviewID)
+	var ical_sequence int32
+	err := row.Scan(&ical_sequence)
+	return ical_sequence, err
+}
 
-SELECT 
-    users.user_id,
-    users.email,
-    TO_CHAR(users.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at,
-    users.confirmed
-FROM users
-WHERE users.confirmed = true
-AND users.is_verified = false
-AND users.role = 1
+const rescheduleProposals = `-- name: RescheduleProposals :many
+SELECT * FROM interview_reschedule_proposals
+WHERE interview_id = $1
+ORDER BY created_at, proposal_id
 `
 
-type ListToVerifyStudentRow struct {
-	UserID    int64
-	Email     string
-	CreatedAt string
-	Confirmed bool
-}
-
-// -- name: GetStudentProfile :one
-// SELECT
-//
-//	students.student_name,
-//	students.roll_number,
-//	students.student_dob,
-//	students.gender,
-//	students.course,
-//	students.department,
-//	students.year_of_study,
-//	students.cgpa,
-//	students.contact_no,
-//	students.student_email,
-//	students.address,
-//	students.skills,
-//	students.extras
-//
-// FROM students
-// WHERE students.student_id = $1;
-// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
-// Admin Functions --------------------------------
-func (q *Queries) ListToVerifyStudent(ctx context.Context) ([]ListToVerifyStudentRow, error) {
-	rows, err := q.db.Query(ctx, listToVerifyStudent)
+func (q *Queries) RescheduleProposals(ctx context.Context, interviewID int64) ([]InterviewRescheduleProposal, error) {
+	rows, err := q.db.Query(ctx, rescheduleProposals, interviewID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListToVerifyStudentRow
+	var items []InterviewRescheduleProposal
 	for rows.Next() {
-		var i ListToVerifyStudentRow
+		var i InterviewRescheduleProposal
 		if err := rows.Scan(
-			&i.UserID,
-			&i.Email,
+			&i.ProposalID,
+			&i.InterviewID,
+			&i.ProposedBy,
+			&i.ProposerID,
+			&i.Times,
+			&i.Reason,
+			&i.PreviousTime,
+			&i.Status,
+			&i.AgreedTime,
+			&i.ResponseReason,
+			&i.RespondedBy,
+			&i.RespondedAt,
 			&i.CreatedAt,
-			&i.Confirmed,
 		); err != nil {
 			return nil, err
 		}
@@ -2046,74 +5993,130 @@ func (q *Queries) ListToVerifyStudent(ctx context.Context) ([]ListToVerifyStuden
 	return items, nil
 }
 
-const newTest = `-- name: NewTest :exec
-INSERT INTO tests (test_name, description, duration, q_count, end_time, type, upload_method, job_id, company_id, file_id, threshold)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT company_id FROM companies WHERE user_id = $9), $10, $11)
+const respondOffer = `-- name: RespondOffer :execrows
+UPDATE offers
+SET status = $1, response_reason = $2, responded_at = CURRENT_TIMESTAMP
+WHERE application_id = $3
+AND status = 'Pending'
+AND respond_by > CURRENT_TIMESTAMP
 `
 
-type NewTestParams struct {
-	TestName     string
-	Description  pgtype.Text
-	Duration     int64
-	QCount       int64
-	EndTime      pgtype.Timestamptz
-	Type         string
-	UploadMethod interface{}
-	JobID        pgtype.Int8
-	UserID       int64
-	FileID       string
-	Threshold    int32
+type RespondOfferParams struct {
+	Status         string
+	ResponseReason string
+	ApplicationID  int64
 }
 
-func (q *Queries) NewTest(ctx context.Context, arg NewTestParams) error {
-	_, err := q.db.Exec(ctx, newTest,
-		arg.TestName,
-		arg.Description,
-		arg.Duration,
-		arg.QCount,
-		arg.EndTime,
-		arg.Type,
-		arg.UploadMethod,
-		arg.JobID,
-		arg.UserID,
-		arg.FileID,
-		arg.Threshold,
+func (q *Queries) RespondOffer(ctx context.Context, arg RespondOfferParams) (int64, error) {
+	result, err := q.db.Exec(ctx, respondOffer, arg.Status, arg.ResponseReason, arg.ApplicationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const respondRescheduleProposal = `-- name: RespondRescheduleProposal :execrows
+UPDATE interview_reschedule_proposals
+SET
+    status = $1,
+    agreed_time = $2,
+    response_reason = $3,
+    responded_by = $4,
+    responded_at = NOW()
+WHERE proposal_id = $5
+AND status = 'Pending'
+`
+
+type RespondRescheduleProposalParams struct {
+	Status         string
+	AgreedTime     pgtype.Timestamptz
+	ResponseReason string
+	RespondedBy    pgtype.Int8
+	ProposalID     int64
+}
+
+func (q *Queries) RespondRescheduleProposal(ctx context.Context, arg RespondRescheduleProposalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, respondRescheduleProposal,
+		arg.Status,
+		arg.AgreedTime,
+		arg.ResponseReason,
+		arg.RespondedBy,
+		arg.ProposalID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const newTestResult = `-- name: NewTestResult :exec
-INSERT INTO testresults (test_id, user_id, start_time)
-VALUES ($1, $2, $3)
+const resubmitApprovedJob = `-- name: ResubmitApprovedJob :execrows
+UPDATE jobs
+SET approval_status = 'submitted',
+    submitted_at = NOW()
+WHERE jobs.job_id = $1
+AND jobs.approval_status = 'approved'
+`
+
+func (q *Queries) ResubmitApprovedJob(ctx context.Context, jobID int64) (int64, error) {
+	result, err := q.db.Exec(ctx, resubmitApprovedJob, jobID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reviewJob = `-- name: ReviewJob :one
+UPDATE jobs
+SET approval_status = $1
+WHERE jobs.job_id = $2
+AND jobs.approval_status = 'submitted'
+AND jobs.revision = $3
+RETURNING
+    jobs.title,
+    (SELECT companies.user_id FROM companies WHERE companies.company_id = jobs.company_id) AS company_user_id
 `
 
-type NewTestResultParams struct {
-	TestID    int64
-	UserID    int64
-	StartTime pgtype.Timestamptz
+type ReviewJobParams struct {
+	Decision string
+	JobID    int64
+	Revision int32
 }
 
-func (q *Queries) NewTestResult(ctx context.Context, arg NewTestResultParams) error {
-	_, err := q.db.Exec(ctx, newTestResult, arg.TestID, arg.UserID, arg.StartTime)
-	return err
+type ReviewJobRow struct {
+	Title         string
+	CompanyUserID int64
+}
+
+func (q *Queries) ReviewJob(ctx context.Context, arg ReviewJobParams) (ReviewJobRow, error) {
+	row := q.db.QueryRow(ctx, reviewJob, arg.Decision, arg.JobID, arg.Revision)
+	var i ReviewJobRow
+	err := row.Scan(&i.Title, &i.CompanyUserID)
+	return i, err
 }
 
 const scheduleInterview = `-- name: ScheduleInterview :one
-INSERT INTO interviews (application_id, company_id, date_time, type, notes, location)
-VALUES ($1, (SELECT company_id FROM companies WHERE user_id = $2), $3, $4, $5, $6)
-RETURNING TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time
+INSERT INTO interviews (application_id, company_id, date_time, type, notes, location, duration_minutes, panel)
+VALUES ($1, (SELECT company_id FROM companies WHERE user_id = $2), $3, $4, $5, $6, $7, $8)
+RETURNING interview_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time
 `
 
 type ScheduleInterviewParams struct {
-	ApplicationID int64
-	UserID        int64
-	DateTime      pgtype.Timestamptz
-	Type          interface{}
-	Notes         pgtype.Text
-	Location      string
+	ApplicationID   int64
+	UserID          int64
+	DateTime        pgtype.Timestamptz
+	Type            interface{}
+	Notes           pgtype.Text
+	Location        string
+	DurationMinutes int32
+	Panel           string
+}
+
+type ScheduleInterviewRow struct {
+	InterviewID int64
+	DateTime    string
 }
 
-func (q *Queries) ScheduleInterview(ctx context.Context, arg ScheduleInterviewParams) (string, error) {
+func (q *Queries) ScheduleInterview(ctx context.Context, arg ScheduleInterviewParams) (ScheduleInterviewRow, error) {
 	row := q.db.QueryRow(ctx, scheduleInterview,
 		arg.ApplicationID,
 		arg.UserID,
@@ -2121,10 +6124,12 @@ func (q *Queries) ScheduleInterview(ctx context.Context, arg ScheduleInterviewPa
 		arg.Type,
 		arg.Notes,
 		arg.Location,
+		arg.DurationMinutes,
+		arg.Panel,
 	)
-	var date_time string
-	err := row.Scan(&date_time)
-	return date_time, err
+	var i ScheduleInterviewRow
+	err := row.Scan(&i.InterviewID, &i.DateTime)
+	return i, err
 }
 
 const scheduledInterviewsCompany = `-- name: ScheduledInterviewsCompany :many
@@ -2260,6 +6265,270 @@ func (q *Queries) ScheduledTestsCompany(ctx context.Context, userID int64) ([]Sc
 	return items, nil
 }
 
+const searchJobFacets = `-- name: SearchJobFacets :many
+WITH matches AS (
+    SELECT * FROM job_search_matches($1::TEXT, $2::TEXT[], $3::TEXT[], $4::TEXT[], $5::TEXT[],
+        $6::TIMESTAMPTZ, $7::DOUBLE PRECISION, $8::DOUBLE PRECISION,
+        $9::BIGINT[])
+)
+SELECT 'location' AS facet, matches.location AS value, COUNT(*) AS count
+FROM matches
+WHERE matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.location
+UNION ALL
+SELECT 'type', matches.type, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_position AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.type
+UNION ALL
+SELECT 'position', matches.position, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_type AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.position
+UNION ALL
+SELECT 'industry', matches.industry, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_posted AND matches.m_salary
+GROUP BY matches.industry
+UNION ALL
+SELECT 'salary', buckets.bucket, COUNT(*)
+FROM matches
+CROSS JOIN LATERAL (SELECT CASE
+    WHEN matches.salary_num IS NULL THEN 'unspecified'
+    WHEN matches.salary_num < 3 THEN '0-3'
+    WHEN matches.salary_num < 6 THEN '3-6'
+    WHEN matches.salary_num < 10 THEN '6-10'
+    WHEN matches.salary_num < 20 THEN '10-20'
+    ELSE '20+'
+END AS bucket) AS buckets
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_posted
+GROUP BY buckets.bucket
+UNION ALL
+-- posted windows overlap, like the filter
+SELECT 'posted', windows.name, COUNT(*)
+FROM matches
+JOIN (VALUES ('24h', INTERVAL '1 day'), ('7d', INTERVAL '7 days'), ('30d', INTERVAL '30 days')) AS windows (name, span)
+ON matches.created_at >= NOW() - windows.span
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_salary
+GROUP BY windows.name
+ORDER BY facet, count DESC
+`
+
+type SearchJobFacetsParams struct {
+	Query            string
+	Locations        []string
+	Types            []string
+	Positions        []string
+	Industries       []string
+	PostedAfter      pgtype.Timestamptz
+	SalaryMin        pgtype.Float8
+	SalaryMax        pgtype.Float8
+	IneligibleJobIds []int64
+}
+
+type SearchJobFacetsRow struct {
+	Facet string
+	Value string
+	Count int64
+}
+
+func (q *Queries) SearchJobFacets(ctx context.Context, arg SearchJobFacetsParams) ([]SearchJobFacetsRow, error) {
+	rows, err := q.db.Query(ctx, searchJobFacets,
+		arg.Query,
+		arg.Locations,
+		arg.Types,
+		arg.Positions,
+		arg.Industries,
+		arg.PostedAfter,
+		arg.SalaryMin,
+		arg.SalaryMax,
+		arg.IneligibleJobIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchJobFacetsRow
+	for rows.Next() {
+		var i SearchJobFacetsRow
+		if err := rows.Scan(&i.Facet, &i.Value, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchJobs = `-- name: SearchJobs :many
+WITH keyed AS (
+    SELECT
+        matches.*,
+        CAST(CASE $1::TEXT
+            WHEN 'newest' THEN EXTRACT(EPOCH FROM matches.created_at)
+            WHEN 'salary' THEN COALESCE(matches.salary_num, -1)
+            ELSE matches.rank
+        END AS DOUBLE PRECISION) AS sort_key
+    FROM job_search_matches($2::TEXT, $3::TEXT[], $4::TEXT[], $5::TEXT[], $6::TEXT[],
+        $7::TIMESTAMPTZ, $8::DOUBLE PRECISION, $9::DOUBLE PRECISION,
+        $10::BIGINT[]) AS matches
+    WHERE matches.m_location AND matches.m_type AND matches.m_position
+    AND matches.m_industry AND matches.m_posted AND matches.m_salary
+)
+SELECT
+    keyed.job_id,
+    keyed.title,
+    keyed.location,
+    keyed.type,
+    keyed.salary,
+    keyed.position,
+    keyed.skills,
+    keyed.created_at,
+    keyed.company_name,
+    keyed.industry,
+    keyed.sort_key,
+    EXISTS (
+        SELECT 1 FROM applications
+        WHERE applications.job_id = keyed.job_id
+        AND applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $11)
+    ) AS applied
+FROM keyed
+WHERE $12::DOUBLE PRECISION IS NULL
+OR (keyed.sort_key, keyed.job_id) < ($12::DOUBLE PRECISION, $13::BIGINT)
+ORDER BY keyed.sort_key DESC, keyed.job_id DESC
+LIMIT $14
+`
+
+type SearchJobsParams struct {
+	Sort             string
+	Query            string
+	Locations        []string
+	Types            []string
+	Positions        []string
+	Industries       []string
+	PostedAfter      pgtype.Timestamptz
+	SalaryMin        pgtype.Float8
+	SalaryMax        pgtype.Float8
+	IneligibleJobIds []int64
+	UserID           int64
+	CursorKey        pgtype.Float8
+	CursorID         pgtype.Int8
+	PageLimit        int32
+}
+
+type SearchJobsRow struct {
+	JobID       int64
+	Title       string
+	Location    string
+	Type        string
+	Salary      string
+	Position    string
+	Skills      []string
+	CreatedAt   pgtype.Timestamp
+	CompanyName string
+	Industry    string
+	SortKey     float64
+	Applied     bool
+}
+
+func (q *Queries) SearchJobs(ctx context.Context, arg SearchJobsParams) ([]SearchJobsRow, error) {
+	rows, err := q.db.Query(ctx, searchJobs,
+		arg.Sort,
+		arg.Query,
+		arg.Locations,
+		arg.Types,
+		arg.Positions,
+		arg.Industries,
+		arg.PostedAfter,
+		arg.SalaryMin,
+		arg.SalaryMax,
+		arg.IneligibleJobIds,
+		arg.UserID,
+		arg.CursorKey,
+		arg.CursorID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchJobsRow
+	for rows.Next() {
+		var i SearchJobsRow
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Title,
+			&i.Location,
+			&i.Type,
+			&i.Salary,
+			&i.Position,
+			&i.Skills,
+			&i.CreatedAt,
+			&i.CompanyName,
+			&i.Industry,
+			&i.SortKey,
+			&i.Applied,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setApplicationStage = `-- name: SetApplicationStage :exec
+UPDATE applications
+SET stage_id = $1
+WHERE application_id = $2
+`
+
+type SetApplicationStageParams struct {
+	StageID       pgtype.Int8
+	ApplicationID int64
+}
+
+func (q *Queries) SetApplicationStage(ctx context.Context, arg SetApplicationStageParams) error {
+	_, err := q.db.Exec(ctx, setApplicationStage, arg.StageID, arg.ApplicationID)
+	return err
+}
+
+const setApplicationStatus = `-- name: SetApplicationStatus :exec
+UPDATE applications
+SET status = $1::TEXT::application_status
+WHERE application_id = $2
+`
+
+type SetApplicationStatusParams struct {
+	Status        string
+	ApplicationID int64
+}
+
+func (q *Queries) SetApplicationStatus(ctx context.Context, arg SetApplicationStatusParams) error {
+	_, err := q.db.Exec(ctx, setApplicationStatus, arg.Status, arg.ApplicationID)
+	return err
+}
+
+const setInterviewStatus = `-- name: SetInterviewStatus :exec
+UPDATE interviews
+SET status = $1
+WHERE interview_id = $2
+`
+
+type SetInterviewStatusParams struct {
+	Status      interface{}
+	InterviewID int64
+}
+
+func (q *Queries) SetInterviewStatus(ctx context.Context, arg SetInterviewStatusParams) error {
+	_, err := q.db.Exec(ctx, setInterviewStatus, arg.Status, arg.InterviewID)
+	return err
+}
+
 const signupUser = `-- name: SignupUser :one
 INSERT INTO users (email, password, role) VALUES ($1, $2, $3)
 RETURNING user_id, email, password, role, user_uuid, created_at, confirmed, is_verified
@@ -2319,6 +6588,56 @@ func (q *Queries) StudentDashboardData(ctx context.Context, userID int64) (Stude
 	return i, err
 }
 
+const studentEligibilityProfile = `-- name: StudentEligibilityProfile :one
+SELECT
+    students.student_id,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = $1
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
+FROM students
+WHERE students.user_id = $2
+`
+
+type StudentEligibilityProfileParams struct {
+	JobID  int64
+	UserID int64
+}
+
+type StudentEligibilityProfileRow struct {
+	StudentID   int64
+	Course      string
+	Department  string
+	YearOfStudy string
+	Cgpa        pgtype.Float8
+	Backlogs    int32
+	Extras      []byte
+	Overridden  bool
+}
+
+func (q *Queries) StudentEligibilityProfile(ctx context.Context, arg StudentEligibilityProfileParams) (StudentEligibilityProfileRow, error) {
+	row := q.db.QueryRow(ctx, studentEligibilityProfile, arg.JobID, arg.UserID)
+	var i StudentEligibilityProfileRow
+	err := row.Scan(
+		&i.StudentID,
+		&i.Course,
+		&i.Department,
+		&i.YearOfStudy,
+		&i.Cgpa,
+		&i.Backlogs,
+		&i.Extras,
+		&i.Overridden,
+	)
+	return i, err
+}
+
 const studentInfo = `-- name: StudentInfo :one
 SELECT
     students.student_id,
@@ -2378,6 +6697,126 @@ func (q *Queries) StudentInfo(ctx context.Context, userID int64) (StudentInfoRow
 	return i, err
 }
 
+const studentJobApplication = `-- name: StudentJobApplication :one
+SELECT applications.application_id, applications.status::TEXT AS status
+FROM applications
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND applications.job_id = $2
+ORDER BY applications.application_id DESC
+LIMIT 1
+`
+
+type StudentJobApplicationParams struct {
+	UserID int64
+	JobID  int64
+}
+
+type StudentJobApplicationRow struct {
+	ApplicationID int64
+	Status        string
+}
+
+func (q *Queries) StudentJobApplication(ctx context.Context, arg StudentJobApplicationParams) (StudentJobApplicationRow, error) {
+	row := q.db.QueryRow(ctx, studentJobApplication, arg.UserID, arg.JobID)
+	var i StudentJobApplicationRow
+	err := row.Scan(&i.ApplicationID, &i.Status)
+	return i, err
+}
+
+const studentPlacements = `-- name: StudentPlacements :many
+SELECT
+    applications.application_id,
+    applications.status::TEXT AS status,
+    jobs.title,
+    jobs.type,
+    companies.company_name,
+    COALESCE(offers.currency, job_compensation.currency) AS currency,
+    COALESCE(offers.ctc, job_compensation.ctc_min) AS ctc_min,
+    COALESCE(offers.ctc, job_compensation.ctc_max) AS ctc_max
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+LEFT JOIN offers ON applications.application_id = offers.application_id
+WHERE students.user_id = $1
+AND applications.status::TEXT IN ('Offered', 'Hired', 'Declined')
+ORDER BY applications.application_id
+`
+
+type StudentPlacementsRow struct {
+	ApplicationID int64
+	Status        string
+	Title         string
+	Type          string
+	CompanyName   string
+	Currency      pgtype.Text
+	CtcMin        pgtype.Int8
+	CtcMax        pgtype.Int8
+}
+
+func (q *Queries) StudentPlacements(ctx context.Context, userID int64) ([]StudentPlacementsRow, error) {
+	rows, err := q.db.Query(ctx, studentPlacements, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StudentPlacementsRow
+	for rows.Next() {
+		var i StudentPlacementsRow
+		if err := rows.Scan(
+			&i.ApplicationID,
+			&i.Status,
+			&i.Title,
+			&i.Type,
+			&i.CompanyName,
+			&i.Currency,
+			&i.CtcMin,
+			&i.CtcMax,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const studentPolicyExceptions = `-- name: StudentPolicyExceptions :many
+SELECT policy_exceptions.rule
+FROM policy_exceptions
+JOIN students ON policy_exceptions.student_id = students.student_id
+WHERE students.user_id = $1
+AND (policy_exceptions.job_id IS NULL OR policy_exceptions.job_id = $2)
+`
+
+type StudentPolicyExceptionsParams struct {
+	UserID int64
+	JobID  int64
+}
+
+func (q *Queries) StudentPolicyExceptions(ctx context.Context, arg StudentPolicyExceptionsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, studentPolicyExceptions, arg.UserID, arg.JobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var rule string
+		if err := rows.Scan(&rule); err != nil {
+			return nil, err
+		}
+		items = append(items, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const studentProfileData = `-- name: StudentProfileData :one
 SELECT 
     students.student_name,
@@ -2546,28 +6985,92 @@ type StudentTestResultRow struct {
 	UserUuid           pgtype.UUID
 }
 
-func (q *Queries) StudentTestResult(ctx context.Context, testID int64) ([]StudentTestResultRow, error) {
-	rows, err := q.db.Query(ctx, studentTestResult, testID)
+func (q *Queries) StudentTestResult(ctx context.Context, testID int64) ([]StudentTestResultRow, error) {
+	rows, err := q.db.Query(ctx, studentTestResult, testID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StudentTestResultRow
+	for rows.Next() {
+		var i StudentTestResultRow
+		if err := rows.Scan(
+			&i.ResultID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Score,
+			&i.StudentID,
+			&i.StudentName,
+			&i.RollNumber,
+			&i.StudentEmail,
+			&i.TotalTimeTaken,
+			&i.QuestionsAttempted,
+			&i.CorrectResponse,
+			&i.UserUuid,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const studentsEligibilityProfiles = `-- name: StudentsEligibilityProfiles :many
+SELECT
+    students.student_id,
+    students.student_name,
+    students.roll_number,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = $1
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
+FROM students
+ORDER BY students.roll_number
+`
+
+type StudentsEligibilityProfilesRow struct {
+	StudentID   int64
+	StudentName string
+	RollNumber  string
+	Course      string
+	Department  string
+	YearOfStudy string
+	Cgpa        pgtype.Float8
+	Backlogs    int32
+	Extras      []byte
+	Overridden  bool
+}
+
+func (q *Queries) StudentsEligibilityProfiles(ctx context.Context, jobID int64) ([]StudentsEligibilityProfilesRow, error) {
+	rows, err := q.db.Query(ctx, studentsEligibilityProfiles, jobID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []StudentTestResultRow
+	var items []StudentsEligibilityProfilesRow
 	for rows.Next() {
-		var i StudentTestResultRow
+		var i StudentsEligibilityProfilesRow
 		if err := rows.Scan(
-			&i.ResultID,
-			&i.StartTime,
-			&i.EndTime,
-			&i.Score,
 			&i.StudentID,
 			&i.StudentName,
 			&i.RollNumber,
-			&i.StudentEmail,
-			&i.TotalTimeTaken,
-			&i.QuestionsAttempted,
-			&i.CorrectResponse,
-			&i.UserUuid,
+			&i.Course,
+			&i.Department,
+			&i.YearOfStudy,
+			&i.Cgpa,
+			&i.Backlogs,
+			&i.Extras,
+			&i.Overridden,
 		); err != nil {
 			return nil, err
 		}
@@ -2647,6 +7150,28 @@ func (q *Queries) StudentsOverview(ctx context.Context) ([]StudentsOverviewRow,
 	return items, nil
 }
 
+const submitJob = `-- name: SubmitJob :one
+UPDATE jobs
+SET approval_status = 'submitted',
+    submitted_at = NOW()
+WHERE jobs.job_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2)
+AND jobs.approval_status IN ('draft', 'rejected')
+RETURNING jobs.title
+`
+
+type SubmitJobParams struct {
+	JobID  int64
+	UserID int64
+}
+
+func (q *Queries) SubmitJob(ctx context.Context, arg SubmitJobParams) (string, error) {
+	row := q.db.QueryRow(ctx, submitJob, arg.JobID, arg.UserID)
+	var title string
+	err := row.Scan(&title)
+	return title, err
+}
+
 const submitTest = `-- name: SubmitTest :one
 UPDATE testresults 
 SET end_time = $1
@@ -2895,6 +7420,148 @@ func (q *Queries) TestResultPoller(ctx context.Context) (int64, error) {
 	return test_id, err
 }
 
+const unemailedAnnouncementRecipients = `-- name: UnemailedAnnouncementRecipients :many
+SELECT
+    announcement_recipients.user_id,
+    users.email
+FROM announcement_recipients
+JOIN users ON announcement_recipients.user_id = users.user_id
+WHERE announcement_recipients.announcement_id = $1
+AND announcement_recipients.emailed_at IS NULL
+`
+
+type UnemailedAnnouncementRecipientsRow struct {
+	UserID int64
+	Email  string
+}
+
+func (q *Queries) UnemailedAnnouncementRecipients(ctx context.Context, announcementID int64) ([]UnemailedAnnouncementRecipientsRow, error) {
+	rows, err := q.db.Query(ctx, unemailedAnnouncementRecipients, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnemailedAnnouncementRecipientsRow
+	for rows.Next() {
+		var i UnemailedAnnouncementRecipientsRow
+		if err := rows.Scan(&i.UserID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unnotifiedAnnouncementRecipients = `-- name: UnnotifiedAnnouncementRecipients :many
+SELECT
+    announcement_recipients.user_id
+FROM announcement_recipients
+WHERE announcement_recipients.announcement_id = $1
+AND announcement_recipients.notified_at IS NULL
+`
+
+func (q *Queries) UnnotifiedAnnouncementRecipients(ctx context.Context, announcementID int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, unnotifiedAnnouncementRecipients, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var user_id int64
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unreadNotificationsCount = `-- name: UnreadNotificationsCount :one
+SELECT
+    COUNT(notifications.notif_id) AS unread_count
+FROM notifications
+WHERE notifications.user_id = $1
+AND notifications.read_status = false
+`
+
+func (q *Queries) UnreadNotificationsCount(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, unreadNotificationsCount, userID)
+	var unread_count int64
+	err := row.Scan(&unread_count)
+	return unread_count, err
+}
+
+const unreadNotificationsSince = `-- name: UnreadNotificationsSince :many
+SELECT
+    notifications.title,
+    notifications.description,
+    notifications.timestamp
+FROM notifications
+WHERE notifications.user_id = $1
+AND notifications.read_status = false
+AND notifications.timestamp >= $2
+ORDER BY notifications.timestamp DESC
+LIMIT $3
+`
+
+type UnreadNotificationsSinceParams struct {
+	UserID    int64
+	Timestamp int64
+	Limit     int32
+}
+
+type UnreadNotificationsSinceRow struct {
+	Title       pgtype.Text
+	Description pgtype.Text
+	Timestamp   int64
+}
+
+func (q *Queries) UnreadNotificationsSince(ctx context.Context, arg UnreadNotificationsSinceParams) ([]UnreadNotificationsSinceRow, error) {
+	rows, err := q.db.Query(ctx, unreadNotificationsSince, arg.UserID, arg.Timestamp, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UnreadNotificationsSinceRow
+	for rows.Next() {
+		var i UnreadNotificationsSinceRow
+		if err := rows.Scan(&i.Title, &i.Description, &i.Timestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unregisterDeviceToken = `-- name: UnregisterDeviceToken :execrows
+DELETE FROM device_tokens
+WHERE token = $1
+AND user_id = $2
+`
+
+type UnregisterDeviceTokenParams struct {
+	Token  string
+	UserID int64
+}
+
+func (q *Queries) UnregisterDeviceToken(ctx context.Context, arg UnregisterDeviceTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, unregisterDeviceToken, arg.Token, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const upcomingInterviewsStudent = `-- name: UpcomingInterviewsStudent :many
 SELECT 
     companies.company_name,
@@ -2903,10 +7570,11 @@ SELECT
     TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
     interviews.type::TEXT,
     interviews.location,
-    interviews.notes
+    interviews.notes,
+    interviews.status::TEXT
 FROM applications
 JOIN interviews ON applications.application_id = interviews.application_id 
-                AND interviews.status != 'Completed'
+                AND interviews.status NOT IN ('Completed', 'Cancelled')
 JOIN jobs ON applications.job_id = jobs.job_id
 JOIN companies ON jobs.company_id = companies.company_id
 WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
@@ -2914,13 +7582,14 @@ AND interviews.date_time > NOW()
 `
 
 type UpcomingInterviewsStudentRow struct {
-	CompanyName    string
-	Title          string
-	InterviewID    int64
-	DateTime       string
-	InterviewsType string
-	Location       string
-	Notes          pgtype.Text
+	CompanyName      string
+	Title            string
+	InterviewID      int64
+	DateTime         string
+	InterviewsType   string
+	Location         string
+	Notes            pgtype.Text
+	InterviewsStatus string
 }
 
 func (q *Queries) UpcomingInterviewsStudent(ctx context.Context, userID int64) ([]UpcomingInterviewsStudentRow, error) {
@@ -2940,6 +7609,7 @@ func (q *Queries) UpcomingInterviewsStudent(ctx context.Context, userID int64) (
 			&i.InterviewsType,
 			&i.Location,
 			&i.Notes,
+			&i.InterviewsStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -3106,24 +7776,30 @@ SET
     date_time = $3,
     type = $4,
     notes = $5,
-    location = $6
+    location = $6,
+    duration_minutes = $7,
+    panel = $8,
+    ical_sequence = ical_sequence + 1
 WHERE company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
 AND interview_id = $2
-RETURNING application_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time
+RETURNING application_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time, ical_sequence
 `
 
 type UpdateInterviewParams struct {
-	UserID      int64
-	InterviewID int64
-	DateTime    pgtype.Timestamptz
-	Type        interface{}
-	Notes       pgtype.Text
-	Location    string
+	UserID          int64
+	InterviewID     int64
+	DateTime        pgtype.Timestamptz
+	Type            interface{}
+	Notes           pgtype.Text
+	Location        string
+	DurationMinutes int32
+	Panel           string
 }
 
 type UpdateInterviewRow struct {
 	ApplicationID int64
 	DateTime      string
+	IcalSequence  int32
 }
 
 func (q *Queries) UpdateInterview(ctx context.Context, arg UpdateInterviewParams) (UpdateInterviewRow, error) {
@@ -3134,13 +7810,15 @@ func (q *Queries) UpdateInterview(ctx context.Context, arg UpdateInterviewParams
 		arg.Type,
 		arg.Notes,
 		arg.Location,
+		arg.DurationMinutes,
+		arg.Panel,
 	)
 	var i UpdateInterviewRow
-	err := row.Scan(&i.ApplicationID, &i.DateTime)
+	err := row.Scan(&i.ApplicationID, &i.DateTime, &i.IcalSequence)
 	return i, err
 }
 
-const updateJob = `-- name: UpdateJob :exec
+const updateJob = `-- name: UpdateJob :one
 UPDATE jobs
 SET location = $1,
     title = $2,
@@ -3149,26 +7827,41 @@ SET location = $1,
     salary = $5,
     skills = $6,
     position = $7,
-    extras = $8
+    extras = $8,
+    deadline = $12,
+    max_applicants = $13,
+    questions = $14,
+    -- a moved deadline is reminded of again
+    deadline_reminder_sent = deadline_reminder_sent AND deadline IS NOT DISTINCT FROM $12,
+    -- a job closed once its deadline passed opens again when the deadline is moved to the future or removed
+    active_status = active_status OR COALESCE(deadline <= NOW() AND ($12 IS NULL OR $12 > NOW()), false),
+    revision = revision + 1,
+    updated_at = NOW()
 WHERE job_id = $9
 AND company_id = (SELECT company_id FROM companies WHERE companies.user_id = $10)
+AND revision = $11
+RETURNING revision
 `
 
 type UpdateJobParams struct {
-	Location    string
-	Title       string
-	Description pgtype.Text
-	Type        string
-	Salary      string
-	Skills      []string
-	Position    string
-	Extras      []byte
-	JobID       int64
-	UserID      int64
+	Location      string
+	Title         string
+	Description   pgtype.Text
+	Type          string
+	Salary        string
+	Skills        []string
+	Position      string
+	Extras        []byte
+	JobID         int64
+	UserID        int64
+	Revision      int32
+	Deadline      pgtype.Timestamptz
+	MaxApplicants pgtype.Int4
+	Questions     []byte
 }
 
-func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) error {
-	_, err := q.db.Exec(ctx, updateJob,
+func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) (int32, error) {
+	row := q.db.QueryRow(ctx, updateJob,
 		arg.Location,
 		arg.Title,
 		arg.Description,
@@ -3179,8 +7872,43 @@ func (q *Queries) UpdateJob(ctx context.Context, arg UpdateJobParams) error {
 		arg.Extras,
 		arg.JobID,
 		arg.UserID,
+		arg.Revision,
+		arg.Deadline,
+		arg.MaxApplicants,
+		arg.Questions,
 	)
-	return err
+	var revision int32
+	err := row.Scan(&revision)
+	return revision, err
+}
+
+const updateOfferLetterTemplate = `-- name: UpdateOfferLetterTemplate :execrows
+UPDATE offer_letter_templates
+SET name = $1, title = $2, body = $3, updated_at = CURRENT_TIMESTAMP
+WHERE template_id = $4
+AND company_id = (SELECT company_id FROM companies WHERE user_id = $5)
+`
+
+type UpdateOfferLetterTemplateParams struct {
+	Name       string
+	Title      string
+	Body       string
+	TemplateID int64
+	UserID     int64
+}
+
+func (q *Queries) UpdateOfferLetterTemplate(ctx context.Context, arg UpdateOfferLetterTemplateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateOfferLetterTemplate,
+		arg.Name,
+		arg.Title,
+		arg.Body,
+		arg.TemplateID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updatePassword = `-- name: UpdatePassword :exec
@@ -3199,6 +7927,61 @@ func (q *Queries) UpdatePassword(ctx context.Context, arg UpdatePasswordParams)
 	return err
 }
 
+const updatePipelineStage = `-- name: UpdatePipelineStage :execrows
+UPDATE job_pipeline_stages
+SET position = $1, name = $2, kind = $3, test_id = $4
+WHERE stage_id = $5
+AND job_id = $6
+`
+
+type UpdatePipelineStageParams struct {
+	Position int32
+	Name     string
+	Kind     string
+	TestID   pgtype.Int8
+	StageID  int64
+	JobID    int64
+}
+
+func (q *Queries) UpdatePipelineStage(ctx context.Context, arg UpdatePipelineStageParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePipelineStage,
+		arg.Position,
+		arg.Name,
+		arg.Kind,
+		arg.TestID,
+		arg.StageID,
+		arg.JobID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updatePlacementPolicy = `-- name: UpdatePlacementPolicy :exec
+INSERT INTO placement_policy (policy_id, max_offers, block_after_acceptance, tiers, updated_by)
+VALUES (1, $1, $2, $3, $4)
+ON CONFLICT (policy_id)
+DO UPDATE SET max_offers = $1, block_after_acceptance = $2, tiers = $3, updated_by = $4, updated_at = NOW()
+`
+
+type UpdatePlacementPolicyParams struct {
+	MaxOffers            int32
+	BlockAfterAcceptance bool
+	Tiers                []byte
+	UpdatedBy            pgtype.Int8
+}
+
+func (q *Queries) UpdatePlacementPolicy(ctx context.Context, arg UpdatePlacementPolicyParams) error {
+	_, err := q.db.Exec(ctx, updatePlacementPolicy,
+		arg.MaxOffers,
+		arg.BlockAfterAcceptance,
+		arg.Tiers,
+		arg.UpdatedBy,
+	)
+	return err
+}
+
 const updateResponse = `-- name: UpdateResponse :exec
 INSERT INTO testresponses (result_id, question_id, response, time_taken)
 VALUES ($1, $2, $3, $4)
@@ -3223,6 +8006,25 @@ func (q *Queries) UpdateResponse(ctx context.Context, arg UpdateResponseParams)
 	return err
 }
 
+const updateStudentBacklogs = `-- name: UpdateStudentBacklogs :execrows
+UPDATE students
+SET backlogs = $1
+WHERE student_id = $2
+`
+
+type UpdateStudentBacklogsParams struct {
+	Backlogs  int32
+	StudentID int64
+}
+
+func (q *Queries) UpdateStudentBacklogs(ctx context.Context, arg UpdateStudentBacklogsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateStudentBacklogs, arg.Backlogs, arg.StudentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateStudentDetails = `-- name: UpdateStudentDetails :exec
 UPDATE students
 SET course = $1,
@@ -3354,6 +8156,177 @@ func (q *Queries) UpdateTestResultURLUnprotected(ctx context.Context, arg Update
 	return err
 }
 
+const upsertCalendarFeedToken = `-- name: UpsertCalendarFeedToken :one
+INSERT INTO calendar_feeds (user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id)
+DO UPDATE SET token = $2, created_at = NOW()
+RETURNING token
+`
+
+type UpsertCalendarFeedTokenParams struct {
+	UserID int64
+	Token  string
+}
+
+func (q *Queries) UpsertCalendarFeedToken(ctx context.Context, arg UpsertCalendarFeedTokenParams) (string, error) {
+	row := q.db.QueryRow(ctx, upsertCalendarFeedToken, arg.UserID, arg.Token)
+	var token string
+	err := row.Scan(&token)
+	return token, err
+}
+
+const upsertDigestPreferences = `-- name: UpsertDigestPreferences :exec
+INSERT INTO digest_preferences (user_id, frequency, send_hour, weekday, timezone)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id)
+DO UPDATE SET frequency = $2, send_hour = $3, weekday = $4, timezone = $5
+`
+
+type UpsertDigestPreferencesParams struct {
+	UserID    int64
+	Frequency string
+	SendHour  int32
+	Weekday   int32
+	Timezone  string
+}
+
+func (q *Queries) UpsertDigestPreferences(ctx context.Context, arg UpsertDigestPreferencesParams) error {
+	_, err := q.db.Exec(ctx, upsertDigestPreferences,
+		arg.UserID,
+		arg.Frequency,
+		arg.SendHour,
+		arg.Weekday,
+		arg.Timezone,
+	)
+	return err
+}
+
+const upsertEligibilityOverride = `-- name: UpsertEligibilityOverride :exec
+INSERT INTO eligibility_overrides (job_id, student_id, granted_by, reason)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (job_id, student_id)
+DO UPDATE SET granted_by = $3, reason = $4, created_at = NOW()
+`
+
+type UpsertEligibilityOverrideParams struct {
+	JobID     int64
+	StudentID int64
+	GrantedBy int64
+	Reason    string
+}
+
+func (q *Queries) UpsertEligibilityOverride(ctx context.Context, arg UpsertEligibilityOverrideParams) error {
+	_, err := q.db.Exec(ctx, upsertEligibilityOverride,
+		arg.JobID,
+		arg.StudentID,
+		arg.GrantedBy,
+		arg.Reason,
+	)
+	return err
+}
+
+const upsertJobCompensation = `-- name: UpsertJobCompensation :exec
+INSERT INTO job_compensation (job_id, currency, ctc_min, ctc_max, fixed_pay, variable_pay, stipend_monthly, bond_months, bond_terms, parsed)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (job_id) DO UPDATE
+SET currency = EXCLUDED.currency,
+    ctc_min = EXCLUDED.ctc_min,
+    ctc_max = EXCLUDED.ctc_max,
+    fixed_pay = EXCLUDED.fixed_pay,
+    variable_pay = EXCLUDED.variable_pay,
+    stipend_monthly = EXCLUDED.stipend_monthly,
+    bond_months = EXCLUDED.bond_months,
+    bond_terms = EXCLUDED.bond_terms,
+    parsed = EXCLUDED.parsed,
+    updated_at = CURRENT_TIMESTAMP
+`
+
+type UpsertJobCompensationParams struct {
+	JobID          int64
+	Currency       string
+	CtcMin         int64
+	CtcMax         int64
+	FixedPay       int64
+	VariablePay    int64
+	StipendMonthly int64
+	BondMonths     int32
+	BondTerms      string
+	Parsed         bool
+}
+
+func (q *Queries) UpsertJobCompensation(ctx context.Context, arg UpsertJobCompensationParams) error {
+	_, err := q.db.Exec(ctx, upsertJobCompensation,
+		arg.JobID,
+		arg.Currency,
+		arg.CtcMin,
+		arg.CtcMax,
+		arg.FixedPay,
+		arg.VariablePay,
+		arg.StipendMonthly,
+		arg.BondMonths,
+		arg.BondTerms,
+		arg.Parsed,
+	)
+	return err
+}
+
+const upsertJobEligibility = `-- name: UpsertJobEligibility :exec
+INSERT INTO job_eligibility (job_id, min_cgpa, courses, departments, years_of_study, max_backlogs, custom)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (job_id)
+DO UPDATE SET min_cgpa = $2, courses = $3, departments = $4, years_of_study = $5, max_backlogs = $6, custom = $7, updated_at = NOW()
+`
+
+type UpsertJobEligibilityParams struct {
+	JobID        int64
+	MinCgpa      pgtype.Float8
+	Courses      []string
+	Departments  []string
+	YearsOfStudy []string
+	MaxBacklogs  pgtype.Int4
+	Custom       []byte
+}
+
+func (q *Queries) UpsertJobEligibility(ctx context.Context, arg UpsertJobEligibilityParams) error {
+	_, err := q.db.Exec(ctx, upsertJobEligibility,
+		arg.JobID,
+		arg.MinCgpa,
+		arg.Courses,
+		arg.Departments,
+		arg.YearsOfStudy,
+		arg.MaxBacklogs,
+		arg.Custom,
+	)
+	return err
+}
+
+const upsertPolicyException = `-- name: UpsertPolicyException :exec
+INSERT INTO policy_exceptions (student_id, job_id, rule, reason, granted_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (student_id, COALESCE(job_id, 0), rule)
+DO UPDATE SET reason = $4, granted_by = $5, created_at = NOW()
+`
+
+type UpsertPolicyExceptionParams struct {
+	StudentID int64
+	JobID     pgtype.Int8
+	Rule      string
+	Reason    string
+	GrantedBy int64
+}
+
+func (q *Queries) UpsertPolicyException(ctx context.Context, arg UpsertPolicyExceptionParams) error {
+	_, err := q.db.Exec(ctx, upsertPolicyException,
+		arg.StudentID,
+		arg.JobID,
+		arg.Rule,
+		arg.Reason,
+		arg.GrantedBy,
+	)
+	return err
+}
+
 const usersTableData = `-- name: UsersTableData :one
 SELECT 
     TO_CHAR(users.created_at, 'HH12:MI AM DD-MM-YYYY') AS created_at,
@@ -0,0 +1,17 @@
+-- per user preferences of the notification digest email, users without a row get the daily digest at 8 AM IST
+CREATE TABLE IF NOT EXISTS digest_preferences (
+    user_id BIGINT NOT NULL,
+    frequency TEXT NOT NULL DEFAULT 'daily',
+    send_hour INTEGER NOT NULL DEFAULT 8,
+    weekday INTEGER NOT NULL DEFAULT 1,
+    timezone TEXT NOT NULL DEFAULT 'Asia/Kolkata',
+    last_sent_at TIMESTAMPTZ,
+    CONSTRAINT digest_preferences_pkey PRIMARY KEY (user_id),
+    CONSTRAINT digest_frequency_check CHECK (frequency IN ('daily', 'weekly', 'off')),
+    CONSTRAINT digest_send_hour_check CHECK (send_hour BETWEEN 0 AND 23),
+    CONSTRAINT digest_weekday_check CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT digest_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+-- iCalendar SEQUENCE of the interview invite, incremented on every update
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS ical_sequence INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id BIGINT NOT NULL,
+    token TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT calendar_feeds_pkey PRIMARY KEY (user_id),
+    CONSTRAINT uni_calendar_feed_token UNIQUE (token),
+    CONSTRAINT calendar_feeds_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
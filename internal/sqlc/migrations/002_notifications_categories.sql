@@ -0,0 +1,7 @@
+-- notification categories, archiving and typed deep links to the referenced entity
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT 'general';
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS ref_type TEXT;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS ref_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS notifications_user_timestamp_idx ON notifications (user_id, timestamp DESC);
@@ -0,0 +1,15 @@
+-- FCM registration tokens of the devices of a user
+CREATE TABLE IF NOT EXISTS device_tokens (
+    token TEXT NOT NULL,
+    user_id BIGINT NOT NULL,
+    platform TEXT NOT NULL DEFAULT 'web',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT device_tokens_pkey PRIMARY KEY (token),
+    CONSTRAINT device_tokens_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS device_tokens_user_id_idx ON device_tokens (user_id);
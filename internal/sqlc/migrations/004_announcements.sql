@@ -0,0 +1,44 @@
+-- admin announcements, targeted by role, department, course, year of study or applicants of a job
+CREATE TABLE IF NOT EXISTS announcements (
+    announcement_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    author_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    target_role BIGINT,
+    department TEXT,
+    course TEXT,
+    year_of_study TEXT,
+    job_id BIGINT,
+    send_email BOOLEAN NOT NULL DEFAULT true,
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT announcements_pkey PRIMARY KEY (announcement_id),
+    CONSTRAINT announcements_users_fkey FOREIGN KEY (author_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT announcements_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+-- one row per delivered recipient, read_at is the read receipt
+CREATE TABLE IF NOT EXISTS announcement_recipients (
+    announcement_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    read_at TIMESTAMPTZ,
+    CONSTRAINT announcement_recipients_pkey PRIMARY KEY (announcement_id, user_id),
+    CONSTRAINT recipients_announcements_fkey FOREIGN KEY (announcement_id)
+        REFERENCES public.announcements (announcement_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT recipients_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS announcement_recipients_user_id_idx ON announcement_recipients (user_id);
+CREATE INDEX IF NOT EXISTS announcements_pending_idx ON announcements (scheduled_at) WHERE delivered_at IS NULL;
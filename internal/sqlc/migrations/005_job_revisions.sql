@@ -0,0 +1,24 @@
+-- optimistic concurrency for job edits, the revision of the job an application was made against
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS job_revision INTEGER NOT NULL DEFAULT 1;
+
+-- one row per edit of a job, changes holds the old and new value of every changed field
+CREATE TABLE IF NOT EXISTS job_revisions (
+    job_id BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    changed_by BIGINT NOT NULL,
+    changed_fields TEXT[] NOT NULL,
+    changes JSONB NOT NULL,
+    material BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_revisions_pkey PRIMARY KEY (job_id, revision),
+    CONSTRAINT job_revisions_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_revisions_users_fkey FOREIGN KEY (changed_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
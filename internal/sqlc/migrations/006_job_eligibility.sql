@@ -0,0 +1,52 @@
+-- eligibility criteria of jobs, per student overrides granted by admins and a general audit log
+ALTER TABLE students ADD COLUMN IF NOT EXISTS backlogs INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS job_eligibility (
+    job_id BIGINT NOT NULL,
+    min_cgpa DOUBLE PRECISION,
+    courses TEXT[] NOT NULL DEFAULT '{}',
+    departments TEXT[] NOT NULL DEFAULT '{}',
+    years_of_study TEXT[] NOT NULL DEFAULT '{}',
+    max_backlogs INTEGER,
+    custom JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_eligibility_pkey PRIMARY KEY (job_id),
+    CONSTRAINT job_eligibility_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS eligibility_overrides (
+    job_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    granted_by BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT eligibility_overrides_pkey PRIMARY KEY (job_id, student_id),
+    CONSTRAINT overrides_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT overrides_students_fkey FOREIGN KEY (student_id)
+        REFERENCES public.students (student_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT overrides_users_fkey FOREIGN KEY (granted_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    audit_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT audit_log_pkey PRIMARY KEY (audit_id)
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id);
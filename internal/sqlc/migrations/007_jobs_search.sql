@@ -0,0 +1,38 @@
+-- full-text search over title, company name, skills and description of jobs
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+-- full-text search document of a job, title and company name weigh the most
+CREATE OR REPLACE FUNCTION jobs_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((SELECT company_name FROM companies WHERE companies.company_id = NEW.company_id), '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(array_to_string(NEW.skills, ' '), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS jobs_search_vector_trigger ON jobs;
+CREATE TRIGGER jobs_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, description, skills, company_id ON jobs
+    FOR EACH ROW EXECUTE FUNCTION jobs_search_vector_update();
+
+-- a company rename re-indexes its jobs
+CREATE OR REPLACE FUNCTION companies_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE jobs SET title = title WHERE jobs.company_id = NEW.company_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS companies_search_vector_trigger ON companies;
+CREATE TRIGGER companies_search_vector_trigger
+    AFTER UPDATE OF company_name ON companies
+    FOR EACH ROW WHEN (OLD.company_name IS DISTINCT FROM NEW.company_name)
+    EXECUTE FUNCTION companies_search_vector_update();
+
+CREATE INDEX IF NOT EXISTS jobs_search_vector_idx ON jobs USING GIN (search_vector);
+
+-- backfill the existing jobs through the trigger
+UPDATE jobs SET title = title;
@@ -0,0 +1,6 @@
+-- application deadline and applicant cap of jobs, jobs are closed once either is reached
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS deadline TIMESTAMPTZ;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS max_applicants INTEGER;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS deadline_reminder_sent BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS jobs_deadline_idx ON jobs (deadline) WHERE active_status;
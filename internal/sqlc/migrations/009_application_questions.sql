@@ -0,0 +1,3 @@
+-- job-specific questions asked on applying and the answers of the applicants, keyed by question ID
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS questions JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS answers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,35 @@
+-- jobs are reviewed by an admin before going live, draft -> submitted -> approved/rejected, rejected jobs can be resubmitted.
+-- Existing jobs are already live, so they are added as approved and only new jobs start as drafts.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS approval_status TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE jobs ALTER COLUMN approval_status SET DEFAULT 'draft';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMPTZ;
+
+DO $$
+BEGIN
+    ALTER TABLE jobs ADD CONSTRAINT jobs_approval_status_check CHECK (approval_status IN ('draft', 'submitted', 'approved', 'rejected'));
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS job_reviews (
+    review_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    job_id BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    reviewer_id BIGINT NOT NULL,
+    decision TEXT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_reviews_pkey PRIMARY KEY (review_id),
+    CONSTRAINT job_reviews_decision_check CHECK (decision IN ('approved', 'rejected')),
+    CONSTRAINT job_reviews_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_reviews_users_fkey FOREIGN KEY (reviewer_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS job_reviews_job_idx ON job_reviews (job_id);
+CREATE INDEX IF NOT EXISTS jobs_submitted_idx ON jobs (submitted_at) WHERE approval_status = 'submitted';
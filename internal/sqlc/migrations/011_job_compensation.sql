@@ -0,0 +1,23 @@
+-- structured compensation of jobs, amounts in whole units of the currency, per annum except the monthly stipend.
+-- jobs.salary stays as the display string, existing free-text salaries are parsed into this on startup by tasks.BackfillCompensation,
+-- rows from it are marked parsed.
+CREATE TABLE IF NOT EXISTS job_compensation (
+    job_id BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'INR',
+    ctc_min BIGINT NOT NULL DEFAULT 0,
+    ctc_max BIGINT NOT NULL DEFAULT 0,
+    fixed_pay BIGINT NOT NULL DEFAULT 0,
+    variable_pay BIGINT NOT NULL DEFAULT 0,
+    stipend_monthly BIGINT NOT NULL DEFAULT 0,
+    bond_months INTEGER NOT NULL DEFAULT 0,
+    bond_terms TEXT NOT NULL DEFAULT '',
+    parsed BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_compensation_pkey PRIMARY KEY (job_id),
+    CONSTRAINT job_compensation_currency_check CHECK (currency IN ('INR', 'USD', 'EUR', 'GBP')),
+    CONSTRAINT job_compensation_ctc_check CHECK (ctc_min >= 0 AND ctc_max >= ctc_min),
+    CONSTRAINT job_compensation_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
@@ -0,0 +1,47 @@
+-- application statuses follow a state machine, see appstatus, with every change recorded in application_status_history.
+-- The new statuses can only be used once this is committed, so the history stores statuses as TEXT.
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'Interview';
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'Declined';
+ALTER TYPE application_status ADD VALUE IF NOT EXISTS 'Withdrawn';
+
+CREATE TABLE IF NOT EXISTS application_status_history (
+    history_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor_id BIGINT,
+    actor_role TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT application_status_history_pkey PRIMARY KEY (history_id),
+    CONSTRAINT application_status_history_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT application_status_history_users_fkey FOREIGN KEY (actor_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS application_status_history_application_idx ON application_status_history (application_id, created_at);
+
+-- existing applications start their timeline as applied, followed by where they are now if they moved on
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role, reason, created_at)
+SELECT applications.application_id, NULL, 'Applied', students.user_id, 'student', '', applications.created_at
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+WHERE NOT EXISTS (
+    SELECT 1 FROM application_status_history
+    WHERE application_status_history.application_id = applications.application_id
+);
+
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role, reason)
+SELECT applications.application_id, 'Applied', applications.status::TEXT, NULL, 'system', 'Status before the history was recorded.'
+FROM applications
+WHERE applications.status::TEXT <> 'Applied'
+AND NOT EXISTS (
+    SELECT 1 FROM application_status_history
+    WHERE application_status_history.application_id = applications.application_id
+    AND application_status_history.from_status IS NOT NULL
+);
@@ -0,0 +1,45 @@
+-- placement policy, offer limits, CTC tiers and blocking after acceptance, with per student exceptions, see policy.
+-- The policy starts out only blocking students who accepted an offer.
+CREATE TABLE IF NOT EXISTS placement_policy (
+    policy_id INTEGER NOT NULL DEFAULT 1,
+    max_offers INTEGER NOT NULL DEFAULT 0,
+    block_after_acceptance BOOLEAN NOT NULL DEFAULT TRUE,
+    tiers JSONB NOT NULL DEFAULT '[]',
+    updated_by BIGINT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT placement_policy_pkey PRIMARY KEY (policy_id),
+    CONSTRAINT placement_policy_single_check CHECK (policy_id = 1),
+    CONSTRAINT placement_policy_max_offers_check CHECK (max_offers >= 0),
+    CONSTRAINT placement_policy_users_fkey FOREIGN KEY (updated_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+INSERT INTO placement_policy (policy_id) VALUES (1) ON CONFLICT (policy_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS policy_exceptions (
+    exception_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    student_id BIGINT NOT NULL,
+    job_id BIGINT,
+    rule TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    granted_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT policy_exceptions_pkey PRIMARY KEY (exception_id),
+    CONSTRAINT policy_exceptions_rule_check CHECK (rule IN ('max_offers', 'tier', 'accepted')),
+    CONSTRAINT policy_exceptions_students_fkey FOREIGN KEY (student_id)
+        REFERENCES public.students (student_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT policy_exceptions_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT policy_exceptions_users_fkey FOREIGN KEY (granted_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS policy_exceptions_student_job_rule_idx ON policy_exceptions (student_id, COALESCE(job_id, 0), rule);
@@ -0,0 +1,3 @@
+-- students withdraw applications instead of deleting them, the application and its history are kept with the reason.
+-- A scheduled interview of a withdrawn application is cancelled rather than deleted.
+ALTER TYPE interview_status ADD VALUE IF NOT EXISTS 'Cancelled';
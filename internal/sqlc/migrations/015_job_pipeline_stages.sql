@@ -0,0 +1,60 @@
+-- jobs may have a hiring pipeline of their own, ordered stages applications move through, see pipeline.
+-- Jobs without one keep to the statuses alone.
+CREATE TABLE IF NOT EXISTS job_pipeline_stages (
+    stage_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    job_id BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    test_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_pipeline_stages_pkey PRIMARY KEY (stage_id),
+    CONSTRAINT job_pipeline_stages_position_key UNIQUE (job_id, position) DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT job_pipeline_stages_kind_check CHECK (kind IN ('screening', 'test', 'interview', 'offer')),
+    CONSTRAINT job_pipeline_stages_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_pipeline_stages_tests_fkey FOREIGN KEY (test_id)
+        REFERENCES public.tests (test_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS stage_id BIGINT
+    CONSTRAINT applications_job_pipeline_stages_fkey REFERENCES public.job_pipeline_stages (stage_id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS stage_id BIGINT
+    CONSTRAINT interviews_job_pipeline_stages_fkey REFERENCES public.job_pipeline_stages (stage_id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS application_stage_moves (
+    move_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    from_stage_id BIGINT,
+    to_stage_id BIGINT,
+    moved_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT application_stage_moves_pkey PRIMARY KEY (move_id),
+    CONSTRAINT application_stage_moves_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT application_stage_moves_from_fkey FOREIGN KEY (from_stage_id)
+        REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT application_stage_moves_to_fkey FOREIGN KEY (to_stage_id)
+        REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT application_stage_moves_users_fkey FOREIGN KEY (moved_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS application_stage_moves_application_idx ON application_stage_moves (application_id, created_at);
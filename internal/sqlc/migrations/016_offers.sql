@@ -0,0 +1,30 @@
+-- offers are recorded with their terms and letter, students accept or decline them before they expire.
+-- Applications offered before have no offer record, they are answered by status alone.
+CREATE TABLE IF NOT EXISTS offers (
+    offer_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    ctc BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'INR',
+    joining_date DATE NOT NULL,
+    respond_by TIMESTAMPTZ NOT NULL,
+    letter_url TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending',
+    response_reason TEXT NOT NULL DEFAULT '',
+    responded_at TIMESTAMPTZ,
+    offered_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT offers_pkey PRIMARY KEY (offer_id),
+    CONSTRAINT offers_application_key UNIQUE (application_id),
+    CONSTRAINT offers_status_check CHECK (status IN ('Pending', 'Accepted', 'Declined', 'Expired')),
+    CONSTRAINT offers_ctc_check CHECK (ctc > 0),
+    CONSTRAINT offers_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT offers_users_fkey FOREIGN KEY (offered_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS offers_pending_idx ON offers (respond_by) WHERE status = 'Pending';
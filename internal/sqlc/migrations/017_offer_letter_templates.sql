@@ -0,0 +1,21 @@
+-- offer letters are generated from templates of the company as PDFs, instead of being uploaded.
+CREATE TABLE IF NOT EXISTS offer_letter_templates (
+    template_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    company_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT offer_letter_templates_pkey PRIMARY KEY (template_id),
+    CONSTRAINT offer_letter_templates_name_key UNIQUE (company_id, name),
+    CONSTRAINT offer_letter_templates_companies_fkey FOREIGN KEY (company_id)
+        REFERENCES public.companies (company_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+ALTER TABLE offers ADD COLUMN IF NOT EXISTS template_id BIGINT
+    CONSTRAINT offers_offer_letter_templates_fkey REFERENCES public.offer_letter_templates (template_id)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
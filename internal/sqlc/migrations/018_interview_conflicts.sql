@@ -0,0 +1,14 @@
+-- interviews last duration_minutes and may name the panel taking them, so that overlapping interviews and tests of
+-- the student, and interviews of the company at the same location or with the same panel, can be found.
+-- Existing interviews keep the duration their calendar invites were sent with.
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS duration_minutes INTEGER NOT NULL DEFAULT 60;
+ALTER TABLE interviews ADD COLUMN IF NOT EXISTS panel TEXT NOT NULL DEFAULT '';
+
+DO $$
+BEGIN
+    ALTER TABLE interviews ADD CONSTRAINT interviews_duration_check CHECK (duration_minutes > 0);
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE INDEX IF NOT EXISTS interviews_company_time_idx ON interviews (company_id, date_time) WHERE status = 'Scheduled';
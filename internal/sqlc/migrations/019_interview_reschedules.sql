@@ -0,0 +1,37 @@
+-- either side of an interview may propose new times for it with a reason, the other side accepts one, rejects them or
+-- counter-proposes. The interview is Reschedule Requested until then, every proposal and its response is kept.
+ALTER TYPE interview_status ADD VALUE IF NOT EXISTS 'Reschedule Requested';
+
+CREATE TABLE IF NOT EXISTS interview_reschedule_proposals (
+    proposal_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    interview_id BIGINT NOT NULL,
+    proposed_by TEXT NOT NULL,
+    proposer_id BIGINT,
+    times TIMESTAMPTZ[] NOT NULL,
+    reason TEXT NOT NULL,
+    previous_time TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending',
+    agreed_time TIMESTAMPTZ,
+    response_reason TEXT NOT NULL DEFAULT '',
+    responded_by BIGINT,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT interview_reschedule_proposals_pkey PRIMARY KEY (proposal_id),
+    CONSTRAINT interview_reschedule_proposals_proposed_by_check CHECK (proposed_by IN ('company', 'student')),
+    CONSTRAINT interview_reschedule_proposals_status_check CHECK (status IN ('Pending', 'Accepted', 'Rejected', 'Countered', 'Cancelled')),
+    CONSTRAINT interview_reschedule_proposals_times_check CHECK (cardinality(times) > 0),
+    CONSTRAINT interview_reschedule_proposals_interviews_fkey FOREIGN KEY (interview_id)
+        REFERENCES public.interviews (interview_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT interview_reschedule_proposals_proposer_fkey FOREIGN KEY (proposer_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT interview_reschedule_proposals_responder_fkey FOREIGN KEY (responded_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS interview_reschedule_proposals_pending_key ON interview_reschedule_proposals (interview_id) WHERE status = 'Pending';
@@ -0,0 +1,16 @@
+-- the recipients of an announcement are notified and emailed once each, a delivery that failed midway is retried
+-- for those not yet notified or emailed. Recipients recorded before were notified along with being recorded,
+-- and emailed unless the delivery of the announcement failed.
+ALTER TABLE announcement_recipients ADD COLUMN IF NOT EXISTS notified_at TIMESTAMPTZ;
+ALTER TABLE announcement_recipients ADD COLUMN IF NOT EXISTS emailed_at TIMESTAMPTZ;
+
+UPDATE announcement_recipients
+SET notified_at = NOW()
+WHERE notified_at IS NULL;
+
+UPDATE announcement_recipients
+SET emailed_at = NOW()
+FROM announcements
+WHERE announcement_recipients.announcement_id = announcements.announcement_id
+AND announcement_recipients.emailed_at IS NULL
+AND (announcements.delivered_at IS NOT NULL OR NOT announcements.send_email);
@@ -0,0 +1,73 @@
+-- job search and its facets exclude the jobs the student is not eligible for, and share the matching of jobs
+-- through job_search_matches
+
+-- the open jobs matching the text of a job search, except the given ones, each with whether it matches
+-- the filter of every facet. Shared by the SearchJobs and SearchJobFacets queries, empty filters match all.
+CREATE OR REPLACE FUNCTION job_search_matches(
+    search_query TEXT,
+    search_locations TEXT[],
+    search_types TEXT[],
+    search_positions TEXT[],
+    search_industries TEXT[],
+    posted_after TIMESTAMPTZ,
+    salary_min DOUBLE PRECISION,
+    salary_max DOUBLE PRECISION,
+    excluded_job_ids BIGINT[]
+) RETURNS TABLE (
+    job_id BIGINT,
+    title TEXT,
+    location TEXT,
+    type TEXT,
+    salary TEXT,
+    position TEXT,
+    skills TEXT[],
+    created_at TIMESTAMP,
+    company_name TEXT,
+    industry TEXT,
+    salary_num DOUBLE PRECISION,
+    rank REAL,
+    m_location BOOLEAN,
+    m_type BOOLEAN,
+    m_position BOOLEAN,
+    m_industry BOOLEAN,
+    m_posted BOOLEAN,
+    m_salary BOOLEAN
+) AS $$
+    WITH base AS (
+        SELECT
+            jobs.job_id,
+            jobs.title,
+            jobs.location,
+            jobs.type,
+            jobs.salary,
+            jobs.position,
+            jobs.skills,
+            jobs.created_at,
+            companies.company_name::TEXT AS company_name,
+            companies.industry::TEXT AS industry,
+            -- in LPA, from the structured compensation, annualised stipend for internships
+            CAST(CASE WHEN job_compensation.currency = 'INR'
+                 THEN COALESCE(NULLIF(job_compensation.ctc_min, 0), NULLIF(job_compensation.stipend_monthly, 0) * 12) / 100000.0
+            END AS DOUBLE PRECISION) AS salary_num,
+            CAST(CASE WHEN search_query = '' THEN 0
+                 ELSE ts_rank(jobs.search_vector, websearch_to_tsquery('english', search_query))
+            END AS REAL) AS rank,
+            (cardinality(search_locations) = 0 OR jobs.location = ANY(search_locations)) AS m_location,
+            (cardinality(search_types) = 0 OR jobs.type = ANY(search_types)) AS m_type,
+            (cardinality(search_positions) = 0 OR jobs.position = ANY(search_positions)) AS m_position,
+            (cardinality(search_industries) = 0 OR companies.industry = ANY(search_industries)) AS m_industry,
+            (posted_after IS NULL OR jobs.created_at >= posted_after) AS m_posted
+        FROM jobs
+        JOIN companies ON jobs.company_id = companies.company_id
+        LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+        WHERE jobs.active_status = true
+        AND jobs.approval_status = 'approved'
+        AND jobs.job_id != ALL(excluded_job_ids)
+        AND (search_query = '' OR jobs.search_vector @@ websearch_to_tsquery('english', search_query))
+    )
+    SELECT
+        base.*,
+        (salary_min IS NULL OR base.salary_num >= salary_min)
+        AND (salary_max IS NULL OR base.salary_num <= salary_max) AS m_salary
+    FROM base
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,7 @@
+-- one-time bonuses (joining, sign-on, relocation) were parsed from salaries as variable pay. The parsed compensation
+-- of such salaries is removed, it is parsed again from the salary by the compensation backfill on the next start.
+DELETE FROM job_compensation
+USING jobs
+WHERE job_compensation.job_id = jobs.job_id
+AND job_compensation.parsed
+AND jobs.salary ~* '(joining|sign(ing)?[- ]?on|signing|relocation|one[- ]?time)';
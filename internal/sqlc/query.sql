@@ -54,7 +54,7 @@ ic AS (
     SELECT COUNT(interviews.interview_id) AS interviews_count
     FROM interviews
     WHERE interviews.company_id = (SELECT company_id FROM company)
-    AND interviews.status = 'Scheduled'
+    AND interviews.status IN ('Scheduled', 'Reschedule Requested')
 )
 SELECT * 
 FROM company
@@ -82,19 +82,65 @@ FROM st
 CROSS JOIN ac;
 
 
--- name: InsertNotifications :exec
-INSERT INTO notifications (user_id, title, description, timestamp)
-VALUES($1, $2, $3, $4);
+-- name: InsertNotifications :one
+INSERT INTO notifications (user_id, title, description, timestamp, category, ref_type, ref_id)
+VALUES($1, $2, $3, $4, $5, $6, $7)
+RETURNING notif_id;
+
+-- name: UnreadNotificationsCount :one
+SELECT
+    COUNT(notifications.notif_id) AS unread_count
+FROM notifications
+WHERE notifications.user_id = $1
+AND notifications.read_status = false;
 
 
 -- name: GetNotifications :many
 SELECT
-    *
+    notif_id, user_id, title, description, read_status, timestamp, category, archived, ref_type, ref_id
 FROM notifications
-WHERE user_id = $1
+WHERE user_id = @user_id
+AND archived = @archived
+AND (sqlc.narg('category')::TEXT IS NULL OR category = sqlc.narg('category'))
+AND (sqlc.narg('from_ts')::BIGINT IS NULL OR timestamp >= sqlc.narg('from_ts'))
+AND (sqlc.narg('to_ts')::BIGINT IS NULL OR timestamp <= sqlc.narg('to_ts'))
 ORDER BY timestamp DESC
-LIMIT $2
-OFFSET $3;
+LIMIT @page_limit
+OFFSET @page_offset;
+
+-- name: MarkNotificationsRead :execrows
+UPDATE notifications
+SET read_status = true
+WHERE user_id = @user_id
+AND notif_id = ANY(@notif_ids::BIGINT[]);
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_status = true
+WHERE user_id = $1
+AND read_status = false;
+
+-- name: ArchiveNotifications :execrows
+UPDATE notifications
+SET archived = @archived
+WHERE user_id = @user_id
+AND notif_id = ANY(@notif_ids::BIGINT[]);
+
+-- name: DeleteNotifications :execrows
+DELETE FROM notifications
+WHERE user_id = @user_id
+AND notif_id = ANY(@notif_ids::BIGINT[]);
+
+-- the latest notifications of the user from since, and before before_id if given, newest first
+-- name: MissedNotifications :many
+SELECT
+    notif_id, title, description, timestamp, category, ref_type, ref_id
+FROM notifications
+WHERE user_id = @user_id
+AND timestamp >= @since
+AND (sqlc.narg('before_id')::BIGINT IS NULL OR notif_id < sqlc.narg('before_id'))
+ORDER BY notif_id DESC
+LIMIT @page_limit;
 
 
 
@@ -104,11 +150,12 @@ OFFSET $3;
 -- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 -- Company queries 
 
--- name: InsertNewJob :exec
-INSERT INTO jobs (data_url, company_id, title, location, type, salary, skills, position, extras, description)
-VALUES ($1, (SELECT company_id FROM companies WHERE companies.user_id = $2), $3, $4, $5, $6, $7, $8, $9, $10);
+-- name: InsertNewJob :one
+INSERT INTO jobs (data_url, company_id, title, location, type, salary, skills, position, extras, description, deadline, max_applicants, questions)
+VALUES ($1, (SELECT company_id FROM companies WHERE companies.user_id = $2), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING job_id;
 
--- name: UpdateJob :exec
+-- name: UpdateJob :one
 UPDATE jobs
 SET location = $1,
     title = $2,
@@ -117,9 +164,65 @@ SET location = $1,
     salary = $5,
     skills = $6,
     position = $7,
-    extras = $8
+    extras = $8,
+    deadline = $12,
+    max_applicants = $13,
+    questions = $14,
+    -- a moved deadline is reminded of again
+    deadline_reminder_sent = deadline_reminder_sent AND deadline IS NOT DISTINCT FROM $12,
+    -- a job closed once its deadline passed opens again when the deadline is moved to the future or removed
+    active_status = active_status OR COALESCE(deadline <= NOW() AND ($12 IS NULL OR $12 > NOW()), false),
+    revision = revision + 1,
+    updated_at = NOW()
 WHERE job_id = $9
-AND company_id = (SELECT company_id FROM companies WHERE companies.user_id = $10);
+AND company_id = (SELECT company_id FROM companies WHERE companies.user_id = $10)
+AND revision = $11
+RETURNING revision;
+
+-- name: GetJobForUpdate :one
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.description,
+    jobs.type,
+    jobs.salary,
+    jobs.skills,
+    jobs.position,
+    jobs.extras,
+    jobs.revision,
+    jobs.deadline,
+    jobs.max_applicants,
+    jobs.questions,
+    jobs.approval_status
+FROM jobs
+WHERE jobs.job_id = $1
+AND jobs.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2);
+
+-- name: InsertJobRevision :exec
+INSERT INTO job_revisions (job_id, revision, changed_by, changed_fields, changes, material)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: JobRevisions :many
+SELECT
+    job_revisions.revision,
+    job_revisions.changed_fields,
+    job_revisions.changes,
+    job_revisions.material,
+    job_revisions.created_at
+FROM job_revisions
+JOIN jobs ON job_revisions.job_id = jobs.job_id
+WHERE job_revisions.job_id = $1
+AND jobs.company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2)
+ORDER BY job_revisions.revision DESC;
+
+-- name: JobApplicantsUserIDs :many
+SELECT
+    students.user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+WHERE applications.job_id = $1
+AND applications.status NOT IN ('Rejected', 'Withdrawn');
 
 
 
@@ -138,9 +241,50 @@ RETURNING *;
 
 
 
--- name: InsertNewApplication :exec
-INSERT INTO applications (job_id, student_id, data_url) 
-VALUES ($1, (SELECT student_id FROM students WHERE user_id = $2), $3);
+-- applications to a job are inserted with the job locked, so concurrent ones cannot exceed its maximum applicants
+-- name: LockJobApplications :exec
+SELECT jobs.job_id
+FROM jobs
+WHERE jobs.job_id = $1
+FOR UPDATE;
+
+-- name: InsertNewApplication :execrows
+WITH ins AS (
+    INSERT INTO applications (job_id, student_id, data_url, job_revision, answers) 
+    SELECT jobs.job_id, (SELECT student_id FROM students WHERE user_id = $2), $3, jobs.revision, $4
+    FROM jobs
+    WHERE jobs.job_id = $1
+    AND jobs.active_status
+    AND jobs.approval_status = 'approved'
+    AND (jobs.deadline IS NULL OR jobs.deadline > NOW())
+    AND (jobs.max_applicants IS NULL OR jobs.max_applicants > (SELECT COUNT(*) FROM applications WHERE applications.job_id = $1 AND applications.status != 'Withdrawn'))
+    RETURNING application_id
+)
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role)
+SELECT ins.application_id, NULL, 'Applied', $2, 'student'
+FROM ins;
+
+-- name: JobQuestions :one
+SELECT jobs.questions FROM jobs WHERE jobs.job_id = $1;
+
+-- name: ApplicationAnswers :one
+SELECT
+    jobs.questions,
+    applications.answers
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+WHERE applications.application_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2);
+
+-- name: JobApplicationWindow :one
+SELECT
+    jobs.active_status,
+    jobs.approval_status,
+    jobs.deadline,
+    jobs.max_applicants,
+    (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn') AS applicants
+FROM jobs
+WHERE jobs.job_id = $1;
 
 
 -- name: GetApplicableJobsTypeFilter :many
@@ -154,12 +298,36 @@ SELECT
     jobs.skills,
     jobs.company_id,
     jobs.active_status,
-    companies.company_name 
+    jobs.deadline,
+    jobs.questions,
+    companies.company_name,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = jobs.job_id
+        AND eligibility_overrides.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+    ) AS overridden,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max,
+    job_compensation.fixed_pay,
+    job_compensation.variable_pay,
+    job_compensation.stipend_monthly,
+    job_compensation.bond_months,
+    job_compensation.bond_terms
 FROM jobs
 JOIN companies ON jobs.company_id = companies.company_id 
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
 LEFT JOIN (SELECT applications.job_id FROM applications WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)) AS t 
 ON jobs.job_id = t.job_id
 WHERE t.job_id IS NULL 
+AND jobs.approval_status = 'approved'
 AND (jobs.type = $2 OR $2 = 'All');
 
 
@@ -178,7 +346,14 @@ SELECT
     companies.company_name,
     companies.representative_email,
     companies.representative_name,
-    applications.status::TEXT AS status
+    applications.status::TEXT AS status,
+    (jobs.revision > applications.job_revision) AS changed_since_applied,
+    CAST(ARRAY(
+        SELECT DISTINCT UNNEST(job_revisions.changed_fields)
+        FROM job_revisions
+        WHERE job_revisions.job_id = jobs.job_id
+        AND job_revisions.revision > applications.job_revision
+    ) AS TEXT[]) AS changed_fields
 FROM applications
 JOIN students ON applications.student_id = students.student_id
 JOIN jobs ON applications.job_id = jobs.job_id
@@ -189,10 +364,13 @@ ORDER BY jobs.job_id;
 
 
 
--- name: CancelApplication :exec
-DELETE FROM applications 
-WHERE student_id = (SELECT student_id FROM students WHERE students.user_id = $1) 
-AND job_id = $2;
+-- name: StudentJobApplication :one
+SELECT applications.application_id, applications.status::TEXT AS status
+FROM applications
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND applications.job_id = $2
+ORDER BY applications.application_id DESC
+LIMIT 1;
 
 
 
@@ -211,11 +389,14 @@ SELECT
     jobs.title, 
     applications.status::TEXT AS status,
     COALESCE(interviews.status::TEXT, '') AS interview_status,
-    applications.application_id
+    applications.application_id,
+    jobs.questions,
+    applications.answers
 FROM applications
 JOIN jobs ON applications.job_id = jobs.job_id
 JOIN students ON applications.student_id = students.student_id
 LEFT JOIN interviews ON applications.application_id = interviews.application_id
+                    AND interviews.status != 'Cancelled'
 WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
 AND (jobs.job_id = $2 OR $2 = 0)
 AND (applications.application_id = $3 OR $3 = 0)
@@ -262,6 +443,7 @@ SELECT
     jobs.skills,
     jobs.position,
     jobs.active_status,
+    jobs.approval_status,
     COALESCE(t.no_of_applications, 0),
     jobs.description,
     jobs.extras    
@@ -303,43 +485,52 @@ JOIN jobs ON jobs.company_id = companies.company_id
 JOIN applications ON applications.job_id = jobs.job_id
 WHERE applications.application_id = $1;
 
--- name: ApplicationStatusToAnd :one
-WITH upd AS (
-    UPDATE applications
-    SET status = $1
-    WHERE application_id = $2 AND status = $3
-    RETURNING student_id
-)
-SELECT
-    students.user_id
-FROM students 
-JOIN upd ON students.student_id = upd.student_id;
-
--- name: ApplicationStatusTo :one
-WITH upd AS (
-    UPDATE applications
-    SET status = $1
-    WHERE application_id = $2
-    RETURNING student_id
-)
-SELECT
-    students.user_id
-FROM students 
-JOIN upd ON students.student_id = upd.student_id;
-
-
+-- a pending reschedule proposal of the interview is cancelled along with the change, cancelled interviews stay so
 -- name: InterviewStatusTo :exec
+WITH closed AS (
+    UPDATE interview_reschedule_proposals
+    SET status = 'Cancelled', responded_at = NOW()
+    WHERE interview_reschedule_proposals.status = 'Pending'
+    AND interview_id IN (SELECT interview_id FROM interviews WHERE application_id = $2)
+)
 UPDATE interviews
 SET status = $1
-WHERE application_id = $2;
+WHERE application_id = $2
+AND status != 'Cancelled';
+
+-- the cancelled interviews are returned with their next iCalendar sequence, for the CANCEL of their event,
+-- with upcoming_only those that already took place are left to be completed
+-- name: CancelScheduledInterview :many
+WITH cancelled AS (
+    SELECT interviews.interview_id
+    FROM interviews
+    WHERE interviews.application_id = @application_id
+    AND interviews.status IN ('Scheduled', 'Reschedule Requested')
+    AND (NOT @upcoming_only::BOOLEAN OR interviews.date_time > NOW())
+),
+closed AS (
+    UPDATE interview_reschedule_proposals
+    SET status = 'Cancelled', responded_at = NOW()
+    WHERE interview_reschedule_proposals.status = 'Pending'
+    AND interview_id IN (SELECT interview_id FROM cancelled)
+)
+UPDATE interviews
+SET status = 'Cancelled',
+    ical_sequence = ical_sequence + 1
+WHERE interview_id IN (SELECT interview_id FROM cancelled)
+RETURNING
+    interviews.interview_id,
+    interviews.ical_sequence,
+    interviews.date_time,
+    interviews.duration_minutes;
 
 
 
 
 -- name: ScheduleInterview :one
-INSERT INTO interviews (application_id, company_id, date_time, type, notes, location)
-VALUES ($1, (SELECT company_id FROM companies WHERE user_id = $2), $3, $4, $5, $6)
-RETURNING TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time;
+INSERT INTO interviews (application_id, company_id, date_time, type, notes, location, duration_minutes, panel)
+VALUES ($1, (SELECT company_id FROM companies WHERE user_id = $2), $3, $4, $5, $6, $7, $8)
+RETURNING interview_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time;
 
 
 -- name: GetScheduleInterviewData :one
@@ -368,30 +559,17 @@ JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
 JOIN (SELECT company_id, company_name, representative_contact, representative_email FROM companies) AS c ON j.company_id = c.company_id;
 
 
--- name: DeleteInterview :exec
-DELETE FROM interviews
-WHERE application_id = $1;
-
-
+-- the interviews cancelled are returned by CancelScheduledInterview
 -- name: CancelInterviewEmailData :one
 SELECT 
     students.student_name, 
     students.student_email,
     j.title,
     c.company_name,
-    TO_CHAR(t.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
     c.representative_name,
     c.representative_email
 FROM students
-JOIN (
-    SELECT 
-        job_id, 
-        student_id, 
-        interviews.date_time
-    FROM applications 
-    JOIN interviews ON interviews.application_id = applications.application_id 
-    WHERE applications.application_id = $1) AS t 
-ON t.student_id = students.student_id
+JOIN (SELECT job_id, student_id FROM applications WHERE application_id = $1) AS t ON t.student_id = students.student_id
 JOIN (SELECT job_id, title, company_id FROM jobs) AS j ON j.job_id = t.job_id
 JOIN (SELECT company_id, company_name, representative_name, representative_email FROM companies) AS c ON j.company_id = c.company_id;
 
@@ -404,10 +582,11 @@ SELECT
     TO_CHAR(interviews.date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time,
     interviews.type::TEXT,
     interviews.location,
-    interviews.notes
+    interviews.notes,
+    interviews.status::TEXT
 FROM applications
 JOIN interviews ON applications.application_id = interviews.application_id 
-                AND interviews.status != 'Completed'
+                AND interviews.status NOT IN ('Completed', 'Cancelled')
 JOIN jobs ON applications.job_id = jobs.job_id
 JOIN companies ON jobs.company_id = companies.company_id
 WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
@@ -451,7 +630,7 @@ FROM interviews
 JOIN applications ON interviews.application_id = applications.application_id
 JOIN students ON applications.student_id = students.student_id
 WHERE interviews.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
-AND interviews.status = 'Scheduled' AND interviews.date_time > NOW()
+AND interviews.status IN ('Scheduled', 'Reschedule Requested') AND interviews.date_time > NOW()
 ORDER BY interviews.date_time;
 
 -- name: ScheduledTestsCompany :many
@@ -563,9 +742,10 @@ WHERE applications.student_id = (SELECT student_id FROM students WHERE students.
 AND tests.test_id = $2;
 
 
--- name: NewTest :exec
+-- name: NewTest :one
 INSERT INTO tests (test_name, description, duration, q_count, end_time, type, upload_method, job_id, company_id, file_id, threshold)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT company_id FROM companies WHERE user_id = $9), $10, $11);
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT company_id FROM companies WHERE user_id = $9), $10, $11)
+RETURNING test_id;
 
 
 -- name: TakeTest :one
@@ -679,7 +859,8 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'ShortListed' THEN 1 END), 0) AS BIGINT) AS shortlisted_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Rejected' THEN 1 END), 0) AS BIGINT) AS rejected_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Offered' THEN 1 END), 0) AS BIGINT) AS offered_count,
-    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count,
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Withdrawn' THEN 1 END), 0) AS BIGINT) AS withdrawn_count
 FROM applications
 WHERE applications.student_id = (SELECT students.student_id FROM students WHERE students.user_id = $1);
 
@@ -704,7 +885,8 @@ SELECT
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'ShortListed' THEN 1 END), 0) AS BIGINT) AS shortlisted_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Rejected' THEN 1 END), 0) AS BIGINT) AS rejected_count,
     CAST(COALESCE(SUM(CASE WHEN applications.status = 'Offered' THEN 1 END), 0) AS BIGINT) AS offered_count,
-    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Hired' THEN 1 END), 0) AS BIGINT) AS hired_count,
+    CAST(COALESCE(SUM(CASE WHEN applications.status = 'Withdrawn' THEN 1 END), 0) AS BIGINT) AS withdrawn_count
 FROM applications
 JOIN ji ON ji.job_id = applications.job_id
 GROUP BY applications.job_id;
@@ -804,10 +986,13 @@ SET
     date_time = $3,
     type = $4,
     notes = $5,
-    location = $6
+    location = $6,
+    duration_minutes = $7,
+    panel = $8,
+    ical_sequence = ical_sequence + 1
 WHERE company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
 AND interview_id = $2
-RETURNING application_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time;
+RETURNING application_id, TO_CHAR(date_time, 'HH12:MI AM DD-MM-YYYY') AS date_time, ical_sequence;
 
 
 
@@ -1203,3 +1388,1482 @@ WHERE students.user_id = $1;
 
 
 
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Digest queries --------------------------------
+
+-- name: DigestRecipients :many
+SELECT
+    users.user_id,
+    users.email,
+    users.role,
+    CAST(COALESCE(digest_preferences.frequency, 'daily') AS TEXT) AS frequency,
+    CAST(COALESCE(digest_preferences.send_hour, 8) AS INTEGER) AS send_hour,
+    CAST(COALESCE(digest_preferences.weekday, 1) AS INTEGER) AS weekday,
+    CAST(COALESCE(digest_preferences.timezone, 'Asia/Kolkata') AS TEXT) AS timezone,
+    digest_preferences.last_sent_at
+FROM users
+LEFT JOIN digest_preferences ON users.user_id = digest_preferences.user_id
+WHERE users.confirmed = true
+AND users.role IN (1, 2)
+AND COALESCE(digest_preferences.frequency, 'daily') != 'off';
+
+-- name: GetDigestPreferences :one
+SELECT
+    CAST(COALESCE(digest_preferences.frequency, 'daily') AS TEXT) AS frequency,
+    CAST(COALESCE(digest_preferences.send_hour, 8) AS INTEGER) AS send_hour,
+    CAST(COALESCE(digest_preferences.weekday, 1) AS INTEGER) AS weekday,
+    CAST(COALESCE(digest_preferences.timezone, 'Asia/Kolkata') AS TEXT) AS timezone
+FROM users
+LEFT JOIN digest_preferences ON users.user_id = digest_preferences.user_id
+WHERE users.user_id = $1;
+
+-- name: UpsertDigestPreferences :exec
+INSERT INTO digest_preferences (user_id, frequency, send_hour, weekday, timezone)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id)
+DO UPDATE SET frequency = $2, send_hour = $3, weekday = $4, timezone = $5;
+
+-- name: MarkDigestSent :exec
+INSERT INTO digest_preferences (user_id, last_sent_at)
+VALUES ($1, $2)
+ON CONFLICT (user_id)
+DO UPDATE SET last_sent_at = $2;
+
+-- name: NewApplicantsPerJob :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    COUNT(applications.application_id) AS new_applicants
+FROM jobs
+JOIN applications ON applications.job_id = jobs.job_id
+WHERE jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $1)
+AND applications.created_at >= $2
+GROUP BY jobs.job_id, jobs.title
+ORDER BY new_applicants DESC;
+
+-- name: UnreadNotificationsSince :many
+SELECT
+    notifications.title,
+    notifications.description,
+    notifications.timestamp
+FROM notifications
+WHERE notifications.user_id = $1
+AND notifications.read_status = false
+AND notifications.timestamp >= $2
+ORDER BY notifications.timestamp DESC
+LIMIT $3;
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Calendar feed queries --------------------------------
+
+-- name: GetCalendarFeedToken :one
+SELECT
+    calendar_feeds.token
+FROM calendar_feeds
+WHERE calendar_feeds.user_id = $1;
+
+-- name: UpsertCalendarFeedToken :one
+INSERT INTO calendar_feeds (user_id, token)
+VALUES ($1, $2)
+ON CONFLICT (user_id)
+DO UPDATE SET token = $2, created_at = NOW()
+RETURNING token;
+
+-- name: CalendarFeedUser :one
+SELECT
+    users.user_id,
+    users.role
+FROM calendar_feeds
+JOIN users ON calendar_feeds.user_id = users.user_id
+WHERE calendar_feeds.token = $1;
+
+-- name: CalendarFeedInterviewsStudent :many
+SELECT
+    interviews.interview_id,
+    interviews.date_time,
+    interviews.ical_sequence,
+    interviews.type::TEXT,
+    interviews.location,
+    interviews.notes,
+    interviews.duration_minutes,
+    companies.company_name,
+    jobs.title
+FROM applications
+JOIN interviews ON applications.application_id = interviews.application_id
+                AND interviews.status NOT IN ('Completed', 'Cancelled')
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND interviews.date_time > NOW()
+ORDER BY interviews.date_time;
+
+-- name: CalendarFeedTestsStudent :many
+SELECT
+    tests.test_id,
+    tests.test_name,
+    tests.duration,
+    tests.end_time,
+    companies.company_name,
+    jobs.title
+FROM applications
+JOIN tests ON applications.job_id = tests.job_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+AND NOT EXISTS (SELECT 1 FROM testresults WHERE testresults.test_id = tests.test_id AND testresults.user_id = $1)
+AND tests.end_time > NOW()
+ORDER BY tests.end_time;
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Push notification queries --------------------------------
+
+-- name: RegisterDeviceToken :exec
+INSERT INTO device_tokens (token, user_id, platform)
+VALUES ($1, $2, $3)
+ON CONFLICT (token)
+DO UPDATE SET user_id = $2, platform = $3, last_seen_at = NOW();
+
+-- name: UnregisterDeviceToken :execrows
+DELETE FROM device_tokens
+WHERE token = $1
+AND user_id = $2;
+
+-- name: GetDeviceTokens :many
+SELECT
+    device_tokens.token
+FROM device_tokens
+WHERE device_tokens.user_id = $1;
+
+-- name: DeleteDeviceTokens :exec
+DELETE FROM device_tokens
+WHERE token = ANY(@tokens::TEXT[]);
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Announcement queries --------------------------------
+
+-- name: InsertAnnouncement :one
+INSERT INTO announcements (author_id, title, message, target_role, department, course, year_of_study, job_id, send_email, scheduled_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING announcement_id;
+
+-- name: DueAnnouncements :many
+SELECT
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.send_email
+FROM announcements
+WHERE announcements.delivered_at IS NULL
+AND announcements.scheduled_at <= NOW()
+ORDER BY announcements.scheduled_at;
+
+-- name: InsertAnnouncementRecipients :exec
+INSERT INTO announcement_recipients (announcement_id, user_id)
+SELECT
+    announcements.announcement_id,
+    users.user_id
+FROM announcements
+JOIN users ON announcements.target_role IS NULL OR users.role = announcements.target_role
+LEFT JOIN students ON students.user_id = users.user_id
+WHERE announcements.announcement_id = $1
+AND users.role != 5
+AND (announcements.department IS NULL OR students.department = announcements.department)
+AND (announcements.course IS NULL OR students.course = announcements.course)
+AND (announcements.year_of_study IS NULL OR students.year_of_study = announcements.year_of_study)
+AND (announcements.job_id IS NULL OR EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = announcements.job_id
+    AND applications.student_id = students.student_id
+))
+ON CONFLICT (announcement_id, user_id) DO NOTHING;
+
+-- name: UnnotifiedAnnouncementRecipients :many
+SELECT
+    announcement_recipients.user_id
+FROM announcement_recipients
+WHERE announcement_recipients.announcement_id = $1
+AND announcement_recipients.notified_at IS NULL;
+
+-- name: MarkRecipientsNotified :exec
+UPDATE announcement_recipients
+SET notified_at = NOW()
+WHERE announcement_id = @announcement_id
+AND user_id = ANY(@user_ids::BIGINT[]);
+
+-- name: UnemailedAnnouncementRecipients :many
+SELECT
+    announcement_recipients.user_id,
+    users.email
+FROM announcement_recipients
+JOIN users ON announcement_recipients.user_id = users.user_id
+WHERE announcement_recipients.announcement_id = $1
+AND announcement_recipients.emailed_at IS NULL;
+
+-- name: MarkRecipientsEmailed :exec
+UPDATE announcement_recipients
+SET emailed_at = NOW()
+WHERE announcement_id = @announcement_id
+AND user_id = ANY(@user_ids::BIGINT[]);
+
+-- name: MarkAnnouncementDelivered :exec
+UPDATE announcements
+SET delivered_at = NOW()
+WHERE announcement_id = $1;
+
+-- name: ListAnnouncements :many
+SELECT
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.target_role,
+    announcements.department,
+    announcements.course,
+    announcements.year_of_study,
+    announcements.job_id,
+    announcements.send_email,
+    announcements.scheduled_at,
+    announcements.delivered_at,
+    announcements.created_at,
+    COUNT(announcement_recipients.user_id) AS recipients,
+    COUNT(announcement_recipients.read_at) AS read_count
+FROM announcements
+LEFT JOIN announcement_recipients ON announcements.announcement_id = announcement_recipients.announcement_id
+GROUP BY announcements.announcement_id
+ORDER BY announcements.scheduled_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: NoticeBoard :many
+SELECT
+    announcements.announcement_id,
+    announcements.title,
+    announcements.message,
+    announcements.delivered_at,
+    announcement_recipients.read_at
+FROM announcement_recipients
+JOIN announcements ON announcement_recipients.announcement_id = announcements.announcement_id
+WHERE announcement_recipients.user_id = $1
+ORDER BY announcements.delivered_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: MarkAnnouncementRead :execrows
+UPDATE announcement_recipients
+SET read_at = COALESCE(read_at, NOW())
+WHERE announcement_id = $1
+AND user_id = $2;
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Eligibility queries --------------------------------
+
+-- name: GetJobEligibility :one
+SELECT
+    job_eligibility.job_id,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    job_eligibility.updated_at
+FROM job_eligibility
+WHERE job_eligibility.job_id = $1;
+
+-- name: UpsertJobEligibility :exec
+INSERT INTO job_eligibility (job_id, min_cgpa, courses, departments, years_of_study, max_backlogs, custom)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (job_id)
+DO UPDATE SET min_cgpa = $2, courses = $3, departments = $4, years_of_study = $5, max_backlogs = $6, custom = $7, updated_at = NOW();
+
+-- name: BumpJobRevision :one
+UPDATE jobs
+SET revision = revision + 1,
+    updated_at = NOW()
+WHERE job_id = $1
+AND company_id = (SELECT company_id FROM companies WHERE companies.user_id = $2)
+RETURNING revision;
+
+-- name: StudentEligibilityProfile :one
+SELECT
+    students.student_id,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = @job_id
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
+FROM students
+WHERE students.user_id = @user_id;
+
+-- name: StudentsEligibilityProfiles :many
+SELECT
+    students.student_id,
+    students.student_name,
+    students.roll_number,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = $1
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
+FROM students
+ORDER BY students.roll_number;
+
+-- name: UpsertEligibilityOverride :exec
+INSERT INTO eligibility_overrides (job_id, student_id, granted_by, reason)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (job_id, student_id)
+DO UPDATE SET granted_by = $3, reason = $4, created_at = NOW();
+
+-- name: DeleteEligibilityOverride :execrows
+DELETE FROM eligibility_overrides
+WHERE job_id = $1
+AND student_id = $2;
+
+-- name: UpdateStudentBacklogs :execrows
+UPDATE students
+SET backlogs = $1
+WHERE student_id = $2;
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Audit log queries --------------------------------
+
+-- name: InsertAuditLog :exec
+INSERT INTO audit_log (actor_id, action, entity_type, entity_id, details)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: AuditLogForEntity :many
+SELECT
+    audit_log.audit_id,
+    audit_log.actor_id,
+    audit_log.action,
+    audit_log.details,
+    audit_log.created_at
+FROM audit_log
+WHERE audit_log.entity_type = $1
+AND audit_log.entity_id = $2
+ORDER BY audit_log.created_at DESC;
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Job search queries --------------------------------
+
+-- name: SearchJobs :many
+WITH keyed AS (
+    SELECT
+        matches.*,
+        CAST(CASE @sort::TEXT
+            WHEN 'newest' THEN EXTRACT(EPOCH FROM matches.created_at)
+            WHEN 'salary' THEN COALESCE(matches.salary_num, -1)
+            ELSE matches.rank
+        END AS DOUBLE PRECISION) AS sort_key
+    FROM job_search_matches(@query::TEXT, @locations::TEXT[], @types::TEXT[], @positions::TEXT[], @industries::TEXT[],
+        sqlc.narg('posted_after')::TIMESTAMPTZ, sqlc.narg('salary_min')::DOUBLE PRECISION, sqlc.narg('salary_max')::DOUBLE PRECISION,
+        @ineligible_job_ids::BIGINT[]) AS matches
+    WHERE matches.m_location AND matches.m_type AND matches.m_position
+    AND matches.m_industry AND matches.m_posted AND matches.m_salary
+)
+SELECT
+    keyed.job_id,
+    keyed.title,
+    keyed.location,
+    keyed.type,
+    keyed.salary,
+    keyed.position,
+    keyed.skills,
+    keyed.created_at,
+    keyed.company_name,
+    keyed.industry,
+    keyed.sort_key,
+    EXISTS (
+        SELECT 1 FROM applications
+        WHERE applications.job_id = keyed.job_id
+        AND applications.student_id = (SELECT student_id FROM students WHERE students.user_id = @user_id)
+    ) AS applied
+FROM keyed
+WHERE sqlc.narg('cursor_key')::DOUBLE PRECISION IS NULL
+OR (keyed.sort_key, keyed.job_id) < (sqlc.narg('cursor_key')::DOUBLE PRECISION, sqlc.narg('cursor_id')::BIGINT)
+ORDER BY keyed.sort_key DESC, keyed.job_id DESC
+LIMIT @page_limit;
+
+-- name: SearchJobFacets :many
+WITH matches AS (
+    SELECT * FROM job_search_matches(@query::TEXT, @locations::TEXT[], @types::TEXT[], @positions::TEXT[], @industries::TEXT[],
+        sqlc.narg('posted_after')::TIMESTAMPTZ, sqlc.narg('salary_min')::DOUBLE PRECISION, sqlc.narg('salary_max')::DOUBLE PRECISION,
+        @ineligible_job_ids::BIGINT[])
+)
+SELECT 'location' AS facet, matches.location AS value, COUNT(*) AS count
+FROM matches
+WHERE matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.location
+UNION ALL
+SELECT 'type', matches.type, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_position AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.type
+UNION ALL
+SELECT 'position', matches.position, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_type AND matches.m_industry AND matches.m_posted AND matches.m_salary
+GROUP BY matches.position
+UNION ALL
+SELECT 'industry', matches.industry, COUNT(*)
+FROM matches
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_posted AND matches.m_salary
+GROUP BY matches.industry
+UNION ALL
+SELECT 'salary', buckets.bucket, COUNT(*)
+FROM matches
+CROSS JOIN LATERAL (SELECT CASE
+    WHEN matches.salary_num IS NULL THEN 'unspecified'
+    WHEN matches.salary_num < 3 THEN '0-3'
+    WHEN matches.salary_num < 6 THEN '3-6'
+    WHEN matches.salary_num < 10 THEN '6-10'
+    WHEN matches.salary_num < 20 THEN '10-20'
+    ELSE '20+'
+END AS bucket) AS buckets
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_posted
+GROUP BY buckets.bucket
+UNION ALL
+-- posted windows overlap, like the filter
+SELECT 'posted', windows.name, COUNT(*)
+FROM matches
+JOIN (VALUES ('24h', INTERVAL '1 day'), ('7d', INTERVAL '7 days'), ('30d', INTERVAL '30 days')) AS windows (name, span)
+ON matches.created_at >= NOW() - windows.span
+WHERE matches.m_location AND matches.m_type AND matches.m_position AND matches.m_industry AND matches.m_salary
+GROUP BY windows.name
+ORDER BY facet, count DESC;
+
+-- the eligibility rules of the open jobs, for the job search to leave out the ones the student is not eligible for
+-- name: OpenJobsEligibility :many
+SELECT
+    job_eligibility.job_id,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = job_eligibility.job_id
+        AND eligibility_overrides.student_id = (SELECT student_id FROM students WHERE students.user_id = $1)
+    ) AS overridden
+FROM job_eligibility
+JOIN jobs ON job_eligibility.job_id = jobs.job_id
+WHERE jobs.active_status = true
+AND jobs.approval_status = 'approved';
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Deadline queries --------------------------------
+
+-- jobs never approved were not live, they are closed once approved
+-- name: CloseDueJobs :many
+UPDATE jobs
+SET active_status = false
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND (jobs.deadline <= NOW()
+    OR jobs.max_applicants <= (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn'))
+RETURNING
+    jobs.job_id,
+    jobs.title,
+    jobs.deadline,
+    jobs.max_applicants,
+    (SELECT companies.user_id FROM companies WHERE companies.company_id = jobs.company_id) AS company_user_id,
+    (SELECT COUNT(*) FROM applications WHERE applications.job_id = jobs.job_id AND applications.status != 'Withdrawn') AS applicants;
+
+-- name: ClaimDeadlineReminders :many
+UPDATE jobs
+SET deadline_reminder_sent = true
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND NOT jobs.deadline_reminder_sent
+AND jobs.deadline > NOW()
+AND jobs.deadline <= @remind_until
+RETURNING
+    jobs.job_id,
+    jobs.title,
+    jobs.deadline,
+    (SELECT companies.company_name FROM companies WHERE companies.company_id = jobs.company_id) AS company_name;
+
+-- name: DeadlineReminderCandidates :many
+SELECT
+    students.user_id,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = $1
+        AND eligibility_overrides.student_id = students.student_id
+    ) AS overridden
+FROM students
+WHERE NOT EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = $1
+    AND applications.student_id = students.student_id
+);
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Job approval queries --------------------------------
+
+-- name: SubmitJob :one
+UPDATE jobs
+SET approval_status = 'submitted',
+    submitted_at = NOW()
+WHERE jobs.job_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2)
+AND jobs.approval_status IN ('draft', 'rejected')
+RETURNING jobs.title;
+
+-- name: ResubmitApprovedJob :execrows
+UPDATE jobs
+SET approval_status = 'submitted',
+    submitted_at = NOW()
+WHERE jobs.job_id = $1
+AND jobs.approval_status = 'approved';
+
+-- name: ReviewJob :one
+UPDATE jobs
+SET approval_status = @decision
+WHERE jobs.job_id = @job_id
+AND jobs.approval_status = 'submitted'
+AND jobs.revision = @revision
+RETURNING
+    jobs.title,
+    (SELECT companies.user_id FROM companies WHERE companies.company_id = jobs.company_id) AS company_user_id;
+
+-- name: InsertJobReview :exec
+INSERT INTO job_reviews (job_id, revision, reviewer_id, decision, comment)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: JobReviewQueue :many
+SELECT
+    jobs.job_id,
+    jobs.revision,
+    jobs.title,
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.description,
+    jobs.deadline,
+    jobs.submitted_at,
+    companies.company_name,
+    (SELECT COUNT(*) FROM job_reviews WHERE job_reviews.job_id = jobs.job_id AND job_reviews.decision = 'rejected') AS rejections,
+    COALESCE((
+        SELECT job_reviews.comment FROM job_reviews
+        WHERE job_reviews.job_id = jobs.job_id
+        ORDER BY job_reviews.review_id DESC
+        LIMIT 1
+    ), '')::TEXT AS last_comment
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE jobs.approval_status = 'submitted'
+ORDER BY jobs.submitted_at, jobs.job_id;
+
+-- name: JobReviews :many
+SELECT
+    job_reviews.revision,
+    job_reviews.decision,
+    job_reviews.comment,
+    job_reviews.created_at
+FROM job_reviews
+JOIN jobs ON job_reviews.job_id = jobs.job_id
+WHERE job_reviews.job_id = $1
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = $2)
+ORDER BY job_reviews.review_id DESC;
+
+-- name: AdminUserIDs :many
+SELECT users.user_id FROM users WHERE users.role = 3;
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Recommendation queries --------------------------------
+
+-- name: RecommendationProfile :one
+SELECT
+    students.student_id,
+    students.skills,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.cgpa,
+    students.backlogs,
+    students.extras
+FROM students
+WHERE students.user_id = $1;
+
+-- name: ApplicationHistory :many
+SELECT
+    jobs.skills,
+    jobs.type,
+    jobs.position,
+    applications.status::TEXT AS status
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+WHERE applications.student_id = $1;
+
+-- name: RecommendableJobs :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.type,
+    jobs.salary,
+    jobs.position,
+    jobs.skills,
+    jobs.deadline,
+    companies.company_name,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom,
+    EXISTS (
+        SELECT 1 FROM eligibility_overrides
+        WHERE eligibility_overrides.job_id = jobs.job_id
+        AND eligibility_overrides.student_id = @student_id
+    ) AS overridden
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+WHERE jobs.active_status
+AND jobs.approval_status = 'approved'
+AND (jobs.deadline IS NULL OR jobs.deadline > NOW())
+AND (sqlc.narg('posted_after')::TIMESTAMPTZ IS NULL OR jobs.created_at >= sqlc.narg('posted_after')::TIMESTAMPTZ)
+AND NOT EXISTS (
+    SELECT 1 FROM applications
+    WHERE applications.job_id = jobs.job_id
+    AND applications.student_id = @student_id
+);
+
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Compensation queries --------------------------------
+
+-- name: GetJobCompensation :one
+SELECT * FROM job_compensation
+WHERE job_id = $1;
+
+-- name: UpsertJobCompensation :exec
+INSERT INTO job_compensation (job_id, currency, ctc_min, ctc_max, fixed_pay, variable_pay, stipend_monthly, bond_months, bond_terms, parsed)
+VALUES (@job_id, @currency, @ctc_min, @ctc_max, @fixed_pay, @variable_pay, @stipend_monthly, @bond_months, @bond_terms, @parsed)
+ON CONFLICT (job_id) DO UPDATE
+SET currency = EXCLUDED.currency,
+    ctc_min = EXCLUDED.ctc_min,
+    ctc_max = EXCLUDED.ctc_max,
+    fixed_pay = EXCLUDED.fixed_pay,
+    variable_pay = EXCLUDED.variable_pay,
+    stipend_monthly = EXCLUDED.stipend_monthly,
+    bond_months = EXCLUDED.bond_months,
+    bond_terms = EXCLUDED.bond_terms,
+    parsed = EXCLUDED.parsed,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: DeleteJobCompensation :exec
+DELETE FROM job_compensation
+WHERE job_id = $1;
+
+-- name: JobsWithoutCompensation :many
+SELECT jobs.job_id, jobs.type, jobs.salary
+FROM jobs
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+WHERE job_compensation.job_id IS NULL
+AND jobs.salary <> ''
+AND jobs.job_id > @after_job_id
+ORDER BY jobs.job_id
+LIMIT @batch_size;
+
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Job export queries --------------------------------
+
+-- name: ExportCompanyJobs :many
+SELECT
+    jobs.job_id,
+    jobs.title,
+    jobs.location,
+    jobs.description,
+    jobs.type,
+    jobs.salary,
+    jobs.skills,
+    jobs.position,
+    jobs.extras,
+    jobs.deadline,
+    jobs.max_applicants,
+    jobs.questions,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max,
+    job_compensation.fixed_pay,
+    job_compensation.variable_pay,
+    job_compensation.stipend_monthly,
+    job_compensation.bond_months,
+    job_compensation.bond_terms,
+    job_compensation.parsed,
+    job_eligibility.min_cgpa,
+    job_eligibility.courses,
+    job_eligibility.departments,
+    job_eligibility.years_of_study,
+    job_eligibility.max_backlogs,
+    job_eligibility.custom
+FROM jobs
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+LEFT JOIN job_eligibility ON jobs.job_id = job_eligibility.job_id
+WHERE companies.user_id = @user_id
+AND (sqlc.narg('posted_from')::TIMESTAMPTZ IS NULL OR jobs.created_at >= sqlc.narg('posted_from')::TIMESTAMPTZ)
+AND (sqlc.narg('posted_to')::TIMESTAMPTZ IS NULL OR jobs.created_at < sqlc.narg('posted_to')::TIMESTAMPTZ)
+ORDER BY jobs.job_id;
+
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Application status queries --------------------------------
+
+-- name: ApplicationParties :one
+SELECT
+    applications.status::TEXT AS status,
+    applications.job_id,
+    jobs.title,
+    students.user_id AS student_user_id,
+    companies.user_id AS company_user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = $1;
+
+-- name: LockApplicationStatus :one
+SELECT applications.status::TEXT AS status
+FROM applications
+WHERE applications.application_id = $1
+FOR UPDATE;
+
+-- name: SetApplicationStatus :exec
+UPDATE applications
+SET status = @status::TEXT::application_status
+WHERE application_id = @application_id;
+
+-- name: InsertApplicationStatusHistory :exec
+INSERT INTO application_status_history (application_id, from_status, to_status, actor_id, actor_role, reason)
+VALUES (@application_id, @from_status, @to_status, sqlc.narg('actor_id'), @actor_role, @reason);
+
+-- name: ApplicationTimeline :many
+SELECT
+    application_status_history.from_status,
+    application_status_history.to_status,
+    application_status_history.actor_role,
+    application_status_history.reason,
+    application_status_history.created_at
+FROM application_status_history
+WHERE application_status_history.application_id = $1
+ORDER BY application_status_history.created_at, application_status_history.history_id;
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Placement policy queries --------------------------------
+
+-- name: GetPlacementPolicy :one
+SELECT max_offers, block_after_acceptance, tiers, updated_by, updated_at
+FROM placement_policy
+WHERE policy_id = 1;
+
+-- name: UpdatePlacementPolicy :exec
+INSERT INTO placement_policy (policy_id, max_offers, block_after_acceptance, tiers, updated_by)
+VALUES (1, @max_offers, @block_after_acceptance, @tiers, @updated_by)
+ON CONFLICT (policy_id)
+DO UPDATE SET max_offers = @max_offers, block_after_acceptance = @block_after_acceptance, tiers = @tiers, updated_by = @updated_by, updated_at = NOW();
+
+-- the placement policy is checked with the student locked, so concurrent offers and acceptances cannot exceed it
+-- name: LockStudent :exec
+SELECT students.student_id FROM students
+WHERE students.user_id = @user_id
+FOR UPDATE;
+
+-- name: StudentPlacements :many
+SELECT
+    applications.application_id,
+    applications.status::TEXT AS status,
+    jobs.title,
+    jobs.type,
+    companies.company_name,
+    COALESCE(offers.currency, job_compensation.currency) AS currency,
+    COALESCE(offers.ctc, job_compensation.ctc_min) AS ctc_min,
+    COALESCE(offers.ctc, job_compensation.ctc_max) AS ctc_max
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+LEFT JOIN offers ON applications.application_id = offers.application_id
+WHERE students.user_id = @user_id
+AND applications.status::TEXT IN ('Offered', 'Hired', 'Declined')
+ORDER BY applications.application_id;
+
+-- name: JobPolicyFacts :one
+SELECT
+    jobs.title,
+    jobs.type,
+    job_compensation.currency,
+    job_compensation.ctc_min,
+    job_compensation.ctc_max
+FROM jobs
+LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+WHERE jobs.job_id = $1;
+
+-- name: StudentPolicyExceptions :many
+SELECT policy_exceptions.rule
+FROM policy_exceptions
+JOIN students ON policy_exceptions.student_id = students.student_id
+WHERE students.user_id = @user_id
+AND (policy_exceptions.job_id IS NULL OR policy_exceptions.job_id = @job_id);
+
+-- name: UpsertPolicyException :exec
+INSERT INTO policy_exceptions (student_id, job_id, rule, reason, granted_by)
+VALUES (@student_id, sqlc.narg('job_id'), @rule, @reason, @granted_by)
+ON CONFLICT (student_id, COALESCE(job_id, 0), rule)
+DO UPDATE SET reason = @reason, granted_by = @granted_by, created_at = NOW();
+
+-- name: DeletePolicyException :execrows
+DELETE FROM policy_exceptions
+WHERE student_id = @student_id
+AND COALESCE(job_id, 0) = COALESCE(sqlc.narg('job_id'), 0)
+AND rule = @rule;
+
+-- name: ListPolicyExceptions :many
+SELECT
+    policy_exceptions.exception_id,
+    policy_exceptions.student_id,
+    students.student_name,
+    students.roll_number,
+    policy_exceptions.job_id,
+    jobs.title,
+    policy_exceptions.rule,
+    policy_exceptions.reason,
+    policy_exceptions.granted_by,
+    policy_exceptions.created_at
+FROM policy_exceptions
+JOIN students ON policy_exceptions.student_id = students.student_id
+LEFT JOIN jobs ON policy_exceptions.job_id = jobs.job_id
+WHERE (@student_id::BIGINT = 0 OR policy_exceptions.student_id = @student_id)
+ORDER BY policy_exceptions.created_at DESC;
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Bulk applicant action queries --------------------------------
+
+-- name: BulkApplicationParties :many
+SELECT
+    applications.application_id,
+    applications.status::TEXT AS status,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_email,
+    companies.user_id AS company_user_id
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = ANY(@application_ids::BIGINT[])
+ORDER BY applications.application_id;
+
+-- name: FilterJobApplications :many
+SELECT applications.application_id
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN testresults ON testresults.test_id = @test_id AND testresults.user_id = students.user_id
+WHERE jobs.job_id = @job_id
+AND jobs.company_id = (SELECT companies.company_id FROM companies WHERE companies.user_id = @user_id)
+AND (sqlc.narg('min_score')::BIGINT IS NULL OR testresults.score >= sqlc.narg('min_score'))
+AND (sqlc.narg('below_score')::BIGINT IS NULL OR COALESCE(testresults.score, 0) < sqlc.narg('below_score'))
+AND (CARDINALITY(@statuses::TEXT[]) = 0 OR applications.status::TEXT = ANY(@statuses::TEXT[]))
+ORDER BY applications.application_id;
+
+-- name: JobTestExists :one
+SELECT EXISTS (
+    SELECT 1 FROM tests
+    WHERE tests.test_id = @test_id
+    AND tests.job_id = @job_id
+) AS exists;
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Applicant export queries --------------------------------
+
+-- name: ExportApplicants :many
+SELECT
+    applications.application_id,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.roll_number,
+    students.gender,
+    students.course,
+    students.department,
+    students.year_of_study,
+    students.student_email,
+    students.contact_no,
+    students.cgpa,
+    students.skills,
+    students.resume_url,
+    applications.status::TEXT AS status,
+    applications.created_at,
+    COALESCE(interviews.status::TEXT, '') AS interview_status,
+    interviews.date_time AS interview_at,
+    jobs.questions,
+    applications.answers
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN interviews ON applications.application_id = interviews.application_id
+                    AND interviews.status != 'Cancelled'
+WHERE (@company_user_id::BIGINT = 0 OR companies.user_id = @company_user_id)
+AND (@job_id::BIGINT = 0 OR applications.job_id = @job_id)
+AND (CARDINALITY(@statuses::TEXT[]) = 0 OR applications.status::TEXT = ANY(@statuses::TEXT[]))
+AND (CARDINALITY(@application_ids::BIGINT[]) = 0 OR applications.application_id = ANY(@application_ids::BIGINT[]))
+ORDER BY companies.company_name, jobs.job_id, students.roll_number;
+
+-- name: ExportTestScores :many
+SELECT
+    tests.job_id,
+    tests.test_id,
+    tests.test_name,
+    testresults.user_id,
+    COALESCE(testresults.score, 0) AS score
+FROM tests
+JOIN companies ON tests.company_id = companies.company_id
+JOIN testresults ON tests.test_id = testresults.test_id
+WHERE (@company_user_id::BIGINT = 0 OR companies.user_id = @company_user_id)
+AND (@job_id::BIGINT = 0 OR tests.job_id = @job_id)
+AND tests.job_id IS NOT NULL
+ORDER BY tests.test_id;
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Hiring pipeline queries --------------------------------
+
+-- name: JobPipelineStages :many
+SELECT
+    job_pipeline_stages.stage_id,
+    job_pipeline_stages.position,
+    job_pipeline_stages.name,
+    job_pipeline_stages.kind,
+    job_pipeline_stages.test_id,
+    tests.test_name
+FROM job_pipeline_stages
+LEFT JOIN tests ON job_pipeline_stages.test_id = tests.test_id
+WHERE job_pipeline_stages.job_id = $1
+ORDER BY job_pipeline_stages.position;
+
+-- name: InsertPipelineStage :one
+INSERT INTO job_pipeline_stages (job_id, position, name, kind, test_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING stage_id;
+
+-- name: UpdatePipelineStage :execrows
+UPDATE job_pipeline_stages
+SET position = @position, name = @name, kind = @kind, test_id = @test_id
+WHERE stage_id = @stage_id
+AND job_id = @job_id;
+
+-- name: DeletePipelineStages :exec
+DELETE FROM job_pipeline_stages
+WHERE job_id = @job_id
+AND NOT (stage_id = ANY(@keep_stage_ids::BIGINT[]));
+
+-- name: PipelineStageApplicants :many
+SELECT
+    applications.stage_id,
+    COUNT(applications.application_id) AS applicants
+FROM applications
+WHERE applications.job_id = $1
+AND applications.stage_id IS NOT NULL
+AND applications.status::TEXT NOT IN ('Hired', 'Declined', 'Rejected', 'Withdrawn')
+GROUP BY applications.stage_id;
+
+-- name: LockApplicationStage :one
+SELECT stage_id, status::TEXT AS status FROM applications
+WHERE application_id = $1
+FOR UPDATE;
+
+-- name: SetApplicationStage :exec
+UPDATE applications
+SET stage_id = @stage_id
+WHERE application_id = @application_id;
+
+-- name: InsertApplicationStageMove :exec
+INSERT INTO application_stage_moves (application_id, from_stage_id, to_stage_id, moved_by)
+VALUES ($1, $2, $3, $4);
+
+-- name: MoveToOfferStage :exec
+WITH offer AS (
+    SELECT job_pipeline_stages.stage_id
+    FROM job_pipeline_stages
+    JOIN applications ON job_pipeline_stages.job_id = applications.job_id
+    WHERE applications.application_id = @application_id
+    AND job_pipeline_stages.kind = 'offer'
+    ORDER BY job_pipeline_stages.position
+    LIMIT 1
+),
+moved AS (
+    UPDATE applications
+    SET stage_id = offer.stage_id
+    FROM offer
+    WHERE applications.application_id = @application_id
+    AND applications.stage_id IS DISTINCT FROM offer.stage_id
+    RETURNING applications.application_id
+)
+INSERT INTO application_stage_moves (application_id, from_stage_id, to_stage_id, moved_by)
+SELECT
+    moved.application_id,
+    (SELECT applications.stage_id FROM applications WHERE applications.application_id = @application_id),
+    (SELECT offer.stage_id FROM offer),
+    @moved_by
+FROM moved;
+
+-- name: LinkInterviewStage :exec
+UPDATE interviews
+SET stage_id = (
+    SELECT job_pipeline_stages.stage_id
+    FROM applications
+    JOIN job_pipeline_stages ON applications.stage_id = job_pipeline_stages.stage_id
+    WHERE applications.application_id = interviews.application_id
+    AND job_pipeline_stages.kind = 'interview'
+)
+WHERE interview_id = $1;
+
+-- name: PipelineBoard :many
+SELECT
+    applications.application_id,
+    applications.stage_id,
+    applications.status::TEXT AS status,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.roll_number,
+    COALESCE(moved.created_at, applications.created_at)::TIMESTAMPTZ AS in_stage_since,
+    testresults.score,
+    COALESCE(stage_interview.status::TEXT, '') AS interview_status,
+    stage_interview.date_time AS interview_at
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN job_pipeline_stages ON applications.stage_id = job_pipeline_stages.stage_id
+LEFT JOIN testresults ON testresults.test_id = job_pipeline_stages.test_id
+    AND testresults.user_id = students.user_id
+LEFT JOIN LATERAL (
+    SELECT application_stage_moves.created_at
+    FROM application_stage_moves
+    WHERE application_stage_moves.application_id = applications.application_id
+    ORDER BY application_stage_moves.created_at DESC
+    LIMIT 1
+) moved ON TRUE
+LEFT JOIN LATERAL (
+    SELECT interviews.status, interviews.date_time
+    FROM interviews
+    WHERE interviews.application_id = applications.application_id
+    AND interviews.stage_id = applications.stage_id
+    ORDER BY interviews.created_at DESC
+    LIMIT 1
+) stage_interview ON TRUE
+WHERE applications.job_id = $1
+ORDER BY in_stage_since, applications.application_id;
+
+-- name: CompanyPipelineFlows :many
+SELECT
+    job_pipeline_stages.job_id,
+    job_pipeline_stages.stage_id,
+    job_pipeline_stages.position,
+    job_pipeline_stages.name,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) >= job_pipeline_stages.position) AS reached,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Rejected') AS rejected,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Withdrawn') AS withdrawn,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Declined') AS declined,
+    COUNT(applications.application_id) FILTER (WHERE COALESCE(current_stage.position, 1) = job_pipeline_stages.position AND applications.status::TEXT = 'Hired') AS hired
+FROM job_pipeline_stages
+JOIN jobs ON job_pipeline_stages.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+LEFT JOIN applications ON applications.job_id = job_pipeline_stages.job_id
+LEFT JOIN job_pipeline_stages current_stage ON applications.stage_id = current_stage.stage_id
+WHERE companies.user_id = $1
+GROUP BY job_pipeline_stages.job_id, job_pipeline_stages.stage_id, job_pipeline_stages.position, job_pipeline_stages.name
+ORDER BY job_pipeline_stages.job_id, job_pipeline_stages.position;
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Offer queries --------------------------------
+
+-- name: InsertOffer :one
+INSERT INTO offers (application_id, ctc, currency, joining_date, respond_by, letter_url, offered_by, template_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING offer_id;
+
+-- name: ApplicationOffer :one
+SELECT
+    applications.application_id,
+    applications.job_id,
+    applications.status::TEXT AS application_status,
+    jobs.title,
+    companies.company_name,
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id,
+    students.student_name,
+    offers.offer_id,
+    offers.ctc,
+    offers.currency,
+    offers.joining_date,
+    offers.respond_by,
+    offers.letter_url,
+    offers.status,
+    offers.response_reason,
+    offers.responded_at,
+    offers.created_at
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+LEFT JOIN offers ON applications.application_id = offers.application_id
+WHERE applications.application_id = $1;
+
+-- name: RespondOffer :execrows
+UPDATE offers
+SET status = @status, response_reason = @response_reason, responded_at = CURRENT_TIMESTAMP
+WHERE application_id = @application_id
+AND status = 'Pending'
+AND respond_by > CURRENT_TIMESTAMP;
+
+-- the offers past their response deadline, each is expired along with declining its application, see ExpireOffer
+-- name: DueOffers :many
+SELECT
+    offers.application_id,
+    offers.respond_by,
+    applications.job_id,
+    jobs.title,
+    companies.company_name,
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id,
+    students.student_name
+FROM offers
+JOIN applications ON offers.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+WHERE offers.status = 'Pending'
+AND offers.respond_by <= CURRENT_TIMESTAMP
+ORDER BY offers.respond_by;
+
+-- none if the offer was responded to since it was found due
+-- name: ExpireOffer :execrows
+UPDATE offers
+SET status = 'Expired', responded_at = CURRENT_TIMESTAMP
+WHERE application_id = $1
+AND status = 'Pending'
+AND respond_by <= CURRENT_TIMESTAMP;
+
+-- name: PlacementStats :one
+SELECT
+    (SELECT COUNT(*) FROM students) AS students,
+    COUNT(DISTINCT applications.student_id) FILTER (WHERE offers.status = 'Accepted' AND jobs.type NOT ILIKE '%intern%') AS placed,
+    COUNT(offers.offer_id) AS offers,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Pending') AS pending,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Accepted') AS accepted,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Declined') AS declined,
+    COUNT(offers.offer_id) FILTER (WHERE offers.status = 'Expired') AS expired,
+    COALESCE(MAX(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS highest_ctc,
+    COALESCE(AVG(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS average_ctc,
+    COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS median_ctc
+FROM offers
+JOIN applications ON offers.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id;
+
+-- name: DepartmentPlacementStats :many
+SELECT
+    students.department,
+    COUNT(DISTINCT students.student_id) AS students,
+    COUNT(DISTINCT students.student_id) FILTER (WHERE offers.status = 'Accepted' AND jobs.type NOT ILIKE '%intern%') AS placed,
+    COUNT(offers.offer_id) AS offers,
+    COALESCE(AVG(offers.ctc) FILTER (WHERE offers.status = 'Accepted' AND offers.currency = 'INR' AND jobs.type NOT ILIKE '%intern%'), 0)::BIGINT AS average_ctc
+FROM students
+LEFT JOIN applications ON applications.student_id = students.student_id
+LEFT JOIN offers ON offers.application_id = applications.application_id
+LEFT JOIN jobs ON applications.job_id = jobs.job_id
+GROUP BY students.department
+ORDER BY students.department;
+
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Offer letter template queries --------------------------------
+
+-- name: OfferLetterTemplates :many
+SELECT t.template_id, t.name, t.title, t.body, t.updated_at
+FROM offer_letter_templates t
+JOIN companies ON t.company_id = companies.company_id
+WHERE companies.user_id = $1
+ORDER BY t.name;
+
+-- name: OfferLetterTemplate :one
+SELECT t.template_id, t.name, t.title, t.body, t.updated_at
+FROM offer_letter_templates t
+JOIN companies ON t.company_id = companies.company_id
+WHERE t.template_id = @template_id
+AND companies.user_id = @user_id;
+
+-- name: InsertOfferLetterTemplate :one
+INSERT INTO offer_letter_templates (company_id, name, title, body)
+VALUES ((SELECT company_id FROM companies WHERE user_id = @user_id), @name, @title, @body)
+RETURNING template_id;
+
+-- name: UpdateOfferLetterTemplate :execrows
+UPDATE offer_letter_templates
+SET name = @name, title = @title, body = @body, updated_at = CURRENT_TIMESTAMP
+WHERE template_id = @template_id
+AND company_id = (SELECT company_id FROM companies WHERE user_id = @user_id);
+
+-- name: DeleteOfferLetterTemplate :execrows
+DELETE FROM offer_letter_templates
+WHERE template_id = @template_id
+AND company_id = (SELECT company_id FROM companies WHERE user_id = @user_id);
+
+-- name: OfferLetterFields :one
+SELECT
+    students.student_name,
+    students.roll_number,
+    students.course,
+    students.department,
+    jobs.title,
+    jobs.type,
+    jobs.location,
+    companies.company_name,
+    companies.address,
+    companies.picture_url,
+    companies.representative_name,
+    companies.representative_email
+FROM applications
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.application_id = $1;
+
+-- name: CompanyLetterhead :one
+SELECT company_name, address, picture_url, representative_name, representative_email
+FROM companies
+WHERE user_id = $1;
+
+
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Interview conflict queries --------------------------------
+
+-- name: CompanyInterview :one
+SELECT
+    interviews.interview_id,
+    interviews.application_id,
+    interviews.date_time,
+    interviews.duration_minutes,
+    interviews.location,
+    interviews.panel,
+    interviews.status::TEXT AS status
+FROM interviews
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = @interview_id
+AND companies.user_id = @user_id;
+
+-- interviews are checked for conflicts with the company and the student of the application locked until the transaction
+-- ends, so concurrent schedules of either are checked against each other
+-- name: LockInterviewParties :exec
+SELECT companies.company_id, students.student_id
+FROM applications
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+JOIN students ON applications.student_id = students.student_id
+WHERE applications.application_id = $1
+FOR UPDATE OF companies, students;
+
+-- the scheduled interviews and the test windows, that the student has not taken, of the student of the application,
+-- and the scheduled interviews of its company in the same room or with the same panel, overlapping starts_at to ends_at.
+-- Locations that are no room (none, Online or Campus) and no panel never clash, interviews run in parallel there.
+-- Events of the student with other companies are not disclosed beyond their time.
+-- name: InterviewConflicts :many
+WITH target AS (
+    SELECT applications.student_id, students.user_id, jobs.company_id
+    FROM applications
+    JOIN students ON applications.student_id = students.student_id
+    JOIN jobs ON applications.job_id = jobs.job_id
+    WHERE applications.application_id = @application_id
+)
+SELECT
+    'student'::TEXT AS party,
+    'interview'::TEXT AS kind,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.interview_id ELSE 0 END::BIGINT AS event_id,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN companies.company_name || ' - ' || jobs.title ELSE 'Interview with another company' END::TEXT AS title,
+    interviews.date_time AS starts_at,
+    (interviews.date_time + make_interval(mins => interviews.duration_minutes))::TIMESTAMPTZ AS ends_at,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.location ELSE '' END::TEXT AS location,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN interviews.panel ELSE '' END::TEXT AS panel
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM target)
+AND interviews.interview_id != @interview_id
+AND interviews.status IN ('Scheduled', 'Reschedule Requested')
+AND interviews.date_time < @ends_at
+AND interviews.date_time + make_interval(mins => interviews.duration_minutes) > @starts_at
+UNION ALL
+SELECT
+    'student'::TEXT,
+    'test'::TEXT,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN tests.test_id ELSE 0 END::BIGINT,
+    CASE WHEN jobs.company_id = (SELECT company_id FROM target) THEN tests.test_name || ' (' || companies.company_name || ' - ' || jobs.title || ')' ELSE 'Test of another company' END::TEXT,
+    (tests.end_time - make_interval(mins => tests.duration::INTEGER))::TIMESTAMPTZ,
+    tests.end_time,
+    '',
+    ''
+FROM tests
+JOIN applications ON tests.job_id = applications.job_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON jobs.company_id = companies.company_id
+WHERE applications.student_id = (SELECT student_id FROM target)
+AND applications.status::TEXT NOT IN ('Rejected', 'Withdrawn', 'Declined')
+AND tests.end_time - make_interval(mins => tests.duration::INTEGER) < @ends_at
+AND tests.end_time > @starts_at
+AND NOT EXISTS (SELECT 1 FROM testresults WHERE testresults.test_id = tests.test_id AND testresults.user_id = (SELECT user_id FROM target))
+UNION ALL
+SELECT
+    'company'::TEXT,
+    'interview'::TEXT,
+    interviews.interview_id,
+    (students.student_name || ' - ' || jobs.title)::TEXT,
+    interviews.date_time,
+    (interviews.date_time + make_interval(mins => interviews.duration_minutes))::TIMESTAMPTZ,
+    interviews.location,
+    interviews.panel
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+WHERE interviews.company_id = (SELECT company_id FROM target)
+AND interviews.interview_id != @interview_id
+AND interviews.status IN ('Scheduled', 'Reschedule Requested')
+AND interviews.date_time < @ends_at
+AND interviews.date_time + make_interval(mins => interviews.duration_minutes) > @starts_at
+AND ((LOWER(TRIM(@location::TEXT)) NOT IN ('', 'online', 'campus') AND LOWER(TRIM(interviews.location)) = LOWER(TRIM(@location::TEXT)))
+    OR (TRIM(@panel::TEXT) != '' AND LOWER(TRIM(interviews.panel)) = LOWER(TRIM(@panel::TEXT))))
+ORDER BY starts_at;
+
+
+-- >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+-- Interview reschedule queries --------------------------------
+
+-- the interview with its parties, locked until the transaction ends so its reschedule is acted on once at a time
+-- name: LockInterviewReschedule :one
+SELECT
+    interviews.interview_id,
+    interviews.application_id,
+    interviews.date_time,
+    interviews.duration_minutes,
+    interviews.type::TEXT,
+    interviews.location,
+    interviews.panel,
+    interviews.notes,
+    interviews.status::TEXT,
+    companies.user_id AS company_user_id,
+    companies.company_name,
+    students.user_id AS student_user_id,
+    students.student_name,
+    students.student_email,
+    jobs.title
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN jobs ON applications.job_id = jobs.job_id
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = $1
+FOR UPDATE OF interviews;
+
+-- name: InterviewParties :one
+SELECT
+    companies.user_id AS company_user_id,
+    students.user_id AS student_user_id
+FROM interviews
+JOIN applications ON interviews.application_id = applications.application_id
+JOIN students ON applications.student_id = students.student_id
+JOIN companies ON interviews.company_id = companies.company_id
+WHERE interviews.interview_id = $1;
+
+-- name: PendingRescheduleProposal :one
+SELECT * FROM interview_reschedule_proposals
+WHERE interview_id = $1
+AND status = 'Pending';
+
+-- name: RescheduleProposals :many
+SELECT * FROM interview_reschedule_proposals
+WHERE interview_id = $1
+ORDER BY created_at, proposal_id;
+
+-- name: InsertRescheduleProposal :one
+INSERT INTO interview_reschedule_proposals (interview_id, proposed_by, proposer_id, times, reason, previous_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: RespondRescheduleProposal :execrows
+UPDATE interview_reschedule_proposals
+SET
+    status = $1,
+    agreed_time = $2,
+    response_reason = $3,
+    responded_by = $4,
+    responded_at = NOW()
+WHERE proposal_id = $5
+AND status = 'Pending';
+
+-- name: SetInterviewStatus :exec
+UPDATE interviews
+SET status = $1
+WHERE interview_id = $2;
+
+-- the agreed time of a reschedule, the sequence is incremented so the calendar event of the interview is updated
+-- name: RescheduleInterviewTo :one
+UPDATE interviews
+SET
+    date_time = $1,
+    status = 'Scheduled',
+    ical_sequence = ical_sequence + 1
+WHERE interview_id = $2
+RETURNING ical_sequence;
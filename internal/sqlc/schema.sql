@@ -47,7 +47,17 @@ CREATE TABLE jobs (
     extras JSON,
     active_status boolean NOT NULL DEFAULT true,
     description TEXT,
+    revision INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ,
+    search_vector TSVECTOR,
+    deadline TIMESTAMPTZ,
+    max_applicants INTEGER,
+    deadline_reminder_sent BOOLEAN NOT NULL DEFAULT false,
+    questions JSONB NOT NULL DEFAULT '[]',
+    approval_status TEXT NOT NULL DEFAULT 'draft',
+    submitted_at TIMESTAMPTZ,
     CONSTRAINT jobs_pkey PRIMARY KEY (job_id),
+    CONSTRAINT jobs_approval_status_check CHECK (approval_status IN ('draft', 'submitted', 'approved', 'rejected')),
     CONSTRAINT jobs_company_id_fkey FOREIGN KEY (company_id)
         REFERENCES companies(company_id)
         ON DELETE CASCADE
@@ -72,6 +82,7 @@ CREATE TABLE students (
     user_id BIGINT NOT NULL,
     extras JSON,
     picture_url TEXT,
+    backlogs INTEGER NOT NULL DEFAULT 0,
     CONSTRAINT uni_result_url UNIQUE (result_url),
     CONSTRAINT uni_roll_no UNIQUE (roll_number),
     CONSTRAINT students_pkey PRIMARY KEY (student_id),
@@ -86,6 +97,9 @@ CREATE TABLE applications (
     data_url TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     status application_status NOT NULL DEFAULT 'Applied',
+    job_revision INTEGER NOT NULL DEFAULT 1,
+    answers JSONB NOT NULL DEFAULT '{}',
+    stage_id BIGINT,
     CONSTRAINT students_app_pkey FOREIGN KEY (student_id) REFERENCES students(student_id) ON DELETE CASCADE,
     CONSTRAINT jobs_pkey FOREIGN KEY (job_id) REFERENCES jobs(job_id) ON DELETE CASCADE
 );
@@ -101,6 +115,11 @@ CREATE TABLE interviews (
     location TEXT NOT NULL DEFAULT 'Campus',
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     extras JSON,
+    ical_sequence INTEGER NOT NULL DEFAULT 0,
+    stage_id BIGINT,
+    duration_minutes INTEGER NOT NULL DEFAULT 60,
+    panel TEXT NOT NULL DEFAULT '',
+    CONSTRAINT interviews_duration_check CHECK (duration_minutes > 0),
     CONSTRAINT applications_interviews_pkey FOREIGN KEY (application_id) REFERENCES applications(application_id),
     CONSTRAINT companies_interviews_pkey FOREIGN KEY (company_id) REFERENCES companies(company_id)
 );
@@ -166,6 +185,10 @@ CREATE TABLE notifications (
     description TEXT COLLATE pg_catalog."default",
     read_status BOOLEAN NOT NULL DEFAULT false,
     "timestamp" BIGINT NOT NULL DEFAULT 0,
+    category TEXT NOT NULL DEFAULT 'general',
+    archived BOOLEAN NOT NULL DEFAULT false,
+    ref_type TEXT,
+    ref_id BIGINT,
     CONSTRAINT notifications_pkey PRIMARY KEY (notif_id),
     CONSTRAINT notifs_users_fkey FOREIGN KEY (user_id)
         REFERENCES public.users (user_id) MATCH SIMPLE
@@ -212,4 +235,529 @@ CREATE TABLE discussions (
         ON UPDATE NO ACTION
         ON DELETE NO ACTION
         NOT VALID
-);
\ No newline at end of file
+);
+
+CREATE TABLE digest_preferences (
+    user_id BIGINT NOT NULL,
+    frequency TEXT NOT NULL DEFAULT 'daily',
+    send_hour INTEGER NOT NULL DEFAULT 8,
+    weekday INTEGER NOT NULL DEFAULT 1,
+    timezone TEXT NOT NULL DEFAULT 'Asia/Kolkata',
+    last_sent_at TIMESTAMPTZ,
+    CONSTRAINT digest_preferences_pkey PRIMARY KEY (user_id),
+    CONSTRAINT digest_frequency_check CHECK (frequency IN ('daily', 'weekly', 'off')),
+    CONSTRAINT digest_send_hour_check CHECK (send_hour BETWEEN 0 AND 23),
+    CONSTRAINT digest_weekday_check CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT digest_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+
+CREATE TABLE calendar_feeds (
+    user_id BIGINT NOT NULL,
+    token TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT calendar_feeds_pkey PRIMARY KEY (user_id),
+    CONSTRAINT uni_calendar_feed_token UNIQUE (token),
+    CONSTRAINT calendar_feeds_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE device_tokens (
+    token TEXT NOT NULL,
+    user_id BIGINT NOT NULL,
+    platform TEXT NOT NULL DEFAULT 'web',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT device_tokens_pkey PRIMARY KEY (token),
+    CONSTRAINT device_tokens_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE announcements (
+    announcement_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    author_id BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    message TEXT NOT NULL,
+    target_role BIGINT,
+    department TEXT,
+    course TEXT,
+    year_of_study TEXT,
+    job_id BIGINT,
+    send_email BOOLEAN NOT NULL DEFAULT true,
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT announcements_pkey PRIMARY KEY (announcement_id),
+    CONSTRAINT announcements_users_fkey FOREIGN KEY (author_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT announcements_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE TABLE announcement_recipients (
+    announcement_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    read_at TIMESTAMPTZ,
+    notified_at TIMESTAMPTZ,
+    emailed_at TIMESTAMPTZ,
+    CONSTRAINT announcement_recipients_pkey PRIMARY KEY (announcement_id, user_id),
+    CONSTRAINT recipients_announcements_fkey FOREIGN KEY (announcement_id)
+        REFERENCES public.announcements (announcement_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT recipients_users_fkey FOREIGN KEY (user_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE job_revisions (
+    job_id BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    changed_by BIGINT NOT NULL,
+    changed_fields TEXT[] NOT NULL,
+    changes JSONB NOT NULL,
+    material BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_revisions_pkey PRIMARY KEY (job_id, revision),
+    CONSTRAINT job_revisions_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_revisions_users_fkey FOREIGN KEY (changed_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE job_eligibility (
+    job_id BIGINT NOT NULL,
+    min_cgpa DOUBLE PRECISION,
+    courses TEXT[] NOT NULL DEFAULT '{}',
+    departments TEXT[] NOT NULL DEFAULT '{}',
+    years_of_study TEXT[] NOT NULL DEFAULT '{}',
+    max_backlogs INTEGER,
+    custom JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_eligibility_pkey PRIMARY KEY (job_id),
+    CONSTRAINT job_eligibility_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE eligibility_overrides (
+    job_id BIGINT NOT NULL,
+    student_id BIGINT NOT NULL,
+    granted_by BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT eligibility_overrides_pkey PRIMARY KEY (job_id, student_id),
+    CONSTRAINT overrides_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT overrides_students_fkey FOREIGN KEY (student_id)
+        REFERENCES public.students (student_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT overrides_users_fkey FOREIGN KEY (granted_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE TABLE audit_log (
+    audit_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT audit_log_pkey PRIMARY KEY (audit_id)
+);
+
+-- full-text search document of a job, title and company name weigh the most
+CREATE OR REPLACE FUNCTION jobs_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE((SELECT company_name FROM companies WHERE companies.company_id = NEW.company_id), '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(array_to_string(NEW.skills, ' '), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER jobs_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, description, skills, company_id ON jobs
+    FOR EACH ROW EXECUTE FUNCTION jobs_search_vector_update();
+
+-- a company rename re-indexes its jobs
+CREATE OR REPLACE FUNCTION companies_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE jobs SET title = title WHERE jobs.company_id = NEW.company_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER companies_search_vector_trigger
+    AFTER UPDATE OF company_name ON companies
+    FOR EACH ROW WHEN (OLD.company_name IS DISTINCT FROM NEW.company_name)
+    EXECUTE FUNCTION companies_search_vector_update();
+
+CREATE INDEX jobs_search_vector_idx ON jobs USING GIN (search_vector);
+
+-- the open jobs matching the text of a job search, except the given ones, each with whether it matches
+-- the filter of every facet. Shared by the SearchJobs and SearchJobFacets queries, empty filters match all.
+CREATE OR REPLACE FUNCTION job_search_matches(
+    search_query TEXT,
+    search_locations TEXT[],
+    search_types TEXT[],
+    search_positions TEXT[],
+    search_industries TEXT[],
+    posted_after TIMESTAMPTZ,
+    salary_min DOUBLE PRECISION,
+    salary_max DOUBLE PRECISION,
+    excluded_job_ids BIGINT[]
+) RETURNS TABLE (
+    job_id BIGINT,
+    title TEXT,
+    location TEXT,
+    type TEXT,
+    salary TEXT,
+    position TEXT,
+    skills TEXT[],
+    created_at TIMESTAMP,
+    company_name TEXT,
+    industry TEXT,
+    salary_num DOUBLE PRECISION,
+    rank REAL,
+    m_location BOOLEAN,
+    m_type BOOLEAN,
+    m_position BOOLEAN,
+    m_industry BOOLEAN,
+    m_posted BOOLEAN,
+    m_salary BOOLEAN
+) AS $$
+    WITH base AS (
+        SELECT
+            jobs.job_id,
+            jobs.title,
+            jobs.location,
+            jobs.type,
+            jobs.salary,
+            jobs.position,
+            jobs.skills,
+            jobs.created_at,
+            companies.company_name::TEXT AS company_name,
+            companies.industry::TEXT AS industry,
+            -- in LPA, from the structured compensation, annualised stipend for internships
+            CAST(CASE WHEN job_compensation.currency = 'INR'
+                 THEN COALESCE(NULLIF(job_compensation.ctc_min, 0), NULLIF(job_compensation.stipend_monthly, 0) * 12) / 100000.0
+            END AS DOUBLE PRECISION) AS salary_num,
+            CAST(CASE WHEN search_query = '' THEN 0
+                 ELSE ts_rank(jobs.search_vector, websearch_to_tsquery('english', search_query))
+            END AS REAL) AS rank,
+            (cardinality(search_locations) = 0 OR jobs.location = ANY(search_locations)) AS m_location,
+            (cardinality(search_types) = 0 OR jobs.type = ANY(search_types)) AS m_type,
+            (cardinality(search_positions) = 0 OR jobs.position = ANY(search_positions)) AS m_position,
+            (cardinality(search_industries) = 0 OR companies.industry = ANY(search_industries)) AS m_industry,
+            (posted_after IS NULL OR jobs.created_at >= posted_after) AS m_posted
+        FROM jobs
+        JOIN companies ON jobs.company_id = companies.company_id
+        LEFT JOIN job_compensation ON jobs.job_id = job_compensation.job_id
+        WHERE jobs.active_status = true
+        AND jobs.approval_status = 'approved'
+        AND jobs.job_id != ALL(excluded_job_ids)
+        AND (search_query = '' OR jobs.search_vector @@ websearch_to_tsquery('english', search_query))
+    )
+    SELECT
+        base.*,
+        (salary_min IS NULL OR base.salary_num >= salary_min)
+        AND (salary_max IS NULL OR base.salary_num <= salary_max) AS m_salary
+    FROM base
+$$ LANGUAGE sql STABLE;
+
+CREATE INDEX jobs_deadline_idx ON jobs (deadline) WHERE active_status;
+
+CREATE TABLE job_reviews (
+    review_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    job_id BIGINT NOT NULL,
+    revision INTEGER NOT NULL,
+    reviewer_id BIGINT NOT NULL,
+    decision TEXT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_reviews_pkey PRIMARY KEY (review_id),
+    CONSTRAINT job_reviews_decision_check CHECK (decision IN ('approved', 'rejected')),
+    CONSTRAINT job_reviews_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_reviews_users_fkey FOREIGN KEY (reviewer_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE INDEX job_reviews_job_idx ON job_reviews (job_id);
+CREATE INDEX jobs_submitted_idx ON jobs (submitted_at) WHERE approval_status = 'submitted';
+
+CREATE TABLE job_compensation (
+    job_id BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'INR',
+    ctc_min BIGINT NOT NULL DEFAULT 0,
+    ctc_max BIGINT NOT NULL DEFAULT 0,
+    fixed_pay BIGINT NOT NULL DEFAULT 0,
+    variable_pay BIGINT NOT NULL DEFAULT 0,
+    stipend_monthly BIGINT NOT NULL DEFAULT 0,
+    bond_months INTEGER NOT NULL DEFAULT 0,
+    bond_terms TEXT NOT NULL DEFAULT '',
+    parsed BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_compensation_pkey PRIMARY KEY (job_id),
+    CONSTRAINT job_compensation_currency_check CHECK (currency IN ('INR', 'USD', 'EUR', 'GBP')),
+    CONSTRAINT job_compensation_ctc_check CHECK (ctc_min >= 0 AND ctc_max >= ctc_min),
+    CONSTRAINT job_compensation_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+-- every change of the status of an application, from_status is NULL for the initial Applied.
+-- actor_id is NULL for changes by the system, actor_role is one of student, company, admin or system.
+CREATE TABLE application_status_history (
+    history_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor_id BIGINT,
+    actor_role TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT application_status_history_pkey PRIMARY KEY (history_id),
+    CONSTRAINT application_status_history_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT application_status_history_users_fkey FOREIGN KEY (actor_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX application_status_history_application_idx ON application_status_history (application_id, created_at);
+
+-- the placement policy, a single row. max_offers 0 is unlimited,
+-- tiers is a JSON array of {"Name", "MinCTC"} with MinCTC the INR CTC per annum a job needs to be in the tier.
+CREATE TABLE placement_policy (
+    policy_id INTEGER NOT NULL DEFAULT 1,
+    max_offers INTEGER NOT NULL DEFAULT 0,
+    block_after_acceptance BOOLEAN NOT NULL DEFAULT TRUE,
+    tiers JSONB NOT NULL DEFAULT '[]',
+    updated_by BIGINT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT placement_policy_pkey PRIMARY KEY (policy_id),
+    CONSTRAINT placement_policy_single_check CHECK (policy_id = 1),
+    CONSTRAINT placement_policy_max_offers_check CHECK (max_offers >= 0),
+    CONSTRAINT placement_policy_users_fkey FOREIGN KEY (updated_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+-- exceptions to a rule of the placement policy granted to a student, for one job or all jobs when job_id is NULL.
+-- rule is one of max_offers, tier or accepted, see policy.
+CREATE TABLE policy_exceptions (
+    exception_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    student_id BIGINT NOT NULL,
+    job_id BIGINT,
+    rule TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    granted_by BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT policy_exceptions_pkey PRIMARY KEY (exception_id),
+    CONSTRAINT policy_exceptions_rule_check CHECK (rule IN ('max_offers', 'tier', 'accepted')),
+    CONSTRAINT policy_exceptions_students_fkey FOREIGN KEY (student_id)
+        REFERENCES public.students (student_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT policy_exceptions_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT policy_exceptions_users_fkey FOREIGN KEY (granted_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX policy_exceptions_student_job_rule_idx ON policy_exceptions (student_id, COALESCE(job_id, 0), rule);
+
+-- the hiring pipeline of a job, its stages in order of position from 1. kind is one of screening, test, interview or
+-- offer, see pipeline. A test stage is of a test of the job, the interviews of an interview stage link to it.
+-- Applications are in the stage of applications.stage_id, the first one when NULL.
+CREATE TABLE job_pipeline_stages (
+    stage_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    job_id BIGINT NOT NULL,
+    position INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    test_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT job_pipeline_stages_pkey PRIMARY KEY (stage_id),
+    CONSTRAINT job_pipeline_stages_position_key UNIQUE (job_id, position) DEFERRABLE INITIALLY DEFERRED,
+    CONSTRAINT job_pipeline_stages_kind_check CHECK (kind IN ('screening', 'test', 'interview', 'offer')),
+    CONSTRAINT job_pipeline_stages_jobs_fkey FOREIGN KEY (job_id)
+        REFERENCES public.jobs (job_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT job_pipeline_stages_tests_fkey FOREIGN KEY (test_id)
+        REFERENCES public.tests (test_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+ALTER TABLE applications ADD CONSTRAINT applications_job_pipeline_stages_fkey FOREIGN KEY (stage_id)
+    REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+ALTER TABLE interviews ADD CONSTRAINT interviews_job_pipeline_stages_fkey FOREIGN KEY (stage_id)
+    REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+-- moves of applications between the stages of the pipeline of their job
+CREATE TABLE application_stage_moves (
+    move_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    from_stage_id BIGINT,
+    to_stage_id BIGINT,
+    moved_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT application_stage_moves_pkey PRIMARY KEY (move_id),
+    CONSTRAINT application_stage_moves_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT application_stage_moves_from_fkey FOREIGN KEY (from_stage_id)
+        REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT application_stage_moves_to_fkey FOREIGN KEY (to_stage_id)
+        REFERENCES public.job_pipeline_stages (stage_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT application_stage_moves_users_fkey FOREIGN KEY (moved_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX application_stage_moves_application_idx ON application_stage_moves (application_id, created_at);
+
+-- offer letter templates of a company, title and body have placeholders like {{StudentName}} filled in for each
+-- offer, see letter.
+CREATE TABLE offer_letter_templates (
+    template_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    company_id BIGINT NOT NULL,
+    name TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT offer_letter_templates_pkey PRIMARY KEY (template_id),
+    CONSTRAINT offer_letter_templates_name_key UNIQUE (company_id, name),
+    CONSTRAINT offer_letter_templates_companies_fkey FOREIGN KEY (company_id)
+        REFERENCES public.companies (company_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE
+);
+
+-- the offer of an application, at most one. ctc is per annum in currency, the letter is the stored offer letter.
+-- status is Pending until the student accepts or declines, or Expired once respond_by passes, see offer.
+-- template_id is the template the letter was generated from, if it was not uploaded.
+CREATE TABLE offers (
+    offer_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    application_id BIGINT NOT NULL,
+    ctc BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'INR',
+    joining_date DATE NOT NULL,
+    respond_by TIMESTAMPTZ NOT NULL,
+    letter_url TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending',
+    response_reason TEXT NOT NULL DEFAULT '',
+    responded_at TIMESTAMPTZ,
+    offered_by BIGINT,
+    template_id BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT offers_pkey PRIMARY KEY (offer_id),
+    CONSTRAINT offers_application_key UNIQUE (application_id),
+    CONSTRAINT offers_status_check CHECK (status IN ('Pending', 'Accepted', 'Declined', 'Expired')),
+    CONSTRAINT offers_ctc_check CHECK (ctc > 0),
+    CONSTRAINT offers_applications_fkey FOREIGN KEY (application_id)
+        REFERENCES public.applications (application_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT offers_users_fkey FOREIGN KEY (offered_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT offers_offer_letter_templates_fkey FOREIGN KEY (template_id)
+        REFERENCES public.offer_letter_templates (template_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+CREATE INDEX offers_pending_idx ON offers (respond_by) WHERE status = 'Pending';
+
+CREATE INDEX interviews_company_time_idx ON interviews (company_id, date_time) WHERE status = 'Scheduled';
+
+CREATE TABLE interview_reschedule_proposals (
+    proposal_id BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    interview_id BIGINT NOT NULL,
+    proposed_by TEXT NOT NULL,
+    proposer_id BIGINT,
+    times TIMESTAMPTZ[] NOT NULL,
+    reason TEXT NOT NULL,
+    previous_time TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'Pending',
+    agreed_time TIMESTAMPTZ,
+    response_reason TEXT NOT NULL DEFAULT '',
+    responded_by BIGINT,
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT interview_reschedule_proposals_pkey PRIMARY KEY (proposal_id),
+    CONSTRAINT interview_reschedule_proposals_proposed_by_check CHECK (proposed_by IN ('company', 'student')),
+    CONSTRAINT interview_reschedule_proposals_status_check CHECK (status IN ('Pending', 'Accepted', 'Rejected', 'Countered', 'Cancelled')),
+    CONSTRAINT interview_reschedule_proposals_times_check CHECK (cardinality(times) > 0),
+    CONSTRAINT interview_reschedule_proposals_interviews_fkey FOREIGN KEY (interview_id)
+        REFERENCES public.interviews (interview_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+    CONSTRAINT interview_reschedule_proposals_proposer_fkey FOREIGN KEY (proposer_id)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL,
+    CONSTRAINT interview_reschedule_proposals_responder_fkey FOREIGN KEY (responded_by)
+        REFERENCES public.users (user_id) MATCH SIMPLE
+        ON UPDATE CASCADE
+        ON DELETE SET NULL
+);
+
+-- one proposal of an interview awaits a response at a time
+CREATE UNIQUE INDEX interview_reschedule_proposals_pending_key ON interview_reschedule_proposals (interview_id) WHERE status = 'Pending';
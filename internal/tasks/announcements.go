@@ -0,0 +1,146 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mod/internal/config"
+	"go.mod/internal/dto"
+	"go.mod/internal/notify"
+	sqlc "go.mod/internal/sqlc/generate"
+	"go.mod/internal/utils"
+)
+
+// AnnouncementsPoller polls the announcements whose scheduled time has passed and delivers them.
+// Announcements without a schedule are picked up on the next tick.
+func (a *AsyncService) AnnouncementsPoller(ctx context.Context) error {
+
+	timeout := config.AnnouncementsPollerTimeout * time.Second
+
+	fmt.Printf("Starting the announcements poller : Timeout: %d\n", timeout)
+
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		due, err := a.Queries.DueAnnouncements(ctx)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		for i := range due {
+			err := a.DeliverAnnouncement(ctx, &due[i])
+			if err != nil {
+				fmt.Printf("Failed to deliver announcement %d : %v\n", due[i].AnnouncementID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeliverAnnouncement records the matching recipients of the announcement, which is its notice-board entry for them,
+// and fans it out as a notification and an email. Each recipient is marked once notified and once emailed,
+// so a delivery that failed midway is retried on the next tick for the remaining recipients only.
+// The announcement is marked as delivered once every recipient was notified.
+func (a *AsyncService) DeliverAnnouncement(ctx context.Context, an *sqlc.DueAnnouncementsRow) error {
+
+	err := a.Queries.InsertAnnouncementRecipients(ctx, an.AnnouncementID)
+	if err != nil {
+		return fmt.Errorf("failed to insert recipients : %v", err)
+	}
+
+	recipients, err := a.Queries.UnnotifiedAnnouncementRecipients(ctx, an.AnnouncementID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipients to notify : %v", err)
+	}
+
+	notified := make([]int64, 0, len(recipients))
+	for _, userID := range recipients {
+		errf := a.Notify.NewNotification(ctx, userID, &dto.NotificationData{
+			Title: an.Title,
+			Description: an.Message,
+			Category: notify.CategoryAnnouncement,
+			RefType: notify.RefAnnouncement,
+			RefID: an.AnnouncementID,
+		})
+		if errf != nil {
+			fmt.Printf("Failed to notify user %d of announcement %d : %s\n", userID, an.AnnouncementID, errf.Message)
+			continue
+		}
+		notified = append(notified, userID)
+	}
+
+	if len(notified) != 0 {
+		err = a.Queries.MarkRecipientsNotified(ctx, sqlc.MarkRecipientsNotifiedParams{
+			AnnouncementID: an.AnnouncementID,
+			UserIds: notified,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to mark recipients as notified : %v", err)
+		}
+	}
+
+	if an.SendEmail {
+		err = a.emailAnnouncement(ctx, an)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(notified) != len(recipients) {
+		return fmt.Errorf("%d of %d recipients were not notified", len(recipients) - len(notified), len(recipients))
+	}
+
+	err = a.Queries.MarkAnnouncementDelivered(ctx, an.AnnouncementID)
+	if err != nil {
+		return fmt.Errorf("failed to mark announcement as delivered : %v", err)
+	}
+
+	return nil
+}
+
+// emailAnnouncement emails the announcement in batches to the recipients not emailed yet.
+// Recipients are marked as emailed before the batches are sent, so no one gets the email twice.
+func (a *AsyncService) emailAnnouncement(ctx context.Context, an *sqlc.DueAnnouncementsRow) error {
+
+	recipients, err := a.Queries.UnemailedAnnouncementRecipients(ctx, an.AnnouncementID)
+	if err != nil {
+		return fmt.Errorf("failed to get recipients to email : %v", err)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	template, err := utils.DynamicHTML("./template/emails/announcement.html", &dto.AnnouncementData{
+		Title: an.Title,
+		Message: an.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]int64, len(recipients))
+	emails := make([]string, len(recipients))
+	for i, r := range recipients {
+		userIDs[i] = r.UserID
+		emails[i] = r.Email
+	}
+
+	err = a.Queries.MarkRecipientsEmailed(ctx, sqlc.MarkRecipientsEmailedParams{
+		AnnouncementID: an.AnnouncementID,
+		UserIds: userIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark recipients as emailed : %v", err)
+	}
+
+	for start := 0; start < len(emails); start += config.AnnouncementEmailBatchSize {
+		end := min(start + config.AnnouncementEmailBatchSize, len(emails))
+		go utils.SendEmailHTML(template, emails[start:end])
+	}
+
+	return nil
+}
@@ -2,20 +2,24 @@ package tasks
 
 import (
 	"context"
+	"fmt"
 
 	"go.mod/internal/apicalls"
+	"go.mod/internal/notify"
 	sqlc "go.mod/internal/sqlc/generate"
 )
 
 type AsyncService struct {
 	Queries *sqlc.Queries
 	GAPIService *apicalls.Caller
+	Notify *notify.Notify
 }
 
-func NewAsyncService(queries *sqlc.Queries, gapiService *apicalls.Caller) *AsyncService {
+func NewAsyncService(queries *sqlc.Queries, gapiService *apicalls.Caller, notifyService *notify.Notify) *AsyncService {
 	return &AsyncService{
 		Queries: queries,
 		GAPIService: gapiService,
+		Notify: notifyService,
 	}
 }
 
@@ -32,6 +36,46 @@ func (a *AsyncService) StartAsyncs() error {
 		}
 	} ()
 
+	// starts the daily/weekly digest poller as a go-routine
+	go func() {
+		err := a.DigestPoller(ctx)
+		if err != nil {
+			return
+		}
+	} ()
+
+	// starts the scheduled announcements poller as a go-routine
+	go func() {
+		err := a.AnnouncementsPoller(ctx)
+		if err != nil {
+			return
+		}
+	} ()
+
+	// starts the job deadlines poller as a go-routine
+	go func() {
+		err := a.JobDeadlinesPoller(ctx)
+		if err != nil {
+			return
+		}
+	} ()
+
+	// starts the offers poller as a go-routine, expiring offers past their response deadline
+	go func() {
+		err := a.OffersPoller(ctx)
+		if err != nil {
+			return
+		}
+	} ()
+
+	// backfills the structured compensation of jobs with only a free-text salary, once
+	go func() {
+		err := a.BackfillCompensation(ctx)
+		if err != nil {
+			fmt.Println(err)
+		}
+	} ()
+
 
 
 	return nil
@@ -0,0 +1,65 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"go.mod/internal/compensation"
+	"go.mod/internal/config"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// BackfillCompensation parses the free-text salaries of jobs without a structured compensation, in batches,
+// storing the ones it can make out as parsed. Salaries it cannot make out, eg. "Competitive", are left as text.
+// It only looks at jobs without a compensation, so running it again is a no-op besides retrying those.
+func (a *AsyncService) BackfillCompensation(ctx context.Context) error {
+
+	var after int64
+	filled, skipped := 0, 0
+
+	for {
+		jobs, err := a.Queries.JobsWithoutCompensation(ctx, sqlc.JobsWithoutCompensationParams{
+			AfterJobID: after,
+			BatchSize: config.CompensationBackfillBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get jobs to backfill compensation : %v", err)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		for _, j := range jobs {
+			after = j.JobID
+
+			comp, ok := compensation.Parse(j.Salary, compensation.IsInternship(j.Type))
+			if !ok {
+				skipped++
+				continue
+			}
+
+			err = a.Queries.UpsertJobCompensation(ctx, sqlc.UpsertJobCompensationParams{
+				JobID: j.JobID,
+				Currency: comp.Currency,
+				CtcMin: comp.CTCMin,
+				CtcMax: comp.CTCMax,
+				FixedPay: comp.Fixed,
+				VariablePay: comp.Variable,
+				StipendMonthly: comp.StipendMonthly,
+				BondMonths: comp.BondMonths,
+				BondTerms: comp.BondTerms,
+				Parsed: true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to backfill compensation of job %d : %v", j.JobID, err)
+			}
+			filled++
+		}
+	}
+
+	if filled != 0 || skipped != 0 {
+		fmt.Printf("Backfilled the compensation of %d jobs, %d salaries could not be parsed\n", filled, skipped)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/config"
+	errs "go.mod/internal/const"
+	"go.mod/internal/dto"
+	"go.mod/internal/eligibility"
+	"go.mod/internal/notify"
+	sqlc "go.mod/internal/sqlc/generate"
+)
+
+// JobDeadlinesPoller polls the active jobs with a fixed timeout.
+// Jobs past their deadline or at their maximum applicants are closed and their company notified of the final applicant count,
+// and students are reminded of eligible jobs they have not applied to once the deadline is within JobDeadlineReminderWindow.
+func (a *AsyncService) JobDeadlinesPoller(ctx context.Context) error {
+
+	timeout := config.JobDeadlinesPollerTimeout * time.Second
+
+	fmt.Printf("Starting the job deadlines poller : Timeout: %d\n", timeout)
+
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := a.CloseDueJobs(ctx)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		err = a.SendDeadlineReminders(ctx)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// CloseDueJobs closes the jobs past their deadline or at their maximum applicants and notifies their companies.
+// Closing and returning the jobs is one statement, so every job is notified of only once.
+func (a *AsyncService) CloseDueJobs(ctx context.Context) error {
+
+	closed, err := a.Queries.CloseDueJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to close due jobs : %v", err)
+	}
+
+	for _, j := range closed {
+		reason := "its application deadline passed"
+		if j.MaxApplicants.Valid && j.Applicants >= int64(j.MaxApplicants.Int32) {
+			reason = fmt.Sprintf("it reached its maximum of %d applicants", j.MaxApplicants.Int32)
+		}
+
+		errf := a.Notify.NewNotification(ctx, j.CompanyUserID, &dto.NotificationData{
+			Title: "Job closed for applications",
+			Description: fmt.Sprintf("%s was closed as %s, with %d applicant(s) in total.", j.Title, reason, j.Applicants),
+			Category: notify.CategoryApplication,
+			RefType: notify.RefJob,
+			RefID: j.JobID,
+		})
+		if errf != nil {
+			fmt.Printf("Failed to notify company of closing job %d : %s\n", j.JobID, errf.Message)
+		}
+	}
+
+	return nil
+}
+
+// SendDeadlineReminders reminds the students of the jobs whose deadline is within JobDeadlineReminderWindow,
+// provided they are eligible (or were made eligible by an admin) and have not applied yet.
+// Jobs are claimed before reminding, so a job is reminded of only once unless its deadline is moved.
+func (a *AsyncService) SendDeadlineReminders(ctx context.Context) error {
+
+	jobs, err := a.Queries.ClaimDeadlineReminders(ctx, pgtype.Timestamptz{
+		Time: time.Now().Add(config.JobDeadlineReminderWindow * time.Hour),
+		Valid: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to claim deadline reminders : %v", err)
+	}
+
+	for i := range jobs {
+		err := a.remindJobDeadline(ctx, &jobs[i])
+		if err != nil {
+			fmt.Printf("Failed to send deadline reminders of job %d : %v\n", jobs[i].JobID, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *AsyncService) remindJobDeadline(ctx context.Context, j *sqlc.ClaimDeadlineRemindersRow) error {
+
+	// a job without eligibility rules is open to every student
+	rules := &eligibility.Rules{}
+	row, err := a.Queries.GetJobEligibility(ctx, j.JobID)
+	if err != nil && err.Error() != errs.NoRowsMatch {
+		return fmt.Errorf("failed to get eligibility rules : %v", err)
+	}
+	if err == nil {
+		rules, err = eligibility.FromColumns(row.MinCgpa, row.Courses, row.Departments, row.YearsOfStudy, row.MaxBacklogs, row.Custom)
+		if err != nil {
+			return err
+		}
+	}
+
+	candidates, err := a.Queries.DeadlineReminderCandidates(ctx, j.JobID)
+	if err != nil {
+		return fmt.Errorf("failed to get students to remind : %v", err)
+	}
+
+	for _, st := range candidates {
+		if !st.Overridden && len(rules.Check(eligibility.ProfileFrom(st.Course, st.Department, st.YearOfStudy, st.Cgpa, st.Backlogs, st.Extras))) != 0 {
+			continue
+		}
+
+		errf := a.Notify.NewNotification(ctx, st.UserID, &dto.NotificationData{
+			Title: "Application deadline approaching",
+			Description: fmt.Sprintf("Applications for %s at %s close at %s, you have not applied yet.",
+				j.Title, j.CompanyName, j.Deadline.Time.Format("03:04 PM 02-01-2006")),
+			Category: notify.CategoryApplication,
+			RefType: notify.RefJob,
+			RefID: j.JobID,
+		})
+		if errf != nil {
+			fmt.Printf("Failed to remind user %d of job %d : %s\n", st.UserID, j.JobID, errf.Message)
+		}
+	}
+
+	return nil
+}
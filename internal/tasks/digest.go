@@ -0,0 +1,205 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.mod/internal/config"
+	"go.mod/internal/dto"
+	"go.mod/internal/recommend"
+	sqlc "go.mod/internal/sqlc/generate"
+	"go.mod/internal/utils"
+)
+
+// DigestPoller polls the digest recipients with a fixed timeout.
+// A digest is sent to a user once their configured local send time (daily, or on the configured weekday for weekly)
+// has passed and no digest was sent since, provided the send time was not missed by more than DigestSendWindow.
+// Users with nothing to report are marked as sent without an email.
+func (a *AsyncService) DigestPoller(ctx context.Context) error {
+
+	timeout := config.DigestPollerTimeout * time.Second
+
+	fmt.Printf("Starting the digest poller : Timeout: %d\n", timeout)
+
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		recipients, err := a.Queries.DigestRecipients(ctx)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		now := time.Now()
+		for i := range recipients {
+			since, due := digestDue(&recipients[i], now)
+			if !due {
+				continue
+			}
+
+			err := a.SendDigest(ctx, &recipients[i], since, now)
+			if err != nil {
+				fmt.Printf("Failed to send digest to user %d : %v\n", recipients[i].UserID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// digestDue returns the start of the period the digest should cover and whether the user's digest is due at now.
+func digestDue(r *sqlc.DigestRecipientsRow, now time.Time) (time.Time, bool) {
+
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := now.In(loc)
+
+	periodDays := 1
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), int(r.SendHour), 0, 0, 0, loc)
+	if r.Frequency == "weekly" {
+		periodDays = 7
+		scheduled = scheduled.AddDate(0, 0, -((int(local.Weekday()) - int(r.Weekday) + 7) % 7))
+	}
+	if scheduled.After(local) {
+		scheduled = scheduled.AddDate(0, 0, -periodDays)
+	}
+
+	if now.Sub(scheduled) > config.DigestSendWindow * time.Minute {
+		return time.Time{}, false
+	}
+	if r.LastSentAt.Valid && !r.LastSentAt.Time.Before(scheduled) {
+		return time.Time{}, false
+	}
+
+	since := scheduled.AddDate(0, 0, -periodDays)
+	if r.LastSentAt.Valid && r.LastSentAt.Time.After(since) {
+		since = r.LastSentAt.Time.In(loc)
+	}
+
+	return since, true
+}
+
+// SendDigest aggregates the unread notifications and upcoming events of a user since the given time
+// and sends them as one templated email. Students get their upcoming interviews and tests and the jobs recommended for them,
+// companies get their scheduled interviews and the new applicants per job.
+func (a *AsyncService) SendDigest(ctx context.Context, r *sqlc.DigestRecipientsRow, since time.Time, now time.Time) error {
+
+	data := &dto.DigestData{
+		Email: r.Email,
+		Frequency: r.Frequency,
+		Since: since.Format("03:04 PM 02-01-2006"),
+	}
+
+	notifications, err := a.Queries.UnreadNotificationsSince(ctx, sqlc.UnreadNotificationsSinceParams{
+		UserID: r.UserID,
+		Timestamp: since.Unix(),
+		Limit: config.DigestNotificationsLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get unread notifications : %v", err)
+	}
+	for _, n := range notifications {
+		data.Notifications = append(data.Notifications, dto.NotificationData{
+			Title: n.Title.String,
+			Description: n.Description.String,
+			TimeStamp: n.Timestamp,
+		})
+	}
+
+	switch r.Role {
+	case 1:
+		interviews, err := a.Queries.UpcomingInterviewsStudent(ctx, r.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get upcoming interviews : %v", err)
+		}
+		for _, i := range interviews {
+			data.Interviews = append(data.Interviews, dto.DigestItem{
+				Title: i.CompanyName + " - " + i.Title,
+				Subtitle: i.InterviewsType + " at " + i.Location,
+				When: i.DateTime,
+			})
+		}
+
+		tests, err := a.Queries.UpcomingTestsStudent(ctx, r.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get upcoming tests : %v", err)
+		}
+		for _, t := range tests {
+			data.Tests = append(data.Tests, dto.DigestItem{
+				Title: t.TestName,
+				Subtitle: fmt.Sprintf("%s - %s, %d questions, %d mins", t.CompanyName, t.Title, t.QCount, t.Duration),
+				When: t.EndTime,
+			})
+		}
+
+		// only the jobs posted in the period, so the same ones are not recommended digest after digest
+		recommendations, err := recommend.ForStudent(ctx, a.Queries, r.UserID, since, config.DigestRecommendationsLimit)
+		if err != nil {
+			return fmt.Errorf("failed to get recommendations : %v", err)
+		}
+		for _, rec := range recommendations {
+			item := dto.DigestItem{
+				Title: rec.CompanyName + " - " + rec.Title,
+				Subtitle: fmt.Sprintf("%s, %s", rec.Location, rec.Type),
+			}
+			if len(rec.Reasons) != 0 {
+				item.Subtitle = rec.Reasons[0]
+			}
+			if rec.Deadline != nil {
+				item.When = rec.Deadline.Format("03:04 PM 02-01-2006")
+			}
+			data.Recommendations = append(data.Recommendations, item)
+		}
+
+	case 2:
+		interviews, err := a.Queries.ScheduledInterviewsCompany(ctx, r.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get scheduled interviews : %v", err)
+		}
+		for _, i := range interviews {
+			data.Interviews = append(data.Interviews, dto.DigestItem{
+				Title: fmt.Sprintf("%s (%s)", i.StudentName, i.RollNumber),
+				Subtitle: i.InterviewsType + " at " + i.Location,
+				When: i.DateTime,
+			})
+		}
+
+		applicants, err := a.Queries.NewApplicantsPerJob(ctx, sqlc.NewApplicantsPerJobParams{
+			UserID: r.UserID,
+			CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get new applicants : %v", err)
+		}
+		for _, j := range applicants {
+			data.NewApplicants = append(data.NewApplicants, dto.DigestItem{
+				Title: j.Title,
+				Subtitle: fmt.Sprintf("%d new applicant(s)", j.NewApplicants),
+			})
+		}
+	}
+
+	// nothing to report, no email but the period is still marked as sent
+	if len(data.Notifications) + len(data.Interviews) + len(data.Tests) + len(data.NewApplicants) + len(data.Recommendations) != 0 {
+		template, err := utils.DynamicHTML("./template/emails/digest.html", data)
+		if err != nil {
+			return err
+		}
+		go utils.SendEmailHTML(template, []string{r.Email})
+	}
+
+	err = a.Queries.MarkDigestSent(ctx, sqlc.MarkDigestSentParams{
+		UserID: r.UserID,
+		LastSentAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark digest as sent : %v", err)
+	}
+
+	return nil
+}
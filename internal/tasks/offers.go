@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mod/internal/config"
+	"go.mod/internal/dto"
+	"go.mod/internal/notify"
+	"go.mod/internal/services"
+)
+
+// OffersPoller polls the pending offers with a fixed timeout, offers past their response deadline are expired.
+func (a *AsyncService) OffersPoller(ctx context.Context) error {
+
+	timeout := config.OffersPollerTimeout * time.Second
+
+	fmt.Printf("Starting the offers poller : Timeout: %d\n", timeout)
+
+	ticker := time.NewTicker(timeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := a.ExpireOffers(ctx)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// ExpireOffers expires the pending offers past their response deadline, their applications are Declined by the system,
+// and notifies the students, the companies and the placement cell. Each offer is expired in the transaction that
+// declines its application, see services.ExpireDueOffers, so every offer is notified of only once.
+func (a *AsyncService) ExpireOffers(ctx context.Context) error {
+
+	expired, err := services.ExpireDueOffers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire due offers : %v", err)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	admins, err := a.Queries.AdminUserIDs(ctx)
+	if err != nil {
+		fmt.Printf("Failed to get admins to notify of expired offers : %v\n", err)
+	}
+
+	for _, o := range expired {
+		deadline := o.RespondBy.Time.Format("03:04 PM 02-01-2006")
+
+		notifications := []struct {
+			userID int64
+			description string
+		}{
+			{o.StudentUserID, fmt.Sprintf("Your offer for %s at %s expired at %s without a response, the application is declined.", o.Title, o.CompanyName, deadline)},
+			{o.CompanyUserID, fmt.Sprintf("The offer to %s for %s expired at %s without a response, the application is declined.", o.StudentName, o.Title, deadline)},
+		}
+		for _, adminID := range admins {
+			notifications = append(notifications, struct {
+				userID int64
+				description string
+			}{adminID, fmt.Sprintf("The offer to %s for %s at %s expired at %s without a response.", o.StudentName, o.Title, o.CompanyName, deadline)})
+		}
+
+		for _, n := range notifications {
+			errf := a.Notify.NewNotification(ctx, n.userID, &dto.NotificationData{
+				Title: "Offer expired",
+				Description: n.description,
+				Category: notify.CategoryOffer,
+				RefType: notify.RefApplication,
+				RefID: o.ApplicationID,
+			})
+			if errf != nil {
+				fmt.Printf("Failed to notify user %d of expired offer of application %d : %s\n", n.userID, o.ApplicationID, errf.Message)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BindStrict binds the request body into obj (a pointer to a struct) like ctx.ShouldBind,
+// but rejects the request if it has any field obj does not declare.
+// JSON bodies are decoded with unknown fields disallowed, form bodies have every key checked
+// against the field names (or form tags) of obj.
+// A string field tagged formmap:"json" also takes Name[key]=value form fields, as a JSON object of them.
+func BindStrict(ctx *gin.Context, obj any) error {
+
+	if ctx.ContentType() == binding.MIMEJSON {
+		dec := json.NewDecoder(ctx.Request.Body)
+		dec.DisallowUnknownFields()
+		err := dec.Decode(obj)
+		if err != nil {
+			return err
+		}
+		return binding.Validator.ValidateStruct(obj)
+	}
+
+	err := ctx.ShouldBind(obj)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	maps := make(map[string]int) // field name -> index of the formmap field
+	t := reflect.TypeOf(obj).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("form")
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		known[name] = true
+		if t.Field(i).Tag.Get("formmap") == "json" && t.Field(i).Type.Kind() == reflect.String {
+			maps[name] = i
+		}
+	}
+
+	for key := range ctx.Request.PostForm {
+		name, _, isMap := strings.Cut(key, "[")
+		if isMap && strings.HasSuffix(key, "]") {
+			if _, ok := maps[name]; ok {
+				continue
+			}
+		}
+		if !known[key] {
+			return fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	for name, i := range maps {
+		m, ok := ctx.GetPostFormMap(name)
+		if !ok {
+			continue
+		}
+		field := reflect.ValueOf(obj).Elem().Field(i)
+		if field.String() != "" {
+			return fmt.Errorf("field %q is given both as a whole and as %s[key] fields", name, name)
+		}
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		field.SetString(string(b))
+	}
+
+	return nil
+}
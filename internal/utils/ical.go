@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// iCalendar methods, RFC 5546
+const (
+	ICalRequest = "REQUEST"
+	ICalCancel = "CANCEL"
+	ICalPublish = "PUBLISH"
+)
+
+// ICalEvent is a single VEVENT of an RFC 5545 calendar.
+// UID must be stable for the lifetime of the event, and Sequence incremented on every change of it,
+// so calendar clients update (or cancel) the existing event instead of creating a new one.
+type ICalEvent struct {
+	UID string
+	Sequence int32
+	Start time.Time
+	End time.Time
+	Summary string
+	Description string
+	Location string
+	Attendees []string
+	Cancelled bool
+}
+
+// ICalUID returns the stable UID for an entity, eg. ICalUID("interview", 12) = "interview-12@pms"
+func ICalUID(kind string, id int64) string {
+	return fmt.Sprintf("%s-%d@pms", kind, id)
+}
+
+// ICalendar builds a VCALENDAR with the given method (REQUEST, CANCEL or PUBLISH) containing the events.
+func ICalendar(method string, events ...ICalEvent) []byte {
+
+	var buf bytes.Buffer
+	organizer := os.Getenv("SMTP_GO_From")
+	stamp := icalTime(time.Now())
+
+	icalLine(&buf, "BEGIN:VCALENDAR")
+	icalLine(&buf, "PRODID:-//PMS//Placement Management Software//EN")
+	icalLine(&buf, "VERSION:2.0")
+	icalLine(&buf, "CALSCALE:GREGORIAN")
+	icalLine(&buf, "METHOD:" + method)
+
+	for _, e := range events {
+		icalLine(&buf, "BEGIN:VEVENT")
+		icalLine(&buf, "UID:" + e.UID)
+		icalLine(&buf, fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+		icalLine(&buf, "DTSTAMP:" + stamp)
+		icalLine(&buf, "DTSTART:" + icalTime(e.Start))
+		icalLine(&buf, "DTEND:" + icalTime(e.End))
+		icalLine(&buf, "SUMMARY:" + icalEscape(e.Summary))
+		if e.Description != "" {
+			icalLine(&buf, "DESCRIPTION:" + icalEscape(e.Description))
+		}
+		if e.Location != "" {
+			icalLine(&buf, "LOCATION:" + icalEscape(e.Location))
+		}
+		if organizer != "" && method != ICalPublish {
+			icalLine(&buf, "ORGANIZER;CN=PMS:mailto:" + organizer)
+		}
+		for _, a := range e.Attendees {
+			icalLine(&buf, "ATTENDEE;ROLE=REQ-PARTICIPANT;RSVP=FALSE:mailto:" + a)
+		}
+		if e.Cancelled || method == ICalCancel {
+			icalLine(&buf, "STATUS:CANCELLED")
+		} else {
+			icalLine(&buf, "STATUS:CONFIRMED")
+		}
+		icalLine(&buf, "END:VEVENT")
+	}
+
+	icalLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// RandomToken returns a hex encoded cryptographically random token of n bytes.
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icalEscape escapes TEXT values as per RFC 5545 3.3.11
+func icalEscape(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+	).Replace(s)
+}
+
+// icalLine writes a content line terminated by CRLF, folded at 75 octets as per RFC 5545 3.1
+func icalLine(buf *bytes.Buffer, line string) {
+	// continuation lines start with a space, which counts towards the limit
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// do not split a multi-byte utf-8 sequence
+		for cut > 0 && line[cut] & 0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	buf.WriteString(line + "\r\n")
+}
@@ -2,7 +2,6 @@ package utils
 
 import (
 	"bytes"
-	"encoding/base64"
 	"fmt"
 	"mime/multipart"
 	"net/smtp"
@@ -53,8 +52,8 @@ func SendEmailHTMLWithAttachment(body bytes.Buffer, to_Email []string, attachmen
 	// Add the file attachment
 	if attachment != nil {
 
-		// Encode file in Base64
-		encoded := base64.StdEncoding.EncodeToString(*attachment)
+		// Encode file in Base64, in lines of 76 characters
+		encoded := base64Lines(*attachment)
 
 		// Create attachment part
 		attachmentPart, err := writer.CreatePart(map[string][]string{
@@ -67,7 +66,7 @@ func SendEmailHTMLWithAttachment(body bytes.Buffer, to_Email []string, attachmen
 		}
 
 		// Write encoded file content to the attachment part
-		_, err = attachmentPart.Write([]byte(encoded))
+		_, err = attachmentPart.Write(encoded)
 		if err != nil {
 			fmt.Println("failed to write attachment: %w", err)
 		}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/smtp"
+	"os"
+)
+
+// SendEmailHTMLWithInvite sends the html body along with an iCalendar invite.
+// The invite is added both as a text/calendar alternative part, which mail clients render as an invite,
+// and as an invite.ics attachment for the ones that do not.
+// For multiple recipients the To header is left undisclosed, so the emails of other recipients are not leaked.
+func SendEmailHTMLWithInvite(body bytes.Buffer, to_Email []string, ics []byte, method string) (error) {
+	// Email headers
+	subject := "Subject: PMS\n"
+	fromEmail := os.Getenv("SMTP_GO_From")
+
+	// Load environment variables
+	smtpHost := os.Getenv("SMTP_GO_Host")
+	smtpPort := os.Getenv("SMTP_GO_HostAddress")
+	username := os.Getenv("SMTP_GO_Username")
+	password := os.Getenv("SMTP_GO_Pass")
+
+	if len(to_Email) == 0 {
+		return nil
+	}
+
+	to := to_Email[0]
+	if len(to_Email) > 1 {
+		to = "undisclosed-recipients:;"
+	}
+
+	var emailContent bytes.Buffer
+	mixed := multipart.NewWriter(&emailContent)
+
+	emailContent.WriteString(fmt.Sprintf("From: %s\n", fromEmail))
+	emailContent.WriteString(fmt.Sprintf("To: %s\n", to))
+	emailContent.WriteString(subject)
+	emailContent.WriteString("MIME-Version: 1.0\n")
+	emailContent.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s\n\n", mixed.Boundary()))
+
+	// html and calendar as alternatives of each other
+	var alternativeContent bytes.Buffer
+	alternative := multipart.NewWriter(&alternativeContent)
+
+	htmlPart, err := alternative.CreatePart(map[string][]string{
+		"Content-Type": {"text/html; charset=UTF-8"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email body part: %w", err)
+	}
+	_, err = htmlPart.Write(body.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+
+	calendarPart, err := alternative.CreatePart(map[string][]string{
+		"Content-Type": {fmt.Sprintf("text/calendar; charset=UTF-8; method=%s", method)},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create calendar part: %w", err)
+	}
+	_, err = calendarPart.Write(base64Lines(ics))
+	if err != nil {
+		return fmt.Errorf("failed to write calendar part: %w", err)
+	}
+	alternative.Close()
+
+	alternativePart, err := mixed.CreatePart(map[string][]string{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%s", alternative.Boundary())},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create alternative part: %w", err)
+	}
+	_, err = alternativePart.Write(alternativeContent.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to write alternative part: %w", err)
+	}
+
+	attachmentPart, err := mixed.CreatePart(map[string][]string{
+		"Content-Type": {fmt.Sprintf("application/ics; name=invite.ics; method=%s", method)},
+		"Content-Disposition": {"attachment; filename=invite.ics"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create attachment part: %w", err)
+	}
+	_, err = attachmentPart.Write(base64Lines(ics))
+	if err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+
+	mixed.Close()
+
+	auth := smtp.PlainAuth("", username, password, smtpHost)
+
+	err = smtp.SendMail(smtpPort, auth, fromEmail, to_Email, emailContent.Bytes())
+	if err != nil {
+		fmt.Println("failed to send email: ", err)
+		return err
+	}
+	return nil
+}
+
+// base64Lines encodes data in base64 broken into CRLF terminated lines of 76 characters, as RFC 2045 limits them
+func base64Lines(data []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var lines bytes.Buffer
+	for len(encoded) > 76 {
+		lines.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	lines.WriteString(encoded + "\r\n")
+	return lines.Bytes()
+}
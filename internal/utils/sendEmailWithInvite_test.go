@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestBase64Lines(t *testing.T) {
+
+	tests := []struct {
+		name string
+		size int
+		lines int
+	}{
+		{"empty", 0, 1},
+		{"short", 10, 1},
+		{"one full line", 57, 1}, // 57 bytes encode to 76 characters
+		{"just over a line", 58, 2},
+		{"invite", 2000, 36},
+	}
+
+	for _, tt := range tests {
+		data := bytes.Repeat([]byte("BEGIN:VCALENDAR\r\n"), tt.size / 17 + 1)[:tt.size]
+		encoded := string(base64Lines(data))
+
+		if !strings.HasSuffix(encoded, "\r\n") {
+			t.Errorf("%s: not terminated by CRLF", tt.name)
+		}
+		lines := strings.Split(strings.TrimSuffix(encoded, "\r\n"), "\r\n")
+		if len(lines) != tt.lines {
+			t.Errorf("%s: got %d lines, want %d", tt.name, len(lines), tt.lines)
+		}
+		for i, line := range lines {
+			if len(line) > 76 || strings.ContainsAny(line, "\r\n") {
+				t.Errorf("%s: line %d is %d characters : %q", tt.name, i, len(line), line)
+			}
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+		if err != nil || !bytes.Equal(decoded, data) {
+			t.Errorf("%s: does not decode back, error %v", tt.name, err)
+		}
+	}
+}